@@ -7,25 +7,40 @@ func newAgentCmd() *cobra.Command {
 		Use:   "agent",
 		Short: "Agent CLI (JSON output)",
 		Example: `vtr agent ls
+vtr agent ls -l owner=agent-7,idle=true
 vtr agent spawn demo --cmd "bash"
 vtr agent spawn spoke-a:demo --cmd "bash"
 vtr agent send --submit demo "git status"
 vtr agent send --wait-for-idle --idle 5s demo "make test"
 vtr agent screen demo --ansi
+vtr agent logs demo -f --strip-ansi
 vtr agent idle demo other --idle 5s --timeout 30s`,
 	}
 	cmd.AddCommand(
 		newListCmd(),
 		newSpawnCmd(),
+		newTagCmd(),
+		newCloneCmd(),
 		newInfoCmd(),
 		newScreenCmd(),
 		newSendCmd(),
 		newKeyCmd(),
 		newRawCmd(),
+		newPasteCmd(),
+		newMouseCmd(),
+		newMacroCmd(),
+		newScriptCmd(),
 		newResizeCmd(),
+		newLockCmd(),
+		newUnlockCmd(),
+		newClientsCmd(),
+		newDisconnectCmd(),
 		newKillCmd(),
 		newRemoveCmd(),
 		newGrepCmd(),
+		newFindCmd(),
+		newAssertScreenCmd(),
+		newLogsCmd(),
 		newWaitCmd(),
 		newIdleCmd(),
 	)
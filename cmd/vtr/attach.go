@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	proto "github.com/advait/vtrpc/proto"
@@ -35,6 +36,8 @@ type attachModel struct {
 	sessionID     string
 	sessionLabel  string
 	sessionCoord  string
+	clientID      string
+	readOnly      bool
 	stream        proto.VTR_SubscribeClient
 	streamCancel  context.CancelFunc
 	streamID      int
@@ -62,12 +65,29 @@ type attachModel struct {
 	createInput      textinput.Model
 	createCoordIdx   int
 	createFocusInput bool
+	createProfileIdx int
+	createFocusProf  bool
 	renameActive     bool
 	renameInput      textinput.Model
+	layoutSaveActive bool
+	layoutInput      textinput.Model
+	configPath       string
+
+	// layout splits the viewport into panes; nil shows the focused session
+	// alone. The fields above hold the focused pane, the others are parked in
+	// panes by pane id.
+	layout    *paneLayout
+	panes     map[int]tuiPane
+	paneID    int
+	zoomed    bool
+	streamSeq int
+
+	copyMode copyModeState
 
 	hoverTabID    string
 	hoverNewCoord string
 
+	keys         tuiKeymap
 	leaderActive bool
 	showExited   bool
 	exited       bool
@@ -78,6 +98,10 @@ type attachModel struct {
 	profiler         *renderProfiler
 	profileQuitAfter time.Duration
 
+	// output is the program's output; sequences the model writes itself,
+	// like OSC 52, go through it so they land between rendered frames.
+	output io.Writer
+
 	now time.Time
 	err error
 }
@@ -175,6 +199,9 @@ type sessionListItem struct {
 	exitCode int32
 	idle     bool
 	order    uint32
+
+	lockHolder string
+	attached   int32
 }
 
 func (s sessionListItem) Title() string {
@@ -369,23 +396,54 @@ func newTuiCmd() *cobra.Command {
 	var profile bool
 	var profileDump bool
 	var profileDuration time.Duration
+	var readOnly bool
+	var raw bool
+	var layoutName string
 	cmd := &cobra.Command{
-		Use:   "tui [name]",
-		Short: "Attach to a session (TUI)",
-		Args:  cobra.MaximumNArgs(1),
+		Use:     "tui [name]",
+		Aliases: []string{"attach"},
+		Short:   "Attach to a session (TUI)",
+		Long: "Attach to a session in the TUI. --raw skips the TUI: the local terminal is " +
+			"put in raw mode, the current screen is replayed, and output is passed through " +
+			"unchanged like a tmux attach. Press ctrl+b d to detach and ctrl+b ctrl+b to send " +
+			"ctrl+b. --layout opens a pane layout saved under [tui.layouts.<name>] in the config. " +
+			"The leader and leader actions can be rebound under [tui.keys].",
+		Example: `vtr tui
+vtr tui --layout dev
+vtr attach --raw demo`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, _, err := loadConfigWithPath()
+			if raw && (profile || profileDump || profileDuration > 0) {
+				return fmt.Errorf("--raw cannot be combined with profiling flags")
+			}
+			if layoutName != "" && (raw || len(args) > 0) {
+				return fmt.Errorf("--layout cannot be combined with --raw or a session name")
+			}
+			cfg, configPath, err := loadConfigWithPath()
 			if err != nil {
 				return err
 			}
 			applyTuiStatusConfig(cfg)
+			keys, err := tuiKeymapFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+			var layout *paneLayout
+			if layoutName != "" {
+				layout, err = configLayout(cfg, layoutName)
+				if err != nil {
+					return err
+				}
+			}
 			profileEnabled := profile || profileDump || profileDuration > 0
 			targetCoord, err := resolveHubTarget(cfg, hub)
 			if err != nil {
 				return err
 			}
 			target := sessionTarget{}
-			if len(args) == 0 {
+			if layout != nil {
+				target.Label = paneLeaves(layout)[0].ref
+			} else if len(args) == 0 {
 				target, err = resolveFirstSessionTarget(context.Background(), targetCoord, cfg)
 				if err != nil {
 					if !errors.Is(err, errNoSessions) {
@@ -427,11 +485,30 @@ func newTuiCmd() *cobra.Command {
 			if target.ID == "" && strings.TrimSpace(target.Label) == "" {
 				listActive = true
 			}
+			var panes map[int]tuiPane
+			if layout != nil {
+				panes, err = resolveLayoutPanes(client, layout)
+				if err != nil {
+					_ = conn.Close()
+					return err
+				}
+				if layout.split == paneSplitNone {
+					layout = nil
+				}
+			}
+			if raw {
+				defer conn.Close()
+				if listActive {
+					return errNoSessions
+				}
+				return runRawAttach(client, sessionRequestRef(target.ID, activeCoord.Name), readOnly, keys, os.Stdin, os.Stdout)
+			}
 
 			var profiler *renderProfiler
 			if profileEnabled {
 				profiler = newRenderProfiler(true)
 			}
+			output := &teaOutput{file: os.Stdout}
 			model := attachModel{
 				conn:             conn,
 				client:           client,
@@ -442,6 +519,8 @@ func newTuiCmd() *cobra.Command {
 				sessionID:        target.ID,
 				sessionLabel:     target.Label,
 				sessionCoord:     activeCoord.Name,
+				clientID:         tuiClientID(),
+				readOnly:         readOnly,
 				streamID:         1,
 				streamBackoff:    time.Second,
 				streamState:      "disconnected",
@@ -449,18 +528,26 @@ func newTuiCmd() *cobra.Command {
 				sessionList:      newSessionListModel(nil, 0, 0),
 				createInput:      newCreateInput(),
 				renameInput:      newRenameInput(),
+				layoutInput:      newLayoutInput(),
+				configPath:       configPath,
+				layout:           layout,
+				panes:            panes,
+				paneID:           1,
+				streamSeq:        len(panes) + 1,
 				createCoordIdx:   coordIdx,
 				createFocusInput: true,
 				listActive:       listActive,
 				profiler:         profiler,
 				profileQuitAfter: profileDuration,
+				keys:             keys,
+				output:           output,
 				now:              time.Now(),
 			}
 			if strings.TrimSpace(model.sessionID) != "" || strings.TrimSpace(model.sessionLabel) != "" {
 				model.streamState = "connecting"
 			}
 
-			prog := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseAllMotion())
+			prog := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseAllMotion(), tea.WithOutput(output))
 			finalModel, err := prog.Run()
 			if profileDump && profiler != nil && err == nil {
 				snapshot := profiler.Snapshot(time.Now())
@@ -495,6 +582,9 @@ func newTuiCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&profile, "profile", false, "show render FPS/latency in the footer")
 	cmd.Flags().BoolVar(&profileDump, "profile-dump", false, "print render profiling JSON on exit")
 	cmd.Flags().DurationVar(&profileDuration, "profile-duration", 0, "auto-exit after duration when profiling")
+	cmd.Flags().BoolVar(&readOnly, "read-only", false, "view sessions without sending input")
+	cmd.Flags().BoolVar(&raw, "raw", false, "pass raw output through instead of rendering the TUI")
+	cmd.Flags().StringVar(&layoutName, "layout", "", "open a named pane layout from the config")
 	addHubFlag(cmd, &hub)
 	return cmd
 }
@@ -639,7 +729,7 @@ func ensureSessionExists(client proto.VTRClient, ref string) (sessionTarget, err
 	}
 	ctx, cancel = context.WithTimeout(context.Background(), rpcTimeout)
 	defer cancel()
-	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: ref})
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: ref, ClientId: tuiClientID()})
 	if err != nil && status.Code(err) != codes.AlreadyExists {
 		return sessionTarget{}, err
 	}
@@ -693,7 +783,13 @@ func (m attachModel) Init() tea.Cmd {
 		cmds = append(cmds, profileQuitCmd(m.profileQuitAfter))
 	}
 	if strings.TrimSpace(m.sessionID) != "" || strings.TrimSpace(m.sessionLabel) != "" {
-		cmds = append(cmds, startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID))
+		cmds = append(cmds, startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID, m.presence()))
+	}
+	for _, id := range paneIDs(m.layout) {
+		if pane, ok := m.panes[id]; ok && pane.sessionID != "" {
+			presence := subscribePresence{clientID: m.clientID, readOnly: m.readOnly}
+			cmds = append(cmds, startSubscribeCmd(m.client, pane.sessionID, pane.sessionCoord, pane.streamID, presence))
+		}
 	}
 	return tea.Batch(cmds...)
 }
@@ -702,6 +798,9 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case subscribeStartMsg:
 		if msg.streamID != m.streamID {
+			if id, ok := paneForStream(m, msg.streamID); ok {
+				return updateBackgroundPane(m, id, msg)
+			}
 			if msg.cancel != nil {
 				msg.cancel()
 			}
@@ -728,6 +827,12 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, waitSubscribeCmd(m.stream, m.streamID)
 	case subscribeEventMsg:
 		if msg.streamID != m.streamID {
+			if id, ok := paneForStream(m, msg.streamID); ok {
+				if status.Code(msg.err) == codes.Aborted {
+					return disconnectPane(m, id, msg.err), nil
+				}
+				return updateBackgroundPane(m, id, msg)
+			}
 			return m, nil
 		}
 		if msg.err != nil {
@@ -736,6 +841,11 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.streamCancel = nil
 			}
 			m.stream = nil
+			if status.Code(msg.err) == codes.Aborted {
+				// Disconnected by another client; reconnecting would undo the kick.
+				m.err = msg.err
+				return m, tea.Quit
+			}
 			m.streamState = "reconnecting"
 			if !errors.Is(msg.err, io.EOF) && !errors.Is(msg.err, context.Canceled) {
 				m.statusMsg = fmt.Sprintf("stream: %v", msg.err)
@@ -837,6 +947,7 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
+		m = refreshPaneLabels(m)
 		if m.createCoordIdx >= len(m.coords) && len(m.coords) > 0 {
 			m.createCoordIdx = len(m.coords) - 1
 		}
@@ -850,10 +961,13 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, startSessionsStreamCmd(m.client, m.sessionsStreamID)
 	case subscribeRetryMsg:
 		if msg.streamID != m.streamID {
+			if id, ok := paneForStream(m, msg.streamID); ok {
+				return updateBackgroundPane(m, id, msg)
+			}
 			return m, nil
 		}
 		m.streamState = "connecting"
-		return m, startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID)
+		return m, startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID, m.presence())
 	case profileDoneMsg:
 		return m, tea.Quit
 	case tickMsg:
@@ -874,10 +988,13 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.sessionList.SetSize(m.viewportWidth, m.viewportHeight)
 		}
 		if m.viewportWidth > 0 && m.viewportHeight > 0 {
+			if m.layout != nil {
+				return m, resizePanesCmd(m)
+			}
 			if m.exited {
 				return m, nil
 			}
-			return m, resizeCmd(m.client, m.sessionID, m.sessionCoord, m.viewportWidth, m.viewportHeight)
+			return m, m.resizeCmd()
 		}
 		return m, nil
 	case rpcErrMsg:
@@ -886,6 +1003,40 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusUntil = time.Now().Add(2 * time.Second)
 		}
 		return m, nil
+	case copyModeRequestMsg:
+		if msg.sessionID != m.sessionID {
+			return m, nil
+		}
+		return beginCopyMode(m, msg.scroll)
+	case copyModeLoadedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("copy mode: %v", msg.err)
+			m.statusUntil = time.Now().Add(2 * time.Second)
+			return m, nil
+		}
+		if msg.sessionID != m.sessionID || m.copyMode.active {
+			return m, nil
+		}
+		height := m.rectForPane(m.paneID).h
+		m.copyMode = newCopyMode(msg.sessionID, msg.screen, height)
+		if msg.scroll > 0 {
+			m.copyMode.scroll(-msg.scroll, height)
+		}
+		return m, nil
+	case layoutSavedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("save layout: %v", msg.err)
+		} else {
+			if m.cfg != nil {
+				if m.cfg.TUI.Layouts == nil {
+					m.cfg.TUI.Layouts = make(map[string]tuiLayoutConfig)
+				}
+				m.cfg.TUI.Layouts[msg.name] = tuiLayoutConfig{Panes: msg.spec}
+			}
+			m.statusMsg = fmt.Sprintf("layout %s saved", msg.name)
+		}
+		m.statusUntil = time.Now().Add(2 * time.Second)
+		return m, nil
 	case sessionSwitchMsg:
 		return switchSession(m, msg)
 	case sessionListMsg:
@@ -949,6 +1100,9 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.MouseMsg:
 		return handleMouse(m, msg)
 	case tea.KeyMsg:
+		if m.layoutSaveActive {
+			return updateLayoutSaveModal(m, msg)
+		}
 		if m.renameActive {
 			return updateRenameModal(m, msg)
 		}
@@ -958,6 +1112,9 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.listActive {
 			return updateSessionList(m, msg)
 		}
+		if m.copyMode.active {
+			return handleCopyModeKey(m, msg)
+		}
 		if m.leaderActive {
 			m.leaderActive = false
 			if msg.String() == "esc" || msg.String() == "escape" {
@@ -965,7 +1122,7 @@ func (m attachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return handleLeaderKey(m, msg)
 		}
-		if msg.String() == "ctrl+b" {
+		if msg.String() == m.keymap().leader {
 			m.leaderActive = true
 			return m, nil
 		}
@@ -985,12 +1142,18 @@ func (m attachModel) View() string {
 			overlayWidth := overlayAvailableWidth(innerWidth)
 			content := ""
 			switch {
+			case m.layoutSaveActive:
+				content = renderLayoutSaveModal(m)
 			case m.renameActive:
 				content = renderRenameModal(m)
 			case m.createActive:
 				content = renderCreateModal(m)
 			case m.listActive:
 				content = renderSessionList(m)
+			case m.layout != nil && !m.zoomed:
+				content = renderPanes(m)
+			case m.copyMode.active:
+				content = strings.Join(renderCopyMode(m.copyMode, m.viewportWidth, m.viewportHeight), "\n")
 			default:
 				content = renderScreen(m.screen, m.viewportWidth, m.viewportHeight, m.now)
 			}
@@ -1014,11 +1177,15 @@ func (m attachModel) View() string {
 			footerLeft, footerRight := renderFooterSegments(footerView{
 				width:       overlayWidth,
 				leader:      m.leaderActive,
+				keys:        m.keymap(),
 				statusMsg:   m.statusMsg,
 				streamState: streamStateLabel(m),
+				pane:        paneFooterLabel(m),
+				copyMode:    copyModeFooterLabel(m.copyMode),
 				exited:      m.exited,
 				coordinator: m.coordinator.Name,
 				active:      activeItem,
+				readOnly:    m.readOnly,
 				profiler:    m.profiler,
 			})
 			view = renderBorderOverlay(content, m.width, m.height, border, headerLeft, headerRight, footerLeft, footerRight)
@@ -1031,7 +1198,25 @@ func (m attachModel) View() string {
 	return view
 }
 
-func startSubscribeCmd(client proto.VTRClient, id, coordinator string, streamID int) tea.Cmd {
+// subscribePresence is what the TUI reports about itself when it attaches.
+type subscribePresence struct {
+	clientID string
+	readOnly bool
+	cols     int
+	rows     int
+}
+
+func (m attachModel) presence() subscribePresence {
+	r := m.rectForPane(m.paneID)
+	return subscribePresence{
+		clientID: m.clientID,
+		readOnly: m.readOnly,
+		cols:     r.w,
+		rows:     r.h,
+	}
+}
+
+func startSubscribeCmd(client proto.VTRClient, id, coordinator string, streamID int, presence subscribePresence) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithCancel(context.Background())
 		sessionRef := sessionRequestRef(id, coordinator)
@@ -1039,6 +1224,12 @@ func startSubscribeCmd(client proto.VTRClient, id, coordinator string, streamID
 			Session:              sessionRef,
 			IncludeScreenUpdates: true,
 			IncludeRawOutput:     false,
+			ReadOnly:             presence.readOnly,
+			ClientId:             presence.clientID,
+			ClientKind:           "tui",
+			ClientName:           agentClientID(),
+			Cols:                 int32(presence.cols),
+			Rows:                 int32(presence.rows),
 		})
 		if err != nil {
 			cancel()
@@ -1110,8 +1301,9 @@ func scheduleSubscribeRetry(m attachModel) (attachModel, tea.Cmd) {
 	if delay <= 0 {
 		delay = 500 * time.Millisecond
 	}
-	nextID := m.streamID + 1
+	nextID := nextStreamID(m)
 	m.streamID = nextID
+	m.streamSeq = nextID
 	m.streamBackoff = nextBackoff(delay)
 	m.streamState = "reconnecting"
 	return m, tea.Tick(delay, func(time.Time) tea.Msg {
@@ -1134,7 +1326,101 @@ func profileQuitCmd(after time.Duration) tea.Cmd {
 	})
 }
 
-func resizeCmd(client proto.VTRClient, id, coordinator string, cols, rows int) tea.Cmd {
+// viewportCell maps a host terminal position to a session cell of the
+// focused pane inside the border.
+func (m attachModel) viewportCell(x, y int) (int, int, bool) {
+	if m.screen == nil {
+		return 0, 0, false
+	}
+	r := m.rectForPane(m.paneID)
+	cellX, cellY := x-1-r.x, y-1-r.y
+	if cellX < 0 || cellY < 0 || cellX >= r.w || cellY >= r.h {
+		return 0, 0, false
+	}
+	if cellX >= int(m.screen.Cols) || cellY >= int(m.screen.Rows) {
+		return 0, 0, false
+	}
+	return cellX, cellY, true
+}
+
+// mouseCmd forwards a mouse event inside the viewport. Hover motion is not
+// forwarded to keep idle pointer movement off the wire. Scrolling up where the
+// session cannot take the wheel opens copy mode instead.
+func (m attachModel) mouseCmd(msg tea.MouseMsg, x, y int) tea.Cmd {
+	if m.readOnly || m.exited {
+		if msg.Button == tea.MouseButtonWheelUp {
+			return copyModeRequestCmd(m.sessionID)
+		}
+		return nil
+	}
+	req := &proto.SendMouseRequest{
+		Session:  sessionRequestRef(m.sessionID, m.sessionCoord),
+		X:        int32(x),
+		Y:        int32(y),
+		Shift:    msg.Shift,
+		Alt:      msg.Alt,
+		Ctrl:     msg.Ctrl,
+		ClientId: m.clientID,
+	}
+	switch msg.Button {
+	case tea.MouseButtonLeft:
+		req.Button = proto.MouseButton_MOUSE_BUTTON_LEFT
+	case tea.MouseButtonMiddle:
+		req.Button = proto.MouseButton_MOUSE_BUTTON_MIDDLE
+	case tea.MouseButtonRight:
+		req.Button = proto.MouseButton_MOUSE_BUTTON_RIGHT
+	case tea.MouseButtonWheelUp:
+		req.Button = proto.MouseButton_MOUSE_BUTTON_WHEEL_UP
+	case tea.MouseButtonWheelDown:
+		req.Button = proto.MouseButton_MOUSE_BUTTON_WHEEL_DOWN
+	case tea.MouseButtonWheelLeft:
+		req.Button = proto.MouseButton_MOUSE_BUTTON_WHEEL_LEFT
+	case tea.MouseButtonWheelRight:
+		req.Button = proto.MouseButton_MOUSE_BUTTON_WHEEL_RIGHT
+	case tea.MouseButtonNone:
+	default:
+		return nil
+	}
+	switch msg.Action {
+	case tea.MouseActionPress:
+		req.Action = proto.MouseAction_MOUSE_ACTION_PRESS
+	case tea.MouseActionRelease:
+		req.Action = proto.MouseAction_MOUSE_ACTION_RELEASE
+	case tea.MouseActionMotion:
+		req.Action = proto.MouseAction_MOUSE_ACTION_MOTION
+	default:
+		return nil
+	}
+	if req.Button == proto.MouseButton_MOUSE_BUTTON_UNSPECIFIED {
+		return nil
+	}
+	client := m.client
+	sessionID := m.sessionID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+		defer cancel()
+		resp, err := client.SendMouse(ctx, req)
+		if err != nil {
+			return rpcErrMsg{err: err, op: "send mouse"}
+		}
+		if !resp.GetSent() && req.Button == proto.MouseButton_MOUSE_BUTTON_WHEEL_UP {
+			return copyModeRequestMsg{sessionID: sessionID, scroll: copyModeWheelLines}
+		}
+		return nil
+	}
+}
+
+// resizeCmd reports the focused pane's size to its session. Read-only viewers
+// never resize.
+func (m attachModel) resizeCmd() tea.Cmd {
+	if m.readOnly {
+		return nil
+	}
+	r := m.rectForPane(m.paneID)
+	return resizeCmd(m.client, m.sessionID, m.sessionCoord, m.clientID, r.w, r.h)
+}
+
+func resizeCmd(client proto.VTRClient, id, coordinator, clientID string, cols, rows int) tea.Cmd {
 	if cols <= 0 || rows <= 0 {
 		return nil
 	}
@@ -1143,9 +1429,10 @@ func resizeCmd(client proto.VTRClient, id, coordinator string, cols, rows int) t
 		defer cancel()
 		sessionRef := sessionRequestRef(id, coordinator)
 		_, err := client.Resize(ctx, &proto.ResizeRequest{
-			Session: sessionRef,
-			Cols:    int32(cols),
-			Rows:    int32(rows),
+			Session:  sessionRef,
+			Cols:     int32(cols),
+			Rows:     int32(rows),
+			ClientId: clientID,
 		})
 		if err != nil {
 			return rpcErrMsg{err: err, op: "resize"}
@@ -1154,7 +1441,39 @@ func resizeCmd(client proto.VTRClient, id, coordinator string, cols, rows int) t
 	}
 }
 
-func sendBytesCmd(client proto.VTRClient, id, coordinator string, data []byte) tea.Cmd {
+// teaOutput serializes writes to the terminal. The renderer writes each frame
+// in one call, so other writers sharing it cannot split a frame. It keeps the
+// file's descriptor visible so Bubble Tea still treats it as a terminal.
+type teaOutput struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (o *teaOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Write(p)
+}
+
+func (o *teaOutput) Read(p []byte) (int, error) { return o.file.Read(p) }
+func (o *teaOutput) Close() error               { return o.file.Close() }
+func (o *teaOutput) Fd() uintptr                { return o.file.Fd() }
+
+// keymap returns the model's key bindings, or the defaults when it has none.
+func (m attachModel) keymap() tuiKeymap {
+	if m.keys.leader == "" {
+		return defaultTuiKeymap
+	}
+	return m.keys
+}
+
+// tuiClientID identifies this TUI process in ListClients, distinct from the
+// agent CLI running as the same user.
+func tuiClientID() string {
+	return fmt.Sprintf("%s/tui-%d", agentClientID(), os.Getpid())
+}
+
+func sendBytesCmd(client proto.VTRClient, id, coordinator, clientID string, data []byte) tea.Cmd {
 	if len(data) == 0 {
 		return nil
 	}
@@ -1164,8 +1483,9 @@ func sendBytesCmd(client proto.VTRClient, id, coordinator string, data []byte) t
 		defer cancel()
 		sessionRef := sessionRequestRef(id, coordinator)
 		_, err := client.SendBytes(ctx, &proto.SendBytesRequest{
-			Session: sessionRef,
-			Data:    payload,
+			Session:  sessionRef,
+			Data:     payload,
+			ClientId: clientID,
 		})
 		if err != nil {
 			return rpcErrMsg{err: err, op: "send bytes"}
@@ -1174,7 +1494,37 @@ func sendBytesCmd(client proto.VTRClient, id, coordinator string, data []byte) t
 	}
 }
 
-func sendKeyCmd(client proto.VTRClient, id, coordinator, key string) tea.Cmd {
+// pasteCmd pastes text from the host terminal so the session program gets
+// its own bracketed paste.
+func pasteCmd(client proto.VTRClient, id, coordinator, clientID, text string) tea.Cmd {
+	if text == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), pasteTimeoutDefault)
+		defer cancel()
+		stream, err := client.Paste(ctx, &proto.PasteRequest{
+			Session:  sessionRequestRef(id, coordinator),
+			Data:     []byte(text),
+			ClientId: clientID,
+		})
+		if err == nil {
+			for {
+				var event *proto.PasteProgress
+				event, err = stream.Recv()
+				if err != nil || event.GetDone() {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return rpcErrMsg{err: err, op: "paste"}
+		}
+		return nil
+	}
+}
+
+func sendKeyCmd(client proto.VTRClient, id, coordinator, clientID, key string) tea.Cmd {
 	if strings.TrimSpace(key) == "" {
 		return nil
 	}
@@ -1183,8 +1533,9 @@ func sendKeyCmd(client proto.VTRClient, id, coordinator, key string) tea.Cmd {
 		defer cancel()
 		sessionRef := sessionRequestRef(id, coordinator)
 		_, err := client.SendKey(ctx, &proto.SendKeyRequest{
-			Session: sessionRef,
-			Key:     key,
+			Session:  sessionRef,
+			Key:      key,
+			ClientId: clientID,
 		})
 		if err != nil {
 			return rpcErrMsg{err: err, op: "send key"}
@@ -1378,6 +1729,9 @@ func loadSessionListCmd(client proto.VTRClient, activate bool, fallbackCoord str
 				exitCode: session.ExitCode,
 				idle:     session.GetIdle(),
 				order:    session.GetOrder(),
+
+				lockHolder: session.GetInputLock().GetHolder(),
+				attached:   session.GetAttachedClients(),
 			}
 			out = append(out, entry)
 		}
@@ -1435,6 +1789,9 @@ func sessionItemsFromSnapshot(snapshot *proto.SessionsSnapshot) ([]coordinatorRe
 				exitCode: session.ExitCode,
 				idle:     session.GetIdle(),
 				order:    session.GetOrder(),
+
+				lockHolder: session.GetInputLock().GetHolder(),
+				attached:   session.GetAttachedClients(),
 			})
 		}
 	}
@@ -1496,11 +1853,11 @@ func sessionFromSpawnResponse(resp *proto.SpawnResponse, requestName string) (st
 	return resp.Session.GetId(), label, coord, nil
 }
 
-func spawnCurrentCmd(client proto.VTRClient, name string) tea.Cmd {
+func spawnCurrentCmd(client proto.VTRClient, name, profile string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 		defer cancel()
-		resp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: name})
+		resp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: name, Profile: profile, ClientId: tuiClientID()})
 		if err != nil {
 			return rpcErrMsg{err: err, op: "spawn"}
 		}
@@ -1542,7 +1899,7 @@ func spawnAutoSessionCmd(client proto.VTRClient, base string) tea.Cmd {
 				continue
 			}
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
-			resp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: candidate})
+			resp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: candidate, ClientId: tuiClientID()})
 			cancel()
 			if err == nil {
 				id, label, coord, err := sessionFromSpawnResponse(resp, candidate)
@@ -1606,7 +1963,7 @@ func spawnSessionCmd(coord coordinatorRef, cfg *clientConfig, name string) tea.C
 		client := proto.NewVTRClient(conn)
 		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 		defer cancel()
-		resp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: name})
+		resp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: name, ClientId: tuiClientID()})
 		if err != nil {
 			_ = conn.Close()
 			return spawnSessionMsg{err: err, coord: coord}
@@ -1621,41 +1978,48 @@ func spawnSessionCmd(coord coordinatorRef, cfg *clientConfig, name string) tea.C
 
 func handleLeaderKey(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
 	key := msg.String()
-	if len(key) == 1 {
-		key = strings.ToLower(key)
+	keys := m.keymap()
+	action, ok := keys.lookup(key)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("unknown leader key: %s", key)
+		m.statusUntil = time.Now().Add(2 * time.Second)
+		return m, nil
 	}
 	if m.exited {
-		switch key {
-		case "ctrl+b", "x":
+		switch action {
+		case actionSendLeader, actionKill:
 			m.statusMsg = "session exited"
 			m.statusUntil = time.Now().Add(2 * time.Second)
 			return m, nil
 		}
 	}
-	switch key {
-	case "ctrl+b":
-		return m, sendKeyCmd(m.client, m.sessionID, m.sessionCoord, "ctrl+b")
-	case "d":
+	switch action {
+	case actionSendLeader:
+		if m.readOnly {
+			return m, nil
+		}
+		return m, sendKeyCmd(m.client, m.sessionID, m.sessionCoord, m.clientID, keys.leader)
+	case actionDetach:
 		return m, tea.Quit
-	case "x":
+	case actionKill:
 		m.statusMsg = "kill sent"
 		m.statusUntil = time.Now().Add(2 * time.Second)
 		return m, killCmd(m.client, m.sessionID, m.sessionCoord)
-	case "j", "n", "l":
+	case actionNext:
 		if msg, ok := nextSessionFromTabs(m, true); ok {
 			return switchSession(m, msg)
 		}
 		return m, nextSessionCmd(m.client, m.sessionID, true, m.showExited, m.hub.Name)
-	case "k", "p", "h":
+	case actionPrev:
 		if msg, ok := nextSessionFromTabs(m, false); ok {
 			return switchSession(m, msg)
 		}
 		return m, nextSessionCmd(m.client, m.sessionID, false, m.showExited, m.hub.Name)
-	case "c":
+	case actionCreate:
 		return beginCreateModal(m)
-	case "r":
+	case actionRename:
 		return beginRenameModal(m)
-	case "e":
+	case actionShowClosed:
 		m.showExited = !m.showExited
 		if m.showExited {
 			m.statusMsg = "showing closed sessions"
@@ -1666,12 +2030,28 @@ func handleLeaderKey(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
 		cmd := m.sessionList.SetItems(sessionItemsToListItems(visibleSessionItems(m), m.coords, m.coordinator.Name))
 		skipSessionListHeaders(&m.sessionList, 1)
 		return m, cmd
-	case "w":
+	case actionList:
 		m.listActive = true
 		return m, nil
+	case actionSplitCols:
+		return splitPane(m, paneSplitCols)
+	case actionSplitRows:
+		return splitPane(m, paneSplitRows)
+	case actionFocusLeft, actionFocusRight, actionFocusUp, actionFocusDown:
+		return movePaneFocus(m, strings.TrimPrefix(string(action), "focus-"))
+	case actionFocusNext:
+		return cyclePaneFocus(m)
+	case actionResizeLeft, actionResizeRight, actionResizeUp, actionResizeDown:
+		return resizePane(m, strings.TrimPrefix(string(action), "resize-"))
+	case actionZoom:
+		return togglePaneZoom(m)
+	case actionClosePane:
+		return closePane(m)
+	case actionSaveLayout:
+		return beginLayoutSaveModal(m)
+	case actionCopyMode:
+		return beginCopyMode(m, 0)
 	default:
-		m.statusMsg = fmt.Sprintf("unknown leader key: %s", key)
-		m.statusUntil = time.Now().Add(2 * time.Second)
 		return m, nil
 	}
 }
@@ -1681,12 +2061,33 @@ func handleMouse(m attachModel, msg tea.MouseMsg) (attachModel, tea.Cmd) {
 		m.hoverTabID = ""
 		m.hoverNewCoord = ""
 	}
-	if m.listActive || m.createActive || m.renameActive {
+	if m.listActive || m.createActive || m.renameActive || m.layoutSaveActive {
 		if m.hoverTabID != "" || m.hoverNewCoord != "" {
 			clearHover()
 		}
 		return m, nil
 	}
+	if id, ok := m.paneAt(msg.X, msg.Y); ok && id != m.paneID {
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			m = exitCopyMode(focusPane(m, id))
+		}
+		return m, nil
+	}
+	if _, _, ok := m.viewportCell(msg.X, msg.Y); ok && m.copyMode.active {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.copyMode.scroll(-copyModeWheelLines, m.rectForPane(m.paneID).h)
+		case tea.MouseButtonWheelDown:
+			m.copyMode.scroll(copyModeWheelLines, m.rectForPane(m.paneID).h)
+		}
+		return m, nil
+	}
+	if x, y, ok := m.viewportCell(msg.X, msg.Y); ok {
+		if m.hoverTabID != "" || m.hoverNewCoord != "" {
+			clearHover()
+		}
+		return m, m.mouseCmd(msg, x, y)
+	}
 	if msg.Action == tea.MouseActionMotion {
 		if msg.Y != 0 || m.width <= 0 {
 			if m.hoverTabID != "" || m.hoverNewCoord != "" {
@@ -1836,14 +2237,20 @@ func handleInputKey(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
 		}
 		return m, nil
 	}
+	if m.readOnly {
+		return m, nil
+	}
+	if msg.Paste && msg.Type == tea.KeyRunes {
+		return m, pasteCmd(m.client, m.sessionID, m.sessionCoord, m.clientID, string(msg.Runes))
+	}
 	key, data, ok := inputForKey(msg)
 	if !ok {
 		return m, nil
 	}
 	if len(data) > 0 {
-		return m, sendBytesCmd(m.client, m.sessionID, m.sessionCoord, data)
+		return m, sendBytesCmd(m.client, m.sessionID, m.sessionCoord, m.clientID, data)
 	}
-	return m, sendKeyCmd(m.client, m.sessionID, m.sessionCoord, key)
+	return m, sendKeyCmd(m.client, m.sessionID, m.sessionCoord, m.clientID, key)
 }
 
 func inputForKey(msg tea.KeyMsg) (string, []byte, bool) {
@@ -1975,6 +2382,8 @@ func beginCreateModal(m attachModel) (attachModel, tea.Cmd) {
 	m.listActive = false
 	m.renameActive = false
 	m.createFocusInput = true
+	m.createFocusProf = false
+	m.createProfileIdx = 0
 	m.createCoordIdx = coordinatorIndex(m.coords, m.coordinator)
 	if m.createCoordIdx < 0 {
 		m.createCoordIdx = 0
@@ -2113,7 +2522,15 @@ func updateCreateModal(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
 		m.createInput.Blur()
 		return m, nil
 	case "tab":
-		m.createFocusInput = !m.createFocusInput
+		switch {
+		case m.createFocusInput:
+			m.createFocusInput = false
+		case !m.createFocusProf && len(profileNames(m.cfg)) > 0:
+			m.createFocusProf = true
+		default:
+			m.createFocusProf = false
+			m.createFocusInput = true
+		}
 		if m.createFocusInput {
 			m.createInput.Focus()
 		} else {
@@ -2137,13 +2554,22 @@ func updateCreateModal(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
 		if m.multiCoordinator || shouldPrefixCoordinator(m.hub.Name, coord.Name) {
 			spawnName = prefixSessionLabel(coord.Name, name)
 		}
-		return m, spawnCurrentCmd(m.client, spawnName)
+		return m, spawnCurrentCmd(m.client, spawnName, createProfile(m))
 	case "j", "down":
+		if m.createFocusProf {
+			m.createProfileIdx = (m.createProfileIdx + 1) % (len(profileNames(m.cfg)) + 1)
+			return m, nil
+		}
 		if !m.createFocusInput && len(m.coords) > 0 {
 			m.createCoordIdx = (m.createCoordIdx + 1) % len(m.coords)
 		}
 		return m, nil
 	case "k", "up":
+		if m.createFocusProf {
+			count := len(profileNames(m.cfg)) + 1
+			m.createProfileIdx = (m.createProfileIdx - 1 + count) % count
+			return m, nil
+		}
 		if !m.createFocusInput && len(m.coords) > 0 {
 			m.createCoordIdx = (m.createCoordIdx - 1 + len(m.coords)) % len(m.coords)
 		}
@@ -2157,6 +2583,16 @@ func updateCreateModal(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
 	return m, nil
 }
 
+// createProfile returns the profile picked in the create modal. Index 0 is
+// "no profile".
+func createProfile(m attachModel) string {
+	names := profileNames(m.cfg)
+	if m.createProfileIdx <= 0 || m.createProfileIdx > len(names) {
+		return ""
+	}
+	return names[m.createProfileIdx-1]
+}
+
 func updateRenameModal(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -2213,7 +2649,8 @@ func switchSession(m attachModel, msg sessionSwitchMsg) (attachModel, tea.Cmd) {
 		m.streamCancel()
 		m.streamCancel = nil
 	}
-	m.streamID++
+	m.streamID = nextStreamID(m)
+	m.streamSeq = m.streamID
 	m.streamBackoff = time.Second
 	m.streamState = "connecting"
 	m.lastScreenAt = time.Time{}
@@ -2231,6 +2668,7 @@ func switchSession(m attachModel, msg sessionSwitchMsg) (attachModel, tea.Cmd) {
 	m.frameID = 0
 	m.exited = false
 	m.exitCode = 0
+	m.copyMode = copyModeState{}
 	m.leaderActive = false
 	m.listActive = false
 	m.createActive = false
@@ -2245,13 +2683,13 @@ func switchSession(m attachModel, msg sessionSwitchMsg) (attachModel, tea.Cmd) {
 	m.statusUntil = time.Now().Add(2 * time.Second)
 	m.sessionItems = ensureSessionItem(m.sessionItems, m.sessionID, m.sessionLabel, false, 0, m.coordinator.Name)
 	cmds := []tea.Cmd{
-		startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID),
+		startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID, m.presence()),
 	}
 	cmd := m.sessionList.SetItems(sessionItemsToListItems(visibleSessionItems(m), m.coords, m.coordinator.Name))
 	skipSessionListHeaders(&m.sessionList, 1)
 	cmds = append(cmds, cmd)
 	if m.viewportWidth > 0 && m.viewportHeight > 0 {
-		cmds = append(cmds, resizeCmd(m.client, m.sessionID, m.sessionCoord, m.viewportWidth, m.viewportHeight))
+		cmds = append(cmds, m.resizeCmd())
 	}
 	return m, tea.Batch(cmds...)
 }
@@ -2606,7 +3044,8 @@ func resubscribe(m attachModel, reason string) (attachModel, tea.Cmd) {
 		m.streamCancel()
 		m.streamCancel = nil
 	}
-	m.streamID++
+	m.streamID = nextStreamID(m)
+	m.streamSeq = m.streamID
 	m.streamBackoff = time.Second
 	m.streamState = "connecting"
 	m.lastScreenAt = time.Time{}
@@ -2615,9 +3054,9 @@ func resubscribe(m attachModel, reason string) (attachModel, tea.Cmd) {
 		m.statusMsg = fmt.Sprintf("resync: %s", reason)
 		m.statusUntil = time.Now().Add(2 * time.Second)
 	}
-	cmds := []tea.Cmd{startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID)}
+	cmds := []tea.Cmd{startSubscribeCmd(m.client, m.sessionID, m.sessionCoord, m.streamID, m.presence())}
 	if m.viewportWidth > 0 && m.viewportHeight > 0 {
-		cmds = append(cmds, resizeCmd(m.client, m.sessionID, m.sessionCoord, m.viewportWidth, m.viewportHeight))
+		cmds = append(cmds, m.resizeCmd())
 	}
 	return m, tea.Batch(cmds...)
 }
@@ -2638,12 +3077,19 @@ func renderScreen(screen *proto.GetScreenResponse, width, height int, now time.T
 	if width <= 0 || height <= 0 {
 		return ""
 	}
+	return strings.Join(renderScreenLines(screen, width, height, true), "\n")
+}
+
+// renderScreenLines renders screen as height lines of width cells.
+func renderScreenLines(screen *proto.GetScreenResponse, width, height int, cursorOn bool) []string {
+	if height <= 0 {
+		return nil
+	}
 	cursorX, cursorY := -1, -1
 	if screen != nil {
 		cursorX = int(screen.CursorX)
 		cursorY = int(screen.CursorY)
 	}
-	cursorOn := true
 	lines := make([]string, height)
 	for row := 0; row < height; row++ {
 		var screenRow *proto.ScreenRow
@@ -2652,7 +3098,7 @@ func renderScreen(screen *proto.GetScreenResponse, width, height int, now time.T
 		}
 		lines[row] = renderRow(screenRow, width, row, cursorX, cursorY, cursorOn)
 	}
-	return strings.Join(lines, "\n")
+	return lines
 }
 
 func renderRow(row *proto.ScreenRow, width int, rowIdx, cursorX, cursorY int, cursorOn bool) string {
@@ -2807,7 +3253,9 @@ func renderCreateModal(m attachModel) string {
 		coordName = m.coords[m.createCoordIdx].Name
 	}
 	focus := "name"
-	if !m.createFocusInput {
+	if m.createFocusProf {
+		focus = "profile"
+	} else if !m.createFocusInput {
 		focus = "coordinator"
 	}
 	lines := []string{
@@ -2815,10 +3263,19 @@ func renderCreateModal(m attachModel) string {
 		"",
 		m.createInput.View(),
 		fmt.Sprintf("Coordinator: %s", coordName),
+	}
+	if len(profileNames(m.cfg)) > 0 {
+		profile := createProfile(m)
+		if profile == "" {
+			profile = "(none)"
+		}
+		lines = append(lines, fmt.Sprintf("Profile: %s", profile))
+	}
+	lines = append(lines,
 		fmt.Sprintf("Focus: %s", focus),
-		"Tab switches field; j/k changes coordinator",
+		"Tab switches field; j/k changes coordinator or profile",
 		"Enter to create, Esc to cancel",
-	}
+	)
 	content := strings.Join(lines, "\n")
 	box := attachModalStyle.Render(content)
 	return lipgloss.Place(m.viewportWidth, m.viewportHeight, lipgloss.Center, lipgloss.Center, box)
@@ -2924,29 +3381,39 @@ type headerView struct {
 type footerView struct {
 	width       int
 	leader      bool
+	keys        tuiKeymap
 	statusMsg   string
 	streamState string
+	pane        string
+	copyMode    string
 	exited      bool
 	coordinator string
 	active      sessionListItem
+	readOnly    bool
 	profiler    *renderProfiler
 }
 
 type legendSegment struct {
-	key   string
-	label string
+	actions []tuiAction
+	label   string
 }
 
+// leaderLegend is rendered with the chords bound in [tui.keys]; unbound
+// actions are left out.
 var leaderLegend = []legendSegment{
-	{key: "w", label: "LIST"},
-	{key: "j/l", label: "NEXT"},
-	{key: "k/h", label: "PREV"},
-	{key: "c", label: "CREATE"},
-	{key: "r", label: "RENAME"},
-	{key: "e", label: "CLOSED"},
-	{key: "d", label: "DETACH"},
-	{key: "x", label: "KILL"},
-	{key: "Ctrl+b", label: "SEND"},
+	{actions: []tuiAction{actionList}, label: "LIST"},
+	{actions: []tuiAction{actionNext}, label: "NEXT"},
+	{actions: []tuiAction{actionPrev}, label: "PREV"},
+	{actions: []tuiAction{actionCreate}, label: "CREATE"},
+	{actions: []tuiAction{actionRename}, label: "RENAME"},
+	{actions: []tuiAction{actionShowClosed}, label: "CLOSED"},
+	{actions: []tuiAction{actionSplitCols, actionSplitRows}, label: "SPLIT"},
+	{actions: []tuiAction{actionFocusNext}, label: "PANE"},
+	{actions: []tuiAction{actionZoom}, label: "ZOOM"},
+	{actions: []tuiAction{actionCopyMode}, label: "COPY"},
+	{actions: []tuiAction{actionDetach}, label: "DETACH"},
+	{actions: []tuiAction{actionKill}, label: "KILL"},
+	{actions: []tuiAction{actionSendLeader}, label: "SEND"},
 }
 
 const (
@@ -3230,6 +3697,21 @@ func renderFooterSegments(view footerView) (string, string) {
 	if view.streamState != "" {
 		leftSegments = append(leftSegments, attachFooterTagStyle.Render(" stream "+view.streamState+" "))
 	}
+	if view.pane != "" {
+		leftSegments = append(leftSegments, attachFooterTagStyle.Render(" "+view.pane+" "))
+	}
+	if view.copyMode != "" {
+		leftSegments = append(leftSegments, attachStatusStyle.Render(" "+view.copyMode+" "))
+	}
+	if view.readOnly {
+		leftSegments = append(leftSegments, attachFooterTagStyle.Render(" read-only "))
+	}
+	if others := view.active.attached - 1; others > 0 {
+		leftSegments = append(leftSegments, attachFooterTagStyle.Render(fmt.Sprintf(" +%d attached ", others)))
+	}
+	if view.active.lockHolder != "" {
+		leftSegments = append(leftSegments, attachFooterTagStyle.Render(" locked by "+view.active.lockHolder+" "))
+	}
 	if view.statusMsg != "" {
 		leftSegments = append(leftSegments, attachStatusStyle.Render(" "+view.statusMsg+" "))
 	}
@@ -3243,9 +3725,9 @@ func renderFooterSegments(view footerView) (string, string) {
 	right := ""
 	switch {
 	case view.leader:
-		right = renderLeaderLegend()
+		right = renderLeaderLegend(view.keys)
 	case view.statusMsg == "":
-		right = renderLeaderHint()
+		right = renderLeaderHint(view.keys)
 	}
 	if right != "" {
 		right = " " + right + " "
@@ -3253,14 +3735,18 @@ func renderFooterSegments(view footerView) (string, string) {
 	return left, right
 }
 
-func renderLeaderHint() string {
-	return renderLegendSegment("Ctrl+b", "LEADER")
+func renderLeaderHint(keys tuiKeymap) string {
+	return renderLegendSegment(formatKeyChord(keys.leader), "LEADER")
 }
 
-func renderLeaderLegend() string {
+func renderLeaderLegend(keys tuiKeymap) string {
 	segments := make([]string, 0, len(leaderLegend))
 	for _, hint := range leaderLegend {
-		segments = append(segments, renderLegendSegment(hint.key, hint.label))
+		key := keys.legendKey(hint.actions...)
+		if key == "" {
+			continue
+		}
+		segments = append(segments, renderLegendSegment(key, hint.label))
 	}
 	return joinLegendSegments(segments)
 }
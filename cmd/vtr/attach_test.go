@@ -1,12 +1,21 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	proto "github.com/advait/vtrpc/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestSessionSnapshotPrefixingForSpokeOnly(t *testing.T) {
@@ -586,3 +595,423 @@ func makeAttachTestScreen(cols, rows int32) *proto.GetScreenResponse {
 		ScreenRows: screenRows,
 	}
 }
+
+func TestRawInputFilter(t *testing.T) {
+	var filter rawInputFilter
+	out, detach := filter.feed([]byte("ls\r\x02"))
+	if string(out) != "ls\r" || detach {
+		t.Fatalf("feed=%q, %v", out, detach)
+	}
+	out, detach = filter.feed([]byte("\x02a\x02c"))
+	if string(out) != "\x02a\x02c" || detach {
+		t.Fatalf("literal prefix feed=%q, %v", out, detach)
+	}
+	out, detach = filter.feed([]byte("q\x02dignored"))
+	if string(out) != "q" || !detach {
+		t.Fatalf("detach feed=%q, %v", out, detach)
+	}
+}
+
+func TestRawAttachKeyframe(t *testing.T) {
+	screen := &proto.GetScreenResponse{
+		Cols:    2,
+		Rows:    2,
+		CursorX: 1,
+		CursorY: 1,
+		ScreenRows: []*proto.ScreenRow{
+			{Cells: []*proto.ScreenCell{{Char: "a"}, {Char: "b"}}},
+			{Cells: []*proto.ScreenCell{{Char: "$"}}},
+		},
+	}
+	want := "\x1b[0m\x1b[H\x1b[2J\x1b[1;1H\x1b[0m\x1b[0mab\x1b[0m\x1b[2;1H\x1b[0m\x1b[0m$ \x1b[0m\x1b[0m\x1b[1;2r\x1b[?25h\x1b[2;2H"
+	if got := string(rawAttachKeyframe(screen)); got != want {
+		t.Fatalf("rawAttachKeyframe=%q, want %q", got, want)
+	}
+
+	screen.ScrollTop, screen.ScrollBottom = 2, 2
+	screen.CursorHidden = true
+	if got := string(rawAttachKeyframe(screen)); !strings.HasSuffix(got, "\x1b[0m\x1b[2;2r\x1b[?25l\x1b[2;2H") {
+		t.Fatalf("expected scroll region and hidden cursor, got %q", got)
+	}
+
+	screen.MouseTracking = proto.MouseTracking_MOUSE_TRACKING_BUTTON
+	screen.MouseEncoding = proto.MouseEncoding_MOUSE_ENCODING_SGR
+	screen.BracketedPaste = true
+	screen.ApplicationCursorKeys = true
+	if got := string(rawAttachKeyframe(screen)); !strings.HasSuffix(got, "\x1b[0m\x1b[?1002h\x1b[?1006h\x1b[?2004h\x1b[?1h\x1b[2;2r\x1b[?25l\x1b[2;2H") {
+		t.Fatalf("expected input modes replayed, got %q", got)
+	}
+}
+
+func TestRawAttachSizeNotice(t *testing.T) {
+	if got := rawAttachSizeNotice(80, 24, 80, 24); got != "" {
+		t.Fatalf("expected no notice for matching sizes, got %q", got)
+	}
+	if got := rawAttachSizeNotice(80, 24, 100, 40); !strings.Contains(got, "\x1b[25;1H") || !strings.Contains(got, "[session is 80x24, terminal is 100x40]") {
+		t.Fatalf("expected notice below the session, got %q", got)
+	}
+	if got := rawAttachSizeNotice(80, 24, 20, 10); !strings.Contains(got, "\x1b[10;1H\x1b[0;7m[session is 80x24, t\x1b[0m") {
+		t.Fatalf("expected truncated notice on the last row, got %q", got)
+	}
+}
+
+func TestPaneLayoutParseFormat(t *testing.T) {
+	spec := "cols:60(editor, rows(server, spoke-a:logs))"
+	layout, err := parsePaneLayout(spec)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := formatPaneLayout(layout, nil); got != spec {
+		t.Fatalf("expected %q, got %q", spec, got)
+	}
+	layout = assignPaneIDs(layout, 1)
+	if ids := paneIDs(layout); len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Fatalf("unexpected pane ids %v", ids)
+	}
+	refs := map[int]string{1: "a", 2: "b", 3: "c"}
+	if got := formatPaneLayout(layout, refs); got != "cols:60(a, rows(b, c))" {
+		t.Fatalf("unexpected formatted layout %q", got)
+	}
+	for _, bad := range []string{"", "cols(a)", "grid(a, b)", "cols:95(a, b)", "rows(a, b) c"} {
+		if _, err := parsePaneLayout(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestPaneRectsAndNeighbors(t *testing.T) {
+	layout := assignPaneIDs(&paneLayout{
+		split:  paneSplitCols,
+		ratio:  50,
+		first:  &paneLayout{},
+		second: &paneLayout{split: paneSplitRows, ratio: 50, first: &paneLayout{}, second: &paneLayout{}},
+	}, 1)
+	rects := paneRects(layout, paneRect{w: 81, h: 21})
+	want := map[int]paneRect{
+		1: {x: 0, y: 0, w: 40, h: 21},
+		2: {x: 41, y: 0, w: 40, h: 10},
+		3: {x: 41, y: 11, w: 40, h: 10},
+	}
+	for id, r := range want {
+		if rects[id] != r {
+			t.Fatalf("pane %d: expected %+v, got %+v", id, r, rects[id])
+		}
+	}
+	if id, ok := paneNeighbor(rects, 1, "right"); !ok || id != 2 {
+		t.Fatalf("expected right of 1 to be 2, got %d %v", id, ok)
+	}
+	if id, ok := paneNeighbor(rects, 3, "up"); !ok || id != 2 {
+		t.Fatalf("expected up of 3 to be 2, got %d %v", id, ok)
+	}
+	if _, ok := paneNeighbor(rects, 1, "left"); ok {
+		t.Fatalf("expected no pane left of 1")
+	}
+	resized, ok := resizePaneLayout(layout, 3, paneSplitCols, -paneResizeStep)
+	if !ok || resized.ratio != 45 {
+		t.Fatalf("expected outer split to shrink to 45, got %d %v", resized.ratio, ok)
+	}
+	if layout.ratio != 50 {
+		t.Fatalf("expected original layout unchanged")
+	}
+}
+
+func TestSplitPaneParksFocusedSession(t *testing.T) {
+	model := attachModel{
+		sessionID:      "id-1",
+		sessionLabel:   "editor",
+		streamID:       4,
+		streamState:    "receiving",
+		viewportWidth:  81,
+		viewportHeight: 20,
+		readOnly:       true,
+		sessionList:    newSessionListModel(nil, 0, 0),
+	}
+	next, _ := splitPane(model, paneSplitCols)
+	if next.sessionID != "" || !next.listActive {
+		t.Fatalf("expected empty focused pane with session list, got %q", next.sessionID)
+	}
+	parked, ok := next.panes[model.paneID]
+	if !ok || parked.sessionID != "id-1" || parked.streamID != 4 {
+		t.Fatalf("expected original session parked, got %+v", parked)
+	}
+	if r := next.rectForPane(next.paneID); r.x != 41 || r.w != 40 {
+		t.Fatalf("expected new pane on the right, got %+v", r)
+	}
+	next.listActive = false
+	next, _ = movePaneFocus(next, "left")
+	if next.sessionID != "id-1" || next.streamState != "receiving" {
+		t.Fatalf("expected focus back on id-1, got %q", next.sessionID)
+	}
+	next, _ = togglePaneZoom(next)
+	if r := next.rectForPane(next.paneID); r.w != 81 {
+		t.Fatalf("expected zoomed pane to fill viewport, got %+v", r)
+	}
+	next, _ = closePane(next)
+	if next.layout != nil || next.sessionID != "" || len(next.panes) != 0 {
+		t.Fatalf("expected single empty pane after close, got %q", next.sessionID)
+	}
+}
+
+func TestBackgroundPaneReceivesScreenUpdates(t *testing.T) {
+	layout := assignPaneIDs(&paneLayout{split: paneSplitRows, ratio: 50, first: &paneLayout{}, second: &paneLayout{}}, 1)
+	model := attachModel{
+		sessionID:   "id-1",
+		streamID:    1,
+		streamSeq:   2,
+		paneID:      1,
+		layout:      layout,
+		sessionList: newSessionListModel(nil, 0, 0),
+		panes: map[int]tuiPane{
+			2: {sessionID: "id-2", streamID: 2, streamState: "connected"},
+		},
+	}
+	screen := screenFromRows("logs")
+	screen.Id = "id-2"
+	updated, _ := model.Update(subscribeEventMsg{
+		streamID: 2,
+		event: &proto.SubscribeEvent{Event: &proto.SubscribeEvent_ScreenUpdate{ScreenUpdate: &proto.ScreenUpdate{
+			FrameId: 1, IsKeyframe: true, Screen: screen,
+		}}},
+	})
+	next := updated.(attachModel)
+	if next.paneID != 1 || next.sessionID != "id-1" || next.screen != nil {
+		t.Fatalf("expected focus to stay on pane 1, got pane %d %q", next.paneID, next.sessionID)
+	}
+	pane := next.panes[2]
+	if pane.screen != screen || pane.frameID != 1 || pane.streamState != "receiving" {
+		t.Fatalf("expected background pane to take keyframe, got %+v", pane)
+	}
+	next, _ = resubscribe(next, "")
+	if next.streamID != 3 {
+		t.Fatalf("expected stream id past every pane, got %d", next.streamID)
+	}
+}
+
+func TestBackgroundPaneDisconnectKeepsTUI(t *testing.T) {
+	layout := assignPaneIDs(&paneLayout{split: paneSplitRows, ratio: 50, first: &paneLayout{}, second: &paneLayout{}}, 1)
+	model := attachModel{
+		sessionID:   "id-1",
+		streamID:    1,
+		streamSeq:   2,
+		paneID:      1,
+		layout:      layout,
+		streamState: "receiving",
+		sessionList: newSessionListModel(nil, 0, 0),
+		panes: map[int]tuiPane{
+			2: {sessionID: "id-2", sessionLabel: "logs", streamID: 2, streamState: "receiving"},
+		},
+	}
+	updated, cmd := model.Update(subscribeEventMsg{
+		streamID: 2,
+		err:      status.Error(codes.Aborted, "client disconnected"),
+	})
+	if cmd != nil {
+		t.Fatalf("expected no command for a disconnected background pane")
+	}
+	next := updated.(attachModel)
+	if next.paneID != 1 || next.sessionID != "id-1" || next.streamState != "receiving" || next.err != nil {
+		t.Fatalf("expected focused pane untouched, got pane %d %q %q", next.paneID, next.sessionID, next.streamState)
+	}
+	if pane := next.panes[2]; pane.streamState != "disconnected" {
+		t.Fatalf("expected background pane disconnected, got %q", pane.streamState)
+	}
+	if next.statusMsg != "logs: client disconnected" {
+		t.Fatalf("statusMsg=%q", next.statusMsg)
+	}
+}
+
+func TestAppendLayoutConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vtrpc.toml")
+	if err := os.WriteFile(path, []byte("[tui]\nspinner = \"dots\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := appendLayoutConfig(path, "dev", "cols(editor, spoke-a:logs)"); err != nil {
+		t.Fatalf("append layout: %v", err)
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.TUI.Spinner != "dots" {
+		t.Fatalf("expected existing settings kept, got %q", cfg.TUI.Spinner)
+	}
+	layout, err := configLayout(cfg, "dev")
+	if err != nil {
+		t.Fatalf("config layout: %v", err)
+	}
+	leaves := paneLeaves(layout)
+	if len(leaves) != 2 || leaves[1].ref != "spoke-a:logs" || leaves[1].pane != 2 {
+		t.Fatalf("unexpected layout leaves %+v", leaves)
+	}
+	if _, err := configLayout(cfg, "missing"); err == nil {
+		t.Fatalf("expected error for unknown layout")
+	}
+}
+
+func copyModeKeys(t *testing.T, m attachModel, keys ...string) attachModel {
+	t.Helper()
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "ctrl+v":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlV}
+		}
+		m, _ = handleCopyModeKey(m, msg)
+	}
+	return m
+}
+
+func TestCopyModeNavigationAndSearch(t *testing.T) {
+	screen := screenFromRows("$ make", "ok")
+	screen.HistoryLines = []string{"build started", "error: disk full", "retrying", "error: timeout"}
+	screen.CursorX, screen.CursorY = 0, 1
+	model := attachModel{sessionID: "id-1", viewportWidth: 20, viewportHeight: 3}
+	model.copyMode = newCopyMode("id-1", screen, 3)
+	if c := model.copyMode; c.y != 5 || c.top != 3 || len(c.rows) != 6 {
+		t.Fatalf("expected cursor on the live screen, got y=%d top=%d rows=%d", c.y, c.top, len(c.rows))
+	}
+
+	model = copyModeKeys(t, model, "?", "e", "r", "r")
+	if c := model.copyMode; !c.searching || c.y != 3 || c.x != 0 || c.top != 3 {
+		t.Fatalf("expected incremental search to land on the last error, got %d,%d top %d", c.y, c.x, c.top)
+	}
+	if matches := model.copyMode.matches(1); len(matches) != 1 || matches[0] != [2]int{0, 3} {
+		t.Fatalf("expected highlighted match on row 1, got %v", matches)
+	}
+	model = copyModeKeys(t, model, "enter", "n")
+	if c := model.copyMode; c.searching || c.y != 1 {
+		t.Fatalf("expected n to repeat the search upward, got row %d", c.y)
+	}
+	model = copyModeKeys(t, model, "N", "g", "w", "$")
+	if c := model.copyMode; c.y != 0 || c.x != 12 || c.top != 0 {
+		t.Fatalf("expected end of first line, got %d,%d top %d", c.y, c.x, c.top)
+	}
+	model = copyModeKeys(t, model, "q")
+	if model.copyMode.active {
+		t.Fatalf("expected q to leave copy mode")
+	}
+}
+
+func TestCopyModeSelections(t *testing.T) {
+	t.Setenv("TMUX", "")
+	var out bytes.Buffer
+	screen := screenFromRows("alpha beta", "gamma delta", "epsilon")
+	model := attachModel{sessionID: "id-1", viewportWidth: 20, viewportHeight: 3, output: &out}
+	model.copyMode = newCopyMode("id-1", screen, 3)
+
+	model = copyModeKeys(t, model, "g", "w", "v", "j")
+	if got := model.copyMode.selectionText(); got != "beta\ngamma d" {
+		t.Fatalf("unexpected character selection %q", got)
+	}
+	model = copyModeKeys(t, model, "V")
+	if got := model.copyMode.selectionText(); got != "alpha beta\ngamma delta" {
+		t.Fatalf("unexpected line selection %q", got)
+	}
+	model = copyModeKeys(t, model, "ctrl+v", "j", "l", "l")
+	if got := model.copyMode.selectionText(); got != "bet\ndel\nn" {
+		t.Fatalf("unexpected rectangle selection %q", got)
+	}
+	next, cmd := handleCopyModeKey(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if next.copyMode.active || cmd == nil {
+		t.Fatalf("expected y to copy and leave copy mode")
+	}
+
+	if msg := cmd(); msg != nil {
+		t.Fatalf("unexpected clipboard result %v", msg)
+	}
+	osc52 := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("bet\ndel\nn")) + "\x07"
+	if out.String() != osc52 {
+		t.Fatalf("expected OSC 52 %q on the model output, got %q", osc52, out.String())
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	out.Reset()
+	if msg := clipboardCmd(&out, "bet\ndel\nn")(); msg != nil {
+		t.Fatalf("unexpected clipboard result %v", msg)
+	}
+	if want := "\x1bPtmux;\x1b" + osc52 + "\x1b\\"; out.String() != want {
+		t.Fatalf("expected tmux passthrough %q, got %q", want, out.String())
+	}
+}
+
+func TestReadOnlyWheelOpensCopyMode(t *testing.T) {
+	model := attachModel{sessionID: "id-1", readOnly: true}
+	cmd := model.mouseCmd(tea.MouseMsg{Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress}, 0, 0)
+	if cmd == nil {
+		t.Fatalf("expected wheel up to request copy mode")
+	}
+	if msg, ok := cmd().(copyModeRequestMsg); !ok || msg.sessionID != "id-1" || msg.scroll != copyModeWheelLines {
+		t.Fatalf("unexpected message %#v", msg)
+	}
+}
+
+func TestTuiKeysConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vtrpc.toml")
+	if err := os.WriteFile(path, []byte("[tui.keys]\nleader = \"Ctrl+A\"\ndetach = [\"Q\", \"ctrl+d\"]\nclose-pane = \"X\"\nkill = []\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	keys, err := tuiKeymapFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("apply keys: %v", err)
+	}
+	if keys.leader != "ctrl+a" {
+		t.Fatalf("expected ctrl+a leader, got %q", keys.leader)
+	}
+	if (attachModel{}).keymap().leader != defaultLeaderKey {
+		t.Fatalf("expected models without a keymap to use the defaults")
+	}
+	if _, cmd := handleLeaderKey(attachModel{keys: keys}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Q")}); cmd == nil {
+		t.Fatalf("expected the model's keymap to bind Q to detach")
+	}
+	for key, want := range map[string]tuiAction{"Q": actionDetach, "ctrl+d": actionDetach, "X": actionClosePane, "ctrl+a": actionSendLeader, "j": actionNext} {
+		if got, ok := keys.lookup(key); !ok || got != want {
+			t.Fatalf("lookup %q = %q, want %q", key, got, want)
+		}
+	}
+	for _, key := range []string{"x", "d", "ctrl+b"} {
+		if got, ok := keys.lookup(key); ok {
+			t.Fatalf("expected %q to be unbound, got %q", key, got)
+		}
+	}
+
+	legend := ansi.Strip(renderLeaderLegend(keys))
+	if !strings.Contains(legend, "Q/Ctrl+d") || !strings.Contains(legend, "Ctrl+a") || strings.Contains(legend, "KILL") {
+		t.Fatalf("legend does not follow bindings: %q", legend)
+	}
+	if hint := ansi.Strip(renderLeaderHint(keys)); !strings.Contains(hint, "Ctrl+a") {
+		t.Fatalf("expected leader hint to show Ctrl+a, got %q", hint)
+	}
+
+	leader, detach, err := keys.rawPrefix()
+	if err != nil {
+		t.Fatalf("raw prefix: %v", err)
+	}
+	filter := rawInputFilter{leader: leader, detach: detach}
+	if out, quit := filter.feed([]byte("\x02\x01\x01\x01q")); string(out) != "\x02\x01" || !quit {
+		t.Fatalf("feed=%q, %v", out, quit)
+	}
+}
+
+func TestTuiKeysValidation(t *testing.T) {
+	cases := map[string]map[string]keyChords{
+		"unknown action":  {"explode": {"e"}},
+		"invalid key":     {"detach": {"ctrl+shift+nope"}},
+		"conflict":        {"detach": {"x"}},
+		"multiple leader": {"leader": {"ctrl+a", "ctrl+b"}},
+		"reserved esc":    {"zoom": {"esc"}},
+		"leader conflict": {"leader": {"d"}},
+	}
+	for name, overrides := range cases {
+		if _, err := newTuiKeymap(overrides); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
+	"os"
+	"os/user"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,6 +18,7 @@ import (
 
 	proto "github.com/advait/vtrpc/proto"
 	"github.com/advait/vtrpc/tracing"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
@@ -28,6 +32,7 @@ const (
 	waitTimeoutDefault  = 30 * time.Second
 	idleTimeoutDefault  = 30 * time.Second
 	idleDurationDefault = 5 * time.Second
+	pasteTimeoutDefault = 2 * time.Minute
 )
 
 var clientTraceOnce sync.Once
@@ -47,9 +52,15 @@ func ensureClientTracing() {
 
 func newListCmd() *cobra.Command {
 	var hub string
+	var selector string
 	cmd := &cobra.Command{
 		Use:   "ls",
 		Short: "List sessions",
+		Long: "List sessions. Use --selector to filter by tags or by the built-in " +
+			"name, id, status, idle and coordinator fields.",
+		Example: `vtr agent ls
+vtr agent ls -l owner=agent-7
+vtr agent ls -l owner=agent-7,idle=true`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			cfg, _, err := loadConfigWithPath()
 			if err != nil {
@@ -63,12 +74,12 @@ func newListCmd() *cobra.Command {
 			coords := make([]jsonCoordinator, 0)
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			err = withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				snapshot, snapErr := fetchSessionsSnapshot(ctx, client)
+				snapshot, snapErr := fetchSessionsSnapshotWithSelector(ctx, client, selector)
 				if snapErr == nil && snapshot != nil {
 					items, coords = snapshotToItems(snapshot, target)
 					return nil
 				}
-				resp, err := client.List(ctx, &proto.ListRequest{})
+				resp, err := client.List(ctx, &proto.ListRequest{Selector: selector})
 				if err != nil {
 					if snapErr != nil {
 						return fmt.Errorf("subscribe sessions: %w", snapErr)
@@ -122,11 +133,16 @@ func newListCmd() *cobra.Command {
 		},
 	}
 	addHubFlag(cmd, &hub)
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "filter sessions by selector (e.g. owner=agent-7,idle=true)")
 	return cmd
 }
 
 func fetchSessionsSnapshot(ctx context.Context, client proto.VTRClient) (*proto.SessionsSnapshot, error) {
-	stream, err := client.SubscribeSessions(ctx, &proto.SubscribeSessionsRequest{})
+	return fetchSessionsSnapshotWithSelector(ctx, client, "")
+}
+
+func fetchSessionsSnapshotWithSelector(ctx context.Context, client proto.VTRClient, selector string) (*proto.SessionsSnapshot, error) {
+	stream, err := client.SubscribeSessions(ctx, &proto.SubscribeSessionsRequest{Selector: selector})
 	if err != nil {
 		return nil, err
 	}
@@ -194,15 +210,35 @@ func newSpawnCmd() *cobra.Command {
 	var cwd string
 	var cols int
 	var rows int
+	var tags []string
+	var profile string
+	var term string
+	var locale string
+	var shellIntegration bool
+	var resizePolicy string
+	var isolation isolationFlags
 	cmd := &cobra.Command{
 		Use:   "spawn <name>",
 		Short: "Spawn a new session",
 		Long: "Spawn a new session. When connected to a hub with multiple coordinators, " +
 			"prefix the name with \"coordinator:\" to target a specific coordinator.",
 		Example: `vtr agent spawn demo --cmd "bash"
-vtr agent spawn spoke-a:demo --cmd "bash"`,
+vtr agent spawn spoke-a:demo --cmd "bash"
+vtr agent spawn demo --tag owner=agent-7 --tag task=1234
+vtr agent spawn --profile codex build
+vtr agent spawn legacy --cmd "vim" --term xterm-256color --locale en_US.UTF-8
+vtr agent spawn shared --resize-policy smallest
+vtr agent spawn sandbox --cmd "make test" --cpus 1 --memory 2G --pids 256 --ns user,pid,net`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			tagMap, err := parseTagArgs(tags)
+			if err != nil {
+				return err
+			}
+			iso, err := isolation.build()
+			if err != nil {
+				return err
+			}
 			cfg, _, err := loadConfigWithPath()
 			if err != nil {
 				return err
@@ -218,6 +254,17 @@ vtr agent spawn spoke-a:demo --cmd "bash"`,
 					Name:       args[0],
 					Command:    command,
 					WorkingDir: cwd,
+					Tags:       tagMap,
+					Profile:    profile,
+					Isolation:  iso,
+					Term:       term,
+					Locale:     locale,
+
+					ResizePolicy: resizePolicy,
+					ClientId:     agentClientID(),
+				}
+				if cmd.Flags().Changed("shell-integration") {
+					req.ShellIntegration = &shellIntegration
 				}
 				if cols > 0 {
 					req.Cols = int32(cols)
@@ -238,6 +285,110 @@ vtr agent spawn spoke-a:demo --cmd "bash"`,
 	cmd.Flags().StringVar(&cwd, "cwd", "", "working directory")
 	cmd.Flags().IntVar(&cols, "cols", 0, "columns (0 uses server default)")
 	cmd.Flags().IntVar(&rows, "rows", 0, "rows (0 uses server default)")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "session tag as key=value (repeatable)")
+	cmd.Flags().StringVar(&profile, "profile", "", "spawn profile configured on the coordinator ([profiles.<name>])")
+	cmd.Flags().StringVar(&term, "term", "", "TERM for the session (default from coordinator)")
+	cmd.Flags().StringVar(&locale, "locale", "", "LANG for the session (default from coordinator)")
+	cmd.Flags().BoolVar(&shellIntegration, "shell-integration", false, "inject vtr shell integration (default from coordinator; =false disables)")
+	cmd.Flags().StringVar(&resizePolicy, "resize-policy", "", "resize policy: latest, smallest, largest, fixed or owner (default from coordinator)")
+	isolation.register(cmd)
+	return cmd
+}
+
+func newTagCmd() *cobra.Command {
+	var hub string
+	cmd := &cobra.Command{
+		Use:   "tag <name> <key=value|key->...",
+		Short: "Set or remove session tags",
+		Long:  "Set session tags with key=value and remove them with a trailing dash (key-).",
+		Example: `vtr agent tag demo owner=agent-7 task=1234
+vtr agent tag demo task-`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			set, remove, err := parseTagUpdates(args[1:])
+			if err != nil {
+				return err
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.UpdateSession(ctx, &proto.UpdateSessionRequest{
+					Session:    sessionRef,
+					SetTags:    set,
+					RemoveTags: remove,
+				})
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), jsonSessionEnvelope{Session: sessionToJSON(resp.Session, target.Name)})
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	return cmd
+}
+
+func newCloneCmd() *cobra.Command {
+	var hub string
+	var copySize bool
+	var copyTags bool
+	cmd := &cobra.Command{
+		Use:   "clone <src> <new>",
+		Short: "Spawn a copy of a session",
+		Long: "Spawn a new session with the command, environment and spawn settings of <src>, " +
+			"in the current working directory of its process. When that cannot be read, the clone " +
+			"starts in the directory <src> was spawned in and a warning is printed on stderr. " +
+			"The clone runs on the same coordinator.",
+		Example: `vtr agent clone build build-2
+vtr agent clone spoke-a:build retry --copy-size --copy-tags`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.Clone(ctx, &proto.CloneRequest{
+					Session:  sessionRef,
+					Name:     args[1],
+					CopySize: copySize,
+					CopyTags: copyTags,
+				})
+				if err != nil {
+					return err
+				}
+				if reason := resp.GetWorkingDirFallback(); reason != "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s; cloned into the spawn working directory\n", reason)
+				}
+				return writeJSON(cmd.OutOrStdout(), jsonSessionEnvelope{Session: sessionToJSON(resp.Session, target.Name)})
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().BoolVar(&copySize, "copy-size", false, "use the source's current cols/rows")
+	cmd.Flags().BoolVar(&copyTags, "copy-tags", false, "copy the source's tags")
 	return cmd
 }
 
@@ -259,7 +410,7 @@ func newInfoCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
@@ -279,14 +430,65 @@ func newScreenCmd() *cobra.Command {
 	var hub string
 	var jsonOut bool
 	var ansi bool
+	var format string
+	var history bool
+	var lines string
+	var since uint64
 	cmd := &cobra.Command{
 		Use:   "screen <name>",
 		Short: "Fetch the current screen",
-		Args:  cobra.ExactArgs(1),
+		Long: "Fetch the current screen as text, ANSI text or JSON. --format llm prints a " +
+			"compact annotated screen for language models. --format html and " +
+			"--format svg render a self-contained page or image with colors, attributes and " +
+			"the cursor; add --history to render scrollback and the screen instead, optionally limited " +
+			"with --lines. --since prints a frame ID and only the rows changed since an " +
+			"earlier frame; pass --since 0 to start, and expect the full screen again when " +
+			"the coordinator no longer holds the base frame.",
+		Example: `vtr agent screen demo
+vtr agent screen demo --since 0
+vtr agent screen demo --since 42 --json
+vtr agent screen demo --format llm
+vtr agent screen demo --format html > demo.html
+vtr agent screen demo --format svg --history --lines -100: > demo.svg`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if jsonOut && ansi {
 				return fmt.Errorf("--json and --ansi are mutually exclusive")
 			}
+			if format == "" {
+				format = "text"
+				if jsonOut {
+					format = "json"
+				} else if ansi {
+					format = "ansi"
+				}
+			} else if jsonOut || ansi {
+				return fmt.Errorf("--format cannot be combined with --json or --ansi")
+			}
+			tracked := cmd.Flags().Changed("since")
+			var exportFormat proto.ExportFormat
+			switch format {
+			case "text", "ansi", "json", "llm":
+				if history || lines != "" {
+					return fmt.Errorf("--history and --lines require --format html or svg")
+				}
+			case "html":
+				exportFormat = proto.ExportFormat_EXPORT_FORMAT_HTML
+			case "svg":
+				exportFormat = proto.ExportFormat_EXPORT_FORMAT_SVG
+			default:
+				return fmt.Errorf("invalid --format %q (expected text, ansi, json, llm, html or svg)", format)
+			}
+			if tracked && format != "text" && format != "ansi" && format != "json" {
+				return fmt.Errorf("--since requires --format text, ansi or json")
+			}
+			startLine, endLine, err := parseLineRange(lines)
+			if err != nil {
+				return err
+			}
+			if lines != "" {
+				history = true
+			}
 			cfg, _, err := loadConfigWithPath()
 			if err != nil {
 				return err
@@ -298,18 +500,49 @@ func newScreenCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
-				resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: sessionRef})
+				if exportFormat != proto.ExportFormat_EXPORT_FORMAT_UNSPECIFIED {
+					resp, err := client.ExportScreen(ctx, &proto.ExportScreenRequest{
+						Session:   sessionRef,
+						Format:    exportFormat,
+						History:   history,
+						StartLine: startLine,
+						EndLine:   endLine,
+					})
+					if err != nil {
+						return err
+					}
+					_, err = cmd.OutOrStdout().Write(resp.Data)
+					return err
+				}
+				resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{
+					Session:                  sessionRef,
+					IncludeScrollbackLines:   format == "llm",
+					IncludeForegroundProcess: format == "llm",
+					TrackFrame:               tracked,
+					SinceFrameId:             since,
+				})
 				if err != nil {
 					return err
 				}
-				if jsonOut {
+				if tracked {
+					if format == "json" {
+						return writeJSON(cmd.OutOrStdout(), screenFrameToJSON(resp))
+					}
+					_, err := io.WriteString(cmd.OutOrStdout(), screenFrameToText(resp, format == "ansi"))
+					return err
+				}
+				if format == "llm" {
+					_, err := io.WriteString(cmd.OutOrStdout(), screenToLLM(resp))
+					return err
+				}
+				if format == "json" {
 					return writeJSON(cmd.OutOrStdout(), jsonScreenEnvelope{Screen: screenToJSON(resp)})
 				}
-				text := screenToText(resp, ansi)
+				text := screenToText(resp, format == "ansi")
 				if text == "" {
 					return nil
 				}
@@ -327,9 +560,39 @@ func newScreenCmd() *cobra.Command {
 	addHubFlag(cmd, &hub)
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output structured JSON")
 	cmd.Flags().BoolVar(&ansi, "ansi", false, "Include ANSI colors/attributes in text output")
+	cmd.Flags().StringVar(&format, "format", "", "output format: text, ansi, json, llm, html or svg")
+	cmd.Flags().BoolVar(&history, "history", false, "render scrollback and the screen (html/svg only)")
+	cmd.Flags().StringVar(&lines, "lines", "", "history line range start:end (negative counts from the end)")
+	cmd.Flags().Uint64Var(&since, "since", 0, "print only rows changed since this frame ID (0 for a full keyframe)")
 	return cmd
 }
 
+// parseLineRange parses "start:end" where either side may be empty and
+// negative values count from the last line.
+func parseLineRange(value string) (int32, int32, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, 0, nil
+	}
+	startText, endText, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid --lines %q (expected start:end)", value)
+	}
+	var bounds [2]int32
+	for i, part := range []string{startText, endText} {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(part, 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid --lines %q (expected start:end)", value)
+		}
+		bounds[i] = int32(n)
+	}
+	return bounds[0], bounds[1], nil
+}
+
 func newSendCmd() *cobra.Command {
 	var hub string
 	var submit bool
@@ -377,11 +640,11 @@ vtr agent send --wait-for-idle --idle 2s --timeout 30s demo "make test\n"`,
 			ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
-				if _, err = client.SendText(ctx, &proto.SendTextRequest{Session: sessionRef, Text: text}); err != nil {
+				if _, err = client.SendText(ctx, &proto.SendTextRequest{Session: sessionRef, Text: text, ClientId: agentClientID(), LockToken: agentLockToken()}); err != nil {
 					return err
 				}
 				result := jsonSend{OK: true}
@@ -427,11 +690,11 @@ func newKeyCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
-				_, err = client.SendKey(ctx, &proto.SendKeyRequest{Session: sessionRef, Key: args[1]})
+				_, err = client.SendKey(ctx, &proto.SendKeyRequest{Session: sessionRef, Key: args[1], ClientId: agentClientID(), LockToken: agentLockToken()})
 				if err != nil {
 					return err
 				}
@@ -465,11 +728,11 @@ func newRawCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
-				_, err = client.SendBytes(ctx, &proto.SendBytesRequest{Session: sessionRef, Data: data})
+				_, err = client.SendBytes(ctx, &proto.SendBytesRequest{Session: sessionRef, Data: data, ClientId: agentClientID(), LockToken: agentLockToken()})
 				if err != nil {
 					return err
 				}
@@ -481,6 +744,241 @@ func newRawCmd() *cobra.Command {
 	return cmd
 }
 
+func newMouseCmd() *cobra.Command {
+	var hub string
+	var button string
+	var action string
+	var shift, alt, ctrl bool
+	cmd := &cobra.Command{
+		Use:   "mouse <name> <x> <y>",
+		Short: "Send a mouse event to a session",
+		Long: "Send a mouse event at a 0-based cell position. The event is encoded for the mouse " +
+			"mode the program enabled; \"sent\" is false when the program is not tracking it.",
+		Example: `vtr agent mouse htop 10 5
+vtr agent mouse lazygit 40 12 --button right
+vtr agent mouse editor 0 3 --button wheel-down --action press
+vtr agent mouse editor 12 4 --action motion --button left`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			x, err := parseMouseCoord(args[1])
+			if err != nil {
+				return err
+			}
+			y, err := parseMouseCoord(args[2])
+			if err != nil {
+				return err
+			}
+			btn, err := parseMouseButton(button)
+			if err != nil {
+				return err
+			}
+			act, err := parseMouseAction(action)
+			if err != nil {
+				return err
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.SendMouse(ctx, &proto.SendMouseRequest{
+					Session:   sessionRef,
+					Button:    btn,
+					Action:    act,
+					X:         x,
+					Y:         y,
+					Shift:     shift,
+					Alt:       alt,
+					Ctrl:      ctrl,
+					ClientId:  agentClientID(),
+					LockToken: agentLockToken(),
+				})
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), jsonMouse{OK: true, Sent: resp.GetSent()})
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().StringVar(&button, "button", "left", "left, middle, right, wheel-up, wheel-down, wheel-left, wheel-right or none")
+	cmd.Flags().StringVar(&action, "action", "click", "click, press, release or motion")
+	cmd.Flags().BoolVar(&shift, "shift", false, "hold shift")
+	cmd.Flags().BoolVar(&alt, "alt", false, "hold alt")
+	cmd.Flags().BoolVar(&ctrl, "ctrl", false, "hold ctrl")
+	return cmd
+}
+
+func parseMouseCoord(value string) (int32, error) {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || parsed < 0 || parsed > int(^uint16(0)) {
+		return 0, fmt.Errorf("invalid cell coordinate %q", value)
+	}
+	return int32(parsed), nil
+}
+
+func parseMouseButton(value string) (proto.MouseButton, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "none", "":
+		return proto.MouseButton_MOUSE_BUTTON_UNSPECIFIED, nil
+	case "left":
+		return proto.MouseButton_MOUSE_BUTTON_LEFT, nil
+	case "middle":
+		return proto.MouseButton_MOUSE_BUTTON_MIDDLE, nil
+	case "right":
+		return proto.MouseButton_MOUSE_BUTTON_RIGHT, nil
+	case "wheel-up":
+		return proto.MouseButton_MOUSE_BUTTON_WHEEL_UP, nil
+	case "wheel-down":
+		return proto.MouseButton_MOUSE_BUTTON_WHEEL_DOWN, nil
+	case "wheel-left":
+		return proto.MouseButton_MOUSE_BUTTON_WHEEL_LEFT, nil
+	case "wheel-right":
+		return proto.MouseButton_MOUSE_BUTTON_WHEEL_RIGHT, nil
+	default:
+		return 0, fmt.Errorf("invalid --button %q", value)
+	}
+}
+
+func parseMouseAction(value string) (proto.MouseAction, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "click", "":
+		return proto.MouseAction_MOUSE_ACTION_CLICK, nil
+	case "press":
+		return proto.MouseAction_MOUSE_ACTION_PRESS, nil
+	case "release":
+		return proto.MouseAction_MOUSE_ACTION_RELEASE, nil
+	case "motion", "move", "drag":
+		return proto.MouseAction_MOUSE_ACTION_MOTION, nil
+	default:
+		return 0, fmt.Errorf("invalid --action %q", value)
+	}
+}
+
+func newPasteCmd() *cobra.Command {
+	var hub string
+	var file string
+	var bracketed string
+	var chunkSize int
+	var timeout time.Duration
+	var progress bool
+	cmd := &cobra.Command{
+		Use:   "paste <name> [text]",
+		Short: "Paste text or a file into a session",
+		Long: "Paste text into a session as a terminal would. The content is wrapped in bracketed " +
+			"paste markers when the program enabled that mode, so multi-line pastes are not run " +
+			"line by line. Without text, the content is read from --file or stdin.",
+		Example: `vtr agent paste repl $'def f():\n    return 1\n'
+vtr agent paste editor --file main.go
+git diff | vtr agent paste review --progress`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := pasteInput(cmd, args, file)
+			if err != nil {
+				return err
+			}
+			mode, err := parseBracketedFlag(bracketed)
+			if err != nil {
+				return err
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			if timeout <= 0 {
+				timeout = pasteTimeoutDefault
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				stream, err := client.Paste(ctx, &proto.PasteRequest{
+					Session:   sessionRef,
+					Data:      data,
+					Bracketed: mode,
+					ChunkSize: int32(chunkSize),
+					ClientId:  agentClientID(),
+					LockToken: agentLockToken(),
+				})
+				if err != nil {
+					return err
+				}
+				for {
+					event, err := stream.Recv()
+					if err != nil {
+						return err
+					}
+					if event.GetDone() {
+						return writeJSON(cmd.OutOrStdout(), jsonPaste{
+							OK:        true,
+							Written:   event.GetWritten(),
+							Total:     event.GetTotal(),
+							Bracketed: event.GetBracketed(),
+						})
+					}
+					if progress {
+						fmt.Fprintf(cmd.ErrOrStderr(), "pasted %d/%d bytes\n", event.GetWritten(), event.GetTotal())
+					}
+				}
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().StringVar(&file, "file", "", "read the content from a file (- for stdin)")
+	cmd.Flags().StringVar(&bracketed, "bracketed", "auto", "bracketed paste markers: auto (follow the program), on or off")
+	cmd.Flags().IntVar(&chunkSize, "chunk-size", 0, "PTY write size in bytes (0 uses server default)")
+	cmd.Flags().DurationVar(&timeout, "timeout", pasteTimeoutDefault, "overall paste timeout")
+	cmd.Flags().BoolVar(&progress, "progress", false, "report progress on stderr")
+	return cmd
+}
+
+// pasteInput returns the paste content from the text argument, --file, or
+// stdin.
+func pasteInput(cmd *cobra.Command, args []string, file string) ([]byte, error) {
+	if len(args) > 1 {
+		if file != "" {
+			return nil, errors.New("pass either text or --file, not both")
+		}
+		return []byte(args[1]), nil
+	}
+	if file != "" && file != "-" {
+		return os.ReadFile(expandPath(file))
+	}
+	return io.ReadAll(cmd.InOrStdin())
+}
+
+func parseBracketedFlag(value string) (*bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return nil, nil
+	case "on", "true":
+		enabled := true
+		return &enabled, nil
+	case "off", "false":
+		enabled := false
+		return &enabled, nil
+	default:
+		return nil, fmt.Errorf("invalid --bracketed %q (expected auto, on or off)", value)
+	}
+}
+
 func newResizeCmd() *cobra.Command {
 	var hub string
 	cmd := &cobra.Command{
@@ -507,11 +1005,170 @@ func newResizeCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
-				_, err = client.Resize(ctx, &proto.ResizeRequest{Session: sessionRef, Cols: int32(cols), Rows: int32(rows)})
+				_, err = client.Resize(ctx, &proto.ResizeRequest{Session: sessionRef, Cols: int32(cols), Rows: int32(rows), ClientId: agentClientID(), LockToken: agentLockToken()})
+				if err != nil {
+					return err
+				}
+				return writeOK(cmd.OutOrStdout())
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	return cmd
+}
+
+func newLockCmd() *cobra.Command {
+	var hub string
+	var lease time.Duration
+	var holder string
+	cmd := &cobra.Command{
+		Use:   "lock <name>",
+		Short: "Take exclusive input for a session",
+		Long: "Acquire or renew the input lock for a session. While the lease is active, input from " +
+			"other clients is rejected. The output includes a lock_token; export it as " +
+			"$VTR_LOCK_TOKEN so later agent commands can write, renew the lock and unlock.",
+		Example: `export VTR_LOCK_TOKEN=$(vtr agent lock demo | jq -r .lock_token)
+vtr agent lock demo --lease 5m --holder "deploy bot"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				if holder == "" {
+					holder = agentClientID()
+				}
+				resp, err := client.AcquireInputLock(ctx, &proto.AcquireInputLockRequest{
+					Session:   sessionRef,
+					LockToken: agentLockToken(),
+					Holder:    holder,
+					Lease:     durationpb.New(lease),
+				})
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), jsonLockGrant{
+					jsonInputLock: inputLockToJSON(resp.Lock),
+					LockToken:     resp.GetLockToken(),
+				})
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().DurationVar(&lease, "lease", 30*time.Second, "how long the lock lasts without renewal (max 10m)")
+	cmd.Flags().StringVar(&holder, "holder", "", "name shown to other clients (default: $VTR_CLIENT_ID or user@host)")
+	return cmd
+}
+
+func newUnlockCmd() *cobra.Command {
+	var hub string
+	cmd := &cobra.Command{
+		Use:   "unlock <name>",
+		Short: "Release the input lock for a session",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				_, err = client.ReleaseInputLock(ctx, &proto.ReleaseInputLockRequest{Session: sessionRef, LockToken: agentLockToken()})
+				if err != nil {
+					return err
+				}
+				return writeOK(cmd.OutOrStdout())
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	return cmd
+}
+
+func newClientsCmd() *cobra.Command {
+	var hub string
+	cmd := &cobra.Command{
+		Use:   "clients <name>",
+		Short: "List clients attached to a session",
+		Long: "List the TUI, web and agent clients streaming a session, with their peer address, " +
+			"identity, viewport size and connect time.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.ListClients(ctx, &proto.ListClientsRequest{Session: sessionRef})
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), clientsToJSON(resp.Clients))
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	return cmd
+}
+
+func newDisconnectCmd() *cobra.Command {
+	var hub string
+	cmd := &cobra.Command{
+		Use:   "disconnect <name> <client-id>",
+		Short: "Disconnect a client attached to a session",
+		Long:  "End the stream of an attached client. <client-id> is the id reported by `vtr agent clients`.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				_, err = client.DisconnectClient(ctx, &proto.DisconnectClientRequest{Session: sessionRef, Id: args[1]})
 				if err != nil {
 					return err
 				}
@@ -542,7 +1199,7 @@ func newKillCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
@@ -577,7 +1234,7 @@ func newRemoveCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
@@ -626,7 +1283,7 @@ func newGrepCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
@@ -652,6 +1309,82 @@ func newGrepCmd() *cobra.Command {
 	return cmd
 }
 
+func newFindCmd() *cobra.Command {
+	var hub string
+	var rect string
+	var maxMatches int
+	cmd := &cobra.Command{
+		Use:   "find <name> <pattern>",
+		Short: "Find text on the visible screen",
+		Long: "Find regex matches on the visible screen and report their 0-based row and " +
+			"cell columns (end_col is exclusive) with the colors and attributes of the first " +
+			"matched cell. Use --rect to search part of the screen.",
+		Example: `vtr agent find htop 'F10Quit'
+vtr agent find menu 'Save' --rect 0,0,40,10`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pattern := strings.Join(args[1:], " ")
+			screenRect, err := parseScreenRect("--rect", rect)
+			if err != nil {
+				return err
+			}
+			if maxMatches <= 0 {
+				maxMatches = 100
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.FindOnScreen(ctx, &proto.FindOnScreenRequest{
+					Session:    sessionRef,
+					Pattern:    pattern,
+					Rect:       screenRect,
+					MaxMatches: int32(maxMatches),
+				})
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), findToJSON(resp.Matches))
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().StringVar(&rect, "rect", "", "search only x,y,width,height (0 width/height extends to the edge)")
+	cmd.Flags().IntVar(&maxMatches, "max", 100, "maximum matches")
+	return cmd
+}
+
+func parseScreenRect(flag, value string) (*proto.ScreenRect, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid %s %q (expected x,y,width,height)", flag, value)
+	}
+	nums := make([]int32, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s %q (expected x,y,width,height)", flag, value)
+		}
+		nums[i] = int32(n)
+	}
+	return &proto.ScreenRect{X: nums[0], Y: nums[1], Width: nums[2], Height: nums[3]}, nil
+}
+
 func newWaitCmd() *cobra.Command {
 	var hub string
 	var timeout time.Duration
@@ -676,7 +1409,7 @@ func newWaitCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
 			defer cancel()
 			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
-				sessionRef, _, err := resolveSessionRef(ctx, client, args[0], "")
+				sessionRef, _, err := resolveSessionRef(args[0], "")
 				if err != nil {
 					return err
 				}
@@ -732,7 +1465,7 @@ func newIdleCmd() *cobra.Command {
 				}
 				targets := make([]idleTarget, 0, len(args))
 				for _, arg := range args {
-					sessionRef, label, err := resolveSessionRef(ctx, client, arg, "")
+					sessionRef, label, err := resolveSessionRef(arg, "")
 					if err != nil {
 						return err
 					}
@@ -784,9 +1517,13 @@ func newIdleCmd() *cobra.Command {
 					if !res.idle {
 						return out
 					}
+					id := res.target.ref.GetId()
+					if id == "" {
+						id = res.screen.GetId()
+					}
 					out = append(out, jsonIdleSession{
 						Coordinator: strings.TrimSpace(res.target.ref.GetCoordinator()),
-						ID:          res.target.ref.GetId(),
+						ID:          id,
 						Name:        res.target.name,
 						Idle:        res.idle,
 						TimedOut:    res.timedOut,
@@ -879,43 +1616,31 @@ func withCoordinator(ctx context.Context, coord coordinatorRef, cfg *clientConfi
 	return fn(client)
 }
 
-func resolveSessionRef(ctx context.Context, client proto.VTRClient, ref string, defaultCoordinator string) (*proto.SessionRef, string, error) {
-	snapshot, err := fetchSessionsSnapshot(ctx, client)
-	if err == nil && snapshot != nil {
-		items, _ := snapshotToItems(snapshot, coordinatorRef{})
-		id, label, coord, err := matchSessionRefWithCoordinator(ref, items)
-		if err == nil {
-			if coord == "" {
-				coord = strings.TrimSpace(defaultCoordinator)
-			}
-			return &proto.SessionRef{Id: id, Coordinator: coord}, label, nil
-		}
-		if !errors.Is(err, errSessionNotFound) {
-			return nil, "", err
-		}
+// resolveSessionRef builds a SessionRef from a CLI argument without a round
+// trip. UUIDs (optionally "coordinator:uuid") address sessions by id; anything
+// else is sent as a label and resolved by the server, which also understands
+// "coordinator:label".
+func resolveSessionRef(ref string, defaultCoordinator string) (*proto.SessionRef, string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, "", errors.New("session name is required")
 	}
-
-	resp, err := client.List(ctx, &proto.ListRequest{})
-	if err != nil {
-		return nil, "", err
+	coord := strings.TrimSpace(defaultCoordinator)
+	if isSessionID(ref) {
+		return &proto.SessionRef{Id: ref, Coordinator: coord}, "", nil
 	}
-	id, label, err := matchSessionRef(ref, resp.Sessions)
-	if err != nil {
-		if errors.Is(err, errSessionNotFound) {
-			return nil, "", fmt.Errorf("session %q not found", ref)
+	if parsedCoord, session, ok := parseSessionRef(ref); ok {
+		if isSessionID(session) {
+			return &proto.SessionRef{Id: session, Coordinator: parsedCoord}, "", nil
 		}
-		return nil, "", err
-	}
-	coord := ""
-	if parsedCoord, _, ok := parseSessionRef(label); ok {
-		coord = parsedCoord
-	} else if parsedCoord, _, ok := parseSessionRef(ref); ok {
-		coord = parsedCoord
-	}
-	if coord == "" {
-		coord = strings.TrimSpace(defaultCoordinator)
+		return &proto.SessionRef{Label: session, Coordinator: parsedCoord}, ref, nil
 	}
-	return &proto.SessionRef{Id: id, Coordinator: coord}, label, nil
+	return &proto.SessionRef{Label: ref, Coordinator: coord}, ref, nil
+}
+
+func isSessionID(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil && len(value) == 36
 }
 
 func dialClient(ctx context.Context, target string, cfg *clientConfig) (*grpc.ClientConn, error) {
@@ -965,6 +1690,29 @@ func addHubFlag(cmd *cobra.Command, target *string) {
 	cmd.Flags().StringVar(target, "hub", "", "hub address (host:port)")
 }
 
+// agentClientID identifies this CLI to coordinators, e.g. as the owner of the
+// sessions it spawns.
+func agentClientID() string {
+	if id := strings.TrimSpace(os.Getenv("VTR_CLIENT_ID")); id != "" {
+		return id
+	}
+	name := "vtr"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return name + "@" + host
+	}
+	return name
+}
+
+// agentLockToken is the input lock token from `vtr agent lock`. Separate
+// invocations share it through $VTR_LOCK_TOKEN so a lock taken by one call
+// covers the next.
+func agentLockToken() string {
+	return strings.TrimSpace(os.Getenv("VTR_LOCK_TOKEN"))
+}
+
 func writeOK(w io.Writer) error {
 	return writeJSON(w, jsonOK{OK: true})
 }
@@ -983,6 +1731,168 @@ func decodeHex(value string) ([]byte, error) {
 	return hex.DecodeString(trimmed)
 }
 
+func parseTagArgs(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tags := make(map[string]string, len(values))
+	for _, value := range values {
+		key, tagValue, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag %q (expected key=value)", value)
+		}
+		tags[key] = strings.TrimSpace(tagValue)
+	}
+	return tags, nil
+}
+
+func parseTagUpdates(values []string) (map[string]string, []string, error) {
+	var set []string
+	var remove []string
+	for _, value := range values {
+		if key, ok := strings.CutSuffix(value, "-"); ok && !strings.Contains(value, "=") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				return nil, nil, fmt.Errorf("invalid tag removal %q", value)
+			}
+			remove = append(remove, key)
+			continue
+		}
+		set = append(set, value)
+	}
+	tags, err := parseTagArgs(set)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tags, remove, nil
+}
+
+// isolationFlags holds the spawn sandboxing flags.
+type isolationFlags struct {
+	cpus       float64
+	memory     string
+	pids       int64
+	namespaces []string
+	rlimits    []string
+	uid        int64
+	gid        int64
+}
+
+func (f *isolationFlags) register(cmd *cobra.Command) {
+	cmd.Flags().Float64Var(&f.cpus, "cpus", 0, "cgroup CPU limit in CPUs (e.g. 0.5)")
+	cmd.Flags().StringVar(&f.memory, "memory", "", "cgroup memory limit (e.g. 512M, 2G)")
+	cmd.Flags().Int64Var(&f.pids, "pids", 0, "cgroup process limit")
+	cmd.Flags().StringSliceVar(&f.namespaces, "ns", nil, "new namespaces: user, mount, pid, net")
+	cmd.Flags().StringArrayVar(&f.rlimits, "rlimit", nil, "resource limit as name=soft[:hard] (repeatable)")
+	cmd.Flags().Int64Var(&f.uid, "uid", -1, "run as uid")
+	cmd.Flags().Int64Var(&f.gid, "gid", -1, "run as gid")
+}
+
+// build returns nil when no isolation flag is set.
+func (f *isolationFlags) build() (*proto.SpawnIsolation, error) {
+	iso := &proto.SpawnIsolation{CpuMax: f.cpus, PidsMax: f.pids}
+	set := f.cpus != 0 || f.pids != 0
+	if strings.TrimSpace(f.memory) != "" {
+		bytes, err := parseByteSize(f.memory)
+		if err != nil {
+			return nil, err
+		}
+		iso.MemoryMaxBytes = bytes
+		set = true
+	}
+	for _, ns := range f.namespaces {
+		switch strings.ToLower(strings.TrimSpace(ns)) {
+		case "user":
+			iso.UserNs = true
+		case "mount":
+			iso.MountNs = true
+		case "pid":
+			iso.PidNs = true
+		case "net":
+			iso.NetNs = true
+		default:
+			return nil, fmt.Errorf("unknown namespace %q (expected user, mount, pid or net)", ns)
+		}
+		set = true
+	}
+	for _, value := range f.rlimits {
+		limit, err := parseRlimit(value)
+		if err != nil {
+			return nil, err
+		}
+		iso.Rlimits = append(iso.Rlimits, limit)
+		set = true
+	}
+	if f.uid >= 0 {
+		if f.uid > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid uid %d", f.uid)
+		}
+		uid := uint32(f.uid)
+		iso.Uid = &uid
+		set = true
+	}
+	if f.gid >= 0 {
+		if f.gid > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid gid %d", f.gid)
+		}
+		gid := uint32(f.gid)
+		iso.Gid = &gid
+		set = true
+	}
+	if !set {
+		return nil, nil
+	}
+	return iso, nil
+}
+
+func parseRlimit(value string) (*proto.Rlimit, error) {
+	name, limits, ok := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid rlimit %q (expected name=soft[:hard])", value)
+	}
+	softValue, hardValue, hasHard := strings.Cut(limits, ":")
+	soft, err := strconv.ParseUint(strings.TrimSpace(softValue), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rlimit %q (expected name=soft[:hard])", value)
+	}
+	hard := soft
+	if hasHard {
+		hard, err = strconv.ParseUint(strings.TrimSpace(hardValue), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rlimit %q (expected name=soft[:hard])", value)
+		}
+	}
+	return &proto.Rlimit{Resource: name, Soft: soft, Hard: hard}, nil
+}
+
+func parseByteSize(value string) (int64, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(value))
+	trimmed = strings.TrimSuffix(trimmed, "B")
+	multiplier := int64(1)
+	if trimmed != "" {
+		switch trimmed[len(trimmed)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		case 'T':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+	}
+	parsed, err := strconv.ParseInt(strings.TrimSpace(trimmed), 10, 64)
+	if err != nil || parsed <= 0 || parsed > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return parsed * multiplier, nil
+}
+
 func parseSize(value string) (int, error) {
 	parsed, err := strconv.Atoi(value)
 	if err != nil {
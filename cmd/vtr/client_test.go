@@ -2,8 +2,11 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -12,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	proto "github.com/advait/vtrpc/proto"
 	"github.com/advait/vtrpc/server"
 	"google.golang.org/grpc"
@@ -140,6 +144,469 @@ func TestCLISendWaitForIdle(t *testing.T) {
 	}
 }
 
+func TestCLITagsAndSelector(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	hubAddr, cleanup := startCLITestServer(t)
+	setupCLIConfig(t, hubAddr)
+	t.Cleanup(cleanup)
+
+	if _, err := runCLICommand(t, "agent", "spawn", "--hub", hubAddr, "--cmd", "sleep 5", "--tag", "owner=agent-7", "cli-tag-mine"); err != nil {
+		t.Fatalf("spawn mine: %v", err)
+	}
+	if _, err := runCLICommand(t, "agent", "spawn", "--hub", hubAddr, "--cmd", "sleep 5", "cli-tag-other"); err != nil {
+		t.Fatalf("spawn other: %v", err)
+	}
+
+	out, err := runCLICommand(t, "agent", "tag", "--hub", hubAddr, "cli-tag-mine", "task=1234", "owner-")
+	if err != nil {
+		t.Fatalf("tag: %v", err)
+	}
+	var tagged jsonSessionEnvelope
+	if err := json.Unmarshal([]byte(out), &tagged); err != nil {
+		t.Fatalf("decode tag: %v", err)
+	}
+	if tagged.Session.Tags["task"] != "1234" || tagged.Session.Tags["owner"] != "" {
+		t.Fatalf("unexpected tags %+v", tagged.Session.Tags)
+	}
+
+	out, err = runCLICommand(t, "agent", "ls", "--hub", hubAddr, "-l", "task=1234")
+	if err != nil {
+		t.Fatalf("ls: %v", err)
+	}
+	var list jsonList
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("decode ls: %v", err)
+	}
+	if len(list.Sessions) != 1 || list.Sessions[0].Name != "cli-tag-mine" {
+		t.Fatalf("expected only cli-tag-mine, got %+v", list.Sessions)
+	}
+}
+
+func TestParseTagUpdates(t *testing.T) {
+	set, remove, err := parseTagUpdates([]string{"owner=agent-7", "task-", "note=a-"})
+	if err != nil {
+		t.Fatalf("parseTagUpdates: %v", err)
+	}
+	if set["owner"] != "agent-7" || set["note"] != "a-" || len(set) != 2 {
+		t.Fatalf("unexpected set %v", set)
+	}
+	if len(remove) != 1 || remove[0] != "task" {
+		t.Fatalf("unexpected remove %v", remove)
+	}
+	if _, _, err := parseTagUpdates([]string{"owner"}); err == nil {
+		t.Fatalf("expected error for tag without value")
+	}
+}
+
+func TestSpawnProfilesConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vtrpc.toml")
+	config := strings.Join([]string{
+		"[profiles.codex]",
+		`command = "codex --full-auto"`,
+		`cwd = "/tmp"`,
+		"cols = 120",
+		`idle_threshold = "30s"`,
+		`restart = "on-failure"`,
+		"[profiles.codex.env]",
+		`OPENAI_LOG = "debug"`,
+		"[profiles.codex.tags]",
+		`kind = "agent"`,
+		"",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	profiles, err := spawnProfiles(cfg)
+	if err != nil {
+		t.Fatalf("spawnProfiles: %v", err)
+	}
+	profile, ok := profiles["codex"]
+	if !ok {
+		t.Fatalf("missing codex profile: %v", profiles)
+	}
+	if profile.Command != "codex --full-auto" || profile.WorkingDir != "/tmp" || profile.Cols != 120 {
+		t.Fatalf("profile=%+v", profile)
+	}
+	if profile.IdleThreshold != 30*time.Second || profile.Restart != "on-failure" {
+		t.Fatalf("profile=%+v", profile)
+	}
+	if profile.Env["OPENAI_LOG"] != "debug" || profile.Tags["kind"] != "agent" {
+		t.Fatalf("profile=%+v", profile)
+	}
+	if names := profileNames(cfg); len(names) != 1 || names[0] != "codex" {
+		t.Fatalf("names=%v", names)
+	}
+
+	cfg.Profiles["bad"] = profileConfig{Restart: "sometimes"}
+	if _, err := spawnProfiles(cfg); err == nil {
+		t.Fatalf("expected error for invalid restart policy")
+	}
+}
+
+func TestStackOrder(t *testing.T) {
+	st, err := buildStack("dev", stackFile{
+		Coordinator: "spoke-a",
+		Sessions: map[string]stackSession{
+			"agent": {DependsOn: []string{"web", "db"}},
+			"web":   {DependsOn: []string{"db"}, Coordinator: "spoke-b"},
+			"db":    {},
+			"tests": {},
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildStack: %v", err)
+	}
+	var order []string
+	for _, entry := range st.entries {
+		order = append(order, entry.label)
+	}
+	want := "spoke-a:db,spoke-b:web,spoke-a:agent,spoke-a:tests"
+	if got := strings.Join(order, ","); got != want {
+		t.Fatalf("order=%s, want %s", got, want)
+	}
+
+	_, err = buildStack("dev", stackFile{Sessions: map[string]stackSession{
+		"a": {DependsOn: []string{"b"}},
+		"b": {DependsOn: []string{"a"}},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	_, err = buildStack("dev", stackFile{Sessions: map[string]stackSession{
+		"a": {DependsOn: []string{"missing"}},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "unknown session") {
+		t.Fatalf("expected unknown dependency error, got %v", err)
+	}
+	_, err = buildStack("dev", stackFile{Sessions: map[string]stackSession{
+		"a": {Ready: stackReadyConfig{Idle: "soon"}},
+	}})
+	if err == nil {
+		t.Fatalf("expected invalid ready.idle error")
+	}
+}
+
+func TestCLIStackUpStatusDown(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	hubAddr, cleanup := startCLITestServer(t)
+	setupCLIConfig(t, hubAddr)
+	t.Cleanup(cleanup)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	path := filepath.Join(t.TempDir(), "vtr-stack.toml")
+	stackConfig := strings.Join([]string{
+		`name = "cli-stack"`,
+		"[sessions.db]",
+		`command = "sleep 0.2; echo db-ready; sleep 30"`,
+		`ready = { pattern = "db-ready", timeout = "5s" }`,
+		"[sessions.web]",
+		`command = "echo web; sleep 30"`,
+		`depends_on = ["db"]`,
+		fmt.Sprintf(`ready = { port = %d, idle = "100ms", timeout = "5s" }`, port),
+		"",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(stackConfig), 0o644); err != nil {
+		t.Fatalf("write stack: %v", err)
+	}
+
+	out, err := runCLICommand(t, "up", "--hub", hubAddr, "-f", path)
+	if err != nil {
+		t.Fatalf("up: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "db: spawned") || !strings.Contains(out, "web: spawned") {
+		t.Fatalf("unexpected up output:\n%s", out)
+	}
+	if strings.Index(out, "db: spawned") > strings.Index(out, "web: spawned") {
+		t.Fatalf("expected db before web:\n%s", out)
+	}
+
+	out, err = runCLICommand(t, "status", "--json", "--hub", hubAddr, "-f", path)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	var report jsonStack
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("decode status: %v\n%s", err, out)
+	}
+	if report.Stack != "cli-stack" || len(report.Sessions) != 2 {
+		t.Fatalf("unexpected status %+v", report)
+	}
+	for _, item := range report.Sessions {
+		if item.Session == nil || item.Session.Status != "running" || item.Session.Tags["stack"] != "cli-stack" {
+			t.Fatalf("unexpected session %+v", item)
+		}
+	}
+
+	out, err = runCLICommand(t, "down", "--json", "--remove", "--hub", hubAddr, "-f", path)
+	if err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("decode down: %v\n%s", err, out)
+	}
+	if len(report.Sessions) != 2 || report.Sessions[0].Name != "web" || report.Sessions[0].Action != "removed" {
+		t.Fatalf("unexpected down %+v", report)
+	}
+}
+
+func TestBuildScript(t *testing.T) {
+	var file scriptFile
+	_, err := toml.Decode(strings.Join([]string{
+		`timeout = "2s"`,
+		"[[steps]]",
+		`spawn = "repl"`,
+		"[[steps]]",
+		`name = "login"`,
+		`expect = ["login:", { pattern = "\\$ ", goto = "done" }]`,
+		`timeout = "5s"`,
+		"[[steps]]",
+		`send = "${user}"`,
+		`goto = "login"`,
+		"[[steps]]",
+		`name = "done"`,
+		`wait_idle = "100ms"`,
+		"",
+	}, "\n"), &file)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	sc, err := buildScript("login", file)
+	if err != nil {
+		t.Fatalf("buildScript: %v", err)
+	}
+	var actions []string
+	for _, step := range sc.steps {
+		actions = append(actions, step.action)
+	}
+	if got := strings.Join(actions, ","); got != "spawn,expect,send,wait_idle" {
+		t.Fatalf("actions=%s", got)
+	}
+	expect := sc.steps[1]
+	if expect.timeout != 5*time.Second || len(expect.cfg.Expect) != 2 || expect.cfg.Expect[1].Goto != "done" {
+		t.Fatalf("unexpected expect step %+v", expect)
+	}
+	if sc.steps[0].timeout != 2*time.Second || sc.steps[3].idle != 100*time.Millisecond || sc.labels["done"] != 3 {
+		t.Fatalf("unexpected script %+v", sc)
+	}
+
+	for _, tc := range []struct {
+		steps []scriptStepConfig
+		want  string
+	}{
+		{nil, "no steps"},
+		{[]scriptStepConfig{{}}, "no action"},
+		{[]scriptStepConfig{{Send: "a", Key: "enter"}}, "only one action"},
+		{[]scriptStepConfig{{Send: "a", Goto: "missing"}}, "unknown goto target"},
+		{[]scriptStepConfig{{Name: "a", Send: "a"}, {Name: "a", Send: "b"}}, "duplicate step name"},
+		{[]scriptStepConfig{{AssertScreen: "("}}, "missing closing"},
+		{[]scriptStepConfig{{WaitIdle: "soon"}}, "invalid wait_idle"},
+	} {
+		if _, err := buildScript("bad", scriptFile{Steps: tc.steps}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("steps %+v: expected %q error, got %v", tc.steps, tc.want, err)
+		}
+	}
+}
+
+func screenFromRows(rows ...string) *proto.GetScreenResponse {
+	resp := &proto.GetScreenResponse{Cols: 20, Rows: int32(len(rows))}
+	for _, row := range rows {
+		cells := make([]*proto.ScreenCell, resp.Cols)
+		for i := range cells {
+			cells[i] = &proto.ScreenCell{}
+		}
+		for i, r := range []rune(row) {
+			cells[i].Char = string(r)
+		}
+		resp.ScreenRows = append(resp.ScreenRows, &proto.ScreenRow{Cells: cells})
+	}
+	return resp
+}
+
+type fakeSubscribeClient struct {
+	grpc.ClientStream
+	events []*proto.SubscribeEvent
+}
+
+func (f *fakeSubscribeClient) Recv() (*proto.SubscribeEvent, error) {
+	if len(f.events) == 0 {
+		return nil, io.EOF
+	}
+	event := f.events[0]
+	f.events = f.events[1:]
+	return event, nil
+}
+
+func TestScriptStreamStartsFromKeyframe(t *testing.T) {
+	raw := func(data string) *proto.SubscribeEvent {
+		return &proto.SubscribeEvent{Event: &proto.SubscribeEvent_RawOutput{RawOutput: []byte(data)}}
+	}
+	keyframe := func(rows ...string) *proto.SubscribeEvent {
+		screen := screenFromRows(rows...)
+		screen.CursorY = int32(len(rows) - 1)
+		screen.CursorX = int32(len(rows[len(rows)-1]))
+		return &proto.SubscribeEvent{Event: &proto.SubscribeEvent_ScreenUpdate{ScreenUpdate: &proto.ScreenUpdate{
+			IsKeyframe: true,
+			Screen:     screen,
+		}}}
+	}
+	stream := &scriptStream{notify: make(chan struct{})}
+	stream.read(&fakeSubscribeClient{events: []*proto.SubscribeEvent{
+		raw("user: "),
+		keyframe("menu", "user: "),
+		raw("alice\n"),
+		keyframe("menu", "user: alice"),
+		raw("ok"),
+	}})
+	if want := "menu\nuser: alice\nok"; stream.buf != want {
+		t.Fatalf("buf=%q, want %q", stream.buf, want)
+	}
+	if !errors.Is(stream.err, io.EOF) {
+		t.Fatalf("err=%v, want EOF", stream.err)
+	}
+}
+
+func TestCLIScriptRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	hubAddr, cleanup := startCLITestServer(t)
+	setupCLIConfig(t, hubAddr)
+	t.Cleanup(cleanup)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "login.toml")
+	scriptConfig := strings.Join([]string{
+		`timeout = "5s"`,
+		"[[steps]]",
+		`spawn = "cli-script"`,
+		`command = "printf 'user: '; read user; printf 'code=%s-42\\n' \"$user\"; printf 'try again: '; read retry; printf 'welcome %s\\n' \"$retry\"; sleep 30"`,
+		"[[steps]]",
+		`name = "prompt"`,
+		`expect = [{ pattern = "try again: ", goto = "retry" }, "user: "]`,
+		"[[steps]]",
+		`send = "${user}"`,
+		`submit = true`,
+		`goto = "prompt"`,
+		"[[steps]]",
+		`name = "retry"`,
+		`capture = "code"`,
+		`pattern = "code=(\\S+)"`,
+		"[[steps]]",
+		`send = "${code}"`,
+		`submit = true`,
+		"[[steps]]",
+		`expect = "welcome"`,
+		"[[steps]]",
+		`wait_idle = "100ms"`,
+		"[[steps]]",
+		`assert_screen = "welcome alice-42"`,
+		"",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(scriptConfig), 0o644); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+
+	out, err := runCLICommand(t, "agent", "script", "run", "--hub", hubAddr, "--var", "user=alice", path)
+	if err != nil {
+		t.Fatalf("script run: %v\n%s", err, out)
+	}
+	var report jsonScript
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("decode report: %v\n%s", err, out)
+	}
+	if !report.OK || report.Script != "login" || report.Vars["code"] != "alice-42" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	var actions []string
+	for _, step := range report.Steps {
+		actions = append(actions, step.Action)
+	}
+	want := "spawn,expect,send,expect,capture,send,expect,wait_idle,assert_screen"
+	if got := strings.Join(actions, ","); got != want {
+		t.Fatalf("steps=%s, want %s", got, want)
+	}
+
+	failPath := filepath.Join(dir, "fail.toml")
+	failConfig := strings.Join([]string{
+		`session = "cli-script"`,
+		"[[steps]]",
+		`expect = "never printed"`,
+		`timeout = "200ms"`,
+		"",
+	}, "\n")
+	if err := os.WriteFile(failPath, []byte(failConfig), 0o644); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+	out, err = runCLICommand(t, "agent", "script", "run", "--hub", hubAddr, failPath)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v\n%s", err, out)
+	}
+	// The report is written before cobra prints the error.
+	if err := json.NewDecoder(strings.NewReader(out)).Decode(&report); err != nil {
+		t.Fatalf("decode report: %v\n%s", err, out)
+	}
+	if report.OK || len(report.Steps) != 1 || !strings.Contains(report.Steps[0].Screen, "welcome alice-42") {
+		t.Fatalf("unexpected failure report %+v", report)
+	}
+}
+
+func TestIsolationFlags(t *testing.T) {
+	flags := isolationFlags{uid: -1, gid: -1}
+	if iso, err := flags.build(); err != nil || iso != nil {
+		t.Fatalf("expected no isolation, got %+v (%v)", iso, err)
+	}
+
+	flags = isolationFlags{
+		cpus:       0.5,
+		memory:     "512M",
+		namespaces: []string{"user", "pid"},
+		rlimits:    []string{"nofile=1024:4096", "core=0"},
+		uid:        1000,
+		gid:        -1,
+	}
+	iso, err := flags.build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if iso.CpuMax != 0.5 || iso.MemoryMaxBytes != 512<<20 || !iso.UserNs || !iso.PidNs || iso.NetNs {
+		t.Fatalf("unexpected isolation %+v", iso)
+	}
+	if len(iso.Rlimits) != 2 || iso.Rlimits[0].Soft != 1024 || iso.Rlimits[0].Hard != 4096 || iso.Rlimits[1].Hard != 0 {
+		t.Fatalf("unexpected rlimits %+v", iso.Rlimits)
+	}
+	if iso.Uid == nil || *iso.Uid != 1000 || iso.Gid != nil {
+		t.Fatalf("unexpected uid/gid %v/%v", iso.Uid, iso.Gid)
+	}
+
+	for _, bad := range []isolationFlags{
+		{uid: -1, gid: -1, memory: "lots"},
+		{uid: -1, gid: -1, namespaces: []string{"ipc"}},
+		{uid: -1, gid: -1, rlimits: []string{"nofile"}},
+	} {
+		if _, err := bad.build(); err == nil {
+			t.Fatalf("expected error for %+v", bad)
+		}
+	}
+}
+
 func TestCLIGrep(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("pty tests not supported on windows")
@@ -183,6 +650,301 @@ func TestCLIGrep(t *testing.T) {
 	}
 }
 
+func TestCompareGolden(t *testing.T) {
+	screen := screenFromRows("CPU 42% up 3:14", "> Save   Quit", "")
+	clock := &proto.ScreenRect{X: 8, Y: 0, Height: 1}
+
+	golden := goldenScreen(screen, goldenOptions{Ignore: []*proto.ScreenRect{clock}})
+	if golden != "CPU 42%\n> Save   Quit\n" {
+		t.Fatalf("golden=%q", golden)
+	}
+	diffs, err := compareGolden(screen, golden, goldenOptions{Ignore: []*proto.ScreenRect{clock}})
+	if err != nil || len(diffs) != 0 {
+		t.Fatalf("masked compare=%+v, %v", diffs, err)
+	}
+	diffs, err = compareGolden(screen, "CPU 42% up 9:99\n> Save   Quit\n", goldenOptions{Ignore: []*proto.ScreenRect{clock}})
+	if err != nil || len(diffs) != 0 {
+		t.Fatalf("golden masked compare=%+v, %v", diffs, err)
+	}
+	diffs, err = compareGolden(screen, "CPU {{\\d+}}% up {{[0-9:]+}}\n> Save   Quit\n\n\n", goldenOptions{})
+	if err != nil || len(diffs) != 0 {
+		t.Fatalf("regex compare=%+v, %v", diffs, err)
+	}
+
+	diffs, err = compareGolden(screen, "CPU 42%\n> Open   Quit\nextra\n", goldenOptions{Ignore: []*proto.ScreenRect{clock}})
+	if err != nil {
+		t.Fatalf("compareGolden: %v", err)
+	}
+	want := []screenDiff{
+		{Row: 1, Expected: "> Open   Quit", Actual: "> Save   Quit"},
+		{Row: 2, Expected: "extra", Actual: ""},
+	}
+	if len(diffs) != len(want) || diffs[0] != want[0] || diffs[1] != want[1] {
+		t.Fatalf("diffs=%+v, want %+v", diffs, want)
+	}
+
+	if _, err := compareGolden(screen, "CPU {{\\d+\n", goldenOptions{}); err == nil {
+		t.Fatalf("expected unterminated regex error")
+	}
+
+	colored := screenFromRows("ok")
+	colored.ScreenRows[0].Cells[0].FgColor = 0xff0000
+	golden = goldenScreen(screen, goldenOptions{ANSI: true})
+	if diffs, err := compareGolden(screen, golden, goldenOptions{ANSI: true}); err != nil || len(diffs) != 0 {
+		t.Fatalf("ansi compare=%+v, %v", diffs, err)
+	}
+	plain := screenFromRows("ok")
+	if diffs, _ := compareGolden(colored, goldenScreen(plain, goldenOptions{}), goldenOptions{}); len(diffs) != 0 {
+		t.Fatalf("plain compare should ignore colors, got %+v", diffs)
+	}
+	if diffs, _ := compareGolden(colored, goldenScreen(plain, goldenOptions{ANSI: true}), goldenOptions{ANSI: true}); len(diffs) != 1 {
+		t.Fatalf("ansi compare should see colors, got %+v", diffs)
+	}
+}
+
+func TestGoldenUpdateEscapesLiteralBraces(t *testing.T) {
+	screen := screenFromRows("tmpl {{.Name}}", "{{{ x }}", "a}} b")
+	golden := goldenScreen(screen, goldenOptions{})
+	if want := "tmpl {{\\{\\{}}.Name}}\n{{\\{\\{}}{ x }}\na}} b\n"; golden != want {
+		t.Fatalf("golden=%q, want %q", golden, want)
+	}
+	if diffs, err := compareGolden(screen, golden, goldenOptions{}); err != nil || len(diffs) != 0 {
+		t.Fatalf("round trip compare=%+v, %v", diffs, err)
+	}
+	changed := screenFromRows("tmpl {{.Nom}}", "{{{ x }}", "a}} b")
+	if diffs, err := compareGolden(changed, golden, goldenOptions{}); err != nil || len(diffs) != 1 || diffs[0].Row != 0 {
+		t.Fatalf("changed compare=%+v, %v", diffs, err)
+	}
+}
+
+func TestBlankIgnoredColumnsCountsCells(t *testing.T) {
+	rects := []*proto.ScreenRect{{X: 2, Y: 0, Width: 1, Height: 1}}
+	for line, want := range map[string]string{
+		"ab|cd": "ab cd",
+		"漢x|":   "漢 |",
+		"漢 x|":  "漢  |",
+		"a漢|":   "a漢|",
+		"ab漢 |": "ab  |",
+	} {
+		if got := blankIgnoredColumns(line, 0, rects); got != want {
+			t.Fatalf("blankIgnoredColumns(%q)=%q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestScreenToLLM(t *testing.T) {
+	screen := screenFromRows("File Edit", "", "", "", "  Open", "  Save", "$ ls", "", "")
+	screen.Name = "demo"
+	screen.CursorX, screen.CursorY = 4, 6
+	screen.ScrollbackLines = 120
+	screen.ForegroundProcess = "vim"
+	for x := 0; x < 4; x++ {
+		screen.ScreenRows[0].Cells[x].Attributes = attrBold
+	}
+	// A selection bar: inverse across the whole row.
+	for _, cell := range screen.ScreenRows[5].Cells {
+		cell.Attributes = attrInverse
+	}
+	screen.ScreenRows[4].Cells[2].FgColor = 0xcd3131
+	screen.ScreenRows[4].Cells[3].FgColor = 0xcd3131
+	screen.ScreenRows[4].Cells[4].FgColor = 0x808080
+
+	want := strings.Join([]string{
+		"screen demo 20x9 cursor 6,4 scrollback 120 process vim",
+		"**File** Edit",
+		"<3 blank rows>",
+		"  {red:Op}en",
+		"[[  Save]]",
+		"$ ls█",
+		"",
+	}, "\n")
+	if got := screenToLLM(screen); got != want {
+		t.Fatalf("screenToLLM=\n%s\nwant\n%s", got, want)
+	}
+
+	screen.CursorX, screen.CursorY = 1, 0
+	if got := screenToLLM(screen); !strings.Contains(got, "\n**F█ile** Edit\n") {
+		t.Fatalf("cursor inside a run:\n%s", got)
+	}
+
+	for rgb, name := range map[int32]string{0x4e9a06: "green", 0x2472c8: "blue", 0xe5e510: "yellow", 0xe5e5e5: "", 0: ""} {
+		if got := llmColorName(rgb); got != name {
+			t.Fatalf("llmColorName(%06x)=%q, want %q", rgb, got, name)
+		}
+	}
+}
+
+func TestScreenFrameToText(t *testing.T) {
+	screen := screenFromRows("$ make", "")
+	screen.FrameId = 7
+	if got, want := screenFrameToText(screen, false), "frame 7 keyframe 20x2 cursor 0,0\n~  0| $ make\n~  1|\n"; got != want {
+		t.Fatalf("keyframe=%q, want %q", got, want)
+	}
+
+	row := screenFromRows("ok").ScreenRows[0]
+	screen = &proto.GetScreenResponse{
+		FrameId:     9,
+		BaseFrameId: 7,
+		Delta: &proto.ScreenDelta{
+			Cols:      20,
+			Rows:      2,
+			CursorY:   1,
+			CursorX:   2,
+			RowDeltas: []*proto.RowDelta{{Row: 1, RowData: row}},
+		},
+	}
+	if got, want := screenFrameToText(screen, false), "frame 9 base 7 changed 1 cursor 1,2\n~  1| ok\n"; got != want {
+		t.Fatalf("delta=%q, want %q", got, want)
+	}
+	out := screenFrameToJSON(screen)
+	if out.Screen != nil || out.Delta == nil || len(out.Delta.Changed) != 1 || out.Delta.Changed[0].Text != "ok" {
+		t.Fatalf("unexpected JSON frame %+v", out)
+	}
+}
+
+func TestCLIAssertScreen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	hubAddr, cleanup := startCLITestServer(t)
+	setupCLIConfig(t, hubAddr)
+	t.Cleanup(cleanup)
+
+	if _, err := runCLICommand(t, "agent", "spawn", "--hub", hubAddr, "--cmd", "printf 'menu\\n> Save\\n'; sleep 30", "cli-golden"); err != nil {
+		t.Fatalf("spawn: %v", err)
+	}
+	waitForCLIScreenContains(t, hubAddr, "cli-golden", "> Save", 2*time.Second)
+
+	golden := filepath.Join(t.TempDir(), "testdata", "menu.golden")
+	if _, err := runCLICommand(t, "agent", "assert-screen", "--hub", hubAddr, "cli-golden", golden); err == nil {
+		t.Fatalf("expected error for missing golden")
+	}
+	out, err := runCLICommand(t, "agent", "assert-screen", "--update", "--hub", hubAddr, "cli-golden", golden)
+	if err != nil {
+		t.Fatalf("assert-screen --update: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(golden)
+	if err != nil || !strings.HasPrefix(string(data), "menu\n> Save\n") {
+		t.Fatalf("golden=%q, %v", data, err)
+	}
+	if out, err := runCLICommand(t, "agent", "assert-screen", "--hub", hubAddr, "cli-golden", golden); err != nil {
+		t.Fatalf("assert-screen: %v\n%s", err, out)
+	}
+
+	if err := os.WriteFile(golden, []byte("menu\n> Quit\n"), 0o644); err != nil {
+		t.Fatalf("write golden: %v", err)
+	}
+	out, err = runCLICommand(t, "agent", "assert-screen", "--hub", hubAddr, "cli-golden", golden)
+	if err == nil || !strings.Contains(out, "@@ row 1 @@\n-> Quit\n+> Save\n") {
+		t.Fatalf("expected row diff, got %v\n%s", err, out)
+	}
+	out, err = runCLICommand(t, "agent", "assert-screen", "--json", "--ignore", "2,1,0,1", "--hub", hubAddr, "cli-golden", golden)
+	if err != nil {
+		t.Fatalf("assert-screen --ignore: %v\n%s", err, out)
+	}
+	var resp jsonAssertScreen
+	if err := json.Unmarshal([]byte(out), &resp); err != nil || !resp.OK {
+		t.Fatalf("unexpected output %q, %v", out, err)
+	}
+}
+
+func TestFilterLogs(t *testing.T) {
+	data := []byte("\x1b[1mbuild\x1b[0m\r\n== mark ==\r\none\r\ntwo\r\nthree\r\n")
+	text, err := filterLogs(data, logsOptions{StripANSI: true, Marker: "== mark =="})
+	if err != nil || text != "one\ntwo\nthree\n" {
+		t.Fatalf("filterLogs marker=%q, %v", text, err)
+	}
+	text, err = filterLogs(data, logsOptions{StripANSI: true, Lines: 2})
+	if err != nil || text != "two\nthree\n" {
+		t.Fatalf("filterLogs lines=%q, %v", text, err)
+	}
+	if _, err := filterLogs(data, logsOptions{Marker: "missing"}); err == nil {
+		t.Fatalf("expected error for missing marker")
+	}
+
+	ready, rest := splitANSITail([]byte("ok\x1b[3"))
+	if string(ready) != "ok" || string(rest) != "\x1b[3" {
+		t.Fatalf("splitANSITail=%q,%q", ready, rest)
+	}
+	ready, rest = splitANSITail([]byte("ok\x1b[31m"))
+	if string(ready) != "ok\x1b[31m" || rest != nil {
+		t.Fatalf("splitANSITail complete=%q,%q", ready, rest)
+	}
+}
+
+type fakeReadOutputClient struct {
+	proto.VTRClient
+	chunks []*proto.ReadOutputResponse
+}
+
+func (f *fakeReadOutputClient) ReadOutput(context.Context, *proto.ReadOutputRequest, ...grpc.CallOption) (*proto.ReadOutputResponse, error) {
+	if len(f.chunks) == 0 {
+		return nil, io.EOF
+	}
+	resp := f.chunks[0]
+	f.chunks = f.chunks[1:]
+	return resp, nil
+}
+
+func TestFollowLogsCarriesFirstChunkTail(t *testing.T) {
+	first := &proto.ReadOutputResponse{Data: []byte("ok\x1b[3"), NextOffset: 5}
+	ready, pending := splitANSITail(first.Data)
+	if string(ready) != "ok" {
+		t.Fatalf("ready=%q", ready)
+	}
+	client := &fakeReadOutputClient{chunks: []*proto.ReadOutputResponse{
+		{Data: []byte("1mred\x1b[0m\n"), Offset: 5, NextOffset: 16},
+		{Data: []byte("late\n"), Offset: 20, NextOffset: 25, DroppedBytes: 4, Exited: true},
+	}}
+	var out, errOut bytes.Buffer
+	if err := followLogs(context.Background(), client, &proto.SessionRef{Id: "s"}, first, pending, true, &out, &errOut); err != nil {
+		t.Fatalf("followLogs: %v", err)
+	}
+	if out.String() != "red\nlate\n" {
+		t.Fatalf("out=%q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "4 bytes dropped before offset 20") {
+		t.Fatalf("expected gap report while following, got %q", errOut.String())
+	}
+}
+
+func TestCLILogs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	hubAddr, cleanup := startCLITestServer(t)
+	setupCLIConfig(t, hubAddr)
+	t.Cleanup(cleanup)
+
+	command := "printf '\\033[1mstart\\033[0m\\n== mark ==\\nafter\\n'; sleep 0.3; printf 'late\\n'; sleep 0.1"
+	if _, err := runCLICommand(t, "agent", "spawn", "--hub", hubAddr, "--cmd", command, "cli-logs"); err != nil {
+		t.Fatalf("spawn: %v", err)
+	}
+	waitForCLIScreenContains(t, hubAddr, "cli-logs", "after", 2*time.Second)
+
+	out, err := runCLICommand(t, "agent", "logs", "--hub", hubAddr, "--json", "--strip-ansi", "--since-marker", "== mark ==", "cli-logs")
+	if err != nil {
+		t.Fatalf("logs: %v\n%s", err, out)
+	}
+	var resp jsonLogs
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("unmarshal logs: %v\n%s", err, out)
+	}
+	if !strings.HasPrefix(resp.Output, "after\n") || resp.NextOffset <= 0 || resp.DroppedBytes != 0 {
+		t.Fatalf("unexpected logs %+v", resp)
+	}
+
+	out, err = runCLICommand(t, "agent", "logs", "--hub", hubAddr, "-f", "--strip-ansi", "cli-logs")
+	if err != nil {
+		t.Fatalf("logs -f: %v\n%s", err, out)
+	}
+	if !strings.HasPrefix(out, "start\n== mark ==\nafter\n") || !strings.HasSuffix(out, "late\n") {
+		t.Fatalf("unexpected followed output %q", out)
+	}
+}
+
 func TestCLIWait(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("pty tests not supported on windows")
@@ -402,3 +1164,13 @@ func screenJSONToString(screen jsonScreen) string {
 	}
 	return b.String()
 }
+
+func TestUint32sRejectsOverflow(t *testing.T) {
+	ids, err := uint32s("allow-uid", []uint{0, 1000, 4294967295})
+	if err != nil || len(ids) != 3 || ids[2] != 4294967295 {
+		t.Fatalf("uint32s=%v, %v", ids, err)
+	}
+	if _, err := uint32s("allow-uid", []uint{4294967296}); err == nil || !strings.Contains(err.Error(), "allow-uid") {
+		t.Fatalf("expected allow-uid range error, got %v", err)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/advait/vtrpc/server"
 )
 
 type clientConfig struct {
@@ -16,6 +19,8 @@ type clientConfig struct {
 	Server serverConfig `toml:"server"`
 	TUI    tuiConfig    `toml:"tui"`
 
+	Profiles map[string]profileConfig `toml:"profiles"`
+
 	// Legacy client config fields (pre-vtrpc.toml).
 	Defaults defaultsConfig `toml:"defaults"`
 }
@@ -47,6 +52,33 @@ type serverConfig struct {
 type tuiConfig struct {
 	Spinner     string `toml:"spinner"`
 	StatusIcons string `toml:"status_icons"`
+
+	Layouts map[string]tuiLayoutConfig `toml:"layouts"`
+	Keys    map[string]keyChords       `toml:"keys"`
+}
+
+// tuiLayoutConfig is a named pane layout ([tui.layouts.<name>]) loaded with
+// vtr tui --layout.
+type tuiLayoutConfig struct {
+	Panes string `toml:"panes"`
+}
+
+// profileConfig is a named spawn profile ([profiles.<name>]). Profiles are
+// resolved by the coordinator that spawns the session.
+type profileConfig struct {
+	Command       string            `toml:"command"`
+	Cwd           string            `toml:"cwd"`
+	Env           map[string]string `toml:"env"`
+	Cols          int               `toml:"cols"`
+	Rows          int               `toml:"rows"`
+	Tags          map[string]string `toml:"tags"`
+	IdleThreshold string            `toml:"idle_threshold"`
+	Restart       string            `toml:"restart"`
+	Term          string            `toml:"term"`
+	Locale        string            `toml:"locale"`
+
+	ShellIntegration *bool  `toml:"shell_integration"`
+	ResizePolicy     string `toml:"resize_policy"`
 }
 
 type defaultsConfig struct {
@@ -79,6 +111,24 @@ func defaultConfigPath() string {
 	return filepath.Join(dir, defaultConfigFileName)
 }
 
+// defaultTerminfoDir is where coordinators install the bundled terminfo.
+func defaultTerminfoDir() string {
+	dir := configDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "terminfo")
+}
+
+// defaultMacroDir is where coordinators store recorded input macros.
+func defaultMacroDir() string {
+	dir := configDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "macros")
+}
+
 func loadConfig(path string) (*clientConfig, error) {
 	cfg := &clientConfig{}
 	if path == "" {
@@ -181,6 +231,69 @@ func expandPath(value string) string {
 	return value
 }
 
+func spawnProfiles(cfg *clientConfig) (map[string]server.SpawnProfile, error) {
+	if cfg == nil || len(cfg.Profiles) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]server.SpawnProfile, len(cfg.Profiles))
+	for name, profile := range cfg.Profiles {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("profile name is required")
+		}
+		if profile.Cols < 0 || profile.Cols > int(^uint16(0)) {
+			return nil, fmt.Errorf("profile %q: cols must be between 0 and %d", name, int(^uint16(0)))
+		}
+		if profile.Rows < 0 || profile.Rows > int(^uint16(0)) {
+			return nil, fmt.Errorf("profile %q: rows must be between 0 and %d", name, int(^uint16(0)))
+		}
+		var idle time.Duration
+		if value := strings.TrimSpace(profile.IdleThreshold); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil || parsed < 0 {
+				return nil, fmt.Errorf("profile %q: invalid idle_threshold %q", name, profile.IdleThreshold)
+			}
+			idle = parsed
+		}
+		restart, err := server.ParseRestartPolicy(profile.Restart)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", name, err)
+		}
+		resizePolicy, err := server.ParseResizePolicy(profile.ResizePolicy)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", name, err)
+		}
+		out[name] = server.SpawnProfile{
+			Command:       profile.Command,
+			WorkingDir:    expandPath(profile.Cwd),
+			Env:           profile.Env,
+			Cols:          uint16(profile.Cols),
+			Rows:          uint16(profile.Rows),
+			Tags:          profile.Tags,
+			IdleThreshold: idle,
+			Restart:       restart,
+			Term:          strings.TrimSpace(profile.Term),
+			Locale:        strings.TrimSpace(profile.Locale),
+
+			ShellIntegration: profile.ShellIntegration,
+			ResizePolicy:     resizePolicy,
+		}
+	}
+	return out, nil
+}
+
+// profileNames returns the configured profile names in sorted order.
+func profileNames(cfg *clientConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func resolveCoordinatorPaths(cfg *clientConfig) ([]string, error) {
 	if cfg == nil {
 		return nil, nil
@@ -0,0 +1,588 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// copyModeHistoryLimit bounds the scrollback loaded into copy mode.
+	copyModeHistoryLimit = 10000
+	// copyModeWheelLines is how far one wheel step scrolls.
+	copyModeWheelLines = 3
+)
+
+type copySelection int
+
+const (
+	copySelectNone copySelection = iota
+	copySelectChar
+	copySelectLine
+	copySelectRect
+)
+
+var (
+	copyMatchStyle  = cellStyle{fg: 0x1c1c1c, bg: 0xffd75f, fgSet: true, bgSet: true}
+	copySelectStyle = cellStyle{fg: 0xffffff, bg: 0x005f87, fgSet: true, bgSet: true}
+)
+
+// copyModeState is a frozen copy of the focused session's history and screen
+// that can be scrolled, searched and selected without sending input. Rows
+// hold the history lines first, then the screen; y is a row in that buffer.
+type copyModeState struct {
+	active    bool
+	sessionID string
+	rows      []*proto.ScreenRow
+	cols      int
+	top       int
+	x, y      int
+
+	selection        copySelection
+	anchorX, anchorY int
+
+	searching      bool
+	searchBackward bool
+	searchInput    textinput.Model
+	searchErr      bool
+	originX        int
+	originY        int
+	originTop      int
+	pattern        *regexp.Regexp
+}
+
+type copyModeRequestMsg struct {
+	sessionID string
+	scroll    int
+}
+
+type copyModeLoadedMsg struct {
+	sessionID string
+	screen    *proto.GetScreenResponse
+	scroll    int
+	err       error
+}
+
+// beginCopyMode loads history for the focused session; copy mode opens when
+// it arrives, scrolled up by scroll lines.
+func beginCopyMode(m attachModel, scroll int) (attachModel, tea.Cmd) {
+	if strings.TrimSpace(m.sessionID) == "" {
+		m.statusMsg = "copy mode: no active session"
+		m.statusUntil = time.Now().Add(2 * time.Second)
+		return m, nil
+	}
+	if m.copyMode.active {
+		return m, nil
+	}
+	return m, loadCopyModeCmd(m.client, m.sessionID, m.sessionCoord, scroll)
+}
+
+func loadCopyModeCmd(client proto.VTRClient, id, coordinator string, scroll int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+		defer cancel()
+		resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{
+			Session:        sessionRequestRef(id, coordinator),
+			IncludeHistory: true,
+			HistoryLimit:   copyModeHistoryLimit,
+		})
+		return copyModeLoadedMsg{sessionID: id, screen: resp, scroll: scroll, err: err}
+	}
+}
+
+func copyModeRequestCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return copyModeRequestMsg{sessionID: id, scroll: copyModeWheelLines}
+	}
+}
+
+// newCopyMode builds the buffer with the cursor where the session's cursor
+// is and the live screen in view.
+func newCopyMode(id string, screen *proto.GetScreenResponse, height int) copyModeState {
+	c := copyModeState{active: true, sessionID: id, searchInput: newCopySearchInput()}
+	for _, line := range screen.GetHistoryLines() {
+		c.rows = append(c.rows, historyRow(line))
+	}
+	history := len(c.rows)
+	c.rows = append(c.rows, screen.GetScreenRows()...)
+	if len(c.rows) == 0 {
+		c.rows = []*proto.ScreenRow{{}}
+	}
+	c.cols = int(screen.GetCols())
+	for _, row := range c.rows {
+		c.cols = max(c.cols, len(row.GetCells()))
+	}
+	c.x = int(screen.GetCursorX())
+	c.y = min(history+int(screen.GetCursorY()), len(c.rows)-1)
+	c.top = max(len(c.rows)-height, 0)
+	c.clampCursor(height)
+	return c
+}
+
+func historyRow(line string) *proto.ScreenRow {
+	row := &proto.ScreenRow{Cells: make([]*proto.ScreenCell, 0, utf8.RuneCountInString(line))}
+	for _, r := range line {
+		row.Cells = append(row.Cells, &proto.ScreenCell{Char: string(r)})
+	}
+	return row
+}
+
+func newCopySearchInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.CharLimit = 256
+	input.Width = 30
+	input.Blur()
+	return input
+}
+
+func (c *copyModeState) cell(x, y int) string {
+	if y < 0 || y >= len(c.rows) {
+		return " "
+	}
+	cells := c.rows[y].GetCells()
+	if x < 0 || x >= len(cells) || cells[x].GetChar() == "" {
+		return " "
+	}
+	return cells[x].GetChar()
+}
+
+// lineLen is the width of row y without trailing blanks.
+func (c *copyModeState) lineLen(y int) int {
+	if y < 0 || y >= len(c.rows) {
+		return 0
+	}
+	n := len(c.rows[y].GetCells())
+	for n > 0 && strings.TrimSpace(c.cell(n-1, y)) == "" {
+		n--
+	}
+	return n
+}
+
+func (c *copyModeState) rowText(y int) (string, []int) {
+	var b strings.Builder
+	n := len(c.rows[y].GetCells())
+	starts := make([]int, n)
+	for x := 0; x < n; x++ {
+		starts[x] = b.Len()
+		b.WriteString(c.cell(x, y))
+	}
+	return b.String(), starts
+}
+
+// matches returns the [start, end) cell ranges matching the search pattern
+// on row y.
+func (c *copyModeState) matches(y int) [][2]int {
+	if c.pattern == nil || y < 0 || y >= len(c.rows) {
+		return nil
+	}
+	text, starts := c.rowText(y)
+	var out [][2]int
+	for _, loc := range c.pattern.FindAllStringIndex(text, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		start := sort.SearchInts(starts, loc[0])
+		end := sort.SearchInts(starts, loc[1])
+		out = append(out, [2]int{start, end})
+	}
+	return out
+}
+
+// find moves to the next match after (x, y), or before it when backward,
+// wrapping around the buffer. A match at (x, y) itself only counts once the
+// search has wrapped all the way around.
+func (c *copyModeState) find(x, y int, backward bool) bool {
+	n := len(c.rows)
+	for i := 0; i <= n; i++ {
+		row := (y + i) % n
+		if backward {
+			row = ((y-i)%n + n) % n
+		}
+		found := c.matches(row)
+		if backward {
+			for j := len(found) - 1; j >= 0; j-- {
+				if i == 0 && found[j][0] >= x {
+					continue
+				}
+				c.x, c.y = found[j][0], row
+				return true
+			}
+			continue
+		}
+		for _, match := range found {
+			if i == 0 && match[0] <= x {
+				continue
+			}
+			c.x, c.y = match[0], row
+			return true
+		}
+	}
+	return false
+}
+
+// clampCursor keeps the cursor inside the buffer and scrolls it into view.
+func (c *copyModeState) clampCursor(height int) {
+	c.y = min(max(c.y, 0), len(c.rows)-1)
+	c.x = min(max(c.x, 0), max(c.cols-1, 0))
+	if c.y < c.top {
+		c.top = c.y
+	}
+	if height > 0 && c.y >= c.top+height {
+		c.top = c.y - height + 1
+	}
+}
+
+// scroll moves the view by delta rows and drags the cursor along when it
+// would leave the view.
+func (c *copyModeState) scroll(delta, height int) {
+	if height <= 0 {
+		return
+	}
+	c.top = min(max(c.top+delta, 0), max(len(c.rows)-height, 0))
+	c.y = min(max(c.y, c.top), c.top+height-1)
+	c.clampCursor(height)
+}
+
+// jump moves the cursor and the view together by delta rows.
+func (c *copyModeState) jump(delta, height int) {
+	c.y += delta
+	c.top = min(max(c.top+delta, 0), max(len(c.rows)-height, 0))
+	c.clampCursor(height)
+}
+
+func copyBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+// wordForward moves to the start of the next word, crossing rows.
+func (c *copyModeState) wordForward() {
+	x, y := c.x, c.y
+	for y < len(c.rows) {
+		for x++; x < c.lineLen(y); x++ {
+			if !copyBlank(c.cell(x, y)) && (x == 0 || copyBlank(c.cell(x-1, y))) {
+				c.x, c.y = x, y
+				return
+			}
+		}
+		y++
+		x = -1
+	}
+}
+
+// wordBackward moves to the start of the previous word, crossing rows.
+func (c *copyModeState) wordBackward() {
+	x, y := c.x, c.y
+	for y >= 0 {
+		for x--; x >= 0; x-- {
+			if !copyBlank(c.cell(x, y)) && (x == 0 || copyBlank(c.cell(x-1, y))) {
+				c.x, c.y = x, y
+				return
+			}
+		}
+		y--
+		x = c.lineLen(y)
+	}
+}
+
+func (c *copyModeState) toggleSelection(mode copySelection) {
+	switch {
+	case c.selection == mode:
+		c.selection = copySelectNone
+	case c.selection == copySelectNone:
+		c.selection = mode
+		c.anchorX, c.anchorY = c.x, c.y
+	default:
+		c.selection = mode
+	}
+}
+
+func (c *copyModeState) selected(x, y int) bool {
+	if c.selection == copySelectNone {
+		return false
+	}
+	y0, y1 := min(c.anchorY, c.y), max(c.anchorY, c.y)
+	if y < y0 || y > y1 {
+		return false
+	}
+	switch c.selection {
+	case copySelectLine:
+		return true
+	case copySelectRect:
+		return x >= min(c.anchorX, c.x) && x <= max(c.anchorX, c.x)
+	}
+	startX, endX := c.anchorX, c.x
+	if c.anchorY > c.y || (c.anchorY == c.y && c.anchorX > c.x) {
+		startX, endX = c.x, c.anchorX
+	}
+	if y == y0 && x < startX {
+		return false
+	}
+	if y == y1 && x > endX {
+		return false
+	}
+	return true
+}
+
+// selectionText returns the selected text with trailing blanks trimmed from
+// each line.
+func (c *copyModeState) selectionText() string {
+	if c.selection == copySelectNone {
+		return ""
+	}
+	y0, y1 := min(c.anchorY, c.y), max(c.anchorY, c.y)
+	lines := make([]string, 0, y1-y0+1)
+	for y := y0; y <= y1; y++ {
+		var b strings.Builder
+		for x := 0; x < max(c.cols, len(c.rows[y].GetCells())); x++ {
+			if c.selected(x, y) {
+				b.WriteString(c.cell(x, y))
+			}
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *copyModeState) startSearch(backward bool) {
+	c.searching = true
+	c.searchBackward = backward
+	c.searchErr = false
+	c.originX, c.originY, c.originTop = c.x, c.y, c.top
+	c.searchInput.Prompt = "/"
+	if backward {
+		c.searchInput.Prompt = "?"
+	}
+	c.searchInput.SetValue("")
+	c.searchInput.Focus()
+}
+
+// updateSearch re-runs the search from where it started as the pattern is
+// typed, so matches are highlighted incrementally.
+func (c *copyModeState) updateSearch(height int) {
+	c.x, c.y, c.top = c.originX, c.originY, c.originTop
+	c.searchErr = false
+	c.pattern = nil
+	value := c.searchInput.Value()
+	if value == "" {
+		return
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		c.searchErr = true
+		return
+	}
+	c.pattern = re
+	from := c.x - 1
+	if c.searchBackward {
+		from = c.x + 1
+	}
+	if c.find(from, c.y, c.searchBackward) {
+		c.clampCursor(height)
+	}
+}
+
+func exitCopyMode(m attachModel) attachModel {
+	m.copyMode = copyModeState{}
+	return m
+}
+
+func handleCopyModeKey(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
+	height := m.rectForPane(m.paneID).h
+	c := &m.copyMode
+	key := msg.String()
+	if c.searching {
+		switch key {
+		case "esc", "ctrl+c":
+			c.searching = false
+			c.searchInput.Blur()
+			c.x, c.y, c.top = c.originX, c.originY, c.originTop
+			c.pattern = nil
+			return m, nil
+		case "enter":
+			c.searching = false
+			c.searchInput.Blur()
+			if c.pattern != nil && len(c.matches(c.y)) == 0 {
+				m.statusMsg = "search: no match"
+				m.statusUntil = time.Now().Add(2 * time.Second)
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		c.searchInput, cmd = c.searchInput.Update(msg)
+		c.updateSearch(height)
+		return m, cmd
+	}
+	switch key {
+	case "q", "ctrl+c":
+		return exitCopyMode(m), nil
+	case "esc":
+		if c.selection != copySelectNone {
+			c.selection = copySelectNone
+			return m, nil
+		}
+		return exitCopyMode(m), nil
+	case "h", "left":
+		c.x--
+	case "l", "right":
+		c.x++
+	case "j", "down":
+		c.y++
+	case "k", "up":
+		c.y--
+	case "0", "home":
+		c.x = 0
+	case "^":
+		c.x = 0
+		for c.x < c.lineLen(c.y) && copyBlank(c.cell(c.x, c.y)) {
+			c.x++
+		}
+	case "$", "end":
+		c.x = max(c.lineLen(c.y)-1, 0)
+	case "w":
+		c.wordForward()
+	case "b":
+		c.wordBackward()
+	case "g":
+		c.x, c.y = 0, 0
+	case "G":
+		c.x, c.y = 0, len(c.rows)-1
+	case "ctrl+u":
+		c.jump(-max(height/2, 1), height)
+	case "ctrl+d":
+		c.jump(max(height/2, 1), height)
+	case "pgup", "ctrl+b":
+		c.jump(-height, height)
+	case "pgdown", "ctrl+f":
+		c.jump(height, height)
+	case "v", " ":
+		c.toggleSelection(copySelectChar)
+	case "V":
+		c.toggleSelection(copySelectLine)
+	case "ctrl+v":
+		c.toggleSelection(copySelectRect)
+	case "/":
+		c.startSearch(false)
+		return m, textinput.Blink
+	case "?":
+		c.startSearch(true)
+		return m, textinput.Blink
+	case "n", "N":
+		if c.pattern == nil {
+			return m, nil
+		}
+		if !c.find(c.x, c.y, c.searchBackward != (key == "N")) {
+			m.statusMsg = "search: no match"
+			m.statusUntil = time.Now().Add(2 * time.Second)
+		}
+	case "y", "enter":
+		text := c.selectionText()
+		if text == "" {
+			m.statusMsg = "copy: nothing selected"
+			m.statusUntil = time.Now().Add(2 * time.Second)
+			return m, nil
+		}
+		m = exitCopyMode(m)
+		m.statusMsg = fmt.Sprintf("copied %d characters", utf8.RuneCountInString(text))
+		m.statusUntil = time.Now().Add(2 * time.Second)
+		return m, clipboardCmd(m.output, text)
+	default:
+		return m, nil
+	}
+	c.clampCursor(height)
+	return m, nil
+}
+
+// clipboardCmd sets the system clipboard of the outer terminal with OSC 52.
+// Inside tmux the sequence is wrapped in a passthrough so it reaches the
+// terminal tmux runs in.
+func clipboardCmd(w io.Writer, text string) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	seq := ansi.SetSystemClipboard(text)
+	if os.Getenv("TMUX") != "" {
+		seq = ansi.TmuxPassthrough(seq)
+	}
+	return func() tea.Msg {
+		if _, err := io.WriteString(w, seq); err != nil {
+			return rpcErrMsg{err: err, op: "copy"}
+		}
+		return nil
+	}
+}
+
+// renderCopyMode draws the visible part of the copy buffer with search
+// matches and the selection highlighted.
+func renderCopyMode(c copyModeState, width, height int) []string {
+	lines := make([]string, 0, max(height, 0))
+	for r := 0; r < height; r++ {
+		y := c.top + r
+		if y >= len(c.rows) {
+			lines = append(lines, renderRow(nil, width, r, -1, -1, false))
+			continue
+		}
+		matches := c.matches(y)
+		var b strings.Builder
+		b.WriteString("\x1b[0m")
+		for x := 0; x < width; x++ {
+			var cell *proto.ScreenCell
+			if cells := c.rows[y].GetCells(); x < len(cells) {
+				cell = cells[x]
+			}
+			style, ch := styleFromCell(cell)
+			for _, match := range matches {
+				if x >= match[0] && x < match[1] {
+					style = copyMatchStyle
+				}
+			}
+			if c.selected(x, y) {
+				style = copySelectStyle
+			}
+			if x == c.x && y == c.y {
+				style = cursorStyle(style)
+			}
+			writeStyleSGR(&b, style)
+			b.WriteString(ch)
+		}
+		b.WriteString("\x1b[0m")
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// copyModeFooterLabel shows the search prompt while typing a pattern, or the
+// position in the buffer.
+func copyModeFooterLabel(c copyModeState) string {
+	if !c.active {
+		return ""
+	}
+	if c.searching {
+		label := c.searchInput.View()
+		if c.searchErr {
+			label += " (invalid)"
+		}
+		return label
+	}
+	label := fmt.Sprintf("copy %d/%d", c.y+1, len(c.rows))
+	switch c.selection {
+	case copySelectChar:
+		label += " select"
+	case copySelectLine:
+		label += " line"
+	case copySelectRect:
+		label += " rect"
+	}
+	return label
+}
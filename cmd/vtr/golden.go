@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+const (
+	goldenRegexOpen  = "{{"
+	goldenRegexClose = "}}"
+	// goldenLiteralOpen is how --update writes a literal "{{" from the screen:
+	// a regex segment that matches it.
+	goldenLiteralOpen = `{{\{\{}}`
+)
+
+// goldenOptions controls how a screen is compared to a golden file.
+type goldenOptions struct {
+	// ANSI compares colors and attributes: goldens hold screenToText output
+	// with includeANSI set.
+	ANSI bool
+	// Ignore lists regions blanked on the screen before comparing. Zero
+	// width or height extends to the edge of the screen.
+	Ignore []*proto.ScreenRect
+}
+
+// screenDiff is one golden row that did not match. Row is 0-based.
+type screenDiff struct {
+	Row      int
+	Expected string
+	Actual   string
+}
+
+func newAssertScreenCmd() *cobra.Command {
+	var hub string
+	var jsonOut bool
+	var ansi bool
+	var update bool
+	var ignore []string
+	cmd := &cobra.Command{
+		Use:   "assert-screen <name> <golden>",
+		Short: "Compare the screen to a golden file",
+		Long: "Compare the current screen to a golden file and print a row-level diff when " +
+			"they differ. Golden rows may contain {{regex}} segments. --ignore blanks a " +
+			"region (x,y,width,height) before comparing, --ansi also compares colors and " +
+			"attributes, and --update rewrites the golden from the screen.",
+		Example: `vtr agent assert-screen htop testdata/htop.golden --ignore 0,0,0,1
+vtr agent assert-screen menu testdata/menu.golden --ansi --update`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := goldenOptions{ANSI: ansi}
+			for _, value := range ignore {
+				rect, err := parseScreenRect("--ignore", value)
+				if err != nil {
+					return err
+				}
+				if rect != nil {
+					opts.Ignore = append(opts.Ignore, rect)
+				}
+			}
+			path := args[1]
+			var golden []byte
+			if !update {
+				data, err := os.ReadFile(path)
+				if errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("golden %s does not exist (use --update to create it)", path)
+				}
+				if err != nil {
+					return err
+				}
+				golden = data
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: sessionRef})
+				if err != nil {
+					return err
+				}
+				if update {
+					if err := writeGolden(path, resp, opts); err != nil {
+						return err
+					}
+					if jsonOut {
+						return writeJSON(cmd.OutOrStdout(), jsonAssertScreen{OK: true, Golden: path, Updated: true})
+					}
+					_, err := fmt.Fprintf(cmd.OutOrStdout(), "updated %s\n", path)
+					return err
+				}
+				diffs, err := compareGolden(resp, string(golden), opts)
+				if err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+				if jsonOut {
+					if err := writeJSON(cmd.OutOrStdout(), assertScreenToJSON(path, diffs)); err != nil {
+						return err
+					}
+				} else if len(diffs) > 0 {
+					if err := printScreenDiff(cmd.OutOrStdout(), path, args[0], diffs, opts.ANSI); err != nil {
+						return err
+					}
+				}
+				if len(diffs) > 0 {
+					return fmt.Errorf("screen does not match %s (%d rows differ)", path, len(diffs))
+				}
+				return nil
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output structured JSON")
+	cmd.Flags().BoolVar(&ansi, "ansi", false, "Compare colors and attributes (golden holds ANSI text)")
+	cmd.Flags().BoolVar(&update, "update", false, "Rewrite the golden from the current screen")
+	cmd.Flags().StringArrayVar(&ignore, "ignore", nil, "ignore region x,y,width,height (repeatable; 0 width/height extends to the edge)")
+	return cmd
+}
+
+// goldenScreen renders the screen as a golden file, with ignored regions
+// blanked and literal "{{" escaped so the golden matches the screen it was
+// written from.
+func goldenScreen(resp *proto.GetScreenResponse, opts goldenOptions) string {
+	text := screenToText(maskScreen(resp, opts.Ignore), opts.ANSI)
+	text = strings.ReplaceAll(text, goldenRegexOpen, goldenLiteralOpen)
+	if opts.ANSI {
+		return text + "\n"
+	}
+	return strings.TrimRight(text, "\n") + "\n"
+}
+
+func writeGolden(path string, resp *proto.GetScreenResponse, opts goldenOptions) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, []byte(goldenScreen(resp, opts)), 0o644)
+}
+
+// compareGolden compares the screen to golden row by row. Missing trailing
+// rows on either side compare as empty. A golden row containing {{regex}}
+// must match the whole screen row; other rows must be equal, ignoring
+// trailing spaces and, for plain text, the ignored columns.
+func compareGolden(resp *proto.GetScreenResponse, golden string, opts goldenOptions) ([]screenDiff, error) {
+	actual := strings.Split(screenToText(maskScreen(resp, opts.Ignore), opts.ANSI), "\n")
+	expected := strings.Split(strings.TrimSuffix(golden, "\n"), "\n")
+	if golden == "" {
+		expected = nil
+	}
+	var diffs []screenDiff
+	for row := 0; row < max(len(actual), len(expected)); row++ {
+		var want, got string
+		if row < len(expected) {
+			want = strings.TrimSuffix(expected[row], "\r")
+		}
+		if row < len(actual) {
+			got = actual[row]
+		}
+		ok, err := goldenRowMatches(want, got, row, opts)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if !ok {
+			diffs = append(diffs, screenDiff{Row: row, Expected: want, Actual: got})
+		}
+	}
+	return diffs, nil
+}
+
+func goldenRowMatches(want, got string, row int, opts goldenOptions) (bool, error) {
+	if strings.Contains(want, goldenRegexOpen) {
+		re, err := goldenRowRegexp(want)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(got) || re.MatchString(strings.TrimRight(got, " ")), nil
+	}
+	if !opts.ANSI {
+		want = blankIgnoredColumns(want, row, opts.Ignore)
+	}
+	return strings.TrimRight(want, " ") == strings.TrimRight(got, " "), nil
+}
+
+// goldenRowRegexp turns a golden row into an anchored regexp: text outside
+// {{ }} is literal.
+func goldenRowRegexp(row string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	rest := row
+	for {
+		start := strings.Index(rest, goldenRegexOpen)
+		if start < 0 {
+			b.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		end := strings.Index(rest[start+len(goldenRegexOpen):], goldenRegexClose)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated %s in %q", goldenRegexOpen, row)
+		}
+		end += start + len(goldenRegexOpen)
+		b.WriteString(regexp.QuoteMeta(rest[:start]))
+		b.WriteString("(?:")
+		b.WriteString(rest[start+len(goldenRegexOpen) : end])
+		b.WriteString(")")
+		rest = rest[end+len(goldenRegexClose):]
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// blankIgnoredColumns blanks the cells of a plain golden row that fall in
+// rects, counting columns in screen cells as maskScreen does: a wide
+// character covers two cells, and the space screenToText writes for its
+// second cell belongs to it.
+func blankIgnoredColumns(line string, row int, rects []*proto.ScreenRect) string {
+	if len(rects) == 0 {
+		return line
+	}
+	runes := []rune(line)
+	var b strings.Builder
+	col := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		width := ansi.StringWidth(string(r))
+		if width == 0 {
+			b.WriteRune(r)
+			continue
+		}
+		spacer := width == 2 && i+1 < len(runes) && runes[i+1] == ' '
+		switch {
+		case !rectContains(rects, col, row):
+			b.WriteRune(r)
+			if spacer {
+				b.WriteByte(' ')
+			}
+		case width == 2:
+			b.WriteString("  ")
+		default:
+			b.WriteByte(' ')
+		}
+		if spacer {
+			i++
+		}
+		col += width
+	}
+	return b.String()
+}
+
+// maskScreen returns a copy of the screen with the cells in rects blanked.
+func maskScreen(resp *proto.GetScreenResponse, rects []*proto.ScreenRect) *proto.GetScreenResponse {
+	if resp == nil || len(rects) == 0 {
+		return resp
+	}
+	masked := goproto.Clone(resp).(*proto.GetScreenResponse)
+	for y, row := range masked.ScreenRows {
+		if row == nil {
+			continue
+		}
+		for x, cell := range row.Cells {
+			if cell != nil && rectContains(rects, x, y) {
+				row.Cells[x] = &proto.ScreenCell{}
+			}
+		}
+	}
+	return masked
+}
+
+func rectContains(rects []*proto.ScreenRect, x, y int) bool {
+	for _, rect := range rects {
+		x0, y0 := int(rect.GetX()), int(rect.GetY())
+		if x < x0 || y < y0 {
+			continue
+		}
+		if w := int(rect.GetWidth()); w > 0 && x >= x0+w {
+			continue
+		}
+		if h := int(rect.GetHeight()); h > 0 && y >= y0+h {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func printScreenDiff(w io.Writer, golden, session string, diffs []screenDiff, quote bool) error {
+	format := func(line string) string {
+		if quote {
+			return strconv.Quote(line)
+		}
+		return line
+	}
+	if _, err := fmt.Fprintf(w, "--- %s\n+++ %s\n", golden, session); err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		if _, err := fmt.Fprintf(w, "@@ row %d @@\n-%s\n+%s\n", diff.Row, format(diff.Expected), format(diff.Actual)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
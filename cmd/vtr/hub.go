@@ -23,16 +23,25 @@ import (
 )
 
 type hubOptions struct {
-	addr          string
-	noWeb         bool
-	noCoordinator bool
-	shell         string
-	cols          int
-	rows          int
-	scrollback    uint
-	killTimeout   time.Duration
-	idleThreshold time.Duration
-	logLevel      string
+	addr             string
+	noWeb            bool
+	noCoordinator    bool
+	shell            string
+	cols             int
+	rows             int
+	scrollback       uint
+	killTimeout      time.Duration
+	idleThreshold    time.Duration
+	cgroupRoot       string
+	allowUIDs        []uint
+	allowGIDs        []uint
+	term             string
+	locale           string
+	terminfoDir      string
+	shellIntegration bool
+	resizePolicy     string
+	macroDir         string
+	logLevel         string
 }
 
 func newHubCmd() *cobra.Command {
@@ -54,6 +63,15 @@ func newHubCmd() *cobra.Command {
 	cmd.Flags().UintVar(&opts.scrollback, "scrollback", 10000, "scrollback lines")
 	cmd.Flags().DurationVar(&opts.killTimeout, "kill-timeout", 5*time.Second, "kill timeout (e.g. 5s)")
 	cmd.Flags().DurationVar(&opts.idleThreshold, "idle-threshold", 5*time.Second, "idle threshold before session is idle")
+	cmd.Flags().StringVar(&opts.cgroupRoot, "cgroup-root", "", "delegated cgroup v2 directory for per-session limits")
+	cmd.Flags().UintSliceVar(&opts.allowUIDs, "allow-uid", nil, "uids sessions may request to run as (repeatable)")
+	cmd.Flags().UintSliceVar(&opts.allowGIDs, "allow-gid", nil, "gids sessions may request to run as (repeatable)")
+	cmd.Flags().StringVar(&opts.term, "term", "", "TERM for sessions (default xterm-ghostty, or xterm-256color without terminfo)")
+	cmd.Flags().StringVar(&opts.locale, "locale", "", "LANG for sessions (default UTF-8 LANG from env, or C.UTF-8)")
+	cmd.Flags().StringVar(&opts.terminfoDir, "terminfo-dir", defaultTerminfoDir(), "directory for the bundled xterm-ghostty terminfo (empty disables)")
+	cmd.Flags().BoolVar(&opts.shellIntegration, "shell-integration", false, "inject OSC 133/OSC 7 shell integration into bash, zsh and fish sessions")
+	cmd.Flags().StringVar(&opts.resizePolicy, "resize-policy", "latest", "default resize policy (latest, smallest, largest, fixed, owner)")
+	cmd.Flags().StringVar(&opts.macroDir, "macro-dir", defaultMacroDir(), "directory for recorded input macros (empty disables)")
 	cmd.Flags().StringVar(&opts.logLevel, "log-level", "info", "log level (debug, info, warn, error)")
 
 	return cmd
@@ -106,6 +124,18 @@ func runHub(opts hubOptions) error {
 	if opts.scrollback > uint(^uint32(0)) {
 		return fmt.Errorf("scrollback must be <= %d", uint(^uint32(0)))
 	}
+	allowUIDs, err := uint32s("allow-uid", opts.allowUIDs)
+	if err != nil {
+		return err
+	}
+	allowGIDs, err := uint32s("allow-gid", opts.allowGIDs)
+	if err != nil {
+		return err
+	}
+	resizePolicy, err := server.ParseResizePolicy(opts.resizePolicy)
+	if err != nil {
+		return err
+	}
 
 	authMode := strings.ToLower(strings.TrimSpace(cfg.Auth.Mode))
 	requireToken, requireClientCert, err := parseAuthMode(authMode)
@@ -126,8 +156,13 @@ func runHub(opts hubOptions) error {
 		return err
 	}
 
+	profiles, err := spawnProfiles(cfg)
+	if err != nil {
+		return err
+	}
 	var coord *server.Coordinator
 	if coordinatorEnabled {
+		term, terminfoDir := sessionTerm(opts.term, opts.terminfoDir, logger)
 		coord = server.NewCoordinator(server.CoordinatorOptions{
 			DefaultShell:  opts.shell,
 			DefaultCols:   uint16(opts.cols),
@@ -135,6 +170,17 @@ func runHub(opts hubOptions) error {
 			Scrollback:    uint32(opts.scrollback),
 			KillTimeout:   opts.killTimeout,
 			IdleThreshold: opts.idleThreshold,
+			Profiles:      profiles,
+			CgroupRoot:    opts.cgroupRoot,
+			AllowedUIDs:   allowUIDs,
+			AllowedGIDs:   allowGIDs,
+			Term:          term,
+			Locale:        opts.locale,
+			TerminfoDir:   terminfoDir,
+
+			ShellIntegration: opts.shellIntegration,
+			ResizePolicy:     resizePolicy,
+			MacroDir:         expandPath(opts.macroDir),
 		})
 		defer coord.CloseAll()
 	}
@@ -262,3 +308,26 @@ func hubDialAddr(addr string) string {
 		return addr
 	}
 }
+
+// sessionTerm returns the default TERM and terminfo dir for sessions. An
+// xterm-ghostty entry already installed on the host wins; otherwise the bundled
+// entry is installed into dir, and sessions fall back to xterm-256color when
+// that fails. An explicit --term is kept either way.
+func sessionTerm(term, dir string, logger *slog.Logger) (string, string) {
+	if server.SystemTerminfo() {
+		logger.Debug("using installed xterm-ghostty terminfo")
+		if term == "" {
+			term = server.GhosttyTerm
+		}
+		return term, ""
+	}
+	dir = expandPath(dir)
+	if dir == "" {
+		return term, ""
+	}
+	if err := server.InstallTerminfo(dir); err != nil {
+		logger.Warn("terminfo install failed; using xterm-256color", "dir", dir, "err", err)
+		return term, ""
+	}
+	return term, dir
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiAction names a leader action that can be rebound under [tui.keys].
+type tuiAction string
+
+const (
+	actionDetach      tuiAction = "detach"
+	actionKill        tuiAction = "kill"
+	actionNext        tuiAction = "next"
+	actionPrev        tuiAction = "prev"
+	actionCreate      tuiAction = "create"
+	actionRename      tuiAction = "rename"
+	actionShowClosed  tuiAction = "show-closed"
+	actionList        tuiAction = "list"
+	actionSplitCols   tuiAction = "split-cols"
+	actionSplitRows   tuiAction = "split-rows"
+	actionFocusLeft   tuiAction = "focus-left"
+	actionFocusRight  tuiAction = "focus-right"
+	actionFocusUp     tuiAction = "focus-up"
+	actionFocusDown   tuiAction = "focus-down"
+	actionFocusNext   tuiAction = "focus-next"
+	actionResizeLeft  tuiAction = "resize-left"
+	actionResizeRight tuiAction = "resize-right"
+	actionResizeUp    tuiAction = "resize-up"
+	actionResizeDown  tuiAction = "resize-down"
+	actionZoom        tuiAction = "zoom"
+	actionClosePane   tuiAction = "close-pane"
+	actionSaveLayout  tuiAction = "save-layout"
+	actionCopyMode    tuiAction = "copy-mode"
+	actionSendLeader  tuiAction = "send-leader"
+)
+
+// tuiKeyLeader is the [tui.keys] entry for the leader key itself.
+const tuiKeyLeader = "leader"
+
+const defaultLeaderKey = "ctrl+b"
+
+// defaultTuiKeys lists every action with its default chords, in the order
+// bindings are checked. The first chord of an action is shown in the legend.
+// send-leader is omitted: it follows the leader unless rebound.
+var defaultTuiKeys = []struct {
+	action tuiAction
+	chords []string
+}{
+	{actionDetach, []string{"d"}},
+	{actionKill, []string{"x"}},
+	{actionNext, []string{"j", "l", "n"}},
+	{actionPrev, []string{"k", "h", "p"}},
+	{actionCreate, []string{"c"}},
+	{actionRename, []string{"r"}},
+	{actionShowClosed, []string{"e"}},
+	{actionList, []string{"w"}},
+	{actionSplitCols, []string{"%", "|"}},
+	{actionSplitRows, []string{"\"", "-"}},
+	{actionFocusLeft, []string{"left"}},
+	{actionFocusRight, []string{"right"}},
+	{actionFocusUp, []string{"up"}},
+	{actionFocusDown, []string{"down"}},
+	{actionFocusNext, []string{"o"}},
+	{actionResizeLeft, []string{"ctrl+left"}},
+	{actionResizeRight, []string{"ctrl+right"}},
+	{actionResizeUp, []string{"ctrl+up"}},
+	{actionResizeDown, []string{"ctrl+down"}},
+	{actionZoom, []string{"z"}},
+	{actionClosePane, []string{"q"}},
+	{actionSaveLayout, []string{"s"}},
+	{actionCopyMode, []string{"["}},
+	{actionSendLeader, nil},
+}
+
+// keyChords accepts `action = "chord"` or a list of chords; an empty list
+// unbinds the action.
+type keyChords []string
+
+func (k *keyChords) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case string:
+		*k = keyChords{v}
+		return nil
+	case []any:
+		out := make(keyChords, 0, len(v))
+		for _, item := range v {
+			chord, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("key chords must be strings")
+			}
+			out = append(out, chord)
+		}
+		*k = out
+		return nil
+	default:
+		return fmt.Errorf("keys must be a chord or a list of chords")
+	}
+}
+
+// tuiKeymap maps leader chords to actions.
+type tuiKeymap struct {
+	leader   string
+	chords   map[tuiAction][]string
+	bindings map[string]tuiAction
+}
+
+// defaultTuiKeymap holds the built-in bindings for models without a keymap.
+var defaultTuiKeymap = mustTuiKeymap(nil)
+
+func mustTuiKeymap(overrides map[string]keyChords) tuiKeymap {
+	keys, err := newTuiKeymap(overrides)
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+// newTuiKeymap applies [tui.keys] overrides to the defaults and rejects
+// unknown actions, malformed chords and chords bound to two actions.
+func newTuiKeymap(overrides map[string]keyChords) (tuiKeymap, error) {
+	keys := tuiKeymap{
+		leader:   defaultLeaderKey,
+		chords:   make(map[tuiAction][]string, len(defaultTuiKeys)),
+		bindings: make(map[string]tuiAction),
+	}
+	for _, def := range defaultTuiKeys {
+		keys.chords[def.action] = def.chords
+	}
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		chords := make([]string, 0, len(overrides[name]))
+		for _, raw := range overrides[name] {
+			chord, err := normalizeKeyChord(raw)
+			if err != nil {
+				return tuiKeymap{}, fmt.Errorf("tui.keys.%s: %w", name, err)
+			}
+			chords = append(chords, chord)
+		}
+		if name == tuiKeyLeader {
+			if len(chords) != 1 {
+				return tuiKeymap{}, fmt.Errorf("tui.keys.leader must be a single chord")
+			}
+			if chords[0] == "esc" {
+				return tuiKeymap{}, fmt.Errorf("tui.keys.leader: esc cannot be the leader")
+			}
+			keys.leader = chords[0]
+			continue
+		}
+		action := tuiAction(name)
+		if _, ok := keys.chords[action]; !ok {
+			return tuiKeymap{}, fmt.Errorf("tui.keys: unknown action %q", name)
+		}
+		for _, chord := range chords {
+			if chord == "esc" {
+				return tuiKeymap{}, fmt.Errorf("tui.keys.%s: esc is reserved to cancel the leader", name)
+			}
+		}
+		keys.chords[action] = chords
+	}
+	if _, ok := overrides[string(actionSendLeader)]; !ok {
+		keys.chords[actionSendLeader] = []string{keys.leader}
+	}
+	for _, def := range defaultTuiKeys {
+		for _, chord := range keys.chords[def.action] {
+			if other, ok := keys.bindings[chord]; ok && other != def.action {
+				return tuiKeymap{}, fmt.Errorf("tui.keys: %s is bound to both %s and %s", formatKeyChord(chord), other, def.action)
+			}
+			keys.bindings[chord] = def.action
+		}
+	}
+	return keys, nil
+}
+
+// lookup returns the action bound to a key typed after the leader. Letters
+// fall back to their lowercase binding so caps lock does not get in the way.
+func (k tuiKeymap) lookup(key string) (tuiAction, bool) {
+	if action, ok := k.bindings[key]; ok {
+		return action, true
+	}
+	if utf8.RuneCountInString(key) == 1 {
+		action, ok := k.bindings[strings.ToLower(key)]
+		return action, ok
+	}
+	return "", false
+}
+
+// legendKey renders the chords for a legend segment: up to two chords of a
+// single action, or the first chord of each of several actions.
+func (k tuiKeymap) legendKey(actions ...tuiAction) string {
+	var parts []string
+	if len(actions) == 1 {
+		chords := k.chords[actions[0]]
+		if len(chords) > 2 {
+			chords = chords[:2]
+		}
+		for _, chord := range chords {
+			parts = append(parts, formatKeyChord(chord))
+		}
+	} else {
+		for _, action := range actions {
+			if chords := k.chords[action]; len(chords) > 0 {
+				parts = append(parts, formatKeyChord(chords[0]))
+			}
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// tuiKeyNames holds the named keys bubbletea reports (enter, ctrl+a, pgup…).
+var tuiKeyNames = func() map[string]bool {
+	names := make(map[string]bool)
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if name := t.String(); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}()
+
+// normalizeKeyChord validates a chord in bubbletea's key syntax: a single
+// character or a key name, optionally prefixed with alt+. "space" is accepted
+// for the space bar.
+func normalizeKeyChord(raw string) (string, error) {
+	chord := strings.TrimSpace(raw)
+	if chord == "" && raw != "" {
+		chord = " "
+	}
+	if utf8.RuneCountInString(chord) != 1 {
+		chord = strings.ToLower(chord)
+	}
+	if chord == "space" {
+		chord = " "
+	}
+	name := strings.TrimPrefix(chord, "alt+")
+	if name == "space" {
+		name = " "
+		chord = "alt+ "
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		if unicode.IsPrint(r) {
+			return chord, nil
+		}
+	}
+	if tuiKeyNames[name] {
+		return chord, nil
+	}
+	return "", fmt.Errorf("invalid key %q", raw)
+}
+
+// formatKeyChord renders a chord for the footer, e.g. Ctrl+b.
+func formatKeyChord(chord string) string {
+	prefix := ""
+	if rest, ok := strings.CutPrefix(chord, "alt+"); ok {
+		prefix, chord = "Alt+", rest
+	}
+	switch {
+	case chord == " ":
+		chord = "Space"
+	case strings.HasPrefix(chord, "ctrl+"):
+		chord = "Ctrl+" + strings.TrimPrefix(chord, "ctrl+")
+	case strings.HasPrefix(chord, "shift+"):
+		chord = "Shift+" + strings.TrimPrefix(chord, "shift+")
+	}
+	return prefix + chord
+}
+
+// rawPrefix returns the leader and detach chords as the bytes a terminal
+// sends for them, for vtr attach --raw.
+func (k tuiKeymap) rawPrefix() (byte, byte, error) {
+	leader, ok := keyChordByte(k.leader)
+	if !ok {
+		return 0, 0, fmt.Errorf("--raw needs a ctrl+letter leader, not %s", formatKeyChord(k.leader))
+	}
+	for _, chord := range k.chords[actionDetach] {
+		if detach, ok := keyChordByte(chord); ok {
+			return leader, detach, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("--raw needs a single-character or ctrl+letter detach key")
+}
+
+func keyChordByte(chord string) (byte, bool) {
+	if rest, ok := strings.CutPrefix(chord, "ctrl+"); ok && len(rest) == 1 && rest[0] >= 'a' && rest[0] <= 'z' {
+		return rest[0] - 'a' + 1, true
+	}
+	if len(chord) == 1 && chord[0] >= ' ' && chord[0] < 0x7f {
+		return chord[0], true
+	}
+	return 0, false
+}
+
+// tuiKeymapFromConfig builds the keymap from cfg's [tui.keys].
+func tuiKeymapFromConfig(cfg *clientConfig) (tuiKeymap, error) {
+	var overrides map[string]keyChords
+	if cfg != nil {
+		overrides = cfg.TUI.Keys
+	}
+	return newTuiKeymap(overrides)
+}
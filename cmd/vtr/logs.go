@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// logsFollowWait bounds each ReadOutput long poll while following.
+const logsFollowWait = 30 * time.Second
+
+// logsOptions filters buffered output before it is printed.
+type logsOptions struct {
+	StripANSI bool
+	// Marker drops everything up to and including the line holding its last
+	// occurrence.
+	Marker string
+	// Lines keeps only the last N lines; 0 keeps all.
+	Lines int
+}
+
+func newLogsCmd() *cobra.Command {
+	var hub string
+	var jsonOut bool
+	var follow bool
+	var offset int64
+	var opts logsOptions
+	cmd := &cobra.Command{
+		Use:   "logs <name>",
+		Short: "Print buffered raw output",
+		Long: "Print the session's buffered PTY output (the last 1 MiB), optionally from a byte " +
+			"--offset; a negative offset counts back from the end. -f keeps following new output " +
+			"until the session exits. --strip-ansi removes escape sequences and carriage returns, " +
+			"--since-marker prints only what follows the last line containing the marker and " +
+			"--lines keeps the last N lines. With --offset or -f, output that left the buffer " +
+			"before it was read is reported on stderr as an output gap.",
+		Example: `vtr agent logs demo --strip-ansi --lines 50
+vtr agent logs demo -f --strip-ansi
+vtr agent logs demo --since-marker '== build ==' --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Lines < 0 {
+				return fmt.Errorf("--lines must be >= 0")
+			}
+			if follow && jsonOut {
+				return fmt.Errorf("--json cannot be combined with -f")
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			if follow {
+				ctx, cancel = signal.NotifyContext(context.Background(), os.Interrupt)
+			}
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.ReadOutput(ctx, &proto.ReadOutputRequest{Session: sessionRef, Offset: offset})
+				if err != nil {
+					return err
+				}
+				if cmd.Flags().Changed("offset") {
+					reportOutputGap(cmd.ErrOrStderr(), resp)
+				} else {
+					// Without --offset we start at whatever is still buffered, so
+					// output that left the buffer earlier is not a gap.
+					resp.DroppedBytes = 0
+				}
+				data := resp.Data
+				var pending []byte
+				if follow && opts.StripANSI {
+					data, pending = splitANSITail(data)
+				}
+				text, err := filterLogs(data, opts)
+				if err != nil {
+					return err
+				}
+				if jsonOut {
+					return writeJSON(cmd.OutOrStdout(), jsonLogs{
+						Offset:       resp.Offset,
+						NextOffset:   resp.NextOffset,
+						DroppedBytes: resp.DroppedBytes,
+						Exited:       resp.Exited,
+						Output:       text,
+					})
+				}
+				if _, err := io.WriteString(cmd.OutOrStdout(), text); err != nil {
+					return err
+				}
+				if !follow {
+					return nil
+				}
+				err = followLogs(ctx, client, sessionRef, resp, pending, opts.StripANSI, cmd.OutOrStdout(), cmd.ErrOrStderr())
+				if errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled {
+					return nil
+				}
+				return err
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output structured JSON")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep printing new output until the session exits")
+	cmd.Flags().Int64Var(&offset, "offset", 0, "start at this byte offset (negative counts back from the end)")
+	cmd.Flags().BoolVar(&opts.StripANSI, "strip-ansi", false, "remove escape sequences and carriage returns")
+	cmd.Flags().StringVar(&opts.Marker, "since-marker", "", "print only output after the last line containing this text")
+	cmd.Flags().IntVar(&opts.Lines, "lines", 0, "print only the last N lines of buffered output")
+	return cmd
+}
+
+// followLogs prints output after last until the session exits or ctx ends.
+// pending holds an escape sequence cut off at the end of last when strip is
+// set.
+func followLogs(ctx context.Context, client proto.VTRClient, ref *proto.SessionRef, last *proto.ReadOutputResponse, pending []byte, strip bool, out, errOut io.Writer) error {
+	for !last.Exited {
+		resp, err := client.ReadOutput(ctx, &proto.ReadOutputRequest{
+			Session: ref,
+			Offset:  last.NextOffset,
+			Wait:    durationpb.New(logsFollowWait),
+		})
+		if err != nil {
+			return err
+		}
+		reportOutputGap(errOut, resp)
+		data := resp.Data
+		if strip {
+			var ready []byte
+			ready, pending = splitANSITail(append(pending, data...))
+			data = []byte(stripLogText(ready))
+		}
+		if _, err := out.Write(data); err != nil {
+			return err
+		}
+		last = resp
+	}
+	if strip && len(pending) > 0 {
+		_, err := io.WriteString(out, stripLogText(pending))
+		return err
+	}
+	return nil
+}
+
+func reportOutputGap(w io.Writer, resp *proto.ReadOutputResponse) {
+	if resp.GetDroppedBytes() <= 0 {
+		return
+	}
+	fmt.Fprintf(w, "output gap detected: %d bytes dropped before offset %d\n", resp.DroppedBytes, resp.Offset)
+}
+
+// filterLogs applies opts to buffered output. The marker and line limit
+// apply to the text as printed, so after stripping when StripANSI is set.
+func filterLogs(data []byte, opts logsOptions) (string, error) {
+	text := string(data)
+	if opts.StripANSI {
+		text = stripLogText(text)
+	}
+	if opts.Marker != "" {
+		idx := strings.LastIndex(text, opts.Marker)
+		if idx < 0 {
+			return "", fmt.Errorf("marker %q not found in buffered output", opts.Marker)
+		}
+		rest := text[idx+len(opts.Marker):]
+		if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+			text = rest[nl+1:]
+		} else {
+			text = ""
+		}
+	}
+	if opts.Lines > 0 {
+		text = lastLines(text, opts.Lines)
+	}
+	return text, nil
+}
+
+// lastLines returns the last n lines of text; a trailing newline does not
+// start a line.
+func lastLines(text string, n int) string {
+	end := len(text)
+	if strings.HasSuffix(text, "\n") {
+		end--
+	}
+	idx := end
+	for i := 0; i < n; i++ {
+		idx = strings.LastIndexByte(text[:idx], '\n')
+		if idx < 0 {
+			return text
+		}
+	}
+	return text[idx+1:]
+}
+
+func stripLogText[T string | []byte](data T) string {
+	text := strings.ReplaceAll(ansi.Strip(string(data)), "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "")
+}
+
+// splitANSITail holds back an escape sequence cut off at the end of data so
+// it can be stripped whole once the rest arrives.
+func splitANSITail(data []byte) ([]byte, []byte) {
+	idx := bytes.LastIndexByte(data, ansi.ESC)
+	if idx < 0 {
+		return data, nil
+	}
+	seq := data[idx+1:]
+	complete := false
+	switch {
+	case len(seq) == 0:
+	case seq[0] == '[':
+		complete = bytes.IndexFunc(seq[1:], func(r rune) bool { return r >= 0x40 && r <= 0x7e }) >= 0
+	case seq[0] == ']' || seq[0] == 'P':
+		complete = bytes.IndexByte(seq, ansi.BEL) >= 0
+	default:
+		complete = true
+	}
+	if complete {
+		return data, nil
+	}
+	return data[:idx], append([]byte(nil), data[idx:]...)
+}
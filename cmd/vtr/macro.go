@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const macroTimeoutDefault = 5 * time.Minute
+
+func newMacroCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "macro",
+		Short: "Record and replay input macros",
+		Long: "Record the input sent to a session, with its timing, into a named macro and " +
+			"replay it into any session on the same coordinator. Macros are stored in the " +
+			"coordinator's --macro-dir.",
+	}
+	cmd.AddCommand(
+		newMacroRecordCmd(),
+		newMacroStopCmd(),
+		newMacroPlayCmd(),
+		newMacroListCmd(),
+		newMacroRemoveCmd(),
+	)
+	return cmd
+}
+
+func newMacroRecordCmd() *cobra.Command {
+	var hub string
+	var duration time.Duration
+	cmd := &cobra.Command{
+		Use:   "record <session> <macro>",
+		Short: "Start recording input into a macro",
+		Long: "Start recording every input write to the session. Without --duration the " +
+			"recording runs until \"vtr agent macro stop\"; with it, the command waits and " +
+			"stores the macro itself.",
+		Example: `vtr agent macro record repl login
+vtr agent macro record tui open-settings --duration 20s`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if duration < 0 {
+				return fmt.Errorf("--duration must be >= 0")
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout+duration)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				if _, err := client.StartMacro(ctx, &proto.StartMacroRequest{
+					Session:   sessionRef,
+					Name:      args[1],
+					ClientId:  agentClientID(),
+					LockToken: agentLockToken(),
+				}); err != nil {
+					return err
+				}
+				if duration == 0 {
+					return writeOK(cmd.OutOrStdout())
+				}
+				time.Sleep(duration)
+				resp, err := client.StopMacro(ctx, &proto.StopMacroRequest{
+					Session:   sessionRef,
+					ClientId:  agentClientID(),
+					LockToken: agentLockToken(),
+				})
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), macroToJSON(resp.GetMacro()))
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().DurationVar(&duration, "duration", 0, "record for this long, then store the macro")
+	return cmd
+}
+
+func newMacroStopCmd() *cobra.Command {
+	var hub string
+	cmd := &cobra.Command{
+		Use:   "stop <session>",
+		Short: "Stop recording and store the macro",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				resp, err := client.StopMacro(ctx, &proto.StopMacroRequest{
+					Session:   sessionRef,
+					ClientId:  agentClientID(),
+					LockToken: agentLockToken(),
+				})
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), macroToJSON(resp.GetMacro()))
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	return cmd
+}
+
+func newMacroPlayCmd() *cobra.Command {
+	var hub string
+	var speed float64
+	var stepDelay time.Duration
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "play <session> <macro>",
+		Short: "Replay a macro into a session",
+		Long: "Replay a macro with its recorded timing. --speed scales the delays and " +
+			"--step-delay replaces them with a fixed wait between steps.",
+		Example: `vtr agent macro play repl login
+vtr agent macro play tui open-settings --speed 4
+vtr agent macro play tui open-settings --step-delay 200ms`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if speed < 0 {
+				return fmt.Errorf("--speed must be >= 0")
+			}
+			if stepDelay < 0 {
+				return fmt.Errorf("--step-delay must be >= 0")
+			}
+			if timeout <= 0 {
+				timeout = macroTimeoutDefault
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				sessionRef, _, err := resolveSessionRef(args[0], "")
+				if err != nil {
+					return err
+				}
+				req := &proto.PlayMacroRequest{
+					Session:   sessionRef,
+					Name:      args[1],
+					Speed:     speed,
+					ClientId:  agentClientID(),
+					LockToken: agentLockToken(),
+				}
+				if stepDelay > 0 {
+					req.StepDelay = durationpb.New(stepDelay)
+				}
+				resp, err := client.PlayMacro(ctx, req)
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), jsonMacroPlay{OK: true, Steps: resp.GetSteps()})
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().Float64Var(&speed, "speed", 1, "scale recorded delays (2 plays twice as fast)")
+	cmd.Flags().DurationVar(&stepDelay, "step-delay", 0, "fixed wait between steps instead of the recorded timing")
+	cmd.Flags().DurationVar(&timeout, "timeout", macroTimeoutDefault, "overall deadline")
+	return cmd
+}
+
+func newMacroListCmd() *cobra.Command {
+	var hub string
+	var coordinator string
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List stored macros",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				resp, err := client.ListMacros(ctx, &proto.ListMacrosRequest{Coordinator: strings.TrimSpace(coordinator)})
+				if err != nil {
+					return err
+				}
+				out := jsonMacros{Macros: make([]jsonMacro, 0, len(resp.GetMacros()))}
+				for _, macro := range resp.GetMacros() {
+					if macro == nil {
+						continue
+					}
+					out.Macros = append(out.Macros, macroToJSON(macro))
+				}
+				return writeJSON(cmd.OutOrStdout(), out)
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().StringVar(&coordinator, "coordinator", "", "only list macros stored on this coordinator")
+	return cmd
+}
+
+func newMacroRemoveCmd() *cobra.Command {
+	var hub string
+	cmd := &cobra.Command{
+		Use:   "rm <macro>",
+		Short: "Delete a stored macro",
+		Long: "Delete a stored macro. Prefix the name with \"coordinator:\" to delete it from " +
+			"a specific coordinator.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			coord, name := "", strings.TrimSpace(args[0])
+			if parsedCoord, parsedName, ok := parseSessionRef(name); ok {
+				coord, name = parsedCoord, parsedName
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				if _, err := client.DeleteMacro(ctx, &proto.DeleteMacroRequest{Name: name, Coordinator: coord}); err != nil {
+					return err
+				}
+				return writeOK(cmd.OutOrStdout())
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	return cmd
+}
@@ -20,15 +20,32 @@ const (
 )
 
 type jsonSession struct {
-	Coordinator string `json:"coordinator,omitempty"`
-	ID          string `json:"id,omitempty"`
-	Name        string `json:"name"`
-	Status      string `json:"status"`
-	Cols        int32  `json:"cols"`
-	Rows        int32  `json:"rows"`
-	ExitCode    *int32 `json:"exit_code,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-	ExitedAt    string `json:"exited_at,omitempty"`
+	Coordinator string            `json:"coordinator,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Status      string            `json:"status"`
+	Cols        int32             `json:"cols"`
+	Rows        int32             `json:"rows"`
+	ExitCode    *int32            `json:"exit_code,omitempty"`
+	CreatedAt   string            `json:"created_at,omitempty"`
+	ExitedAt    string            `json:"exited_at,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+
+	ShellIntegration bool           `json:"shell_integration,omitempty"`
+	InputLock        *jsonInputLock `json:"input_lock,omitempty"`
+	AttachedClients  int32          `json:"attached_clients"`
+	ResizePolicy     string         `json:"resize_policy,omitempty"`
+}
+
+type jsonInputLock struct {
+	Holder    string `json:"holder"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// jsonLockGrant is printed by `vtr agent lock`; only the holder sees the token.
+type jsonLockGrant struct {
+	*jsonInputLock
+	LockToken string `json:"lock_token"`
 }
 
 type sessionItem struct {
@@ -63,6 +80,29 @@ type jsonScreenRow struct {
 	Cells []jsonScreenCell `json:"cells"`
 }
 
+// jsonScreenFrame is a tracked screen: the full screen for a keyframe, or
+// the rows changed since base_frame_id.
+type jsonScreenFrame struct {
+	FrameID     uint64           `json:"frame_id"`
+	BaseFrameID uint64           `json:"base_frame_id,omitempty"`
+	Screen      *jsonScreen      `json:"screen,omitempty"`
+	Delta       *jsonScreenDelta `json:"delta,omitempty"`
+}
+
+type jsonScreenDelta struct {
+	Cols    int32          `json:"cols"`
+	Rows    int32          `json:"rows"`
+	CursorX int32          `json:"cursor_x"`
+	CursorY int32          `json:"cursor_y"`
+	Changed []jsonRowDelta `json:"changed_rows"`
+}
+
+type jsonRowDelta struct {
+	Row   int32            `json:"row"`
+	Text  string           `json:"text"`
+	Cells []jsonScreenCell `json:"cells"`
+}
+
 type jsonScreenCell struct {
 	Char       string `json:"char"`
 	FgColor    int32  `json:"fg_color"`
@@ -80,6 +120,18 @@ type jsonSend struct {
 	TimedOut bool `json:"timed_out,omitempty"`
 }
 
+type jsonMouse struct {
+	OK   bool `json:"ok"`
+	Sent bool `json:"sent"`
+}
+
+type jsonPaste struct {
+	OK        bool  `json:"ok"`
+	Written   int64 `json:"written"`
+	Total     int64 `json:"total"`
+	Bracketed bool  `json:"bracketed"`
+}
+
 type jsonGrepMatch struct {
 	LineNumber    int32    `json:"line_number"`
 	Line          string   `json:"line"`
@@ -91,6 +143,83 @@ type jsonGrep struct {
 	Matches []jsonGrepMatch `json:"matches"`
 }
 
+type jsonMacro struct {
+	Name        string `json:"name"`
+	Coordinator string `json:"coordinator,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	Steps       int32  `json:"steps"`
+	Bytes       int64  `json:"bytes"`
+	DurationMs  int64  `json:"duration_ms"`
+}
+
+type jsonMacros struct {
+	Macros []jsonMacro `json:"macros"`
+}
+
+type jsonMacroPlay struct {
+	OK    bool  `json:"ok"`
+	Steps int32 `json:"steps"`
+}
+
+type jsonScriptStep struct {
+	Index      int    `json:"index"`
+	Name       string `json:"name,omitempty"`
+	Action     string `json:"action"`
+	Session    string `json:"session,omitempty"`
+	OK         bool   `json:"ok"`
+	StartedMs  int64  `json:"started_ms"`
+	DurationMs int64  `json:"duration_ms"`
+	Matched    string `json:"matched,omitempty"`
+	Captured   string `json:"captured,omitempty"`
+	Goto       string `json:"goto,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Screen     string `json:"screen,omitempty"`
+}
+
+type jsonScript struct {
+	Script     string            `json:"script"`
+	OK         bool              `json:"ok"`
+	DurationMs int64             `json:"duration_ms"`
+	Steps      []jsonScriptStep  `json:"steps"`
+	Vars       map[string]string `json:"vars,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+type jsonScreenMatch struct {
+	Row        int32  `json:"row"`
+	Col        int32  `json:"col"`
+	EndCol     int32  `json:"end_col"`
+	Text       string `json:"text"`
+	FgColor    int32  `json:"fg_color"`
+	BgColor    int32  `json:"bg_color"`
+	Attributes uint32 `json:"attributes"`
+}
+
+type jsonFind struct {
+	Matches []jsonScreenMatch `json:"matches"`
+}
+
+type jsonScreenDiff struct {
+	Row      int    `json:"row"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+type jsonAssertScreen struct {
+	OK      bool             `json:"ok"`
+	Golden  string           `json:"golden"`
+	Updated bool             `json:"updated,omitempty"`
+	Diff    []jsonScreenDiff `json:"diff,omitempty"`
+}
+
+type jsonLogs struct {
+	Offset       int64  `json:"offset"`
+	NextOffset   int64  `json:"next_offset"`
+	DroppedBytes int64  `json:"dropped_bytes,omitempty"`
+	Exited       bool   `json:"exited"`
+	Output       string `json:"output"`
+}
+
 type jsonWait struct {
 	Matched     bool   `json:"matched"`
 	MatchedLine string `json:"matched_line,omitempty"`
@@ -112,6 +241,23 @@ type jsonIdleSession struct {
 	Screen      *jsonScreen `json:"screen,omitempty"`
 }
 
+type jsonClient struct {
+	ID          string `json:"id"`
+	ClientID    string `json:"client_id,omitempty"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name,omitempty"`
+	Peer        string `json:"peer,omitempty"`
+	Identity    string `json:"identity,omitempty"`
+	ReadOnly    bool   `json:"read_only,omitempty"`
+	Cols        int32  `json:"cols,omitempty"`
+	Rows        int32  `json:"rows,omitempty"`
+	ConnectedAt string `json:"connected_at,omitempty"`
+}
+
+type jsonClients struct {
+	Clients []jsonClient `json:"clients"`
+}
+
 type jsonCoordinator struct {
 	Name  string `json:"name"`
 	Path  string `json:"path"`
@@ -152,6 +298,12 @@ func sessionToJSON(session *proto.Session, coordinator string) jsonSession {
 		Rows:        session.Rows,
 		CreatedAt:   formatTimestamp(session.CreatedAt),
 		ExitedAt:    formatTimestamp(session.ExitedAt),
+		Tags:        session.GetTags(),
+
+		ShellIntegration: session.GetShellIntegration(),
+		InputLock:        inputLockToJSON(session.GetInputLock()),
+		AttachedClients:  session.GetAttachedClients(),
+		ResizePolicy:     session.GetResizePolicy(),
 	}
 	if session.Status == proto.SessionStatus_SESSION_STATUS_EXITED {
 		exitCode := session.ExitCode
@@ -160,6 +312,35 @@ func sessionToJSON(session *proto.Session, coordinator string) jsonSession {
 	return out
 }
 
+func inputLockToJSON(lock *proto.InputLock) *jsonInputLock {
+	if lock == nil {
+		return nil
+	}
+	return &jsonInputLock{
+		Holder:    lock.GetHolder(),
+		ExpiresAt: formatTimestamp(lock.GetExpiresAt()),
+	}
+}
+
+func clientsToJSON(clients []*proto.AttachedClient) jsonClients {
+	out := make([]jsonClient, 0, len(clients))
+	for _, client := range clients {
+		out = append(out, jsonClient{
+			ID:          client.GetId(),
+			ClientID:    client.GetClientId(),
+			Kind:        client.GetKind(),
+			Name:        client.GetName(),
+			Peer:        client.GetPeer(),
+			Identity:    client.GetIdentity(),
+			ReadOnly:    client.GetReadOnly(),
+			Cols:        client.GetCols(),
+			Rows:        client.GetRows(),
+			ConnectedAt: formatTimestamp(client.GetConnectedAt()),
+		})
+	}
+	return jsonClients{Clients: out}
+}
+
 func sessionsToJSON(items []sessionItem) jsonList {
 	out := make([]jsonSession, 0, len(items))
 	for _, item := range items {
@@ -223,6 +404,9 @@ func printSessionHuman(w io.Writer, session *proto.Session, coordinator string)
 	fmt.Fprintf(w, "Coordinator: %s\n", coordinator)
 	fmt.Fprintf(w, "Status: %s\n", statusString(session.Status))
 	fmt.Fprintf(w, "Size: %dx%d\n", session.Cols, session.Rows)
+	if policy := session.GetResizePolicy(); policy != "" {
+		fmt.Fprintf(w, "Resize Policy: %s\n", policy)
+	}
 	if session.Status == proto.SessionStatus_SESSION_STATUS_EXITED {
 		fmt.Fprintf(w, "Exit Code: %d\n", session.ExitCode)
 	}
@@ -232,6 +416,9 @@ func printSessionHuman(w io.Writer, session *proto.Session, coordinator string)
 	if ts := formatTimestamp(session.ExitedAt); ts != "" {
 		fmt.Fprintf(w, "Exited: %s\n", ts)
 	}
+	if lock := session.GetInputLock(); lock != nil {
+		fmt.Fprintf(w, "Input Lock: %s (until %s)\n", lock.GetHolder(), formatTimestamp(lock.GetExpiresAt()))
+	}
 }
 
 func printScreenHuman(w io.Writer, resp *proto.GetScreenResponse) {
@@ -282,26 +469,189 @@ func screenToText(resp *proto.GetScreenResponse, includeANSI bool) string {
 	return strings.Join(lines, "\n")
 }
 
+// LLM screen markers. They are rare in terminal text and cost few tokens.
+const (
+	llmCursor         = "█"
+	llmHighlightOpen  = "[["
+	llmHighlightClose = "]]"
+	llmBold           = "**"
+)
+
+type llmStyle struct {
+	highlight bool
+	bold      bool
+	color     string
+}
+
+// screenToLLM renders a compact screen for language models: a header with
+// the size, cursor and scrollback, then the rows with trailing whitespace and
+// blank rows collapsed. The cursor is marked with █; inverse or background
+// highlighted runs are wrapped in [[ ]], bold runs in ** **, and colored runs
+// in {color:...}.
+func screenToLLM(resp *proto.GetScreenResponse) string {
+	if resp == nil {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "screen %s %dx%d cursor %d,%d scrollback %d",
+		resp.Name, resp.Cols, resp.Rows, resp.CursorY, resp.CursorX, resp.ScrollbackLines)
+	if resp.ForegroundProcess != "" {
+		fmt.Fprintf(&b, " process %s", resp.ForegroundProcess)
+	}
+	b.WriteByte('\n')
+
+	blank := 0
+	for y, row := range resp.ScreenRows {
+		line := llmRow(row, y, resp)
+		if line == "" {
+			blank++
+			continue
+		}
+		writeLLMBlankRows(&b, blank)
+		blank = 0
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func writeLLMBlankRows(b *strings.Builder, n int) {
+	if n >= 2 {
+		fmt.Fprintf(b, "<%d blank rows>\n", n)
+		return
+	}
+	for ; n > 0; n-- {
+		b.WriteByte('\n')
+	}
+}
+
+func llmRow(row *proto.ScreenRow, y int, resp *proto.GetScreenResponse) string {
+	var cells []*proto.ScreenCell
+	if row != nil {
+		cells = row.Cells
+	}
+	cursorX := -1
+	if y == int(resp.CursorY) {
+		cursorX = int(resp.CursorX)
+	}
+	end := 0
+	for x, cell := range cells {
+		if cell != nil && strings.TrimSpace(cell.Char) != "" {
+			end = x + 1
+		}
+	}
+	var b strings.Builder
+	var run strings.Builder
+	var style llmStyle
+	flush := func() {
+		if run.Len() > 0 {
+			writeLLMRun(&b, run.String(), style)
+			run.Reset()
+		}
+	}
+	for x := 0; x < end; x++ {
+		var cell *proto.ScreenCell
+		if x < len(cells) {
+			cell = cells[x]
+		}
+		if next := llmCellStyle(cell); next != style {
+			flush()
+			style = next
+		}
+		if x == cursorX {
+			run.WriteString(llmCursor)
+		}
+		if cell == nil || cell.Char == "" {
+			run.WriteByte(' ')
+		} else {
+			run.WriteString(cell.Char)
+		}
+	}
+	flush()
+	if cursorX >= end {
+		b.WriteString(strings.Repeat(" ", cursorX-end))
+		b.WriteString(llmCursor)
+	}
+	return b.String()
+}
+
+func llmCellStyle(cell *proto.ScreenCell) llmStyle {
+	if cell == nil {
+		return llmStyle{}
+	}
+	return llmStyle{
+		highlight: cell.Attributes&attrInverse != 0 || cell.BgColor != 0,
+		bold:      cell.Attributes&attrBold != 0,
+		color:     llmColorName(cell.FgColor),
+	}
+}
+
+func writeLLMRun(b *strings.Builder, text string, style llmStyle) {
+	if strings.TrimSpace(text) == "" && !style.highlight {
+		b.WriteString(text)
+		return
+	}
+	if style.color != "" {
+		b.WriteString("{" + style.color + ":")
+	}
+	if style.bold {
+		b.WriteString(llmBold)
+	}
+	if style.highlight {
+		b.WriteString(llmHighlightOpen)
+	}
+	b.WriteString(text)
+	if style.highlight {
+		b.WriteString(llmHighlightClose)
+	}
+	if style.bold {
+		b.WriteString(llmBold)
+	}
+	if style.color != "" {
+		b.WriteString("}")
+	}
+}
+
+// llmColorName maps a packed RGB color to a basic hue name. Default, gray,
+// black and white text is left unannotated.
+func llmColorName(rgb int32) string {
+	r, g, bl := int(rgb>>16&0xff), int(rgb>>8&0xff), int(rgb&0xff)
+	hi, lo := max(r, g, bl), min(r, g, bl)
+	if rgb == 0 || hi-lo < 48 {
+		return ""
+	}
+	var hue int
+	switch hi {
+	case r:
+		hue = (60*(g-bl)/(hi-lo) + 360) % 360
+	case g:
+		hue = 60*(bl-r)/(hi-lo) + 120
+	default:
+		hue = 60*(r-g)/(hi-lo) + 240
+	}
+	switch {
+	case hue < 30 || hue >= 330:
+		return "red"
+	case hue < 90:
+		return "yellow"
+	case hue < 150:
+		return "green"
+	case hue < 210:
+		return "cyan"
+	case hue < 270:
+		return "blue"
+	default:
+		return "magenta"
+	}
+}
+
 func screenToJSON(resp *proto.GetScreenResponse) jsonScreen {
 	if resp == nil {
 		return jsonScreen{}
 	}
 	rows := make([]jsonScreenRow, len(resp.ScreenRows))
 	for i, row := range resp.ScreenRows {
-		cells := make([]jsonScreenCell, len(row.Cells))
-		for j, cell := range row.Cells {
-			if cell == nil {
-				cells[j] = jsonScreenCell{Char: " "}
-				continue
-			}
-			cells[j] = jsonScreenCell{
-				Char:       cell.Char,
-				FgColor:    cell.FgColor,
-				BgColor:    cell.BgColor,
-				Attributes: cell.Attributes,
-			}
-		}
-		rows[i] = jsonScreenRow{Cells: cells}
+		rows[i] = jsonScreenRow{Cells: screenCellsToJSON(row)}
 	}
 	return jsonScreen{
 		ID:         resp.GetId(),
@@ -314,6 +664,81 @@ func screenToJSON(resp *proto.GetScreenResponse) jsonScreen {
 	}
 }
 
+func screenCellsToJSON(row *proto.ScreenRow) []jsonScreenCell {
+	cells := make([]jsonScreenCell, len(row.GetCells()))
+	for j, cell := range row.GetCells() {
+		if cell == nil {
+			cells[j] = jsonScreenCell{Char: " "}
+			continue
+		}
+		cells[j] = jsonScreenCell{
+			Char:       cell.Char,
+			FgColor:    cell.FgColor,
+			BgColor:    cell.BgColor,
+			Attributes: cell.Attributes,
+		}
+	}
+	return cells
+}
+
+func screenFrameToJSON(resp *proto.GetScreenResponse) jsonScreenFrame {
+	out := jsonScreenFrame{FrameID: resp.GetFrameId(), BaseFrameID: resp.GetBaseFrameId()}
+	delta := resp.GetDelta()
+	if delta == nil {
+		out.Screen = screenJSONFromProto(resp)
+		return out
+	}
+	out.Delta = &jsonScreenDelta{
+		Cols:    delta.Cols,
+		Rows:    delta.Rows,
+		CursorX: delta.CursorX,
+		CursorY: delta.CursorY,
+		Changed: make([]jsonRowDelta, 0, len(delta.RowDeltas)),
+	}
+	for _, row := range delta.RowDeltas {
+		out.Delta.Changed = append(out.Delta.Changed, jsonRowDelta{
+			Row:   row.Row,
+			Text:  deltaRowText(row.RowData, delta.Cols, false),
+			Cells: screenCellsToJSON(row.RowData),
+		})
+	}
+	return out
+}
+
+// screenFrameToText renders a tracked screen with a frame header. Each
+// listed row is marked with "~" and its 0-based index; for a delta only the
+// changed rows are listed, for a keyframe every row is.
+func screenFrameToText(resp *proto.GetScreenResponse, includeANSI bool) string {
+	var b strings.Builder
+	delta := resp.GetDelta()
+	if delta == nil {
+		fmt.Fprintf(&b, "frame %d keyframe %dx%d cursor %d,%d\n",
+			resp.GetFrameId(), resp.GetCols(), resp.GetRows(), resp.GetCursorY(), resp.GetCursorX())
+		for y, row := range resp.GetScreenRows() {
+			writeFrameRow(&b, int32(y), deltaRowText(row, resp.GetCols(), includeANSI))
+		}
+		return b.String()
+	}
+	fmt.Fprintf(&b, "frame %d base %d changed %d cursor %d,%d\n",
+		resp.GetFrameId(), resp.GetBaseFrameId(), len(delta.RowDeltas), delta.CursorY, delta.CursorX)
+	for _, row := range delta.RowDeltas {
+		writeFrameRow(&b, row.Row, deltaRowText(row.RowData, delta.Cols, includeANSI))
+	}
+	return b.String()
+}
+
+func writeFrameRow(b *strings.Builder, row int32, text string) {
+	if text == "" {
+		fmt.Fprintf(b, "~%3d|\n", row)
+		return
+	}
+	fmt.Fprintf(b, "~%3d| %s\n", row, text)
+}
+
+func deltaRowText(row *proto.ScreenRow, cols int32, includeANSI bool) string {
+	return screenToText(&proto.GetScreenResponse{Cols: cols, ScreenRows: []*proto.ScreenRow{row}}, includeANSI)
+}
+
 func screenJSONFromProto(resp *proto.GetScreenResponse) *jsonScreen {
 	if resp == nil {
 		return nil
@@ -357,6 +782,47 @@ func grepToJSON(matches []*proto.GrepMatch) jsonGrep {
 	return jsonGrep{Matches: out}
 }
 
+func macroToJSON(macro *proto.MacroInfo) jsonMacro {
+	if macro == nil {
+		return jsonMacro{}
+	}
+	return jsonMacro{
+		Name:        macro.GetName(),
+		Coordinator: macro.GetCoordinator(),
+		CreatedAt:   formatTimestamp(macro.GetCreatedAt()),
+		Steps:       macro.GetSteps(),
+		Bytes:       macro.GetBytes(),
+		DurationMs:  macro.GetDuration().AsDuration().Milliseconds(),
+	}
+}
+
+func findToJSON(matches []*proto.ScreenMatch) jsonFind {
+	out := make([]jsonScreenMatch, 0, len(matches))
+	for _, match := range matches {
+		if match == nil {
+			continue
+		}
+		out = append(out, jsonScreenMatch{
+			Row:        match.Row,
+			Col:        match.Col,
+			EndCol:     match.EndCol,
+			Text:       match.Text,
+			FgColor:    match.FgColor,
+			BgColor:    match.BgColor,
+			Attributes: match.Attributes,
+		})
+	}
+	return jsonFind{Matches: out}
+}
+
+func assertScreenToJSON(golden string, diffs []screenDiff) jsonAssertScreen {
+	out := jsonAssertScreen{OK: len(diffs) == 0, Golden: golden}
+	for _, diff := range diffs {
+		out.Diff = append(out.Diff, jsonScreenDiff{Row: diff.Row, Expected: diff.Expected, Actual: diff.Actual})
+	}
+	return out
+}
+
 func printWaitHuman(w io.Writer, matched bool, line string, timedOut bool) {
 	if timedOut {
 		fmt.Fprintln(w, "timed out")
@@ -0,0 +1,940 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"google.golang.org/grpc/status"
+)
+
+// paneSplit is how a layout node divides its area between two children.
+type paneSplit int
+
+const (
+	paneSplitNone paneSplit = iota
+	// paneSplitCols places the children side by side.
+	paneSplitCols
+	// paneSplitRows stacks the children.
+	paneSplitRows
+)
+
+const (
+	paneRatioDefault = 50
+	paneRatioMin     = 10
+	paneRatioMax     = 90
+	paneResizeStep   = 5
+)
+
+// paneLayout is a binary tree of splits. Leaves hold a pane id, or a session
+// ref while a layout parsed from config has not been attached yet.
+type paneLayout struct {
+	pane   int
+	ref    string
+	split  paneSplit
+	ratio  int
+	first  *paneLayout
+	second *paneLayout
+}
+
+// paneRect is a pane's area within the viewport, excluding separators.
+type paneRect struct {
+	x, y int
+	w, h int
+}
+
+// tuiPane is the per-session state of a pane. The focused pane lives in the
+// attachModel fields; the others are parked in attachModel.panes.
+type tuiPane struct {
+	sessionID     string
+	sessionLabel  string
+	sessionCoord  string
+	coordinator   coordinatorRef
+	stream        proto.VTR_SubscribeClient
+	streamCancel  context.CancelFunc
+	streamID      int
+	frameID       uint64
+	streamBackoff time.Duration
+	streamState   string
+	lastScreenAt  time.Time
+	screen        *proto.GetScreenResponse
+	exited        bool
+	exitCode      int32
+}
+
+type layoutSavedMsg struct {
+	name string
+	spec string
+	err  error
+}
+
+var attachPaneSeparatorStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("240"))
+
+// parsePaneLayout parses a layout spec such as
+// "cols:60(editor, rows(server, spoke-a:logs))". cols splits side by side and
+// rows stacks; the optional percentage sizes the first child.
+func parsePaneLayout(spec string) (*paneLayout, error) {
+	p := paneLayoutParser{input: spec}
+	node, err := p.node()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("layout: unexpected %q at offset %d", p.input[p.pos], p.pos)
+	}
+	return node, nil
+}
+
+type paneLayoutParser struct {
+	input string
+	pos   int
+}
+
+func (p *paneLayoutParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *paneLayoutParser) expect(ch byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return fmt.Errorf("layout: expected %q at end of spec", ch)
+	}
+	if p.input[p.pos] != ch {
+		return fmt.Errorf("layout: expected %q at offset %d, got %q", ch, p.pos, p.input[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+func (p *paneLayoutParser) node() (*paneLayout, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune("(),", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	token := strings.TrimSpace(p.input[start:p.pos])
+	p.skipSpace()
+	if p.pos >= len(p.input) || p.input[p.pos] != '(' {
+		if token == "" {
+			return nil, fmt.Errorf("layout: expected session at offset %d", start)
+		}
+		return &paneLayout{ref: token}, nil
+	}
+	split, ratio, err := parsePaneSplit(token)
+	if err != nil {
+		return nil, err
+	}
+	p.pos++
+	first, err := p.node()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(','); err != nil {
+		return nil, err
+	}
+	second, err := p.node()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return &paneLayout{split: split, ratio: ratio, first: first, second: second}, nil
+}
+
+func parsePaneSplit(token string) (paneSplit, int, error) {
+	name, pct, hasPct := strings.Cut(token, ":")
+	var split paneSplit
+	switch strings.TrimSpace(name) {
+	case "cols":
+		split = paneSplitCols
+	case "rows":
+		split = paneSplitRows
+	default:
+		return paneSplitNone, 0, fmt.Errorf("layout: unknown split %q (want cols or rows)", token)
+	}
+	if !hasPct {
+		return split, paneRatioDefault, nil
+	}
+	ratio, err := strconv.Atoi(strings.TrimSpace(pct))
+	if err != nil || ratio < paneRatioMin || ratio > paneRatioMax {
+		return paneSplitNone, 0, fmt.Errorf("layout: split size %q must be %d-%d", pct, paneRatioMin, paneRatioMax)
+	}
+	return split, ratio, nil
+}
+
+// formatPaneLayout renders l in the syntax parsePaneLayout reads. Leaves use
+// refs[pane] when present, otherwise their parsed ref.
+func formatPaneLayout(l *paneLayout, refs map[int]string) string {
+	if l == nil {
+		return ""
+	}
+	if l.split == paneSplitNone {
+		if ref, ok := refs[l.pane]; ok {
+			return ref
+		}
+		return l.ref
+	}
+	name := "cols"
+	if l.split == paneSplitRows {
+		name = "rows"
+	}
+	if l.ratio != paneRatioDefault {
+		name = fmt.Sprintf("%s:%d", name, l.ratio)
+	}
+	return fmt.Sprintf("%s(%s, %s)", name, formatPaneLayout(l.first, refs), formatPaneLayout(l.second, refs))
+}
+
+// paneLeaves returns the leaves of l in reading order.
+func paneLeaves(l *paneLayout) []*paneLayout {
+	if l == nil {
+		return nil
+	}
+	if l.split == paneSplitNone {
+		return []*paneLayout{l}
+	}
+	return append(paneLeaves(l.first), paneLeaves(l.second)...)
+}
+
+func paneIDs(l *paneLayout) []int {
+	leaves := paneLeaves(l)
+	ids := make([]int, 0, len(leaves))
+	for _, leaf := range leaves {
+		ids = append(ids, leaf.pane)
+	}
+	return ids
+}
+
+func containsPane(l *paneLayout, id int) bool {
+	for _, leaf := range paneLeaves(l) {
+		if leaf.pane == id {
+			return true
+		}
+	}
+	return false
+}
+
+// assignPaneIDs numbers the leaves of l in reading order starting at first.
+func assignPaneIDs(l *paneLayout, first int) *paneLayout {
+	next := first
+	var assign func(*paneLayout) *paneLayout
+	assign = func(node *paneLayout) *paneLayout {
+		out := *node
+		if node.split == paneSplitNone {
+			out.pane = next
+			next++
+			return &out
+		}
+		out.first = assign(node.first)
+		out.second = assign(node.second)
+		return &out
+	}
+	if l == nil {
+		return nil
+	}
+	return assign(l)
+}
+
+// splitPaneLayout replaces pane id with a split holding it and newID.
+func splitPaneLayout(l *paneLayout, id, newID int, split paneSplit) *paneLayout {
+	if l == nil {
+		return nil
+	}
+	if l.split == paneSplitNone {
+		if l.pane != id {
+			return l
+		}
+		leaf := *l
+		return &paneLayout{split: split, ratio: paneRatioDefault, first: &leaf, second: &paneLayout{pane: newID}}
+	}
+	out := *l
+	out.first = splitPaneLayout(l.first, id, newID, split)
+	out.second = splitPaneLayout(l.second, id, newID, split)
+	return &out
+}
+
+// removePaneLayout drops pane id; its sibling takes over the parent's area.
+// It returns the new tree and the pane that should take focus.
+func removePaneLayout(l *paneLayout, id int) (*paneLayout, int, bool) {
+	if l == nil || l.split == paneSplitNone {
+		return l, 0, false
+	}
+	if l.first.split == paneSplitNone && l.first.pane == id {
+		return l.second, paneLeaves(l.second)[0].pane, true
+	}
+	if l.second.split == paneSplitNone && l.second.pane == id {
+		leaves := paneLeaves(l.first)
+		return l.first, leaves[len(leaves)-1].pane, true
+	}
+	out := *l
+	if next, focus, ok := removePaneLayout(l.first, id); ok {
+		out.first = next
+		return &out, focus, true
+	}
+	if next, focus, ok := removePaneLayout(l.second, id); ok {
+		out.second = next
+		return &out, focus, true
+	}
+	return l, 0, false
+}
+
+// resizePaneLayout moves the nearest split of the given kind around pane id
+// by delta percent.
+func resizePaneLayout(l *paneLayout, id int, split paneSplit, delta int) (*paneLayout, bool) {
+	if l == nil || l.split == paneSplitNone {
+		return l, false
+	}
+	out := *l
+	switch {
+	case containsPane(l.first, id):
+		if next, ok := resizePaneLayout(l.first, id, split, delta); ok {
+			out.first = next
+			return &out, true
+		}
+	case containsPane(l.second, id):
+		if next, ok := resizePaneLayout(l.second, id, split, delta); ok {
+			out.second = next
+			return &out, true
+		}
+	default:
+		return l, false
+	}
+	if l.split != split {
+		return l, false
+	}
+	out.ratio = min(max(l.ratio+delta, paneRatioMin), paneRatioMax)
+	return &out, out.ratio != l.ratio
+}
+
+// splitSizes divides total cells between two children and a one-cell
+// separator.
+func splitSizes(total, ratio int) (int, int) {
+	avail := total - 1
+	if avail < 2 {
+		return max(avail, 0), 0
+	}
+	first := min(max(avail*ratio/100, 1), avail-1)
+	return first, avail - first
+}
+
+func paneRects(l *paneLayout, area paneRect) map[int]paneRect {
+	rects := make(map[int]paneRect)
+	var walk func(*paneLayout, paneRect)
+	walk = func(node *paneLayout, r paneRect) {
+		switch node.split {
+		case paneSplitCols:
+			a, b := splitSizes(r.w, node.ratio)
+			walk(node.first, paneRect{x: r.x, y: r.y, w: a, h: r.h})
+			walk(node.second, paneRect{x: r.x + a + 1, y: r.y, w: b, h: r.h})
+		case paneSplitRows:
+			a, b := splitSizes(r.h, node.ratio)
+			walk(node.first, paneRect{x: r.x, y: r.y, w: r.w, h: a})
+			walk(node.second, paneRect{x: r.x, y: r.y + a + 1, w: r.w, h: b})
+		default:
+			rects[node.pane] = r
+		}
+	}
+	if l != nil {
+		walk(l, area)
+	}
+	return rects
+}
+
+// paneNeighbor finds the closest pane in direction dir ("left", "right",
+// "up" or "down") that overlaps from along the other axis.
+func paneNeighbor(rects map[int]paneRect, from int, dir string) (int, bool) {
+	cur, ok := rects[from]
+	if !ok {
+		return 0, false
+	}
+	ids := make([]int, 0, len(rects))
+	for id := range rects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	best, bestDist, bestOffset := 0, -1, 0
+	for _, id := range ids {
+		if id == from {
+			continue
+		}
+		r := rects[id]
+		overlapX := r.x < cur.x+cur.w && cur.x < r.x+r.w
+		overlapY := r.y < cur.y+cur.h && cur.y < r.y+r.h
+		var dist, offset int
+		switch dir {
+		case "left":
+			dist, offset, ok = cur.x-(r.x+r.w), abs(r.y-cur.y), overlapY
+		case "right":
+			dist, offset, ok = r.x-(cur.x+cur.w), abs(r.y-cur.y), overlapY
+		case "up":
+			dist, offset, ok = cur.y-(r.y+r.h), abs(r.x-cur.x), overlapX
+		case "down":
+			dist, offset, ok = r.y-(cur.y+cur.h), abs(r.x-cur.x), overlapX
+		default:
+			return 0, false
+		}
+		if !ok || dist < 0 {
+			continue
+		}
+		if bestDist < 0 || dist < bestDist || (dist == bestDist && offset < bestOffset) {
+			best, bestDist, bestOffset = id, dist, offset
+		}
+	}
+	return best, bestDist >= 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// rectForPane is the area of pane id; a zoomed focused pane fills the viewport.
+func (m attachModel) rectForPane(id int) paneRect {
+	area := paneRect{w: m.viewportWidth, h: m.viewportHeight}
+	if m.layout == nil || (m.zoomed && id == m.paneID) {
+		return area
+	}
+	if r, ok := paneRects(m.layout, area)[id]; ok {
+		return r
+	}
+	return area
+}
+
+// paneAt returns the pane under a host terminal position.
+func (m attachModel) paneAt(x, y int) (int, bool) {
+	if m.layout == nil || m.zoomed {
+		return 0, false
+	}
+	cellX, cellY := x-1, y-1
+	for id, r := range paneRects(m.layout, paneRect{w: m.viewportWidth, h: m.viewportHeight}) {
+		if cellX >= r.x && cellX < r.x+r.w && cellY >= r.y && cellY < r.y+r.h {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// nextStreamID returns a subscribe stream id no pane has used, so a late
+// message from a replaced stream is never mistaken for a live one.
+func nextStreamID(m attachModel) int {
+	return max(m.streamID, m.streamSeq) + 1
+}
+
+func parkPane(m attachModel) tuiPane {
+	return tuiPane{
+		sessionID:     m.sessionID,
+		sessionLabel:  m.sessionLabel,
+		sessionCoord:  m.sessionCoord,
+		coordinator:   m.coordinator,
+		stream:        m.stream,
+		streamCancel:  m.streamCancel,
+		streamID:      m.streamID,
+		frameID:       m.frameID,
+		streamBackoff: m.streamBackoff,
+		streamState:   m.streamState,
+		lastScreenAt:  m.lastScreenAt,
+		screen:        m.screen,
+		exited:        m.exited,
+		exitCode:      m.exitCode,
+	}
+}
+
+func loadPane(m attachModel, p tuiPane) attachModel {
+	m.sessionID = p.sessionID
+	m.sessionLabel = p.sessionLabel
+	m.sessionCoord = p.sessionCoord
+	m.coordinator = p.coordinator
+	m.stream = p.stream
+	m.streamCancel = p.streamCancel
+	m.streamID = p.streamID
+	m.frameID = p.frameID
+	m.streamBackoff = p.streamBackoff
+	m.streamState = p.streamState
+	m.lastScreenAt = p.lastScreenAt
+	m.screen = p.screen
+	m.exited = p.exited
+	m.exitCode = p.exitCode
+	return m
+}
+
+// focusPane parks the focused pane and moves pane id into the model fields.
+func focusPane(m attachModel, id int) attachModel {
+	if id == m.paneID {
+		return m
+	}
+	pane, ok := m.panes[id]
+	if !ok {
+		return m
+	}
+	panes := make(map[int]tuiPane, len(m.panes))
+	for other, p := range m.panes {
+		if other != id {
+			panes[other] = p
+		}
+	}
+	panes[m.paneID] = parkPane(m)
+	m.panes = panes
+	m.paneID = id
+	return loadPane(m, pane)
+}
+
+func paneForStream(m attachModel, streamID int) (int, bool) {
+	for id, pane := range m.panes {
+		if pane.streamID == streamID {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// updateBackgroundPane runs a stream message for an unfocused pane through
+// the usual handling by focusing that pane for the duration of the update.
+func updateBackgroundPane(m attachModel, id int, msg tea.Msg) (tea.Model, tea.Cmd) {
+	focused, leader := m.paneID, m.leaderActive
+	next, cmd := focusPane(m, id).Update(msg)
+	out := focusPane(next.(attachModel), focused)
+	out.leaderActive = leader
+	return out, cmd
+}
+
+// disconnectPane marks an unfocused pane disconnected after another client
+// disconnected it from its session. Like the focused pane it does not
+// reconnect, but the rest of the TUI stays attached.
+func disconnectPane(m attachModel, id int, err error) attachModel {
+	panes := make(map[int]tuiPane, len(m.panes))
+	for other, pane := range m.panes {
+		panes[other] = pane
+	}
+	pane := panes[id]
+	if pane.streamCancel != nil {
+		pane.streamCancel()
+	}
+	pane.stream = nil
+	pane.streamCancel = nil
+	pane.streamState = "disconnected"
+	panes[id] = pane
+	m.panes = panes
+	m.statusMsg = fmt.Sprintf("%s: %s", pane.sessionLabel, status.Convert(err).Message())
+	m.statusUntil = time.Now().Add(2 * time.Second)
+	return m
+}
+
+// refreshPaneLabels picks up renames of sessions shown in unfocused panes.
+func refreshPaneLabels(m attachModel) attachModel {
+	if len(m.panes) == 0 {
+		return m
+	}
+	panes := make(map[int]tuiPane, len(m.panes))
+	for id, pane := range m.panes {
+		for _, item := range m.sessionItems {
+			if item.id == pane.sessionID && item.label != "" {
+				pane.sessionLabel = item.label
+				pane.sessionCoord = item.coord
+				break
+			}
+		}
+		panes[id] = pane
+	}
+	m.panes = panes
+	return m
+}
+
+// resizePanesCmd reports every pane's size to its session after the window
+// or layout changes. A zoomed layout only resizes the focused pane.
+func resizePanesCmd(m attachModel) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(m.panes)+1)
+	if m.sessionID != "" && !m.exited {
+		cmds = append(cmds, m.resizeCmd())
+	}
+	if m.readOnly || m.zoomed {
+		return tea.Batch(cmds...)
+	}
+	for _, id := range paneIDs(m.layout) {
+		pane, ok := m.panes[id]
+		if !ok || pane.sessionID == "" || pane.exited {
+			continue
+		}
+		r := m.rectForPane(id)
+		cmds = append(cmds, resizeCmd(m.client, pane.sessionID, pane.sessionCoord, m.clientID, r.w, r.h))
+	}
+	return tea.Batch(cmds...)
+}
+
+// splitPane splits the focused pane and focuses the new, empty pane with the
+// session list open so a session can be picked for it.
+func splitPane(m attachModel, split paneSplit) (attachModel, tea.Cmd) {
+	if m.layout == nil {
+		m.layout = &paneLayout{pane: m.paneID}
+	}
+	id := m.paneID
+	for _, other := range paneIDs(m.layout) {
+		id = max(id, other)
+	}
+	id++
+	m.layout = splitPaneLayout(m.layout, m.paneID, id, split)
+	m.zoomed = false
+	panes := make(map[int]tuiPane, len(m.panes)+1)
+	for other, p := range m.panes {
+		panes[other] = p
+	}
+	panes[m.paneID] = parkPane(m)
+	m.panes = panes
+	m.paneID = id
+	m = loadPane(m, tuiPane{streamBackoff: time.Second, streamState: "disconnected"})
+	m.listActive = true
+	cmd := m.sessionList.SetItems(sessionItemsToListItems(visibleSessionItems(m), m.coords, m.coordinator.Name))
+	skipSessionListHeaders(&m.sessionList, 1)
+	return m, tea.Batch(cmd, resizePanesCmd(m))
+}
+
+// closePane removes the focused pane. Its session keeps running.
+func closePane(m attachModel) (attachModel, tea.Cmd) {
+	if m.layout == nil {
+		m.statusMsg = "close: only one pane"
+		m.statusUntil = time.Now().Add(2 * time.Second)
+		return m, nil
+	}
+	layout, focus, ok := removePaneLayout(m.layout, m.paneID)
+	if !ok {
+		return m, nil
+	}
+	if m.streamCancel != nil {
+		m.streamCancel()
+	}
+	pane := m.panes[focus]
+	panes := make(map[int]tuiPane, len(m.panes))
+	for other, p := range m.panes {
+		if other != focus {
+			panes[other] = p
+		}
+	}
+	m.panes = panes
+	m.paneID = focus
+	m = loadPane(m, pane)
+	if layout.split == paneSplitNone {
+		layout = nil
+	}
+	m.layout = layout
+	m.zoomed = false
+	return m, resizePanesCmd(m)
+}
+
+func movePaneFocus(m attachModel, dir string) (attachModel, tea.Cmd) {
+	if m.layout == nil {
+		return m, nil
+	}
+	m.zoomed = false
+	rects := paneRects(m.layout, paneRect{w: m.viewportWidth, h: m.viewportHeight})
+	if id, ok := paneNeighbor(rects, m.paneID, dir); ok {
+		m = focusPane(m, id)
+	}
+	return m, resizePanesCmd(m)
+}
+
+func cyclePaneFocus(m attachModel) (attachModel, tea.Cmd) {
+	ids := paneIDs(m.layout)
+	if len(ids) < 2 {
+		return m, nil
+	}
+	for i, id := range ids {
+		if id == m.paneID {
+			m.zoomed = false
+			m = focusPane(m, ids[(i+1)%len(ids)])
+			break
+		}
+	}
+	return m, resizePanesCmd(m)
+}
+
+// resizePane grows the focused pane toward dir by moving the nearest split
+// on that axis.
+func resizePane(m attachModel, dir string) (attachModel, tea.Cmd) {
+	if m.layout == nil || m.zoomed {
+		return m, nil
+	}
+	split, delta := paneSplitCols, paneResizeStep
+	switch dir {
+	case "left":
+		delta = -paneResizeStep
+	case "up":
+		split, delta = paneSplitRows, -paneResizeStep
+	case "down":
+		split = paneSplitRows
+	}
+	layout, ok := resizePaneLayout(m.layout, m.paneID, split, delta)
+	if !ok {
+		return m, nil
+	}
+	m.layout = layout
+	return m, resizePanesCmd(m)
+}
+
+func togglePaneZoom(m attachModel) (attachModel, tea.Cmd) {
+	if m.layout == nil {
+		m.statusMsg = "zoom: only one pane"
+		m.statusUntil = time.Now().Add(2 * time.Second)
+		return m, nil
+	}
+	m.zoomed = !m.zoomed
+	return m, resizePanesCmd(m)
+}
+
+func paneFooterLabel(m attachModel) string {
+	ids := paneIDs(m.layout)
+	if len(ids) < 2 {
+		return ""
+	}
+	for i, id := range ids {
+		if id == m.paneID {
+			label := fmt.Sprintf("pane %d/%d", i+1, len(ids))
+			if m.zoomed {
+				label += " zoomed"
+			}
+			return label
+		}
+	}
+	return ""
+}
+
+// renderPanes draws every pane of the layout with one-cell separators; only
+// the focused pane shows its cursor.
+func renderPanes(m attachModel) string {
+	return strings.Join(renderPaneLayout(m, m.layout, m.viewportWidth, m.viewportHeight), "\n")
+}
+
+func renderPaneLayout(m attachModel, l *paneLayout, width, height int) []string {
+	switch l.split {
+	case paneSplitCols:
+		a, b := splitSizes(width, l.ratio)
+		left := renderPaneLayout(m, l.first, a, height)
+		right := renderPaneLayout(m, l.second, b, height)
+		sep := attachPaneSeparatorStyle.Render("│")
+		lines := make([]string, height)
+		for i := range lines {
+			lines[i] = left[i] + sep + right[i]
+		}
+		return lines
+	case paneSplitRows:
+		a, b := splitSizes(height, l.ratio)
+		lines := renderPaneLayout(m, l.first, width, a)
+		lines = append(lines, attachPaneSeparatorStyle.Render(strings.Repeat("─", width)))
+		return append(lines, renderPaneLayout(m, l.second, width, b)...)
+	}
+	if l.pane == m.paneID {
+		if m.copyMode.active {
+			return renderCopyMode(m.copyMode, width, height)
+		}
+		return renderScreenLines(m.screen, width, height, true)
+	}
+	return renderScreenLines(m.panes[l.pane].screen, width, height, false)
+}
+
+// layoutPaneRefs names each pane's session for a saved layout.
+func layoutPaneRefs(m attachModel) (map[int]string, error) {
+	refs := make(map[int]string, len(m.panes)+1)
+	add := func(label, coord string) (string, error) {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return "", fmt.Errorf("a pane has no session")
+		}
+		if strings.ContainsAny(label, "(),") {
+			return "", fmt.Errorf("session %q cannot be saved in a layout", label)
+		}
+		return prefixSessionLabel(coord, label), nil
+	}
+	ref, err := add(m.sessionLabel, m.sessionCoord)
+	if err != nil {
+		return nil, err
+	}
+	refs[m.paneID] = ref
+	for id, pane := range m.panes {
+		ref, err := add(pane.sessionLabel, pane.sessionCoord)
+		if err != nil {
+			return nil, err
+		}
+		refs[id] = ref
+	}
+	return refs, nil
+}
+
+func currentLayoutSpec(m attachModel) (string, error) {
+	refs, err := layoutPaneRefs(m)
+	if err != nil {
+		return "", err
+	}
+	layout := m.layout
+	if layout == nil {
+		layout = &paneLayout{pane: m.paneID}
+	}
+	return formatPaneLayout(layout, refs), nil
+}
+
+func validLayoutName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// configLayout parses the named layout from [tui.layouts.<name>].
+func configLayout(cfg *clientConfig, name string) (*paneLayout, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("layout %q not found in config", name)
+	}
+	entry, ok := cfg.TUI.Layouts[name]
+	if !ok {
+		return nil, fmt.Errorf("layout %q not found in config", name)
+	}
+	layout, err := parsePaneLayout(entry.Panes)
+	if err != nil {
+		return nil, fmt.Errorf("layout %q: %w", name, err)
+	}
+	return assignPaneIDs(layout, 1), nil
+}
+
+// resolveLayoutPanes resolves every session of layout but the first, which
+// becomes the focused pane. Parked panes get stream ids 2..n.
+func resolveLayoutPanes(client proto.VTRClient, layout *paneLayout) (map[int]tuiPane, error) {
+	leaves := paneLeaves(layout)
+	panes := make(map[int]tuiPane, len(leaves))
+	for i, leaf := range leaves {
+		if i == 0 {
+			continue
+		}
+		target, err := ensureSessionExists(client, leaf.ref)
+		if err != nil {
+			return nil, err
+		}
+		panes[leaf.pane] = tuiPane{
+			sessionID:     target.ID,
+			sessionLabel:  target.Label,
+			sessionCoord:  target.Coordinator.Name,
+			coordinator:   target.Coordinator,
+			streamID:      i + 1,
+			streamBackoff: time.Second,
+			streamState:   "connecting",
+		}
+	}
+	return panes, nil
+}
+
+// appendLayoutConfig adds a [tui.layouts.<name>] table to the config file.
+func appendLayoutConfig(path, name, spec string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("no config file")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(file, "\n[tui.layouts.%s]\npanes = %s\n", name, strconv.Quote(spec))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func saveLayoutCmd(path, name, spec string) tea.Cmd {
+	return func() tea.Msg {
+		return layoutSavedMsg{name: name, spec: spec, err: appendLayoutConfig(path, name, spec)}
+	}
+}
+
+func newLayoutInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Layout: "
+	input.Placeholder = "name"
+	input.CharLimit = 64
+	input.Width = 30
+	input.Blur()
+	return input
+}
+
+func beginLayoutSaveModal(m attachModel) (attachModel, tea.Cmd) {
+	if _, err := currentLayoutSpec(m); err != nil {
+		m.statusMsg = fmt.Sprintf("save layout: %v", err)
+		m.statusUntil = time.Now().Add(2 * time.Second)
+		return m, nil
+	}
+	m.layoutSaveActive = true
+	m.listActive = false
+	m.layoutInput.SetValue("")
+	m.layoutInput.Focus()
+	return m, nil
+}
+
+func updateLayoutSaveModal(m attachModel, msg tea.KeyMsg) (attachModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.layoutSaveActive = false
+		m.layoutInput.Blur()
+		return m, nil
+	case "enter":
+		name := strings.TrimSpace(m.layoutInput.Value())
+		if !validLayoutName(name) {
+			m.statusMsg = "save layout: use letters, digits, - and _"
+			m.statusUntil = time.Now().Add(2 * time.Second)
+			return m, nil
+		}
+		if m.cfg != nil {
+			if _, exists := m.cfg.TUI.Layouts[name]; exists {
+				m.statusMsg = fmt.Sprintf("save layout: %s already exists", name)
+				m.statusUntil = time.Now().Add(2 * time.Second)
+				return m, nil
+			}
+		}
+		spec, err := currentLayoutSpec(m)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("save layout: %v", err)
+			m.statusUntil = time.Now().Add(2 * time.Second)
+			return m, nil
+		}
+		m.layoutSaveActive = false
+		m.layoutInput.Blur()
+		return m, saveLayoutCmd(m.configPath, name, spec)
+	}
+	var cmd tea.Cmd
+	m.layoutInput, cmd = m.layoutInput.Update(msg)
+	return m, cmd
+}
+
+func renderLayoutSaveModal(m attachModel) string {
+	if m.viewportWidth <= 0 || m.viewportHeight <= 0 {
+		return ""
+	}
+	spec, _ := currentLayoutSpec(m)
+	lines := []string{
+		"Save layout",
+		"",
+		fmt.Sprintf("Panes: %s", spec),
+		m.layoutInput.View(),
+		"Enter to save, Esc to cancel",
+	}
+	box := attachModalStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.viewportWidth, m.viewportHeight, lipgloss.Center, lipgloss.Center, box)
+}
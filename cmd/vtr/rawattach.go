@@ -0,0 +1,331 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"unicode"
+
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/cancelreader"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rawAttachPrefix and rawAttachDetach are the default ctrl+b leader and d
+// detach key; [tui.keys] rebinds both for the TUI and --raw alike. The leader
+// typed twice sends a literal leader.
+const (
+	rawAttachPrefix = 0x02
+	rawAttachDetach = 'd'
+)
+
+const (
+	rawAttachEnter = "\x1b[?1049h"
+	// Leaving resets attributes, the scrolling region, the cursor and the
+	// input modes in case the session left them changed.
+	rawAttachLeave = "\x1b[0m\x1b[r\x1b[?25h\x1b[?1;9;1000;1002;1003;1005;1006;1015;1016;2004l\x1b[?1049l"
+)
+
+// DECSET modes for the mouse tracking and encoding a keyframe reports.
+var (
+	rawAttachMouseTracking = map[proto.MouseTracking]int{
+		proto.MouseTracking_MOUSE_TRACKING_X10:    9,
+		proto.MouseTracking_MOUSE_TRACKING_NORMAL: 1000,
+		proto.MouseTracking_MOUSE_TRACKING_BUTTON: 1002,
+		proto.MouseTracking_MOUSE_TRACKING_ANY:    1003,
+	}
+	rawAttachMouseEncoding = map[proto.MouseEncoding]int{
+		proto.MouseEncoding_MOUSE_ENCODING_UTF8:       1005,
+		proto.MouseEncoding_MOUSE_ENCODING_SGR:        1006,
+		proto.MouseEncoding_MOUSE_ENCODING_URXVT:      1015,
+		proto.MouseEncoding_MOUSE_ENCODING_SGR_PIXELS: 1016,
+	}
+)
+
+// runRawAttach attaches the local terminal to a session without re-rendering:
+// it replays the stream's only keyframe, then copies raw output to out and
+// forwards input from in with SendBytes until the user detaches or the session
+// exits.
+func runRawAttach(client proto.VTRClient, ref *proto.SessionRef, readOnly bool, keys tuiKeymap, in, out *os.File) error {
+	if !term.IsTerminal(in.Fd()) || !term.IsTerminal(out.Fd()) {
+		return errors.New("--raw requires a terminal")
+	}
+	leader, detachKey, err := keys.rawPrefix()
+	if err != nil {
+		return err
+	}
+	cols, rows, err := term.GetSize(out.Fd())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clientID := tuiClientID()
+	stream, err := client.Subscribe(ctx, &proto.SubscribeRequest{
+		Session:              ref,
+		IncludeInitialScreen: true,
+		IncludeRawOutput:     true,
+		ReadOnly:             readOnly,
+		ClientId:             clientID,
+		ClientKind:           "tui",
+		ClientName:           agentClientID(),
+		Cols:                 int32(cols),
+		Rows:                 int32(rows),
+	})
+	if err != nil {
+		return err
+	}
+	// resize returns the size the session actually took, which the resize
+	// policy may keep different from the local terminal.
+	resize := func(cols, rows int) (int, int, error) {
+		if readOnly {
+			return 0, 0, nil
+		}
+		rctx, rcancel := context.WithTimeout(ctx, rpcTimeout)
+		defer rcancel()
+		resp, err := client.Resize(rctx, &proto.ResizeRequest{Session: ref, Cols: int32(cols), Rows: int32(rows), ClientId: clientID})
+		if err != nil {
+			return 0, 0, err
+		}
+		return int(resp.GetCols()), int(resp.GetRows()), nil
+	}
+	sessionCols, sessionRows, err := resize(cols, rows)
+	if err != nil {
+		return err
+	}
+	// The stream starts with a keyframe and raw output after it applies on
+	// top.
+	var screen *proto.GetScreenResponse
+	for screen == nil {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if update := event.GetScreenUpdate(); update.GetIsKeyframe() {
+			screen = update.GetScreen()
+		}
+	}
+	if sessionCols == 0 || sessionRows == 0 {
+		sessionCols, sessionRows = int(screen.GetCols()), int(screen.GetRows())
+	}
+
+	state, err := term.MakeRaw(in.Fd())
+	if err != nil {
+		return err
+	}
+	reader, err := cancelreader.NewReader(in)
+	if err != nil {
+		_ = term.Restore(in.Fd(), state)
+		return err
+	}
+	var outMu sync.Mutex
+	write := func(data []byte) error {
+		outMu.Lock()
+		defer outMu.Unlock()
+		_, err := out.Write(data)
+		return err
+	}
+	_ = write(append([]byte(rawAttachEnter), rawAttachKeyframe(screen)...))
+	_ = write([]byte(rawAttachSizeNotice(sessionCols, sessionRows, cols, rows)))
+
+	done := make(chan string, 3)
+	errCh := make(chan error, 3)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil && !errors.Is(err, io.EOF) {
+					errCh <- err
+				}
+				done <- "[stream closed]"
+				return
+			}
+			if data := event.GetRawOutput(); len(data) > 0 {
+				if err := write(data); err != nil {
+					errCh <- err
+					done <- ""
+					return
+				}
+			}
+			if exited := event.GetSessionExited(); exited != nil {
+				done <- fmt.Sprintf("[exited %d]", exited.GetExitCode())
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		filter := rawInputFilter{leader: leader, detach: detachKey}
+		buf := make([]byte, 4096)
+		for {
+			n, err := reader.Read(buf)
+			if err != nil {
+				if !errors.Is(err, cancelreader.ErrCanceled) {
+					done <- ""
+				}
+				return
+			}
+			data, detach := filter.feed(buf[:n])
+			if len(data) > 0 && !readOnly {
+				ictx, icancel := context.WithTimeout(ctx, rpcTimeout)
+				_, err := client.SendBytes(ictx, &proto.SendBytesRequest{Session: ref, Data: data, ClientId: clientID})
+				icancel()
+				if err != nil && status.Code(err) != codes.FailedPrecondition {
+					errCh <- err
+					done <- ""
+					return
+				}
+			}
+			if detach {
+				done <- "[detached]"
+				return
+			}
+		}
+	}()
+	winch := make(chan os.Signal, 1)
+	notifyResize(winch)
+	defer signal.Stop(winch)
+
+	var message string
+wait:
+	for {
+		select {
+		case message = <-done:
+			break wait
+		case <-winch:
+			if cols, rows, err := term.GetSize(out.Fd()); err == nil {
+				if sessionCols, sessionRows, err := resize(cols, rows); err == nil && sessionCols > 0 {
+					_ = write([]byte(rawAttachSizeNotice(sessionCols, sessionRows, cols, rows)))
+				}
+			}
+		}
+	}
+	cancel()
+	reader.Cancel()
+	wg.Wait()
+	reader.Close()
+	_ = write([]byte(rawAttachLeave))
+	_ = term.Restore(in.Fd(), state)
+	if message != "" {
+		fmt.Fprintln(out, message)
+	}
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// rawAttachKeyframe draws screen from the top-left corner and restores the
+// input modes, scrolling region, cursor visibility and cursor position, so
+// raw output that follows lands where the session expects and input is
+// encoded the way the program asked for. Without a region of its own the
+// session scrolls within its rows, letterboxing it in a taller terminal.
+func rawAttachKeyframe(screen *proto.GetScreenResponse) []byte {
+	var b strings.Builder
+	b.WriteString("\x1b[0m\x1b[H\x1b[2J")
+	for y, row := range screen.GetScreenRows() {
+		fmt.Fprintf(&b, "\x1b[%d;1H", y+1)
+		b.WriteString(renderRow(row, int(screen.GetCols()), y, -1, -1, false))
+	}
+	b.WriteString("\x1b[0m")
+	if mode, ok := rawAttachMouseTracking[screen.GetMouseTracking()]; ok {
+		fmt.Fprintf(&b, "\x1b[?%dh", mode)
+	}
+	if mode, ok := rawAttachMouseEncoding[screen.GetMouseEncoding()]; ok {
+		fmt.Fprintf(&b, "\x1b[?%dh", mode)
+	}
+	if screen.GetBracketedPaste() {
+		b.WriteString("\x1b[?2004h")
+	}
+	if screen.GetApplicationCursorKeys() {
+		b.WriteString("\x1b[?1h")
+	}
+	top, bottom := screen.GetScrollTop(), screen.GetScrollBottom()
+	if top == 0 || bottom == 0 {
+		top, bottom = 1, screen.GetRows()
+	}
+	if bottom > 0 {
+		fmt.Fprintf(&b, "\x1b[%d;%dr", top, bottom)
+	}
+	if screen.GetCursorHidden() {
+		b.WriteString("\x1b[?25l")
+	} else {
+		b.WriteString("\x1b[?25h")
+	}
+	fmt.Fprintf(&b, "\x1b[%d;%dH", screen.GetCursorY()+1, screen.GetCursorX()+1)
+	return []byte(b.String())
+}
+
+// rawAttachSizeNotice warns when the session is not the size of the local
+// terminal, since raw output is laid out for the session. The notice goes on
+// the row below the session when the terminal is taller, closing off the
+// letterbox, and on the last row otherwise. It is empty when the sizes match.
+func rawAttachSizeNotice(cols, rows, localCols, localRows int) string {
+	if (cols == localCols && rows == localRows) || cols <= 0 || rows <= 0 || localCols <= 0 || localRows <= 0 {
+		return ""
+	}
+	row := localRows
+	if rows < localRows {
+		row = rows + 1
+	}
+	msg := fmt.Sprintf("[session is %dx%d, terminal is %dx%d]", cols, rows, localCols, localRows)
+	if len(msg) > localCols {
+		msg = msg[:localCols]
+	}
+	// Save and restore the cursor so the session's output is not disturbed.
+	return fmt.Sprintf("\x1b7\x1b[%d;1H\x1b[0;7m%s\x1b[0m\x1b[K\x1b8", row, msg)
+}
+
+// rawInputFilter watches input for the detach key. Other bytes typed after
+// the prefix are forwarded along with it. Zero leader and detach bytes mean
+// ctrl+b and d.
+type rawInputFilter struct {
+	leader byte
+	detach byte
+	prefix bool
+}
+
+// feed returns the bytes to forward and whether the leader and detach key
+// were typed; input after the detach key is dropped.
+func (f *rawInputFilter) feed(data []byte) ([]byte, bool) {
+	leader, detach := f.leader, f.detach
+	if leader == 0 {
+		leader = rawAttachPrefix
+	}
+	if detach == 0 {
+		detach = rawAttachDetach
+	}
+	out := make([]byte, 0, len(data))
+	for _, b := range data {
+		if f.prefix {
+			f.prefix = false
+			switch {
+			case unicode.ToLower(rune(b)) == unicode.ToLower(rune(detach)):
+				return out, true
+			case b == leader:
+				out = append(out, b)
+			default:
+				out = append(out, leader, b)
+			}
+			continue
+		}
+		if b == leader {
+			f.prefix = true
+			continue
+		}
+		out = append(out, b)
+	}
+	return out, false
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// notifyResize is a no-op: there is no resize signal to watch.
+func notifyResize(ch chan<- os.Signal) {}
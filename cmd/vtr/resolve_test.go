@@ -28,3 +28,35 @@ func TestMatchSessionRefStripsCoordinatorPrefix(t *testing.T) {
 		t.Fatalf("unexpected match result id=%q label=%q", id, label)
 	}
 }
+
+func TestResolveSessionRefWithoutRoundTrip(t *testing.T) {
+	const id = "0b7f3c1e-5f1a-4c1d-9a55-1e1f2d3c4b5a"
+
+	ref, label, err := resolveSessionRef("demo", "")
+	if err != nil {
+		t.Fatalf("resolveSessionRef label: %v", err)
+	}
+	if ref.GetLabel() != "demo" || ref.GetId() != "" || label != "demo" {
+		t.Fatalf("unexpected label ref %v label=%q", ref, label)
+	}
+
+	ref, _, err = resolveSessionRef("spoke-a:demo", "")
+	if err != nil {
+		t.Fatalf("resolveSessionRef prefixed label: %v", err)
+	}
+	if ref.GetLabel() != "demo" || ref.GetCoordinator() != "spoke-a" {
+		t.Fatalf("prefixed labels should route to their coordinator, got %v", ref)
+	}
+
+	ref, _, err = resolveSessionRef("spoke-a:"+id, "")
+	if err != nil {
+		t.Fatalf("resolveSessionRef prefixed id: %v", err)
+	}
+	if ref.GetId() != id || ref.GetCoordinator() != "spoke-a" || ref.GetLabel() != "" {
+		t.Fatalf("unexpected id ref %v", ref)
+	}
+
+	if _, _, err := resolveSessionRef("  ", ""); err == nil {
+		t.Fatalf("expected error for empty session")
+	}
+}
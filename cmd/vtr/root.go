@@ -16,6 +16,9 @@ func newRootCmd() *cobra.Command {
 		newAgentCmd(),
 		newTuiCmd(),
 		newSetupCmd(),
+		newUpCmd(),
+		newDownCmd(),
+		newStatusCmd(),
 	)
 
 	return root
@@ -0,0 +1,765 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	scriptTimeoutDefault = 30 * time.Second
+	// scriptMaxSteps bounds executed steps so a goto loop cannot run forever.
+	scriptMaxSteps = 10000
+	// scriptBufferLimit caps the unconsumed output kept per session for expect.
+	scriptBufferLimit = 1 << 20
+	// scriptEnd is the goto target that ends a script successfully.
+	scriptEnd = "end"
+)
+
+const (
+	scriptActionSpawn        = "spawn"
+	scriptActionSend         = "send"
+	scriptActionKey          = "key"
+	scriptActionExpect       = "expect"
+	scriptActionWaitIdle     = "wait_idle"
+	scriptActionAssertScreen = "assert_screen"
+	scriptActionCapture      = "capture"
+	scriptActionFail         = "fail"
+	scriptActionGoto         = "goto"
+)
+
+var scriptVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// scriptFile is the on-disk format read by `vtr agent script run`.
+type scriptFile struct {
+	Name    string             `toml:"name"`
+	Session string             `toml:"session"`
+	Timeout string             `toml:"timeout"`
+	Vars    map[string]string  `toml:"vars"`
+	Steps   []scriptStepConfig `toml:"steps"`
+}
+
+// scriptStepConfig is one [[steps]] table. Exactly one action key (spawn,
+// send, key, expect, wait_idle, assert_screen, capture or fail) is set.
+type scriptStepConfig struct {
+	Name    string `toml:"name"`
+	Session string `toml:"session"`
+	Timeout string `toml:"timeout"`
+	Goto    string `toml:"goto"`
+
+	Spawn   string `toml:"spawn"`
+	Command string `toml:"command"`
+	Cwd     string `toml:"cwd"`
+	Cols    int    `toml:"cols"`
+	Rows    int    `toml:"rows"`
+
+	Send   string `toml:"send"`
+	Submit bool   `toml:"submit"`
+	Key    string `toml:"key"`
+
+	Expect    scriptExpects `toml:"expect"`
+	OnTimeout string        `toml:"on_timeout"`
+
+	WaitIdle     string `toml:"wait_idle"`
+	AssertScreen string `toml:"assert_screen"`
+	Capture      string `toml:"capture"`
+	Pattern      string `toml:"pattern"`
+	Fail         string `toml:"fail"`
+}
+
+// scriptBranch is one expect alternative. A match jumps to Goto, or continues
+// with the next step when Goto is empty.
+type scriptBranch struct {
+	Pattern string `toml:"pattern"`
+	Goto    string `toml:"goto"`
+}
+
+// scriptExpects accepts `expect = "pattern"`, a list of patterns, or a list
+// of {pattern, goto} tables.
+type scriptExpects []scriptBranch
+
+func (e *scriptExpects) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case string:
+		*e = scriptExpects{{Pattern: v}}
+		return nil
+	case []any:
+		out := make(scriptExpects, 0, len(v))
+		for _, item := range v {
+			switch branch := item.(type) {
+			case string:
+				out = append(out, scriptBranch{Pattern: branch})
+			case map[string]any:
+				pattern, _ := branch["pattern"].(string)
+				target, _ := branch["goto"].(string)
+				out = append(out, scriptBranch{Pattern: pattern, Goto: target})
+			default:
+				return fmt.Errorf("expect alternatives must be strings or {pattern, goto} tables")
+			}
+		}
+		*e = out
+		return nil
+	default:
+		return fmt.Errorf("expect must be a pattern or a list of alternatives")
+	}
+}
+
+type scriptStep struct {
+	index   int
+	name    string
+	action  string
+	timeout time.Duration
+	idle    time.Duration
+	cfg     scriptStepConfig
+}
+
+type script struct {
+	name    string
+	session string
+	vars    map[string]string
+	steps   []scriptStep
+	labels  map[string]int
+}
+
+func loadScript(path string) (*script, error) {
+	var file scriptFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(file.Name)
+	if name == "" {
+		base := filepath.Base(path)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return buildScript(name, file)
+}
+
+func buildScript(name string, file scriptFile) (*script, error) {
+	if len(file.Steps) == 0 {
+		return nil, errors.New("script has no steps")
+	}
+	timeout := scriptTimeoutDefault
+	if value := strings.TrimSpace(file.Timeout); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q", file.Timeout)
+		}
+		timeout = parsed
+	}
+	sc := &script{
+		name:    name,
+		session: strings.TrimSpace(file.Session),
+		vars:    make(map[string]string, len(file.Vars)),
+		labels:  make(map[string]int),
+	}
+	for key, value := range file.Vars {
+		sc.vars[key] = value
+	}
+	for i, cfg := range file.Steps {
+		step, err := buildScriptStep(i+1, cfg, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if step.name != "" {
+			if step.name == scriptEnd {
+				return nil, fmt.Errorf("step %d: %q is reserved", step.index, scriptEnd)
+			}
+			if _, ok := sc.labels[step.name]; ok {
+				return nil, fmt.Errorf("step %d: duplicate step name %q", step.index, step.name)
+			}
+			sc.labels[step.name] = i
+		}
+		sc.steps = append(sc.steps, step)
+	}
+	for _, step := range sc.steps {
+		targets := []string{step.cfg.Goto, step.cfg.OnTimeout}
+		for _, branch := range step.cfg.Expect {
+			targets = append(targets, branch.Goto)
+		}
+		for _, target := range targets {
+			target = strings.TrimSpace(target)
+			if _, ok := sc.labels[target]; target != "" && target != scriptEnd && !ok {
+				return nil, fmt.Errorf("step %d: unknown goto target %q", step.index, target)
+			}
+		}
+	}
+	return sc, nil
+}
+
+func buildScriptStep(index int, cfg scriptStepConfig, timeout time.Duration) (scriptStep, error) {
+	step := scriptStep{index: index, name: strings.TrimSpace(cfg.Name), timeout: timeout, cfg: cfg}
+	if value := strings.TrimSpace(cfg.Timeout); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return step, fmt.Errorf("step %d: invalid timeout %q", index, cfg.Timeout)
+		}
+		step.timeout = parsed
+	}
+	actions := make([]string, 0, 1)
+	if cfg.Spawn != "" {
+		actions = append(actions, scriptActionSpawn)
+	}
+	if cfg.Send != "" {
+		actions = append(actions, scriptActionSend)
+	}
+	if cfg.Key != "" {
+		actions = append(actions, scriptActionKey)
+	}
+	if len(cfg.Expect) > 0 {
+		actions = append(actions, scriptActionExpect)
+	}
+	if cfg.WaitIdle != "" {
+		actions = append(actions, scriptActionWaitIdle)
+	}
+	if cfg.AssertScreen != "" {
+		actions = append(actions, scriptActionAssertScreen)
+	}
+	if cfg.Capture != "" {
+		actions = append(actions, scriptActionCapture)
+	}
+	if cfg.Fail != "" {
+		actions = append(actions, scriptActionFail)
+	}
+	switch {
+	case len(actions) > 1:
+		return step, fmt.Errorf("step %d: only one action per step (got %s)", index, strings.Join(actions, ", "))
+	case len(actions) == 1:
+		step.action = actions[0]
+	case cfg.Goto != "":
+		step.action = scriptActionGoto
+	default:
+		return step, fmt.Errorf("step %d: no action", index)
+	}
+	if cfg.Cols < 0 || cfg.Cols > int(^uint16(0)) || cfg.Rows < 0 || cfg.Rows > int(^uint16(0)) {
+		return step, fmt.Errorf("step %d: cols/rows must be between 0 and %d", index, int(^uint16(0)))
+	}
+	if step.action == scriptActionWaitIdle {
+		idle, err := time.ParseDuration(strings.TrimSpace(cfg.WaitIdle))
+		if err != nil || idle <= 0 {
+			return step, fmt.Errorf("step %d: invalid wait_idle %q", index, cfg.WaitIdle)
+		}
+		step.idle = idle
+	}
+	patterns := []string{cfg.AssertScreen, cfg.Pattern}
+	for _, branch := range cfg.Expect {
+		if strings.TrimSpace(branch.Pattern) == "" {
+			return step, fmt.Errorf("step %d: expect pattern is required", index)
+		}
+		patterns = append(patterns, branch.Pattern)
+	}
+	for _, pattern := range patterns {
+		// Patterns with variables are compiled when the step runs.
+		if pattern == "" || scriptVarPattern.MatchString(pattern) {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return step, fmt.Errorf("step %d: %w", index, err)
+		}
+	}
+	return step, nil
+}
+
+func newScriptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "script",
+		Short: "Run send/expect scripts",
+	}
+	cmd.AddCommand(newScriptRunCmd())
+	return cmd
+}
+
+func newScriptRunCmd() *cobra.Command {
+	var hub string
+	var vars []string
+	cmd := &cobra.Command{
+		Use:   "run <file>",
+		Short: "Run a script file",
+		Long: "Run a TOML script of spawn, send, key, expect, wait_idle, assert_screen, capture " +
+			"and fail steps over one connection and print a JSON report with the timing of " +
+			"each step. A failing step records the session screen and the command exits non-zero.",
+		Example: `vtr agent script run login.toml
+vtr agent script run deploy.toml --var env=staging`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sc, err := loadScript(args[0])
+			if err != nil {
+				return err
+			}
+			for _, value := range vars {
+				key, varValue, ok := strings.Cut(value, "=")
+				key = strings.TrimSpace(key)
+				if !ok || key == "" {
+					return fmt.Errorf("invalid --var %q (expected key=value)", value)
+				}
+				sc.vars[key] = varValue
+			}
+			cfg, _, err := loadConfigWithPath()
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				report, runErr := runScript(ctx, client, sc)
+				if err := writeJSON(cmd.OutOrStdout(), report); err != nil {
+					return err
+				}
+				return runErr
+			})
+		},
+	}
+	addHubFlag(cmd, &hub)
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "set a script variable (key=value, repeatable)")
+	return cmd
+}
+
+type scriptRunner struct {
+	ctx     context.Context
+	client  proto.VTRClient
+	vars    map[string]string
+	session string
+	streams map[string]*scriptStream
+}
+
+func runScript(ctx context.Context, client proto.VTRClient, sc *script) (jsonScript, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	runner := &scriptRunner{
+		ctx:     ctx,
+		client:  client,
+		vars:    sc.vars,
+		session: sc.session,
+		streams: make(map[string]*scriptStream),
+	}
+	start := time.Now()
+	report := jsonScript{Script: sc.name, Steps: make([]jsonScriptStep, 0, len(sc.steps))}
+	var runErr error
+	next := 0
+	for executed := 0; next < len(sc.steps); executed++ {
+		if executed >= scriptMaxSteps {
+			runErr = fmt.Errorf("script ran more than %d steps", scriptMaxSteps)
+			break
+		}
+		step := sc.steps[next]
+		item := jsonScriptStep{
+			Index:     step.index,
+			Name:      step.name,
+			Action:    step.action,
+			StartedMs: time.Since(start).Milliseconds(),
+		}
+		stepStart := time.Now()
+		target, err := runner.run(step, &item)
+		item.DurationMs = time.Since(stepStart).Milliseconds()
+		if err != nil {
+			item.Error = err.Error()
+			item.Screen = runner.screen(item.Session)
+			report.Steps = append(report.Steps, item)
+			runErr = fmt.Errorf("step %d (%s): %w", step.index, step.action, err)
+			break
+		}
+		item.OK = true
+		report.Steps = append(report.Steps, item)
+		switch target {
+		case "":
+			next++
+		case scriptEnd:
+			next = len(sc.steps)
+		default:
+			next = sc.labels[target]
+		}
+	}
+	report.OK = runErr == nil
+	report.DurationMs = time.Since(start).Milliseconds()
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+	if len(runner.vars) > 0 {
+		report.Vars = runner.vars
+	}
+	return report, runErr
+}
+
+// run executes one step and returns the goto target, if any.
+func (r *scriptRunner) run(step scriptStep, item *jsonScriptStep) (string, error) {
+	cfg := step.cfg
+	session, err := r.expand(cfg.Session)
+	if err != nil {
+		return "", err
+	}
+	if session == "" {
+		session = r.session
+	}
+	if step.action == scriptActionSpawn {
+		if session, err = r.expand(cfg.Spawn); err != nil {
+			return "", err
+		}
+	}
+	item.Session = session
+	switch step.action {
+	case scriptActionGoto, scriptActionFail:
+	default:
+		if session == "" {
+			return "", errors.New("no session: set session or spawn one first")
+		}
+	}
+	ref, _, err := resolveSessionRef(session, "")
+	if session != "" && err != nil {
+		return "", err
+	}
+
+	switch step.action {
+	case scriptActionSpawn:
+		command, err := r.expand(cfg.Command)
+		if err != nil {
+			return "", err
+		}
+		ctx, cancel := context.WithTimeout(r.ctx, rpcTimeout)
+		defer cancel()
+		if _, err := r.client.Spawn(ctx, &proto.SpawnRequest{
+			Name:       session,
+			Command:    command,
+			WorkingDir: cfg.Cwd,
+			Cols:       int32(cfg.Cols),
+			Rows:       int32(cfg.Rows),
+			ClientId:   agentClientID(),
+		}); err != nil {
+			return "", err
+		}
+		r.session = session
+		if _, err := r.stream(session, ref); err != nil {
+			return "", err
+		}
+	case scriptActionSend:
+		text, err := r.expand(cfg.Send)
+		if err != nil {
+			return "", err
+		}
+		if cfg.Submit && !strings.HasSuffix(text, "\n") && !strings.HasSuffix(text, "\r") {
+			text += "\r"
+		}
+		if _, err := r.stream(session, ref); err != nil {
+			return "", err
+		}
+		ctx, cancel := context.WithTimeout(r.ctx, rpcTimeout)
+		defer cancel()
+		if _, err := r.client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: text, ClientId: agentClientID(), LockToken: agentLockToken()}); err != nil {
+			return "", err
+		}
+	case scriptActionKey:
+		key, err := r.expand(cfg.Key)
+		if err != nil {
+			return "", err
+		}
+		if _, err := r.stream(session, ref); err != nil {
+			return "", err
+		}
+		ctx, cancel := context.WithTimeout(r.ctx, rpcTimeout)
+		defer cancel()
+		if _, err := r.client.SendKey(ctx, &proto.SendKeyRequest{Session: ref, Key: key, ClientId: agentClientID(), LockToken: agentLockToken()}); err != nil {
+			return "", err
+		}
+	case scriptActionExpect:
+		branches := make([]*regexp.Regexp, len(cfg.Expect))
+		for i, branch := range cfg.Expect {
+			re, err := r.compile(branch.Pattern)
+			if err != nil {
+				return "", err
+			}
+			branches[i] = re
+		}
+		stream, err := r.stream(session, ref)
+		if err != nil {
+			return "", err
+		}
+		idx, matched, err := stream.expect(r.ctx, branches, step.timeout)
+		if errors.Is(err, errScriptTimeout) && cfg.OnTimeout != "" {
+			item.Goto = cfg.OnTimeout
+			return cfg.OnTimeout, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		item.Matched = matched
+		item.Goto = cfg.Expect[idx].Goto
+		if item.Goto != "" {
+			return item.Goto, nil
+		}
+	case scriptActionWaitIdle:
+		ctx, cancel := context.WithTimeout(r.ctx, step.timeout+rpcTimeout)
+		defer cancel()
+		resp, err := r.client.WaitForIdle(ctx, &proto.WaitForIdleRequest{
+			Session:      ref,
+			IdleDuration: durationpb.New(step.idle),
+			Timeout:      durationpb.New(step.timeout),
+		})
+		if err != nil {
+			return "", err
+		}
+		if !resp.GetIdle() {
+			return "", fmt.Errorf("session was not idle for %s within %s", step.idle, step.timeout)
+		}
+	case scriptActionAssertScreen:
+		re, err := r.compile(cfg.AssertScreen)
+		if err != nil {
+			return "", err
+		}
+		screen, err := r.screenText(ref)
+		if err != nil {
+			return "", err
+		}
+		if !re.MatchString(screen) {
+			return "", fmt.Errorf("screen does not match %q", re.String())
+		}
+	case scriptActionCapture:
+		screen, err := r.screenText(ref)
+		if err != nil {
+			return "", err
+		}
+		value := screen
+		if cfg.Pattern != "" {
+			re, err := r.compile(cfg.Pattern)
+			if err != nil {
+				return "", err
+			}
+			match := re.FindStringSubmatch(screen)
+			if match == nil {
+				return "", fmt.Errorf("screen does not match %q", re.String())
+			}
+			value = match[0]
+			if len(match) > 1 {
+				value = match[1]
+			}
+		}
+		r.vars[cfg.Capture] = value
+		item.Captured = value
+	case scriptActionFail:
+		message, err := r.expand(cfg.Fail)
+		if err != nil {
+			return "", err
+		}
+		return "", errors.New(message)
+	}
+	if cfg.Goto != "" {
+		item.Goto = cfg.Goto
+		return cfg.Goto, nil
+	}
+	return "", nil
+}
+
+// expand replaces ${name} with script variables.
+func (r *scriptRunner) expand(value string) (string, error) {
+	var missing string
+	out := scriptVarPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := match[2 : len(match)-1]
+		v, ok := r.vars[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return v
+	})
+	if missing != "" {
+		return "", fmt.Errorf("undefined variable %q", missing)
+	}
+	return out, nil
+}
+
+func (r *scriptRunner) compile(pattern string) (*regexp.Regexp, error) {
+	expanded, err := r.expand(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile(expanded)
+}
+
+func (r *scriptRunner) screenText(ref *proto.SessionRef) (string, error) {
+	ctx, cancel := context.WithTimeout(r.ctx, rpcTimeout)
+	defer cancel()
+	resp, err := r.client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref})
+	if err != nil {
+		return "", err
+	}
+	return screenToText(resp, false), nil
+}
+
+// screen returns the session screen for a failure report, or "" when it
+// cannot be read.
+func (r *scriptRunner) screen(session string) string {
+	if session == "" {
+		return ""
+	}
+	ref, _, err := resolveSessionRef(session, "")
+	if err != nil {
+		return ""
+	}
+	screen, _ := r.screenText(ref)
+	return screen
+}
+
+// stream returns the output stream expect reads for session, opening it on
+// first use. It starts with the subscription's initial screen so a prompt
+// printed before the script touched the session can still be matched.
+func (r *scriptRunner) stream(session string, ref *proto.SessionRef) (*scriptStream, error) {
+	if stream, ok := r.streams[session]; ok {
+		return stream, nil
+	}
+	sub, err := r.client.Subscribe(r.ctx, &proto.SubscribeRequest{
+		Session:              ref,
+		IncludeScreenUpdates: true,
+		IncludeRawOutput:     true,
+		ReadOnly:             true,
+		ClientId:             agentClientID(),
+		ClientKind:           "agent",
+	})
+	if err != nil {
+		return nil, err
+	}
+	stream := &scriptStream{notify: make(chan struct{})}
+	go stream.read(sub)
+	r.streams[session] = stream
+	return stream, nil
+}
+
+// scriptScreenSeed returns the screen text up to the cursor, keeping the
+// trailing spaces of a prompt such as "$ " that screenToText trims.
+func scriptScreenSeed(resp *proto.GetScreenResponse) string {
+	lines := strings.Split(screenToText(resp, false), "\n")
+	y, x := int(resp.GetCursorY()), int(resp.GetCursorX())
+	if y < 0 || y >= len(lines) || x < 0 {
+		return strings.Join(lines, "\n")
+	}
+	row := []rune(lines[y])
+	if x < len(row) {
+		row = row[:x]
+	}
+	last := string(row) + strings.Repeat(" ", x-len(row))
+	return strings.Join(append(lines[:y], last), "\n")
+}
+
+var errScriptTimeout = errors.New("timed out")
+
+// scriptStream buffers session output, with escape sequences removed, for
+// expect. Each match consumes the output up to its end.
+type scriptStream struct {
+	mu     sync.Mutex
+	buf    string
+	notify chan struct{}
+	// seeded is set once the first keyframe is in buf. Raw output sent
+	// before it is already on that screen and is dropped.
+	seeded bool
+	exited bool
+	err    error
+}
+
+func (s *scriptStream) read(sub proto.VTR_SubscribeClient) {
+	for {
+		event, err := sub.Recv()
+		if err != nil {
+			s.mu.Lock()
+			s.err = err
+			s.signalLocked()
+			s.mu.Unlock()
+			return
+		}
+		switch {
+		case event.GetScreenUpdate() != nil:
+			if update := event.GetScreenUpdate(); update.GetIsKeyframe() {
+				s.start(scriptScreenSeed(update.GetScreen()))
+			}
+		case event.GetRawOutput() != nil:
+			s.append(string(event.GetRawOutput()))
+		case event.GetSessionExited() != nil:
+			s.mu.Lock()
+			s.exited = true
+			s.signalLocked()
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *scriptStream) append(output string) {
+	text := strings.ReplaceAll(ansi.Strip(output), "\r", "")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.seeded {
+		return
+	}
+	s.buf += text
+	if len(s.buf) > scriptBufferLimit {
+		s.buf = s.buf[len(s.buf)-scriptBufferLimit:]
+	}
+	s.signalLocked()
+}
+
+// start puts the first keyframe's screen text in the buffer. Later
+// keyframes are ignored; the raw output after them is already buffered.
+func (s *scriptStream) start(screen string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seeded {
+		return
+	}
+	s.buf = screen
+	s.seeded = true
+	s.signalLocked()
+}
+
+func (s *scriptStream) signalLocked() {
+	close(s.notify)
+	s.notify = make(chan struct{})
+}
+
+// expect waits for the first of branches to match buffered output and
+// returns its index and the matched text. When several match, the one that
+// starts earliest wins.
+func (s *scriptStream) expect(ctx context.Context, branches []*regexp.Regexp, timeout time.Duration) (int, string, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		s.mu.Lock()
+		best, bestLoc := -1, []int(nil)
+		for i, re := range branches {
+			loc := re.FindStringIndex(s.buf)
+			if loc != nil && (bestLoc == nil || loc[0] < bestLoc[0]) {
+				best, bestLoc = i, loc
+			}
+		}
+		if best >= 0 {
+			matched := s.buf[bestLoc[0]:bestLoc[1]]
+			s.buf = s.buf[bestLoc[1]:]
+			s.mu.Unlock()
+			return best, matched, nil
+		}
+		exited, err, notify := s.exited, s.err, s.notify
+		s.mu.Unlock()
+		switch {
+		case exited:
+			return -1, "", errors.New("session exited before output matched")
+		case err != nil:
+			return -1, "", err
+		}
+		select {
+		case <-notify:
+		case <-timer.C:
+			return -1, "", fmt.Errorf("%w after %s waiting for output", errScriptTimeout, timeout)
+		case <-ctx.Done():
+			return -1, "", ctx.Err()
+		}
+	}
+}
@@ -23,15 +23,24 @@ import (
 )
 
 type spokeOptions struct {
-	name          string
-	hubAddr       string
-	shell         string
-	cols          int
-	rows          int
-	scrollback    uint
-	killTimeout   time.Duration
-	idleThreshold time.Duration
-	logLevel      string
+	name             string
+	hubAddr          string
+	shell            string
+	cols             int
+	rows             int
+	scrollback       uint
+	killTimeout      time.Duration
+	idleThreshold    time.Duration
+	cgroupRoot       string
+	allowUIDs        []uint
+	allowGIDs        []uint
+	term             string
+	locale           string
+	terminfoDir      string
+	shellIntegration bool
+	resizePolicy     string
+	macroDir         string
+	logLevel         string
 }
 
 func newSpokeCmd() *cobra.Command {
@@ -52,6 +61,15 @@ func newSpokeCmd() *cobra.Command {
 	cmd.Flags().UintVar(&opts.scrollback, "scrollback", 10000, "scrollback lines")
 	cmd.Flags().DurationVar(&opts.killTimeout, "kill-timeout", 5*time.Second, "kill timeout (e.g. 5s)")
 	cmd.Flags().DurationVar(&opts.idleThreshold, "idle-threshold", 5*time.Second, "idle threshold before session is idle")
+	cmd.Flags().StringVar(&opts.cgroupRoot, "cgroup-root", "", "delegated cgroup v2 directory for per-session limits")
+	cmd.Flags().UintSliceVar(&opts.allowUIDs, "allow-uid", nil, "uids sessions may request to run as (repeatable)")
+	cmd.Flags().UintSliceVar(&opts.allowGIDs, "allow-gid", nil, "gids sessions may request to run as (repeatable)")
+	cmd.Flags().StringVar(&opts.term, "term", "", "TERM for sessions (default xterm-ghostty, or xterm-256color without terminfo)")
+	cmd.Flags().StringVar(&opts.locale, "locale", "", "LANG for sessions (default UTF-8 LANG from env, or C.UTF-8)")
+	cmd.Flags().StringVar(&opts.terminfoDir, "terminfo-dir", defaultTerminfoDir(), "directory for the bundled xterm-ghostty terminfo (empty disables)")
+	cmd.Flags().BoolVar(&opts.shellIntegration, "shell-integration", false, "inject OSC 133/OSC 7 shell integration into bash, zsh and fish sessions")
+	cmd.Flags().StringVar(&opts.resizePolicy, "resize-policy", "latest", "default resize policy (latest, smallest, largest, fixed, owner)")
+	cmd.Flags().StringVar(&opts.macroDir, "macro-dir", defaultMacroDir(), "directory for recorded input macros (empty disables)")
 	cmd.Flags().StringVar(&opts.logLevel, "log-level", "info", "log level (debug, info, warn, error)")
 
 	return cmd
@@ -88,6 +106,18 @@ func runSpoke(opts spokeOptions) error {
 	if opts.scrollback > uint(^uint32(0)) {
 		return fmt.Errorf("scrollback must be <= %d", uint(^uint32(0)))
 	}
+	allowUIDs, err := uint32s("allow-uid", opts.allowUIDs)
+	if err != nil {
+		return err
+	}
+	allowGIDs, err := uint32s("allow-gid", opts.allowGIDs)
+	if err != nil {
+		return err
+	}
+	resizePolicy, err := server.ParseResizePolicy(opts.resizePolicy)
+	if err != nil {
+		return err
+	}
 
 	authMode := strings.ToLower(strings.TrimSpace(cfg.Auth.Mode))
 	requireToken, _, err := parseAuthMode(authMode)
@@ -103,6 +133,11 @@ func runSpoke(opts spokeOptions) error {
 		token = loaded
 	}
 
+	profiles, err := spawnProfiles(cfg)
+	if err != nil {
+		return err
+	}
+	term, terminfoDir := sessionTerm(opts.term, opts.terminfoDir, logger)
 	coord := server.NewCoordinator(server.CoordinatorOptions{
 		DefaultShell:  opts.shell,
 		DefaultCols:   uint16(opts.cols),
@@ -110,6 +145,17 @@ func runSpoke(opts spokeOptions) error {
 		Scrollback:    uint32(opts.scrollback),
 		KillTimeout:   opts.killTimeout,
 		IdleThreshold: opts.idleThreshold,
+		Profiles:      profiles,
+		CgroupRoot:    opts.cgroupRoot,
+		AllowedUIDs:   allowUIDs,
+		AllowedGIDs:   allowGIDs,
+		Term:          term,
+		Locale:        opts.locale,
+		TerminfoDir:   terminfoDir,
+
+		ShellIntegration: opts.shellIntegration,
+		ResizePolicy:     resizePolicy,
+		MacroDir:         expandPath(opts.macroDir),
 	})
 	defer coord.CloseAll()
 	localService := server.NewGRPCServer(coord)
@@ -213,3 +259,16 @@ func sleepWithContext(ctx context.Context, d time.Duration) bool {
 		return true
 	}
 }
+
+// uint32s converts the ids given to flag, rejecting values that would wrap
+// around (4294967296 is not uid 0).
+func uint32s(flag string, values []uint) ([]uint32, error) {
+	out := make([]uint32, 0, len(values))
+	for _, value := range values {
+		if value > uint(^uint32(0)) {
+			return nil, fmt.Errorf("%s must be <= %d, got %d", flag, uint(^uint32(0)), value)
+		}
+		out = append(out, uint32(value))
+	}
+	return out, nil
+}
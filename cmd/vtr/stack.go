@@ -0,0 +1,608 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	proto "github.com/advait/vtrpc/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	defaultStackFile         = "vtr-stack.toml"
+	stackTagKey              = "stack"
+	stackReadyTimeoutDefault = 60 * time.Second
+	stackGrepInterval        = 500 * time.Millisecond
+	stackPortInterval        = 250 * time.Millisecond
+)
+
+// stackFile is the on-disk format read by `vtr up`.
+type stackFile struct {
+	Name        string                  `toml:"name"`
+	Coordinator string                  `toml:"coordinator"`
+	Sessions    map[string]stackSession `toml:"sessions"`
+}
+
+type stackSession struct {
+	Command     string            `toml:"command"`
+	Cwd         string            `toml:"cwd"`
+	Env         map[string]string `toml:"env"`
+	Cols        int               `toml:"cols"`
+	Rows        int               `toml:"rows"`
+	Tags        map[string]string `toml:"tags"`
+	Profile     string            `toml:"profile"`
+	Coordinator string            `toml:"coordinator"`
+	DependsOn   []string          `toml:"depends_on"`
+	Ready       stackReadyConfig  `toml:"ready"`
+}
+
+// stackReadyConfig lists readiness checks. All configured checks must pass
+// before dependents start.
+type stackReadyConfig struct {
+	Pattern string `toml:"pattern"`
+	Idle    string `toml:"idle"`
+	Port    int    `toml:"port"`
+	Host    string `toml:"host"`
+	Timeout string `toml:"timeout"`
+}
+
+type stackReady struct {
+	pattern string
+	idle    time.Duration
+	addr    string
+	timeout time.Duration
+}
+
+func (r stackReady) empty() bool {
+	return r.pattern == "" && r.idle == 0 && r.addr == ""
+}
+
+type stackEntry struct {
+	name      string
+	label     string
+	session   stackSession
+	dependsOn []string
+	ready     stackReady
+}
+
+type stack struct {
+	name    string
+	entries []stackEntry
+}
+
+func loadStack(path string) (*stack, error) {
+	var file stackFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(file.Name)
+	if name == "" {
+		base := filepath.Base(path)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return buildStack(name, file)
+}
+
+func buildStack(name string, file stackFile) (*stack, error) {
+	if len(file.Sessions) == 0 {
+		return nil, errors.New("stack has no sessions")
+	}
+	entries := make(map[string]stackEntry, len(file.Sessions))
+	for key, session := range file.Sessions {
+		sessionName := strings.TrimSpace(key)
+		if sessionName == "" || strings.Contains(sessionName, ":") {
+			return nil, fmt.Errorf("invalid session name %q", key)
+		}
+		ready, err := parseStackReady(session.Ready)
+		if err != nil {
+			return nil, fmt.Errorf("session %q: %w", sessionName, err)
+		}
+		if session.Cols < 0 || session.Cols > int(^uint16(0)) || session.Rows < 0 || session.Rows > int(^uint16(0)) {
+			return nil, fmt.Errorf("session %q: cols/rows must be between 0 and %d", sessionName, int(^uint16(0)))
+		}
+		coord := strings.TrimSpace(session.Coordinator)
+		if coord == "" {
+			coord = strings.TrimSpace(file.Coordinator)
+		}
+		entries[sessionName] = stackEntry{
+			name:      sessionName,
+			label:     prefixSessionLabel(coord, sessionName),
+			session:   session,
+			dependsOn: session.DependsOn,
+			ready:     ready,
+		}
+	}
+	ordered, err := orderStackEntries(entries)
+	if err != nil {
+		return nil, err
+	}
+	return &stack{name: name, entries: ordered}, nil
+}
+
+func parseStackReady(cfg stackReadyConfig) (stackReady, error) {
+	ready := stackReady{pattern: cfg.Pattern, timeout: stackReadyTimeoutDefault}
+	if value := strings.TrimSpace(cfg.Idle); value != "" {
+		idle, err := time.ParseDuration(value)
+		if err != nil || idle <= 0 {
+			return stackReady{}, fmt.Errorf("invalid ready.idle %q", cfg.Idle)
+		}
+		ready.idle = idle
+	}
+	if value := strings.TrimSpace(cfg.Timeout); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return stackReady{}, fmt.Errorf("invalid ready.timeout %q", cfg.Timeout)
+		}
+		ready.timeout = timeout
+	}
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		return stackReady{}, fmt.Errorf("invalid ready.port %d", cfg.Port)
+	}
+	if cfg.Port > 0 {
+		host := strings.TrimSpace(cfg.Host)
+		if host == "" {
+			host = "127.0.0.1"
+		}
+		ready.addr = net.JoinHostPort(host, strconv.Itoa(cfg.Port))
+	}
+	return ready, nil
+}
+
+// orderStackEntries sorts sessions so dependencies start first. Ties are
+// broken by name to keep the order stable.
+func orderStackEntries(entries map[string]stackEntry) ([]stackEntry, error) {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		visiting = iota + 1
+		done
+	)
+	state := make(map[string]int, len(entries))
+	ordered := make([]stackEntry, 0, len(entries))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+		state[name] = visiting
+		entry := entries[name]
+		deps := append([]string(nil), entry.dependsOn...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, ok := entries[dep]; !ok {
+				return fmt.Errorf("session %q depends on unknown session %q", name, dep)
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		ordered = append(ordered, entry)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func (e stackEntry) spawnRequest(stackName string) *proto.SpawnRequest {
+	tags := make(map[string]string, len(e.session.Tags)+1)
+	for key, value := range e.session.Tags {
+		tags[key] = value
+	}
+	tags[stackTagKey] = stackName
+	return &proto.SpawnRequest{
+		Name:       e.label,
+		Command:    e.session.Command,
+		WorkingDir: expandPath(e.session.Cwd),
+		Env:        e.session.Env,
+		Cols:       int32(e.session.Cols),
+		Rows:       int32(e.session.Rows),
+		Tags:       tags,
+		Profile:    e.session.Profile,
+	}
+}
+
+type jsonStack struct {
+	Stack    string             `json:"stack"`
+	Sessions []jsonStackSession `json:"sessions"`
+}
+
+type jsonStackSession struct {
+	Name      string       `json:"name"`
+	Action    string       `json:"action,omitempty"`
+	Ready     bool         `json:"ready,omitempty"`
+	DependsOn []string     `json:"depends_on,omitempty"`
+	Idle      *bool        `json:"idle,omitempty"`
+	Session   *jsonSession `json:"session,omitempty"`
+}
+
+func (item *jsonStackSession) setSession(entry stackEntry, session *proto.Session) {
+	if session == nil {
+		return
+	}
+	coord, _, _ := parseSessionRef(entry.label)
+	out := sessionToJSON(session, coord)
+	idle := session.GetIdle()
+	item.Session = &out
+	item.Idle = &idle
+}
+
+func newUpCmd() *cobra.Command {
+	var hub string
+	var file string
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Start the sessions in a stack file",
+		Long: "Spawn every session in a stack file in dependency order, waiting for each " +
+			"session's readiness checks before starting its dependents. Sessions that are " +
+			"already running are kept; exited sessions are removed and spawned again.",
+		Example: `vtr up
+vtr up -f dev/vtr-stack.toml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			st, err := loadStack(file)
+			if err != nil {
+				return err
+			}
+			cfg, _, output, err := loadConfigAndOutput(jsonFlag)
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				report := jsonStack{Stack: st.name}
+				for _, entry := range st.entries {
+					item, err := stackUp(ctx, client, st.name, entry, cmd.ErrOrStderr())
+					if item != nil {
+						report.Sessions = append(report.Sessions, *item)
+					}
+					if err != nil {
+						return fmt.Errorf("%s: %w", entry.name, err)
+					}
+				}
+				return writeStack(cmd.OutOrStdout(), output, report)
+			})
+		},
+	}
+	addStackFlags(cmd, &hub, &file, &jsonFlag)
+	return cmd
+}
+
+func stackUp(ctx context.Context, client proto.VTRClient, stackName string, entry stackEntry, progress io.Writer) (*jsonStackSession, error) {
+	item := &jsonStackSession{Name: entry.name, DependsOn: entry.dependsOn}
+	ref, _, err := resolveSessionRef(entry.label, "")
+	if err != nil {
+		return nil, err
+	}
+	session, err := stackInfo(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case session != nil && session.Status != proto.SessionStatus_SESSION_STATUS_EXITED:
+		item.Action = "kept"
+	default:
+		if session != nil {
+			rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+			_, err := client.Remove(rpcCtx, &proto.RemoveRequest{Session: ref})
+			cancel()
+			if err != nil {
+				return nil, err
+			}
+		}
+		rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+		resp, err := client.Spawn(rpcCtx, entry.spawnRequest(stackName))
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		session = resp.GetSession()
+		item.Action = "spawned"
+	}
+	if id := session.GetId(); id != "" {
+		ref = &proto.SessionRef{Id: id, Coordinator: ref.GetCoordinator()}
+	}
+	fmt.Fprintf(progress, "%s: %s\n", entry.name, item.Action)
+
+	if !entry.ready.empty() {
+		fmt.Fprintf(progress, "%s: waiting for readiness\n", entry.name)
+		if err := waitStackReady(ctx, client, ref, entry.ready); err != nil {
+			return item, err
+		}
+	}
+	item.Ready = true
+	item.setSession(entry, session)
+	return item, nil
+}
+
+// stackInfo returns the session or nil when it does not exist.
+func stackInfo(ctx context.Context, client proto.VTRClient, ref *proto.SessionRef) (*proto.Session, error) {
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+	resp, err := client.Info(rpcCtx, &proto.InfoRequest{Session: ref})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetSession(), nil
+}
+
+// waitStackReady runs the configured readiness checks in order: pattern, then
+// idle, then port.
+func waitStackReady(ctx context.Context, client proto.VTRClient, ref *proto.SessionRef, ready stackReady) error {
+	ctx, cancel := context.WithTimeout(ctx, ready.timeout)
+	defer cancel()
+	if ready.pattern != "" {
+		if err := waitStackPattern(ctx, client, ref, ready.pattern); err != nil {
+			return err
+		}
+	}
+	if ready.idle > 0 {
+		deadline, _ := ctx.Deadline()
+		resp, err := client.WaitForIdle(ctx, &proto.WaitForIdleRequest{
+			Session:      ref,
+			IdleDuration: durationpb.New(ready.idle),
+			Timeout:      durationpb.New(time.Until(deadline)),
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.GetIdle() {
+			return fmt.Errorf("timed out waiting for %s idle", ready.idle)
+		}
+	}
+	if ready.addr != "" {
+		if err := waitStackPort(ctx, ready.addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitStackPattern combines WaitFor, which only sees output produced after
+// the request starts, with periodic Grep calls over scrollback so output
+// printed before the wait began is not missed.
+func waitStackPattern(ctx context.Context, client proto.VTRClient, ref *proto.SessionRef, pattern string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	waitCh := make(chan error, 1)
+	go func() {
+		resp, err := client.WaitFor(ctx, &proto.WaitForRequest{
+			Session: ref,
+			Pattern: pattern,
+			Timeout: durationpb.New(time.Until(deadline)),
+		})
+		switch {
+		case err != nil:
+			waitCh <- err
+		case resp.GetMatched():
+			waitCh <- nil
+		default:
+			waitCh <- fmt.Errorf("timed out waiting for %q", pattern)
+		}
+	}()
+	ticker := time.NewTicker(stackGrepInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := client.Grep(ctx, &proto.GrepRequest{Session: ref, Pattern: pattern, MaxMatches: 1})
+		if err == nil && len(resp.GetMatches()) > 0 {
+			return nil
+		}
+		if status.Code(err) == codes.InvalidArgument {
+			return err
+		}
+		select {
+		case err := <-waitCh:
+			return err
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %q", pattern)
+		}
+	}
+}
+
+func waitStackPort(ctx context.Context, addr string) error {
+	var dialer net.Dialer
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for port %s", addr)
+		case <-time.After(stackPortInterval):
+		}
+	}
+}
+
+func newDownCmd() *cobra.Command {
+	var hub string
+	var file string
+	var jsonFlag bool
+	var remove bool
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Close the sessions in a stack file",
+		Long:  "Close every session in a stack file in reverse dependency order. Missing sessions are skipped.",
+		Example: `vtr down
+vtr down -f dev/vtr-stack.toml --remove`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			st, err := loadStack(file)
+			if err != nil {
+				return err
+			}
+			cfg, _, output, err := loadConfigAndOutput(jsonFlag)
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				report := jsonStack{Stack: st.name}
+				for i := len(st.entries) - 1; i >= 0; i-- {
+					entry := st.entries[i]
+					ref, _, err := resolveSessionRef(entry.label, "")
+					if err != nil {
+						return err
+					}
+					item := jsonStackSession{Name: entry.name, Action: "missing"}
+					rpcCtx, rpcCancel := context.WithTimeout(ctx, rpcTimeout)
+					if remove {
+						_, err = client.Remove(rpcCtx, &proto.RemoveRequest{Session: ref})
+						item.Action = "removed"
+					} else {
+						_, err = client.Close(rpcCtx, &proto.CloseRequest{Session: ref})
+						item.Action = "closed"
+					}
+					rpcCancel()
+					if status.Code(err) == codes.NotFound {
+						item.Action = "missing"
+						err = nil
+					}
+					if err != nil {
+						return fmt.Errorf("%s: %w", entry.name, err)
+					}
+					report.Sessions = append(report.Sessions, item)
+				}
+				return writeStack(cmd.OutOrStdout(), output, report)
+			})
+		},
+	}
+	addStackFlags(cmd, &hub, &file, &jsonFlag)
+	cmd.Flags().BoolVar(&remove, "remove", false, "remove sessions instead of closing them")
+	return cmd
+}
+
+func newStatusCmd() *cobra.Command {
+	var hub string
+	var file string
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the state of the sessions in a stack file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			st, err := loadStack(file)
+			if err != nil {
+				return err
+			}
+			cfg, _, output, err := loadConfigAndOutput(jsonFlag)
+			if err != nil {
+				return err
+			}
+			target, err := resolveHubTarget(cfg, hub)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			return withCoordinator(ctx, target, cfg, func(client proto.VTRClient) error {
+				report := jsonStack{Stack: st.name}
+				for _, entry := range st.entries {
+					ref, _, err := resolveSessionRef(entry.label, "")
+					if err != nil {
+						return err
+					}
+					session, err := stackInfo(ctx, client, ref)
+					if err != nil {
+						return fmt.Errorf("%s: %w", entry.name, err)
+					}
+					item := jsonStackSession{Name: entry.name, DependsOn: entry.dependsOn}
+					item.setSession(entry, session)
+					report.Sessions = append(report.Sessions, item)
+				}
+				return writeStack(cmd.OutOrStdout(), output, report)
+			})
+		},
+	}
+	addStackFlags(cmd, &hub, &file, &jsonFlag)
+	return cmd
+}
+
+func addStackFlags(cmd *cobra.Command, hub, file *string, jsonFlag *bool) {
+	addHubFlag(cmd, hub)
+	cmd.Flags().StringVarP(file, "file", "f", defaultStackFile, "stack file")
+	cmd.Flags().BoolVar(jsonFlag, "json", false, "output JSON")
+}
+
+func writeStack(w io.Writer, output outputFormat, report jsonStack) error {
+	if output == outputJSON {
+		return writeJSON(w, report)
+	}
+	printStackHuman(w, report)
+	return nil
+}
+
+func printStackHuman(w io.Writer, report jsonStack) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SESSION\tCOORDINATOR\tSTATUS\tIDLE\tACTION\tDEPENDS")
+	for _, item := range report.Sessions {
+		coord := "-"
+		state := "missing"
+		idle := "-"
+		if item.Session != nil {
+			if item.Session.Coordinator != "" {
+				coord = item.Session.Coordinator
+			}
+			state = item.Session.Status
+			if item.Session.ExitCode != nil {
+				state = fmt.Sprintf("%s (%d)", state, *item.Session.ExitCode)
+			}
+		}
+		if item.Idle != nil {
+			idle = strconv.FormatBool(*item.Idle)
+		}
+		action := item.Action
+		if action == "" {
+			action = "-"
+		}
+		deps := strings.Join(item.DependsOn, ",")
+		if deps == "" {
+			deps = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", item.Name, coord, state, idle, action, deps)
+	}
+	_ = tw.Flush()
+}
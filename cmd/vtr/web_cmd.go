@@ -89,6 +89,7 @@ func newWebHandler(opts webOptions, resolver webResolver) (http.Handler, error)
 		Version:    Version,
 		RPCTimeout: rpcTimeout,
 		LogResize:  webtransport.EnvBool("VTR_LOG_RESIZE", false),
+		Profiles:   profileNames(resolver.cfg),
 	}, resolver, func(ctx context.Context, addr string) (*grpc.ClientConn, error) {
 		return dialClient(ctx, addr, resolver.cfg)
 	})
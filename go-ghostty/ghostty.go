@@ -63,6 +63,37 @@ type Cell struct {
 	Wide  Wide
 }
 
+// MouseEvents is the mouse tracking mode the program enabled.
+type MouseEvents uint8
+
+const (
+	MouseEventsNone   MouseEvents = MouseEvents(C.VTR_GHOSTTY_MOUSE_EVENT_NONE)
+	MouseEventsX10    MouseEvents = MouseEvents(C.VTR_GHOSTTY_MOUSE_EVENT_X10)
+	MouseEventsNormal MouseEvents = MouseEvents(C.VTR_GHOSTTY_MOUSE_EVENT_NORMAL)
+	MouseEventsButton MouseEvents = MouseEvents(C.VTR_GHOSTTY_MOUSE_EVENT_BUTTON)
+	MouseEventsAny    MouseEvents = MouseEvents(C.VTR_GHOSTTY_MOUSE_EVENT_ANY)
+)
+
+// MouseFormat is the mouse report encoding the program enabled.
+type MouseFormat uint8
+
+const (
+	MouseFormatX10       MouseFormat = MouseFormat(C.VTR_GHOSTTY_MOUSE_FORMAT_X10)
+	MouseFormatUTF8      MouseFormat = MouseFormat(C.VTR_GHOSTTY_MOUSE_FORMAT_UTF8)
+	MouseFormatSGR       MouseFormat = MouseFormat(C.VTR_GHOSTTY_MOUSE_FORMAT_SGR)
+	MouseFormatURXVT     MouseFormat = MouseFormat(C.VTR_GHOSTTY_MOUSE_FORMAT_URXVT)
+	MouseFormatSGRPixels MouseFormat = MouseFormat(C.VTR_GHOSTTY_MOUSE_FORMAT_SGR_PIXELS)
+)
+
+// Modes reports terminal modes that affect how input is encoded.
+type Modes struct {
+	MouseEvents    MouseEvents
+	MouseFormat    MouseFormat
+	BracketedPaste bool
+	// CursorKeys reports application cursor keys (DECCKM).
+	CursorKeys bool
+}
+
 // Snapshot captures the viewport state.
 type Snapshot struct {
 	Cols          int
@@ -70,7 +101,13 @@ type Snapshot struct {
 	CursorX       int
 	CursorY       int
 	CursorVisible bool
-	Cells         []Cell
+	// ScrollTop and ScrollBottom bound the scrolling region (DECSTBM),
+	// 0-based inclusive.
+	ScrollTop    int
+	ScrollBottom int
+	// Modes holds the input modes in effect when the snapshot was taken.
+	Modes Modes
+	Cells []Cell
 }
 
 // Terminal wraps a Ghostty VT instance.
@@ -143,7 +180,63 @@ func (t *Terminal) Snapshot() (*Snapshot, error) {
 		return nil, err
 	}
 	defer C.vtr_ghostty_snapshot_free(nil, &snap)
+	modes, err := t.Modes()
+	if err != nil {
+		return nil, err
+	}
+	out := snapshotFromC(&snap)
+	out.Modes = modes
+	return out, nil
+}
+
+// ScreenRows returns the number of screen rows: scrollback history followed
+// by the active area.
+func (t *Terminal) ScreenRows() (int, error) {
+	if t == nil || t.ptr == nil {
+		return 0, errors.New("ghostty: terminal is closed")
+	}
+	var out C.uint32_t
+	res := C.vtr_ghostty_terminal_screen_rows(t.ptr, &out)
+	if err := resultToErr(res); err != nil {
+		return 0, err
+	}
+	return int(out), nil
+}
 
+// ScrollbackRows returns the number of scrollback history rows above the
+// active area.
+func (t *Terminal) ScrollbackRows() (int, error) {
+	if t == nil || t.ptr == nil {
+		return 0, errors.New("ghostty: terminal is closed")
+	}
+	var out C.uint32_t
+	res := C.vtr_ghostty_terminal_scrollback_rows(t.ptr, &out)
+	if err := resultToErr(res); err != nil {
+		return 0, err
+	}
+	return int(out), nil
+}
+
+// ScreenSnapshot returns a styled copy of screen rows [start, end), counted
+// from the oldest history row. The cursor is relative to start and visible
+// only when it falls in the range.
+func (t *Terminal) ScreenSnapshot(start, end int) (*Snapshot, error) {
+	if t == nil || t.ptr == nil {
+		return nil, errors.New("ghostty: terminal is closed")
+	}
+	if start < 0 || end < start {
+		return nil, errors.New("ghostty: invalid row range")
+	}
+	var snap C.vtr_ghostty_snapshot_t
+	res := C.vtr_ghostty_terminal_screen_snapshot(t.ptr, C.uint32_t(start), C.uint32_t(end), nil, &snap)
+	if err := resultToErr(res); err != nil {
+		return nil, err
+	}
+	defer C.vtr_ghostty_snapshot_free(nil, &snap)
+	return snapshotFromC(&snap), nil
+}
+
+func snapshotFromC(snap *C.vtr_ghostty_snapshot_t) *Snapshot {
 	rows := int(snap.rows)
 	cols := int(snap.cols)
 	cells := make([]Cell, rows*cols)
@@ -167,8 +260,10 @@ func (t *Terminal) Snapshot() (*Snapshot, error) {
 		CursorX:       int(snap.cursor_x),
 		CursorY:       int(snap.cursor_y),
 		CursorVisible: snap.cursor_visible != 0,
+		ScrollTop:     int(snap.scroll_top),
+		ScrollBottom:  int(snap.scroll_bottom),
 		Cells:         cells,
-	}, nil
+	}
 }
 
 // Dump returns a text dump of the requested scope.
@@ -188,6 +283,24 @@ func (t *Terminal) Dump(scope DumpScope, unwrap bool) (string, error) {
 	return string(bytes), nil
 }
 
+// Modes returns the current input-related terminal modes.
+func (t *Terminal) Modes() (Modes, error) {
+	if t == nil || t.ptr == nil {
+		return Modes{}, errors.New("ghostty: terminal is closed")
+	}
+	var out C.vtr_ghostty_modes_t
+	res := C.vtr_ghostty_terminal_modes(t.ptr, &out)
+	if err := resultToErr(res); err != nil {
+		return Modes{}, err
+	}
+	return Modes{
+		MouseEvents:    MouseEvents(out.mouse_event),
+		MouseFormat:    MouseFormat(out.mouse_format),
+		BracketedPaste: bool(out.bracketed_paste),
+		CursorKeys:     bool(out.cursor_keys),
+	}, nil
+}
+
 func copyBytesAndFree(bytes *C.vtr_ghostty_bytes_t) ([]byte, error) {
 	if bytes == nil || bytes.ptr == nil || bytes.len == 0 {
 		return nil, nil
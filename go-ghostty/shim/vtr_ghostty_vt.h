@@ -63,6 +63,8 @@ typedef struct {
     uint32_t cursor_x;
     uint32_t cursor_y;
     uint8_t  cursor_visible;
+    uint32_t scroll_top;    /* scrolling region (DECSTBM), 0-based inclusive */
+    uint32_t scroll_bottom;
     vtr_ghostty_cell_t *cells; /* rows*cols */
 } vtr_ghostty_snapshot_t;
 
@@ -79,6 +81,31 @@ enum {
     VTR_GHOSTTY_ATTR_OVERLINE = 1u << 8,
 };
 
+// Mouse tracking (DECSET 9/1000/1002/1003)
+typedef enum {
+    VTR_GHOSTTY_MOUSE_EVENT_NONE = 0,
+    VTR_GHOSTTY_MOUSE_EVENT_X10 = 1,    /* 9: press only */
+    VTR_GHOSTTY_MOUSE_EVENT_NORMAL = 2, /* 1000: press and release */
+    VTR_GHOSTTY_MOUSE_EVENT_BUTTON = 3, /* 1002: plus drag */
+    VTR_GHOSTTY_MOUSE_EVENT_ANY = 4,    /* 1003: plus motion */
+} vtr_ghostty_mouse_event_t;
+
+// Mouse report encoding (DECSET 1005/1006/1015/1016)
+typedef enum {
+    VTR_GHOSTTY_MOUSE_FORMAT_X10 = 0,
+    VTR_GHOSTTY_MOUSE_FORMAT_UTF8 = 1,
+    VTR_GHOSTTY_MOUSE_FORMAT_SGR = 2,
+    VTR_GHOSTTY_MOUSE_FORMAT_URXVT = 3,
+    VTR_GHOSTTY_MOUSE_FORMAT_SGR_PIXELS = 4,
+} vtr_ghostty_mouse_format_t;
+
+typedef struct {
+    uint8_t mouse_event;  /* vtr_ghostty_mouse_event_t */
+    uint8_t mouse_format; /* vtr_ghostty_mouse_format_t */
+    bool bracketed_paste; /* DECSET 2004 */
+    bool cursor_keys;     /* DECCKM (DECSET 1): application cursor keys */
+} vtr_ghostty_modes_t;
+
 GhosttyResult vtr_ghostty_terminal_new(
     const vtr_ghostty_terminal_options_t *opts,
     GhosttyAllocator *alloc,
@@ -111,6 +138,30 @@ void vtr_ghostty_snapshot_free(
     vtr_ghostty_snapshot_t *snap
 );
 
+// Number of screen rows: scrollback history followed by the active area.
+GhosttyResult vtr_ghostty_terminal_screen_rows(
+    vtr_ghostty_terminal_t *t,
+    uint32_t *out
+);
+
+// Number of scrollback history rows above the active area.
+GhosttyResult vtr_ghostty_terminal_scrollback_rows(
+    vtr_ghostty_terminal_t *t,
+    uint32_t *out
+);
+
+// Styled copy of screen rows [start_row, end_row), counted from the oldest
+// history row and clamped to the screen. The cursor is relative to start_row
+// and only visible when it falls in the range. Free with
+// vtr_ghostty_snapshot_free.
+GhosttyResult vtr_ghostty_terminal_screen_snapshot(
+    vtr_ghostty_terminal_t *t,
+    uint32_t start_row,
+    uint32_t end_row,
+    GhosttyAllocator *alloc,
+    vtr_ghostty_snapshot_t *out
+);
+
 GhosttyResult vtr_ghostty_terminal_dump(
     vtr_ghostty_terminal_t *t,
     vtr_ghostty_dump_scope_t scope,
@@ -124,6 +175,11 @@ void vtr_ghostty_bytes_free(
     vtr_ghostty_bytes_t *bytes
 );
 
+GhosttyResult vtr_ghostty_terminal_modes(
+    vtr_ghostty_terminal_t *t,
+    vtr_ghostty_modes_t *out
+);
+
 #ifdef __cplusplus
 }
 #endif
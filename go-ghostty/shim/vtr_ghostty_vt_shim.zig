@@ -133,9 +133,18 @@ pub const vtr_ghostty_snapshot_t = extern struct {
     cursor_x: u32,
     cursor_y: u32,
     cursor_visible: u8,
+    scroll_top: u32,
+    scroll_bottom: u32,
     cells: ?[*]vtr_ghostty_cell_t,
 };
 
+pub const vtr_ghostty_modes_t = extern struct {
+    mouse_event: u8,
+    mouse_format: u8,
+    bracketed_paste: bool,
+    cursor_keys: bool,
+};
+
 const AttrBold: u32 = 1 << 0;
 const AttrItalic: u32 = 1 << 1;
 const AttrUnderline: u32 = 1 << 2;
@@ -199,6 +208,38 @@ fn cellWideValue(wide: vt.page.Cell.Wide) u8 {
     };
 }
 
+fn styledCell(raw: *const vt.page.Cell, style: vt.Style, colors: anytype) vtr_ghostty_cell_t {
+    var fg = style.fg(.{
+        .default = colors.foreground,
+        .palette = &colors.palette,
+        .bold = null,
+    });
+    var bg = style.bg(raw, &colors.palette) orelse colors.background;
+    if (style.flags.inverse) {
+        const tmp = fg;
+        fg = bg;
+        bg = tmp;
+    }
+
+    const ul = style.underlineColor(&colors.palette) orelse
+        vt.color.RGB{ .r = 0, .g = 0, .b = 0 };
+
+    return .{
+        .codepoint = @intCast(raw.codepoint()),
+        .fg_rgb = packRgb(fg),
+        .bg_rgb = packRgb(bg),
+        .ul_rgb = packRgb(ul),
+        .attrs = attrsFromStyle(style),
+        .wide = cellWideValue(raw.wide),
+    };
+}
+
+fn screenRowCount(pages: anytype) usize {
+    const br = pages.getBottomRight(.screen) orelse return 0;
+    const pt = pages.pointFromPin(.screen, br) orelse return 0;
+    return @as(usize, pt.screen.y) + 1;
+}
+
 pub export fn vtr_ghostty_terminal_new(
     opts: ?*const vtr_ghostty_terminal_options_t,
     c_alloc: ?*const GhosttyAllocator,
@@ -314,33 +355,7 @@ pub export fn vtr_ghostty_terminal_snapshot(
             if (has_managed and raw.style_id > 0) {
                 style = cell_style[x];
             }
-
-            const fg_style = style.fg(.{
-                .default = handle.render_state.colors.foreground,
-                .palette = &handle.render_state.colors.palette,
-                .bold = null,
-            });
-            var bg = style.bg(&raw, &handle.render_state.colors.palette) orelse
-                handle.render_state.colors.background;
-            var fg = fg_style;
-            if (style.flags.inverse) {
-                const tmp = fg;
-                fg = bg;
-                bg = tmp;
-            }
-
-            const ul = style.underlineColor(&handle.render_state.colors.palette) orelse
-                vt.color.RGB{ .r = 0, .g = 0, .b = 0 };
-
-            const idx = y * cols + x;
-            cells[idx] = .{
-                .codepoint = @intCast(raw.codepoint()),
-                .fg_rgb = packRgb(fg),
-                .bg_rgb = packRgb(bg),
-                .ul_rgb = packRgb(ul),
-                .attrs = attrsFromStyle(style),
-                .wide = cellWideValue(raw.wide),
-            };
+            cells[y * cols + x] = styledCell(&raw, style, &handle.render_state.colors);
         }
     }
 
@@ -359,6 +374,8 @@ pub export fn vtr_ghostty_terminal_snapshot(
         .cursor_x = cursor_x,
         .cursor_y = cursor_y,
         .cursor_visible = cursor_visible,
+        .scroll_top = @intCast(handle.terminal.scrolling_region.top),
+        .scroll_bottom = @intCast(handle.terminal.scrolling_region.bottom),
         .cells = cells.ptr,
     };
 
@@ -377,6 +394,8 @@ pub export fn vtr_ghostty_snapshot_free(
             .cursor_x = 0,
             .cursor_y = 0,
             .cursor_visible = 0,
+            .scroll_top = 0,
+            .scroll_bottom = 0,
             .cells = null,
         };
         return;
@@ -398,10 +417,100 @@ pub export fn vtr_ghostty_snapshot_free(
         .cursor_x = 0,
         .cursor_y = 0,
         .cursor_visible = 0,
+        .scroll_top = 0,
+        .scroll_bottom = 0,
         .cells = null,
     };
 }
 
+pub export fn vtr_ghostty_terminal_screen_rows(
+    t: ?*vtr_ghostty_terminal_t,
+    out: ?*u32,
+) GhosttyResult {
+    if (t == null or out == null) return .invalid_value;
+
+    const handle = handleFromOpaque(t.?);
+    const total = screenRowCount(&handle.terminal.screens.active.pages);
+    out.?.* = std.math.cast(u32, total) orelse return .invalid_value;
+    return .success;
+}
+
+pub export fn vtr_ghostty_terminal_scrollback_rows(
+    t: ?*vtr_ghostty_terminal_t,
+    out: ?*u32,
+) GhosttyResult {
+    if (t == null or out == null) return .invalid_value;
+
+    const handle = handleFromOpaque(t.?);
+    const pages = &handle.terminal.screens.active.pages;
+    const total = screenRowCount(pages);
+    const active: usize = @intCast(pages.rows);
+    out.?.* = std.math.cast(u32, total -| active) orelse return .invalid_value;
+    return .success;
+}
+
+pub export fn vtr_ghostty_terminal_screen_snapshot(
+    t: ?*vtr_ghostty_terminal_t,
+    start_row: u32,
+    end_row: u32,
+    c_alloc: ?*const GhosttyAllocator,
+    out: ?*vtr_ghostty_snapshot_t,
+) GhosttyResult {
+    if (t == null or out == null) return .invalid_value;
+
+    const handle = handleFromOpaque(t.?);
+    // The render state carries the palette and default colors.
+    handle.render_state.update(handle.alloc, &handle.terminal) catch |err| return mapError(err);
+
+    const screen = handle.terminal.screens.active;
+    const pages = &screen.pages;
+    const total = screenRowCount(pages);
+    const start = @min(@as(usize, start_row), total);
+    const end = @max(start, @min(@as(usize, end_row), total));
+    const rows = end - start;
+    const cols: usize = @intCast(pages.cols);
+    const count = std.math.mul(usize, rows, cols) catch return .invalid_value;
+
+    const alloc = defaultAllocator(c_alloc);
+    const cells = alloc.alloc(vtr_ghostty_cell_t, count) catch return .out_of_memory;
+    errdefer alloc.free(cells);
+    @memset(cells, std.mem.zeroes(vtr_ghostty_cell_t));
+
+    for (0..rows) |y| {
+        const pin = pages.pin(.{ .screen = .{ .y = @intCast(start + y) } }) orelse continue;
+        const row_cells = pin.cells(.all);
+        for (row_cells, 0..) |*raw, x| {
+            if (x >= cols) break;
+            cells[y * cols + x] = styledCell(raw, pin.style(raw), &handle.render_state.colors);
+        }
+    }
+
+    var cursor_x: u32 = 0;
+    var cursor_y: u32 = 0;
+    var cursor_visible: u8 = 0;
+    if (pages.pointFromPin(.screen, screen.cursor.page_pin.*)) |pt| {
+        const y: usize = @intCast(pt.screen.y);
+        if (y >= start and y < end) {
+            cursor_x = @intCast(pt.screen.x);
+            cursor_y = @intCast(y - start);
+            cursor_visible = if (handle.terminal.modes.get(.cursor_visible)) 1 else 0;
+        }
+    }
+
+    out.?.* = .{
+        .rows = @intCast(rows),
+        .cols = @intCast(cols),
+        .cursor_x = cursor_x,
+        .cursor_y = cursor_y,
+        .cursor_visible = cursor_visible,
+        // History rows have no scrolling region of their own.
+        .scroll_top = 0,
+        .scroll_bottom = @intCast(rows -| 1),
+        .cells = cells.ptr,
+    };
+    return .success;
+}
+
 pub export fn vtr_ghostty_terminal_dump(
     t: ?*vtr_ghostty_terminal_t,
     scope: vtr_ghostty_dump_scope_t,
@@ -444,3 +553,32 @@ pub export fn vtr_ghostty_bytes_free(
     alloc.free(slice);
     bytes.?.* = .{ .ptr = null, .len = 0 };
 }
+
+pub export fn vtr_ghostty_terminal_modes(
+    t: ?*vtr_ghostty_terminal_t,
+    out: ?*vtr_ghostty_modes_t,
+) GhosttyResult {
+    if (t == null or out == null) return .invalid_value;
+
+    const handle = handleFromOpaque(t.?);
+    const flags = handle.terminal.flags;
+    out.?.* = .{
+        .mouse_event = switch (flags.mouse_event) {
+            .none => 0,
+            .x10 => 1,
+            .normal => 2,
+            .button => 3,
+            .any => 4,
+        },
+        .mouse_format = switch (flags.mouse_format) {
+            .x10 => 0,
+            .utf8 => 1,
+            .sgr => 2,
+            .urxvt => 3,
+            .sgr_pixels => 4,
+        },
+        .bracketed_paste = handle.terminal.modes.get(.bracketed_paste),
+        .cursor_keys = handle.terminal.modes.get(.cursor_keys),
+    };
+    return .success;
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client kinds reported by attached viewers.
+const (
+	ClientKindTUI   = "tui"
+	ClientKindWeb   = "web"
+	ClientKindAgent = "agent"
+)
+
+// ClientInfo describes one attachment (an open Subscribe stream) on a session.
+type ClientInfo struct {
+	// ID is assigned by AttachClient and addresses DisconnectClient.
+	ID        string
+	SessionID string
+	// ClientID is the client's self-reported id, shared with input locks.
+	ClientID string
+	Kind     string
+	Name     string
+	Peer     string
+	// Identity is the authenticated identity of the connection, if any.
+	Identity    string
+	ReadOnly    bool
+	Cols        uint16
+	Rows        uint16
+	ConnectedAt time.Time
+}
+
+// ClientAttachment is the handle for a registered client. Detach it when the
+// stream ends; Done closes when the client is disconnected.
+type ClientAttachment struct {
+	coord   *Coordinator
+	session *Session
+	id      string
+	done    chan struct{}
+	once    sync.Once
+}
+
+// ID returns the attachment id.
+func (a *ClientAttachment) ID() string {
+	return a.id
+}
+
+// Done is closed when DisconnectClient targets this attachment.
+func (a *ClientAttachment) Done() <-chan struct{} {
+	return a.done
+}
+
+// Detach unregisters the attachment. It is safe to call more than once.
+func (a *ClientAttachment) Detach() {
+	a.session.mu.Lock()
+	_, ok := a.session.clients[a.id]
+	delete(a.session.clients, a.id)
+	a.session.mu.Unlock()
+	if ok {
+		a.coord.applyViewportPolicy(a.session)
+		a.coord.signalSessionsChanged()
+	}
+}
+
+func (a *ClientAttachment) kick() {
+	a.once.Do(func() { close(a.done) })
+}
+
+type attachedClient struct {
+	info       ClientInfo
+	attachment *ClientAttachment
+	// lastInput is when the client last sent input; zero until it does. The
+	// latest resize policy follows the most recent one.
+	lastInput time.Time
+}
+
+// AttachClient registers a viewer on a session. Empty kinds are recorded as
+// ClientKindAgent.
+func (c *Coordinator) AttachClient(id string, info ClientInfo) (*ClientAttachment, error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	info.Kind = strings.TrimSpace(info.Kind)
+	if info.Kind == "" {
+		info.Kind = ClientKindAgent
+	}
+	info.ID = uuid.NewString()
+	info.SessionID = session.ID()
+	info.ClientID = strings.TrimSpace(info.ClientID)
+	info.ConnectedAt = time.Now()
+	attachment := &ClientAttachment{
+		coord:   c,
+		session: session,
+		id:      info.ID,
+		done:    make(chan struct{}),
+	}
+	session.mu.Lock()
+	if session.clients == nil {
+		session.clients = make(map[string]*attachedClient)
+	}
+	session.clients[info.ID] = &attachedClient{info: info, attachment: attachment}
+	session.mu.Unlock()
+
+	c.applyViewportPolicy(session)
+	c.signalSessionsChanged()
+	return attachment, nil
+}
+
+// ListClients returns the clients attached to a session, oldest first.
+func (c *Coordinator) ListClients(id string) ([]ClientInfo, error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	session.mu.Lock()
+	out := make([]ClientInfo, 0, len(session.clients))
+	for _, client := range session.clients {
+		out = append(out, client.info)
+	}
+	session.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ConnectedAt.Equal(out[j].ConnectedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].ConnectedAt.Before(out[j].ConnectedAt)
+	})
+	return out, nil
+}
+
+// DisconnectClient ends the stream of an attached client. The client is
+// removed once its stream detaches.
+func (c *Coordinator) DisconnectClient(id, clientID string) error {
+	session, err := c.getSession(id)
+	if err != nil {
+		return err
+	}
+	session.mu.Lock()
+	client, ok := session.clients[strings.TrimSpace(clientID)]
+	session.mu.Unlock()
+	if !ok {
+		return ErrClientNotFound
+	}
+	client.attachment.kick()
+	return nil
+}
+
+// UpdateClientSize records the viewport of every attachment with clientID.
+// It reports whether any attachment matched.
+func (c *Coordinator) UpdateClientSize(id, clientID string, cols, rows uint16) (bool, error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return false, err
+	}
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return false, nil
+	}
+	matched := false
+	session.mu.Lock()
+	for _, client := range session.clients {
+		if client.info.ClientID == clientID {
+			client.info.Cols = cols
+			client.info.Rows = rows
+			matched = true
+		}
+	}
+	session.mu.Unlock()
+	return matched, nil
+}
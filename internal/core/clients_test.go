@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAttachedClients(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("watched", SpawnOptions{Command: []string{"/bin/sh", "-c", "sleep 5"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	tui, err := coord.AttachClient(info.ID, ClientInfo{ClientID: "alice", Kind: ClientKindTUI, Cols: 120, Rows: 40})
+	if err != nil {
+		t.Fatalf("AttachClient: %v", err)
+	}
+	viewer, err := coord.AttachClient(info.ID, ClientInfo{ClientID: "bob", ReadOnly: true})
+	if err != nil {
+		t.Fatalf("AttachClient: %v", err)
+	}
+
+	snapshot, err := coord.Info(info.ID)
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if snapshot.AttachedClients != 2 {
+		t.Fatalf("AttachedClients=%d, want 2", snapshot.AttachedClients)
+	}
+	clients, err := coord.ListClients(info.ID)
+	if err != nil {
+		t.Fatalf("ListClients: %v", err)
+	}
+	if len(clients) != 2 || clients[0].ID != tui.ID() || clients[1].Kind != ClientKindAgent || !clients[1].ReadOnly {
+		t.Fatalf("unexpected clients %+v", clients)
+	}
+
+	matched, err := coord.UpdateClientSize(info.ID, "alice", 100, 30)
+	if err != nil || !matched {
+		t.Fatalf("UpdateClientSize matched=%v err=%v", matched, err)
+	}
+	clients, _ = coord.ListClients(info.ID)
+	if clients[0].Cols != 100 || clients[0].Rows != 30 {
+		t.Fatalf("expected updated viewport, got %dx%d", clients[0].Cols, clients[0].Rows)
+	}
+
+	if err := coord.DisconnectClient(info.ID, "missing"); !errors.Is(err, ErrClientNotFound) {
+		t.Fatalf("expected ErrClientNotFound, got %v", err)
+	}
+	if err := coord.DisconnectClient(info.ID, viewer.ID()); err != nil {
+		t.Fatalf("DisconnectClient: %v", err)
+	}
+	select {
+	case <-viewer.Done():
+	default:
+		t.Fatalf("expected disconnected client to be signalled")
+	}
+	viewer.Detach()
+	viewer.Detach()
+	tui.Detach()
+	snapshot, _ = coord.Info(info.ID)
+	if snapshot.AttachedClients != 0 {
+		t.Fatalf("AttachedClients=%d after detach, want 0", snapshot.AttachedClients)
+	}
+}
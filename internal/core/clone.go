@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// CloneOptions selects which live session state a clone copies in addition
+// to the source's spawn options.
+type CloneOptions struct {
+	CopySize bool
+	CopyTags bool
+}
+
+// Clone spawns label with the spawn options of session id. While the source
+// is running, the clone starts in the live working directory of its
+// foreground process. When that cannot be read the clone starts in the
+// source's spawn working directory instead, and dirErr (wrapping
+// ErrWorkingDirUnavailable) says why.
+func (c *Coordinator) Clone(id, label string, opts CloneOptions) (info *SessionInfo, dirErr error, err error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	spawn := cloneSpawnOptions(session.spawnOpts)
+	dir, dirErr := session.liveWorkingDir()
+	if dir != "" {
+		spawn.WorkingDir = dir
+	}
+	src := session.Info()
+	spawn.Cols, spawn.Rows, spawn.Tags = 0, 0, nil
+	if opts.CopySize {
+		spawn.Cols, spawn.Rows = src.Cols, src.Rows
+	}
+	if opts.CopyTags {
+		spawn.Tags = src.Tags
+	}
+	info, err = c.Spawn(label, spawn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, dirErr, nil
+}
+
+// liveWorkingDir returns the cwd of the process group leader in the
+// foreground of the session's terminal, so a job started in another
+// directory (cd sub && make) is followed rather than the shell. It returns ""
+// once the session has exited.
+func (s *Session) liveWorkingDir() (string, error) {
+	if !s.IsRunning() {
+		return "", nil
+	}
+	if runtime.GOOS != "linux" {
+		return "", fmt.Errorf("%w: reading a process cwd requires /proc", ErrWorkingDirUnavailable)
+	}
+	handle := s.ptyHandle()
+	if handle == nil {
+		return "", nil
+	}
+	pgrp, err := handle.ForegroundPgrp()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrWorkingDirUnavailable, err)
+	}
+	dir, err := os.Readlink(fmt.Sprintf("/proc/%d/cwd", pgrp))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrWorkingDirUnavailable, err)
+	}
+	if deleted, ok := strings.CutSuffix(dir, " (deleted)"); ok {
+		return "", fmt.Errorf("%w: %s was removed", ErrWorkingDirUnavailable, deleted)
+	}
+	return dir, nil
+}
+
+func cloneSpawnOptions(opts SpawnOptions) SpawnOptions {
+	out := opts
+	out.Command = append([]string(nil), opts.Command...)
+	out.Env = append([]string(nil), opts.Env...)
+	out.Tags = cloneTags(opts.Tags)
+	if opts.Isolation != nil {
+		iso := *opts.Isolation
+		iso.Rlimits = append([]Rlimit(nil), opts.Isolation.Rlimits...)
+		out.Isolation = &iso
+	}
+	if opts.ShellIntegration != nil {
+		enabled := *opts.ShellIntegration
+		out.ShellIntegration = &enabled
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCloneReusesSpawnOptions(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	src, err := coord.Spawn("src", SpawnOptions{
+		Command: []string{"/bin/sh"},
+		Env:     []string{"VTR_CLONE_TEST=from-src"},
+		Cols:    100,
+		Rows:    30,
+		Tags:    map[string]string{"owner": "agent-7"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks: %v", err)
+	}
+	if err := coord.Send(src.ID, []byte("cd "+dir+"\n")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if runtime.GOOS == "linux" {
+		session, err := coord.GetSession(src.ID)
+		if err != nil {
+			t.Fatalf("GetSession: %v", err)
+		}
+		waitForWorkingDir(t, session, dir)
+	}
+
+	clone, _, err := coord.Clone(src.ID, "copy", CloneOptions{})
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if clone.Cols != 80 || clone.Rows != 24 {
+		t.Fatalf("expected default size without CopySize, got %dx%d", clone.Cols, clone.Rows)
+	}
+	if len(clone.Tags) != 0 {
+		t.Fatalf("expected no tags without CopyTags, got %v", clone.Tags)
+	}
+	if err := coord.Send(clone.ID, []byte("echo env=$VTR_CLONE_TEST; pwd\n")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	waitForDumpContains(t, coord, clone.ID, "env=from-src", 2*time.Second)
+	if runtime.GOOS == "linux" {
+		waitForDumpContains(t, coord, clone.ID, dir, 2*time.Second)
+	}
+
+	sized, _, err := coord.Clone(src.ID, "copy-2", CloneOptions{CopySize: true, CopyTags: true})
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if sized.Cols != 100 || sized.Rows != 30 || sized.Tags["owner"] != "agent-7" {
+		t.Fatalf("expected copied size and tags, got %dx%d %v", sized.Cols, sized.Rows, sized.Tags)
+	}
+
+	if _, _, err := coord.Clone(src.ID, "copy", CloneOptions{}); !errors.Is(err, ErrSessionExists) {
+		t.Fatalf("expected ErrSessionExists, got %v", err)
+	}
+	if _, _, err := coord.Clone("missing", "copy-3", CloneOptions{}); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func waitForWorkingDir(t *testing.T, session *Session, want string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		dir, err := session.liveWorkingDir()
+		if err == nil && dir == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("cwd=%q (%v), want %q", dir, err, want)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestCloneFollowsForegroundJob(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("live working directory requires /proc")
+	}
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	src, err := coord.Spawn("src", SpawnOptions{Command: []string{"/bin/sh", "-i"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks: %v", err)
+	}
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	// The shell stays in dir while the foreground job runs in sub.
+	if err := coord.Send(src.ID, []byte("cd "+dir+" && (cd sub && exec sleep 30)\n")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	session, err := coord.GetSession(src.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	waitForWorkingDir(t, session, sub)
+}
+
+func TestCloneFallsBackToSpawnWorkingDir(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("live working directory requires /proc")
+	}
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks: %v", err)
+	}
+	gone := filepath.Join(base, "gone")
+	if err := os.Mkdir(gone, 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	src, err := coord.Spawn("src", SpawnOptions{Command: []string{"/bin/sh", "-i"}, WorkingDir: base})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if err := coord.Send(src.ID, []byte("cd "+gone+" && rmdir "+gone+"\n")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	session, err := coord.GetSession(src.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := session.liveWorkingDir(); errors.Is(err, ErrWorkingDirUnavailable) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the removed cwd to be unavailable")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	clone, dirErr, err := coord.Clone(src.ID, "copy", CloneOptions{})
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if !errors.Is(dirErr, ErrWorkingDirUnavailable) {
+		t.Fatalf("expected the fallback to be reported, got %v", dirErr)
+	}
+	copied, err := coord.GetSession(clone.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if copied.spawnOpts.WorkingDir != base {
+		t.Fatalf("clone working dir=%q, want %q", copied.spawnOpts.WorkingDir, base)
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"sort"
@@ -64,6 +65,24 @@ var (
 	ErrSessionNotRunning = errors.New("session not running")
 	ErrInvalidName       = errors.New("session name is required")
 	ErrInvalidSize       = errors.New("cols/rows must be > 0")
+	ErrInvalidTags       = errors.New("invalid session tags")
+	ErrProfileNotFound   = errors.New("spawn profile not found")
+	ErrInputLocked       = errors.New("session input is locked")
+	ErrInvalidClientID   = errors.New("client id is required")
+	ErrClientNotFound    = errors.New("client not found")
+
+	ErrInvalidIsolation     = errors.New("invalid isolation settings")
+	ErrIsolationUnavailable = errors.New("isolation unavailable")
+	ErrInvalidResizePolicy  = errors.New("invalid resize policy")
+	ErrInvalidMouse         = errors.New("invalid mouse event")
+	ErrInvalidRect          = errors.New("invalid screen rectangle")
+	ErrInvalidMacro         = errors.New("invalid macro")
+	ErrMacroNotFound        = errors.New("macro not found")
+	ErrMacroRecording       = errors.New("session is already recording a macro")
+	ErrNotRecording         = errors.New("session is not recording a macro")
+	ErrMacrosDisabled       = errors.New("macros are disabled on this coordinator")
+
+	ErrWorkingDirUnavailable = errors.New("live working directory unavailable")
 )
 
 // CoordinatorOptions configures the session coordinator.
@@ -74,6 +93,32 @@ type CoordinatorOptions struct {
 	Scrollback    uint32
 	KillTimeout   time.Duration
 	IdleThreshold time.Duration
+	Profiles      map[string]SpawnProfile
+	// CgroupRoot is a delegated cgroup v2 directory under which sessions
+	// with cgroup limits get their own leaf.
+	CgroupRoot string
+	// AllowedUIDs and AllowedGIDs list the ids spawns may request in
+	// Isolation.UID/GID. Requests for other ids are rejected.
+	AllowedUIDs []uint32
+	AllowedGIDs []uint32
+	// Term, ColorTerm and Locale set TERM, COLORTERM and LANG for sessions.
+	// Term defaults to xterm-ghostty when TerminfoDir is set, otherwise
+	// xterm-256color; Locale defaults to a UTF-8 LANG from the coordinator
+	// environment, otherwise C.UTF-8.
+	Term      string
+	ColorTerm string
+	Locale    string
+	// TerminfoDir holds the bundled terminfo installed by InstallTerminfo
+	// and is prepended to TERMINFO_DIRS for sessions.
+	TerminfoDir string
+	// ShellIntegration injects vtr's OSC 133/OSC 7 shell integration when a
+	// session runs an interactive bash, zsh or fish.
+	ShellIntegration bool
+	// ResizePolicy is the default for sessions that do not set one.
+	// Default: ResizeLatest.
+	ResizePolicy ResizePolicy
+	// MacroDir stores recorded input macros. Empty disables macros.
+	MacroDir string
 }
 
 // SpawnOptions configures a new session.
@@ -83,6 +128,23 @@ type SpawnOptions struct {
 	Env        []string
 	Cols       uint16
 	Rows       uint16
+	Tags       map[string]string
+	// IdleThreshold overrides the coordinator idle threshold when > 0.
+	IdleThreshold time.Duration
+	// Restart controls whether the process is restarted when it exits.
+	Restart RestartPolicy
+	// Isolation sandboxes the process when set.
+	Isolation *Isolation
+	// Term and Locale override the coordinator TERM and LANG; Env wins over
+	// both.
+	Term   string
+	Locale string
+	// ShellIntegration overrides CoordinatorOptions.ShellIntegration when set.
+	ShellIntegration *bool
+	// ResizePolicy overrides CoordinatorOptions.ResizePolicy when set.
+	ResizePolicy ResizePolicy
+	// Owner is the client id whose Resize calls apply under ResizeOwner.
+	Owner string
 }
 
 // SessionInfo reports session metadata and status.
@@ -97,6 +159,18 @@ type SessionInfo struct {
 	Order     uint32
 	CreatedAt time.Time
 	ExitedAt  time.Time
+	Tags      map[string]string
+	Restarts  int
+	// ShellIntegration reports whether vtr's shell integration was injected.
+	ShellIntegration bool
+	// InputLock is the unexpired input lock, if any.
+	InputLock *InputLock
+	// AttachedClients counts registered viewers (see AttachClient).
+	AttachedClients int
+	ResizePolicy    ResizePolicy
+	Owner           string
+	// BracketedPaste reports whether the program enabled bracketed paste.
+	BracketedPaste bool
 }
 
 // Coordinator manages named PTY sessions.
@@ -109,6 +183,9 @@ type Coordinator struct {
 	nextOrder uint32
 	changeMu  sync.Mutex
 	changeCh  chan struct{}
+
+	shellMu  sync.Mutex
+	shellDir string
 }
 
 // NewCoordinator creates a coordinator with defaults applied.
@@ -137,6 +214,24 @@ func NewCoordinator(opts CoordinatorOptions) *Coordinator {
 	if opts.IdleThreshold == 0 {
 		opts.IdleThreshold = 5 * time.Second
 	}
+	if opts.Term == "" {
+		if opts.TerminfoDir != "" {
+			opts.Term = GhosttyTerm
+		} else {
+			opts.Term = FallbackTerm
+		}
+	}
+	if opts.ColorTerm == "" {
+		opts.ColorTerm = defaultColorTerm
+	}
+	if opts.Locale == "" {
+		if opts.Locale = hostLocale(); opts.Locale == "" {
+			opts.Locale = defaultLocale
+		}
+	}
+	if opts.ResizePolicy == "" {
+		opts.ResizePolicy = ResizeLatest
+	}
 	return &Coordinator{
 		sessions: make(map[string]*Session),
 		labels:   make(map[string]string),
@@ -151,19 +246,40 @@ func (c *Coordinator) Spawn(label string, opts SpawnOptions) (*SessionInfo, erro
 	if label == "" {
 		return nil, ErrInvalidName
 	}
+	if err := validateTags(opts.Tags); err != nil {
+		return nil, err
+	}
+	if err := validateIsolation(opts.Isolation); err != nil {
+		return nil, err
+	}
+	if err := checkIsolationIDs(opts.Isolation, c.opts.AllowedUIDs, c.opts.AllowedGIDs); err != nil {
+		return nil, err
+	}
 
 	cmdArgs := opts.Command
 	if len(cmdArgs) == 0 {
 		cmdArgs = []string{c.opts.DefaultShell}
 	}
-	cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
-	if opts.WorkingDir != "" {
-		cmd.Dir = opts.WorkingDir
-	} else if home := defaultWorkingDir(); home != "" {
-		cmd.Dir = home
+	dir := opts.WorkingDir
+	if dir == "" {
+		dir = defaultWorkingDir()
+	}
+	spawnOpts := cloneSpawnOptions(opts)
+	spawnOpts.Command = append([]string(nil), cmdArgs...)
+	spawnOpts.WorkingDir = dir
+	env := c.sessionEnv(opts)
+	integrate := c.opts.ShellIntegration
+	if opts.ShellIntegration != nil {
+		integrate = *opts.ShellIntegration
 	}
-	if len(opts.Env) > 0 {
-		cmd.Env = mergeEnv(os.Environ(), opts.Env)
+	shellIntegration := false
+	if integrate {
+		cmdArgs, env, shellIntegration = c.injectShellIntegration(cmdArgs, env)
+	}
+	newCmd := commandFactory(cmdArgs, dir, env)
+	idleThreshold := opts.IdleThreshold
+	if idleThreshold <= 0 {
+		idleThreshold = c.opts.IdleThreshold
 	}
 
 	cols := opts.Cols
@@ -205,14 +321,31 @@ func (c *Coordinator) Spawn(label string, opts SpawnOptions) (*SessionInfo, erro
 	if err != nil {
 		return nil, err
 	}
-	ptyHandle, err := startPTY(cmd, cols, rows)
+	sb, err := newSandbox(id, opts.Isolation, c.opts.CgroupRoot)
 	if err != nil {
 		_ = vt.Close()
 		return nil, err
 	}
+	ptyHandle, err := startSandboxedPTY(newCmd, sb, cols, rows)
+	if err != nil {
+		sb.release()
+		_ = vt.Close()
+		return nil, err
+	}
 
 	order := atomic.AddUint32(&c.nextOrder, 1)
-	session := newSession(id, label, cols, rows, order, vt, ptyHandle, c.opts.IdleThreshold, c.signalSessionsChanged)
+	session := newSession(id, label, cols, rows, order, vt, ptyHandle, idleThreshold, c.signalSessionsChanged)
+	session.tags = cloneTags(opts.Tags)
+	session.newCmd = newCmd
+	session.restart = opts.Restart
+	session.sandbox = sb
+	session.shellIntegration = shellIntegration
+	session.spawnOpts = spawnOpts
+	session.resizePolicy = opts.ResizePolicy
+	if session.resizePolicy == "" {
+		session.resizePolicy = c.opts.ResizePolicy
+	}
+	session.owner = strings.TrimSpace(opts.Owner)
 
 	c.mu.Lock()
 	c.sessions[id] = session
@@ -291,6 +424,12 @@ func (c *Coordinator) DefaultShell() string {
 
 // Send writes bytes into a running session.
 func (c *Coordinator) Send(name string, data []byte) error {
+	return c.SendAs(name, InputSource{}, data)
+}
+
+// SendAs writes bytes into a running session on behalf of from. Writes fail
+// with ErrInputLocked while the session is locked to another token.
+func (c *Coordinator) SendAs(name string, from InputSource, data []byte) error {
 	session, err := c.getSession(name)
 	if err != nil {
 		return err
@@ -298,8 +437,12 @@ func (c *Coordinator) Send(name string, data []byte) error {
 	if !session.IsRunning() {
 		return ErrSessionNotRunning
 	}
-	_, err = session.pty.Write(data)
+	if err := c.acceptInput(session, from); err != nil {
+		return err
+	}
+	_, err = session.ptyHandle().Write(data)
 	if err == nil {
+		session.recordInput(from, data)
 		session.recordActivity()
 	}
 	return err
@@ -317,7 +460,7 @@ func (c *Coordinator) Resize(name string, cols, rows uint16) error {
 	if !session.IsRunning() {
 		return ErrSessionNotRunning
 	}
-	if err := session.pty.Resize(cols, rows); err != nil {
+	if err := session.ptyHandle().Resize(cols, rows); err != nil {
 		return err
 	}
 	if err := session.vt.Resize(uint32(cols), uint32(rows)); err != nil {
@@ -358,7 +501,7 @@ func (c *Coordinator) Kill(id string, sig os.Signal) error {
 	if sig == nil {
 		sig = syscall.SIGTERM
 	}
-	return session.pty.Signal(sig)
+	return session.ptyHandle().Signal(sig)
 }
 
 // Close sends SIGHUP and schedules a SIGKILL if the session does not exit.
@@ -373,7 +516,7 @@ func (c *Coordinator) Close(id string) error {
 	if session.MarkClosing() {
 		c.signalSessionsChanged()
 	}
-	if err := session.pty.SignalGroup(syscall.SIGHUP); err != nil {
+	if err := session.ptyHandle().SignalGroup(syscall.SIGHUP); err != nil {
 		return err
 	}
 	if c.opts.KillTimeout <= 0 {
@@ -384,7 +527,7 @@ func (c *Coordinator) Close(id string) error {
 		if session.IsExited() {
 			return
 		}
-		_ = session.pty.SignalGroup(syscall.SIGKILL)
+		_ = session.ptyHandle().SignalGroup(syscall.SIGKILL)
 	}()
 	return nil
 }
@@ -395,10 +538,11 @@ func (c *Coordinator) Remove(id string) error {
 	if err != nil {
 		return err
 	}
+	session.disableRestart()
 	if session.IsRunning() {
-		_ = session.pty.Signal(syscall.SIGTERM)
+		_ = session.ptyHandle().Signal(syscall.SIGTERM)
 		if !session.WaitExited(c.opts.KillTimeout) {
-			_ = session.pty.Signal(syscall.SIGKILL)
+			_ = session.ptyHandle().Signal(syscall.SIGKILL)
 			_ = session.WaitExited(c.opts.KillTimeout)
 		}
 	}
@@ -440,6 +584,25 @@ func (c *Coordinator) Rename(id, newLabel string) error {
 	return nil
 }
 
+// UpdateTags sets and removes session tags. Removals are applied after sets.
+func (c *Coordinator) UpdateTags(id string, set map[string]string, remove []string) (*SessionInfo, error) {
+	if err := validateTags(set); err != nil {
+		return nil, err
+	}
+	session, err := c.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(set) == 0 && len(remove) == 0 {
+		info := session.Info()
+		return &info, nil
+	}
+	session.updateTags(set, remove)
+	c.signalSessionsChanged()
+	info := session.Info()
+	return &info, nil
+}
+
 // LookupIDByLabel returns the session ID for the given label.
 func (c *Coordinator) LookupIDByLabel(label string) (string, error) {
 	label = strings.TrimSpace(label)
@@ -479,6 +642,7 @@ func (c *Coordinator) CloseAll() error {
 			firstErr = err
 		}
 	}
+	c.removeShellIntegrationDir()
 	return firstErr
 }
 
@@ -549,11 +713,27 @@ type Session struct {
 	onListChange func()
 
 	mu       sync.Mutex
+	tags     map[string]string
 	state    SessionState
 	exitCode int
 	exitedAt time.Time
 	finalSnapshot *Snapshot
 
+	newCmd   func() *exec.Cmd
+	restart  RestartPolicy
+	restarts int
+	sandbox  *sandbox
+	// shellIntegration and spawnOpts are set before start and never change.
+	shellIntegration bool
+	spawnOpts        SpawnOptions
+
+	inputLock      *InputLock
+	inputLockToken string
+	inputLockTimer *time.Timer
+	clients        map[string]*attachedClient
+	resizePolicy   ResizePolicy
+	owner          string
+
 	exitCh   chan struct{}
 	exitOnce sync.Once
 	closeOnce sync.Once
@@ -565,6 +745,11 @@ type Session struct {
 	outputCh    chan struct{}
 	lastOutput  time.Time
 
+	pasteMu sync.Mutex
+
+	macroMu sync.Mutex
+	macro   *macroRecorder
+
 	activityMu    sync.Mutex
 	lastActivity  time.Time
 	activityCh    chan struct{}
@@ -603,15 +788,24 @@ func newSession(id, label string, cols, rows uint16, order uint32, vt *VT, ptyHa
 }
 
 func (s *Session) start() {
+	s.mu.Lock()
 	s.ioDone = s.pty.StartReadLoop(s.vt, s.recordOutput, nil)
+	s.mu.Unlock()
 	go s.trackIdle()
 	go s.waitForExit()
 }
 
 func (s *Session) waitForExit() {
-	err := s.pty.Wait()
-	code := exitCodeFromErr(err, s.pty.ProcessState())
-	s.markExited(code)
+	for {
+		handle := s.ptyHandle()
+		err := handle.Wait()
+		code := exitCodeFromErr(err, handle.ProcessState())
+		if s.restartAfterExit(code) {
+			continue
+		}
+		s.markExited(code)
+		return
+	}
 }
 
 func (s *Session) markExited(code int) {
@@ -620,12 +814,14 @@ func (s *Session) markExited(code int) {
 		s.state = SessionExited
 		s.exitCode = code
 		s.exitedAt = time.Now()
+		s.clearInputLock()
 		s.mu.Unlock()
 		if s.onListChange != nil {
 			s.onListChange()
 		}
 		close(s.exitCh)
 		go s.closeAndCaptureSnapshot(500 * time.Millisecond)
+		go s.sandbox.release()
 	})
 }
 
@@ -641,8 +837,20 @@ func (s *Session) Info() SessionInfo {
 	order := s.order
 	createdAt := s.createdAt
 	exitedAt := s.exitedAt
+	tags := cloneTags(s.tags)
+	restarts := s.restarts
+	shellIntegration := s.shellIntegration
+	var inputLock *InputLock
+	if lock := s.activeInputLock(time.Now()); lock != nil {
+		copied := *lock
+		inputLock = &copied
+	}
+	attachedClients := len(s.clients)
+	resizePolicy := s.resizePolicy
+	owner := s.owner
 	s.mu.Unlock()
 	idle := s.isIdle()
+	bracketedPaste := s.BracketedPaste()
 	return SessionInfo{
 		ID:        id,
 		Label:     label,
@@ -654,6 +862,15 @@ func (s *Session) Info() SessionInfo {
 		Order:     order,
 		CreatedAt: createdAt,
 		ExitedAt:  exitedAt,
+		Tags:      tags,
+		Restarts:  restarts,
+
+		ShellIntegration: shellIntegration,
+		InputLock:        inputLock,
+		AttachedClients:  attachedClients,
+		ResizePolicy:     resizePolicy,
+		Owner:            owner,
+		BracketedPaste:   bracketedPaste,
 	}
 }
 
@@ -677,6 +894,23 @@ func (s *Session) SetLabel(label string) {
 	s.mu.Unlock()
 }
 
+func (s *Session) updateTags(set map[string]string, remove []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tags == nil {
+		s.tags = make(map[string]string, len(set))
+	}
+	for key, value := range set {
+		s.tags[key] = value
+	}
+	for _, key := range remove {
+		delete(s.tags, strings.TrimSpace(key))
+	}
+	if len(s.tags) == 0 {
+		s.tags = nil
+	}
+}
+
 func (s *Session) MarkClosing() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -768,6 +1002,49 @@ func (s *Session) Snapshot() (*Snapshot, error) {
 	return nil, errors.New("session: terminal not available")
 }
 
+// ScrollbackLines returns the number of lines in scrollback above the
+// viewport.
+func (s *Session) ScrollbackLines() (int, error) {
+	if s == nil || s.vt == nil {
+		return 0, errors.New("session: terminal not available")
+	}
+	return s.vt.ScrollbackRows()
+}
+
+// HistoryLines returns the scrollback text above the viewport, oldest first.
+func (s *Session) HistoryLines() ([]string, error) {
+	if s == nil || s.vt == nil {
+		return nil, errors.New("session: terminal not available")
+	}
+	dump, err := s.vt.Dump(DumpHistory, false)
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(dump), nil
+}
+
+// ForegroundProcess returns the name of the process group leader in the
+// foreground of the session's terminal, or "" when it has exited or the
+// platform does not expose it.
+func (s *Session) ForegroundProcess() string {
+	if !s.IsRunning() {
+		return ""
+	}
+	handle := s.ptyHandle()
+	if handle == nil {
+		return ""
+	}
+	pgrp, err := handle.ForegroundPgrp()
+	if err != nil || pgrp <= 0 {
+		return ""
+	}
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pgrp))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
+
 func (s *Session) Close(ioTimeout time.Duration) {
 	s.closeAndCaptureSnapshot(ioTimeout)
 }
@@ -778,12 +1055,12 @@ func (s *Session) closeAndCaptureSnapshot(ioTimeout time.Duration) *Snapshot {
 	}
 	var captured *Snapshot
 	s.closeOnce.Do(func() {
-		if s.pty != nil {
-			_ = s.pty.Close()
+		if handle := s.ptyHandle(); handle != nil {
+			_ = handle.Close()
 		}
-		if s.ioDone != nil {
+		if ioDone := s.ioDoneCh(); ioDone != nil {
 			select {
-			case <-s.ioDone:
+			case <-ioDone:
 			case <-time.After(ioTimeout):
 			}
 		}
@@ -181,6 +181,60 @@ func TestRemoveSession(t *testing.T) {
 	}
 }
 
+func TestSessionTags(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	tags := map[string]string{"owner": "agent-7", "task": "1234"}
+	info, err := coord.Spawn("tagged", SpawnOptions{
+		Command: []string{"/bin/sh", "-c", "sleep 5"},
+		Tags:    tags,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	tags["owner"] = "mutated"
+	if info.Tags["owner"] != "agent-7" || info.Tags["task"] != "1234" {
+		t.Fatalf("spawn tags=%v", info.Tags)
+	}
+
+	changed := coord.SessionsChanged()
+	updated, err := coord.UpdateTags(info.ID, map[string]string{"repo": "api"}, []string{"task"})
+	if err != nil {
+		t.Fatalf("UpdateTags: %v", err)
+	}
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatalf("expected sessions changed signal")
+	}
+	if updated.Tags["repo"] != "api" || updated.Tags["owner"] != "agent-7" {
+		t.Fatalf("updated tags=%v", updated.Tags)
+	}
+	if _, ok := updated.Tags["task"]; ok {
+		t.Fatalf("expected task tag removed, got %v", updated.Tags)
+	}
+
+	sel, err := ParseSelector("owner=agent-7,repo=api,status=running")
+	if err != nil {
+		t.Fatalf("ParseSelector: %v", err)
+	}
+	if !sel.MatchesInfo(*updated) {
+		t.Fatalf("expected selector to match %v", updated.Tags)
+	}
+
+	if _, err := coord.UpdateTags(info.ID, map[string]string{"name": "x"}, nil); !errors.Is(err, ErrInvalidTags) {
+		t.Fatalf("expected ErrInvalidTags, got %v", err)
+	}
+	if _, err := coord.Spawn("bad-tags", SpawnOptions{Tags: map[string]string{"bad key": "x"}}); !errors.Is(err, ErrInvalidTags) {
+		t.Fatalf("expected ErrInvalidTags, got %v", err)
+	}
+}
+
 func TestScreenCapture(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("pty tests not supported on windows")
@@ -305,3 +359,21 @@ func TestSpawnDefaultWorkingDir(t *testing.T) {
 
 	waitForDumpContains(t, coord, info.ID, tmpDir, 2*time.Second)
 }
+
+func TestSessionScrollbackLinesCountsVTRows(t *testing.T) {
+	vt, err := NewVT(10, 2, 100)
+	if err != nil {
+		t.Fatalf("NewVT: %v", err)
+	}
+	defer vt.Close()
+	session := &Session{vt: vt}
+	if lines, err := session.ScrollbackLines(); err != nil || lines != 0 {
+		t.Fatalf("ScrollbackLines=%d, %v; want 0", lines, err)
+	}
+	if _, err := vt.Feed([]byte("1\r\n2\r\n\r\n4\r\n5")); err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	if lines, err := session.ScrollbackLines(); err != nil || lines != 3 {
+		t.Fatalf("ScrollbackLines=%d, %v; want 3", lines, err)
+	}
+}
@@ -0,0 +1,328 @@
+package core
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/advait/vtrpc/internal/vt"
+)
+
+// ExportFormat selects how ExportScreen renders a session.
+type ExportFormat int
+
+const (
+	ExportHTML ExportFormat = iota
+	ExportSVG
+)
+
+// ExportOptions configures Coordinator.ExportScreen.
+type ExportOptions struct {
+	Format ExportFormat
+	// History exports scrollback and screen rows instead of the viewport.
+	// The cursor is drawn when it falls in the range.
+	History bool
+	// StartLine and EndLine select history lines [StartLine, EndLine).
+	// Negative values count from the last line; EndLine 0 means through the
+	// last line.
+	StartLine int
+	EndLine   int
+}
+
+const (
+	exportFontSize   = 14
+	exportCellWidth  = 8.4
+	exportLineHeight = 17
+	exportPadding    = 8
+)
+
+var (
+	exportDefaultFg = color.RGBA{R: 0xd4, G: 0xd4, B: 0xd4, A: 0xff}
+	exportDefaultBg = color.RGBA{R: 0x1e, G: 0x1e, B: 0x1e, A: 0xff}
+)
+
+// exportCell is one rendered glyph. Width is 2 for wide characters, whose
+// spacer cells are dropped.
+type exportCell struct {
+	text  string
+	width int
+	fg    color.RGBA
+	bg    color.RGBA
+	attrs vt.Attrs
+}
+
+type exportGrid struct {
+	title   string
+	cols    int
+	rows    [][]exportCell
+	cursorX int
+	cursorY int
+}
+
+// ExportScreen renders the viewport, or a range of history, as a
+// self-contained HTML document or SVG image.
+func (c *Coordinator) ExportScreen(id string, opts ExportOptions) ([]byte, error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	var snap *Snapshot
+	if opts.History {
+		snap, err = session.vt.ScreenSnapshot(opts.StartLine, opts.EndLine)
+	} else {
+		snap, err = session.Snapshot()
+	}
+	if err != nil {
+		return nil, err
+	}
+	grid := exportGridFromSnapshot(snap)
+	grid.title = session.Label()
+	switch opts.Format {
+	case ExportHTML:
+		return []byte(renderExportHTML(grid)), nil
+	case ExportSVG:
+		return []byte(renderExportSVG(grid)), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %d", opts.Format)
+	}
+}
+
+func exportGridFromSnapshot(snap *Snapshot) *exportGrid {
+	grid := &exportGrid{cursorX: -1, cursorY: -1}
+	if snap == nil || snap.Cols <= 0 || snap.Rows <= 0 {
+		return grid
+	}
+	grid.cols = snap.Cols
+	if snap.CursorVisible {
+		grid.cursorX, grid.cursorY = snap.CursorX, snap.CursorY
+	}
+	grid.rows = make([][]exportCell, snap.Rows)
+	for row := 0; row < snap.Rows; row++ {
+		cells := make([]exportCell, 0, snap.Cols)
+		for col := 0; col < snap.Cols; col++ {
+			idx := row*snap.Cols + col
+			if idx >= len(snap.Cells) {
+				break
+			}
+			cell := snap.Cells[idx]
+			if cell.Wide == vt.WideSpacerTail || cell.Wide == vt.WideSpacerHead {
+				continue
+			}
+			out := exportCell{text: " ", width: 1, fg: cell.Fg, bg: cell.Bg, attrs: cell.Attrs}
+			if cell.Rune != 0 {
+				out.text = string(cell.Rune)
+			}
+			if cell.Wide == vt.WideWide {
+				out.width = 2
+			}
+			cells = append(cells, out)
+		}
+		grid.rows[row] = cells
+	}
+	return grid
+}
+
+// style resolves the colors a cell is drawn with, applying inverse and
+// invisible. A zero RGB value is the terminal default.
+func (cell exportCell) style() (fg, bg color.RGBA, fgSet, bgSet bool) {
+	fg, bg = cell.fg, cell.bg
+	fgSet = fg.R|fg.G|fg.B != 0
+	bgSet = bg.R|bg.G|bg.B != 0
+	if !fgSet {
+		fg = exportDefaultFg
+	}
+	if !bgSet {
+		bg = exportDefaultBg
+	}
+	if cell.attrs&vt.AttrInverse != 0 {
+		fg, bg = bg, fg
+		fgSet, bgSet = true, true
+	}
+	if cell.attrs&vt.AttrInvisible != 0 {
+		fg, fgSet = bg, bgSet
+	}
+	return fg, bg, fgSet, bgSet
+}
+
+// sameStyle reports whether two cells can share one HTML span or SVG text run.
+func (cell exportCell) sameStyle(other exportCell) bool {
+	return cell.fg == other.fg && cell.bg == other.bg && cell.attrs == other.attrs
+}
+
+// cursorCell returns the cell drawn under the cursor: inverted, like a
+// block cursor.
+func (cell exportCell) cursorCell() exportCell {
+	cell.attrs ^= vt.AttrInverse
+	return cell
+}
+
+func cssColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func exportTextDecoration(attrs vt.Attrs) string {
+	var parts []string
+	if attrs&vt.AttrUnderline != 0 {
+		parts = append(parts, "underline")
+	}
+	if attrs&vt.AttrStrikethrough != 0 {
+		parts = append(parts, "line-through")
+	}
+	if attrs&vt.AttrOverline != 0 {
+		parts = append(parts, "overline")
+	}
+	return strings.Join(parts, " ")
+}
+
+// exportRuns splits a row into runs of cells with the same style, giving the
+// cursor cell its own run.
+func (g *exportGrid) exportRuns(row int) [][]exportCell {
+	var runs [][]exportCell
+	col := 0
+	split := true
+	for _, cell := range g.rows[row] {
+		cursor := row == g.cursorY && col == g.cursorX
+		if cursor {
+			cell = cell.cursorCell()
+		}
+		if n := len(runs); !split && !cursor && runs[n-1][0].sameStyle(cell) {
+			runs[n-1] = append(runs[n-1], cell)
+		} else {
+			runs = append(runs, []exportCell{cell})
+		}
+		split = cursor
+		col += cell.width
+	}
+	if row == g.cursorY && g.cursorX >= col {
+		// The cursor sits past the last glyph of the row.
+		if g.cursorX > col {
+			runs = append(runs, []exportCell{{text: strings.Repeat(" ", g.cursorX-col), width: g.cursorX - col}})
+		}
+		runs = append(runs, []exportCell{exportCell{text: " ", width: 1}.cursorCell()})
+	}
+	return runs
+}
+
+func renderExportHTML(g *exportGrid) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(g.title))
+	fmt.Fprintf(&b, "<style>\nbody { margin: 0; background: %s; }\n", cssColor(exportDefaultBg))
+	fmt.Fprintf(&b, "pre.vtr { margin: 0; padding: %dpx; color: %s; background: %s; "+
+		"font: %dpx/%dpx ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }\n",
+		exportPadding, cssColor(exportDefaultFg), cssColor(exportDefaultBg), exportFontSize, exportLineHeight)
+	b.WriteString("pre.vtr span.w { display: inline-block; width: 2ch; }\n</style>\n</head>\n<body>\n<pre class=\"vtr\">")
+	for row := range g.rows {
+		if row > 0 {
+			b.WriteByte('\n')
+		}
+		for _, run := range g.exportRuns(row) {
+			writeExportHTMLRun(&b, run)
+		}
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+	return b.String()
+}
+
+func writeExportHTMLRun(b *strings.Builder, run []exportCell) {
+	var css []string
+	fg, bg, fgSet, bgSet := run[0].style()
+	if fgSet {
+		css = append(css, "color:"+cssColor(fg))
+	}
+	if bgSet {
+		css = append(css, "background:"+cssColor(bg))
+	}
+	attrs := run[0].attrs
+	if attrs&vt.AttrBold != 0 {
+		css = append(css, "font-weight:bold")
+	}
+	if attrs&vt.AttrItalic != 0 {
+		css = append(css, "font-style:italic")
+	}
+	if attrs&vt.AttrFaint != 0 {
+		css = append(css, "opacity:0.5")
+	}
+	if deco := exportTextDecoration(attrs); deco != "" {
+		css = append(css, "text-decoration:"+deco)
+	}
+	if len(css) > 0 {
+		fmt.Fprintf(b, "<span style=\"%s\">", strings.Join(css, ";"))
+	}
+	for _, cell := range run {
+		if cell.width == 2 {
+			// Pin wide glyphs to two cells so fallback fonts keep the grid.
+			fmt.Fprintf(b, "<span class=\"w\">%s</span>", html.EscapeString(cell.text))
+			continue
+		}
+		b.WriteString(html.EscapeString(cell.text))
+	}
+	if len(css) > 0 {
+		b.WriteString("</span>")
+	}
+}
+
+func renderExportSVG(g *exportGrid) string {
+	width := float64(g.cols)*exportCellWidth + 2*exportPadding
+	height := len(g.rows)*exportLineHeight + 2*exportPadding
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%d\" viewBox=\"0 0 %s %d\" "+
+		"font-family=\"ui-monospace, SFMono-Regular, Menlo, Consolas, monospace\" font-size=\"%d\">\n",
+		svgNum(width), height, svgNum(width), height, exportFontSize)
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(g.title))
+	fmt.Fprintf(&b, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", cssColor(exportDefaultBg))
+	for row := range g.rows {
+		y := exportPadding + row*exportLineHeight
+		col := 0
+		for _, run := range g.exportRuns(row) {
+			cols := 0
+			for _, cell := range run {
+				cols += cell.width
+			}
+			writeExportSVGRun(&b, run, col, cols, y)
+			col += cols
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+func writeExportSVGRun(b *strings.Builder, run []exportCell, col, cols, y int) {
+	fg, bg, _, bgSet := run[0].style()
+	x := exportPadding + float64(col)*exportCellWidth
+	w := float64(cols) * exportCellWidth
+	if bgSet {
+		fmt.Fprintf(b, "<rect x=\"%s\" y=\"%d\" width=\"%s\" height=\"%d\" fill=\"%s\"/>\n",
+			svgNum(x), y, svgNum(w), exportLineHeight, cssColor(bg))
+	}
+	var text strings.Builder
+	for _, cell := range run {
+		text.WriteString(cell.text)
+	}
+	if strings.TrimSpace(text.String()) == "" {
+		return
+	}
+	attrs := run[0].attrs
+	fmt.Fprintf(b, "<text x=\"%s\" y=\"%d\" fill=\"%s\" textLength=\"%s\" lengthAdjust=\"spacingAndGlyphs\" xml:space=\"preserve\"",
+		svgNum(x), y+exportLineHeight-4, cssColor(fg), svgNum(w))
+	if attrs&vt.AttrBold != 0 {
+		b.WriteString(" font-weight=\"bold\"")
+	}
+	if attrs&vt.AttrItalic != 0 {
+		b.WriteString(" font-style=\"italic\"")
+	}
+	if attrs&vt.AttrFaint != 0 {
+		b.WriteString(" opacity=\"0.5\"")
+	}
+	if deco := exportTextDecoration(attrs); deco != "" {
+		fmt.Fprintf(b, " text-decoration=\"%s\"", deco)
+	}
+	fmt.Fprintf(b, ">%s</text>\n", html.EscapeString(text.String()))
+}
+
+func svgNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
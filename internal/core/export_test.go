@@ -0,0 +1,109 @@
+package core
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/advait/vtrpc/internal/vt"
+)
+
+func TestExportSnapshot(t *testing.T) {
+	snap := snapshotFromRows([]string{"ok <go>", ""})
+	red := color.RGBA{R: 0xff, A: 0xff}
+	for x := 0; x < 2; x++ {
+		snap.Cells[x].Fg = red
+		snap.Cells[x].Attrs = vt.AttrBold
+	}
+	copy(snap.Cells[snap.Cols:], []Cell{
+		{Rune: '漢', Wide: vt.WideWide}, {Wide: vt.WideSpacerTail}, {Rune: 'x'},
+	})
+	snap.CursorVisible = true
+	snap.CursorX, snap.CursorY = 3, 1
+
+	grid := exportGridFromSnapshot(snap)
+	grid.title = "demo"
+	page := renderExportHTML(grid)
+	for _, want := range []string{
+		"<title>demo</title>",
+		`<span style="color:#ff0000;font-weight:bold">ok</span> &lt;go&gt;`,
+		`<span class="w">漢</span>x`,
+		// The cursor is drawn inverted after "x".
+		`<span style="color:#1e1e1e;background:#d4d4d4"> </span>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("html missing %q:\n%s", want, page)
+		}
+	}
+
+	image := renderExportSVG(grid)
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="74.8" height="50"`,
+		`<text x="8" y="21" fill="#ff0000" textLength="16.8" lengthAdjust="spacingAndGlyphs" xml:space="preserve" font-weight="bold">ok</text>`,
+		// 漢 spans two cells, so x starts at the fourth cell.
+		`<text x="8" y="38" fill="#d4d4d4" textLength="25.2"`,
+		`<rect x="33.2" y="25" width="8.4" height="17" fill="#d4d4d4"/>`,
+	} {
+		if !strings.Contains(image, want) {
+			t.Fatalf("svg missing %q:\n%s", want, image)
+		}
+	}
+}
+
+func TestExportHistoryRange(t *testing.T) {
+	vt, err := NewVT(10, 2, 100)
+	if err != nil {
+		t.Fatalf("NewVT: %v", err)
+	}
+	defer vt.Close()
+	if _, err := vt.Feed([]byte("one\r\n\x1b[31mtwo\x1b[0m\r\nthree\r\nfour")); err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	for _, tc := range []struct {
+		start, end int
+		want       string
+	}{
+		{0, 0, "one,two,three,four"},
+		{1, 3, "two,three"},
+		{-2, 0, "three,four"},
+		{0, -3, "one"},
+		{5, 0, ""},
+	} {
+		snap, err := vt.ScreenSnapshot(tc.start, tc.end)
+		if err != nil {
+			t.Fatalf("ScreenSnapshot: %v", err)
+		}
+		grid := exportGridFromSnapshot(snap)
+		var lines []string
+		for _, row := range grid.rows {
+			var line strings.Builder
+			for _, cell := range row {
+				line.WriteString(cell.text)
+			}
+			lines = append(lines, strings.TrimRight(line.String(), " "))
+		}
+		if got := strings.Join(lines, ","); got != tc.want {
+			t.Fatalf("range %d:%d=%q, want %q", tc.start, tc.end, got, tc.want)
+		}
+	}
+
+	// History keeps its colors, and the cursor is drawn only in range.
+	snap, err := vt.ScreenSnapshot(1, 2)
+	if err != nil {
+		t.Fatalf("ScreenSnapshot: %v", err)
+	}
+	grid := exportGridFromSnapshot(snap)
+	if fg := grid.rows[0][0].fg; fg.R == 0 || fg.G != 0 {
+		t.Fatalf("history fg=%v, want red", fg)
+	}
+	if grid.cursorY != -1 {
+		t.Fatalf("cursor drawn outside the range at row %d", grid.cursorY)
+	}
+	snap, err = vt.ScreenSnapshot(-1, 0)
+	if err != nil {
+		t.Fatalf("ScreenSnapshot: %v", err)
+	}
+	if grid := exportGridFromSnapshot(snap); grid.cursorX != 4 || grid.cursorY != 0 {
+		t.Fatalf("cursor=%d,%d, want 4,0", grid.cursorX, grid.cursorY)
+	}
+}
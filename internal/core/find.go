@@ -0,0 +1,129 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"regexp"
+	"strings"
+
+	"github.com/advait/vtrpc/internal/vt"
+)
+
+// ScreenRect is a region of the viewport in 0-based cells. A zero Width or
+// Height extends the region to the edge of the screen.
+type ScreenRect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// ScreenMatch is a regex match on one viewport row. Col and EndCol are cell
+// columns, EndCol exclusive, so a wide character counts as two cells. The
+// colors and attributes are those of the first matched cell.
+type ScreenMatch struct {
+	Row    int
+	Col    int
+	EndCol int
+	Text   string
+	Fg     color.RGBA
+	Bg     color.RGBA
+	Attrs  vt.Attrs
+}
+
+// FindOnScreen searches the rendered viewport rows for re and reports where
+// each match is. Matches do not span rows.
+func (c *Coordinator) FindOnScreen(id string, re *regexp.Regexp, rect ScreenRect, maxMatches int) ([]ScreenMatch, error) {
+	if re == nil {
+		return nil, errors.New("find pattern is required")
+	}
+	if maxMatches <= 0 {
+		maxMatches = 100
+	}
+	session, err := c.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := session.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return findInSnapshot(snap, re, rect, maxMatches)
+}
+
+func findInSnapshot(snap *Snapshot, re *regexp.Regexp, rect ScreenRect, maxMatches int) ([]ScreenMatch, error) {
+	if snap == nil || snap.Cols <= 0 || snap.Rows <= 0 {
+		return nil, nil
+	}
+	if rect.X < 0 || rect.Y < 0 || rect.Width < 0 || rect.Height < 0 ||
+		rect.X >= snap.Cols || rect.Y >= snap.Rows {
+		return nil, fmt.Errorf("%w: %d,%d %dx%d on a %dx%d screen",
+			ErrInvalidRect, rect.X, rect.Y, rect.Width, rect.Height, snap.Cols, snap.Rows)
+	}
+	right := snap.Cols
+	if rect.Width > 0 {
+		right = min(rect.X+rect.Width, snap.Cols)
+	}
+	bottom := snap.Rows
+	if rect.Height > 0 {
+		bottom = min(rect.Y+rect.Height, snap.Rows)
+	}
+
+	matches := make([]ScreenMatch, 0)
+	var text strings.Builder
+	// cols maps each byte offset of the row text to the cell it came from,
+	// with one extra entry for the end of the row.
+	cols := make([]int, 0, right-rect.X+1)
+	for row := rect.Y; row < bottom; row++ {
+		text.Reset()
+		cols = cols[:0]
+		cells := snap.Cells[row*snap.Cols : (row+1)*snap.Cols]
+		for col := rect.X; col < right; col++ {
+			cell := cells[col]
+			if cell.Wide == vt.WideSpacerTail || cell.Wide == vt.WideSpacerHead {
+				continue
+			}
+			r := cell.Rune
+			if r == 0 {
+				r = ' '
+			}
+			n, _ := text.WriteRune(r)
+			for i := 0; i < n; i++ {
+				cols = append(cols, col)
+			}
+		}
+		cols = append(cols, right)
+		line := text.String()
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			start := cols[loc[0]]
+			cell := cells[start]
+			matches = append(matches, ScreenMatch{
+				Row:    row,
+				Col:    start,
+				EndCol: matchEndCol(cells, cols, loc[1], right),
+				Text:   line[loc[0]:loc[1]],
+				Fg:     cell.Fg,
+				Bg:     cell.Bg,
+				Attrs:  cell.Attrs,
+			})
+			if len(matches) >= maxMatches {
+				return matches, nil
+			}
+		}
+	}
+	return matches, nil
+}
+
+// matchEndCol returns the cell after the last rune of a match ending at byte
+// offset end, accounting for the spacer cell of a wide last rune.
+func matchEndCol(cells []Cell, cols []int, end, right int) int {
+	last := cols[end-1]
+	if cells[last].Wide == vt.WideWide {
+		return min(last+2, right)
+	}
+	return last + 1
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/advait/vtrpc/internal/vt"
+)
+
+func snapshotFromRows(rows []string) *Snapshot {
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len([]rune(row)))
+	}
+	snap := &Snapshot{Cols: cols, Rows: len(rows), Cells: make([]Cell, cols*len(rows))}
+	for y, row := range rows {
+		x := 0
+		for _, r := range row {
+			snap.Cells[y*cols+x] = Cell{Rune: r}
+			x++
+		}
+	}
+	return snap
+}
+
+func TestFindInSnapshot(t *testing.T) {
+	snap := snapshotFromRows([]string{
+		"File  Edit  View",
+		"> Save   Save As",
+		"",
+	})
+	// Lay the last row out as a terminal would: each wide rune is followed
+	// by a spacer cell.
+	row := snap.Cells[2*snap.Cols : 3*snap.Cols]
+	copy(row, []Cell{
+		{Rune: '漢', Wide: vt.WideWide}, {Wide: vt.WideSpacerTail},
+		{Rune: '_'},
+		{Rune: '字', Wide: vt.WideWide}, {Wide: vt.WideSpacerTail},
+		{Rune: ' '}, {Rune: 'S'}, {Rune: 'a'}, {Rune: 'v'}, {Rune: 'e'},
+	})
+	for x := 2; x < 6; x++ {
+		snap.Cells[1*snap.Cols+x].Attrs = vt.Attrs(1 << 5)
+	}
+
+	matches, err := findInSnapshot(snap, regexp.MustCompile(`Save`), ScreenRect{}, 100)
+	if err != nil {
+		t.Fatalf("findInSnapshot: %v", err)
+	}
+	want := []ScreenMatch{
+		{Row: 1, Col: 2, EndCol: 6, Text: "Save", Attrs: vt.Attrs(1 << 5)},
+		{Row: 1, Col: 9, EndCol: 13, Text: "Save"},
+		{Row: 2, Col: 6, EndCol: 10, Text: "Save"},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("matches=%+v, want %+v", matches, want)
+	}
+	for i := range want {
+		if matches[i] != want[i] {
+			t.Fatalf("match %d=%+v, want %+v", i, matches[i], want[i])
+		}
+	}
+
+	matches, err = findInSnapshot(snap, regexp.MustCompile(`漢_字`), ScreenRect{}, 100)
+	if err != nil || len(matches) != 1 || matches[0].Col != 0 || matches[0].EndCol != 5 {
+		t.Fatalf("wide match=%+v, %v", matches, err)
+	}
+
+	matches, err = findInSnapshot(snap, regexp.MustCompile(`Save`), ScreenRect{X: 6, Y: 1, Height: 1}, 100)
+	if err != nil || len(matches) != 1 || matches[0].Col != 9 {
+		t.Fatalf("rect matches=%+v, %v", matches, err)
+	}
+	matches, err = findInSnapshot(snap, regexp.MustCompile(`Save`), ScreenRect{X: 3, Y: 1, Width: 4}, 100)
+	if err != nil || len(matches) != 0 {
+		t.Fatalf("clipped matches=%+v, %v", matches, err)
+	}
+
+	matches, err = findInSnapshot(snap, regexp.MustCompile(`Save`), ScreenRect{}, 1)
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("max matches=%+v, %v", matches, err)
+	}
+
+	if _, err := findInSnapshot(snap, regexp.MustCompile(`x`), ScreenRect{Y: 3}, 100); !errors.Is(err, ErrInvalidRect) {
+		t.Fatalf("expected ErrInvalidRect, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultInputLockLease is used when AcquireInputLock gets no lease.
+	DefaultInputLockLease = 30 * time.Second
+	// MaxInputLockLease caps a single lease; holders renew by acquiring again.
+	MaxInputLockLease = 10 * time.Minute
+)
+
+// InputLock reports who holds exclusive input for a session. It carries only
+// the holder's display name; the lock itself is bound to a token that
+// AcquireInputLock hands to the holder and that is never listed.
+type InputLock struct {
+	Holder    string
+	ExpiresAt time.Time
+}
+
+// InputSource identifies the sender of input. ClientID is the client's
+// self-reported id and LockToken the token AcquireInputLock issued, if any.
+// Only LockToken is checked against the input lock.
+type InputSource struct {
+	ClientID  string
+	LockToken string
+}
+
+// AcquireInputLock grants exclusive input for lease and returns the lock with
+// a new token. Passing the token of the current lock renews it instead; a
+// token whose lock already expired is replaced by a new one. It fails with
+// ErrInputLocked while another token holds an unexpired lock.
+func (c *Coordinator) AcquireInputLock(id, token, holder string, lease time.Duration) (*InputLock, string, error) {
+	token = strings.TrimSpace(token)
+	holder = strings.TrimSpace(holder)
+	if holder == "" {
+		holder = "anonymous"
+	}
+	if lease <= 0 {
+		lease = DefaultInputLockLease
+	}
+	if lease > MaxInputLockLease {
+		lease = MaxInputLockLease
+	}
+	session, err := c.getSession(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if !session.IsRunning() {
+		return nil, "", ErrSessionNotRunning
+	}
+
+	now := time.Now()
+	session.mu.Lock()
+	if lock := session.activeInputLock(now); lock != nil {
+		if token == "" || token != session.inputLockToken {
+			session.mu.Unlock()
+			return nil, "", inputLockedError(lock)
+		}
+	} else {
+		token = rand.Text()
+	}
+	lock := &InputLock{Holder: holder, ExpiresAt: now.Add(lease)}
+	session.inputLock = lock
+	session.inputLockToken = token
+	if session.inputLockTimer != nil {
+		session.inputLockTimer.Stop()
+	}
+	// Publish the expiry so session lists drop the holder without polling.
+	session.inputLockTimer = time.AfterFunc(lease, c.signalSessionsChanged)
+	out := *lock
+	session.mu.Unlock()
+
+	c.signalSessionsChanged()
+	return &out, token, nil
+}
+
+// ReleaseInputLock drops the lock issued with token. Releasing an unlocked or
+// expired session is a no-op.
+func (c *Coordinator) ReleaseInputLock(id, token string) error {
+	session, err := c.getSession(id)
+	if err != nil {
+		return err
+	}
+	session.mu.Lock()
+	lock := session.activeInputLock(time.Now())
+	if lock == nil {
+		session.mu.Unlock()
+		return nil
+	}
+	if session.inputLockToken != strings.TrimSpace(token) {
+		session.mu.Unlock()
+		return inputLockedError(lock)
+	}
+	session.clearInputLock()
+	session.mu.Unlock()
+
+	c.signalSessionsChanged()
+	return nil
+}
+
+// acceptInput admits a write from `from` and, under the latest resize
+// policy, sizes the session to the client that sent it.
+func (c *Coordinator) acceptInput(session *Session, from InputSource) error {
+	if err := session.checkInput(from); err != nil {
+		return err
+	}
+	c.applyViewportPolicy(session)
+	return nil
+}
+
+// checkInput rejects writes that do not carry the lock token while the
+// session is locked, and records accepted input against the attachments of
+// from.ClientID.
+func (s *Session) checkInput(from InputSource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if err := s.checkLockLocked(from, now); err != nil {
+		return err
+	}
+	if clientID := strings.TrimSpace(from.ClientID); clientID != "" {
+		for _, client := range s.clients {
+			if client.info.ClientID == clientID {
+				client.lastInput = now
+			}
+		}
+	}
+	return nil
+}
+
+// checkLock is checkInput for requests that are not input, such as Resize.
+func (s *Session) checkLock(from InputSource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkLockLocked(from, time.Now())
+}
+
+func (s *Session) checkLockLocked(from InputSource, now time.Time) error {
+	lock := s.activeInputLock(now)
+	if lock != nil && s.inputLockToken != strings.TrimSpace(from.LockToken) {
+		return inputLockedError(lock)
+	}
+	return nil
+}
+
+// activeInputLock returns the unexpired lock. Callers hold s.mu.
+func (s *Session) activeInputLock(now time.Time) *InputLock {
+	if s.inputLock == nil || !now.Before(s.inputLock.ExpiresAt) {
+		return nil
+	}
+	return s.inputLock
+}
+
+// clearInputLock drops the lock and its expiry timer. Callers hold s.mu.
+func (s *Session) clearInputLock() {
+	s.inputLock = nil
+	s.inputLockToken = ""
+	if s.inputLockTimer != nil {
+		s.inputLockTimer.Stop()
+		s.inputLockTimer = nil
+	}
+}
+
+func inputLockedError(lock *InputLock) error {
+	return fmt.Errorf("%w by %s", ErrInputLocked, lock.Holder)
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInputLockRejectsOtherWriters(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("locked", SpawnOptions{Command: []string{"/bin/sh", "-c", "cat"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	lock, token, err := coord.AcquireInputLock(info.ID, "", "deploy bot", time.Minute)
+	if err != nil {
+		t.Fatalf("AcquireInputLock: %v", err)
+	}
+	if lock.Holder != "deploy bot" || time.Until(lock.ExpiresAt) <= 0 || token == "" {
+		t.Fatalf("unexpected lock %+v token %q", lock, token)
+	}
+	snapshot, err := coord.Info(info.ID)
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if snapshot.InputLock == nil || snapshot.InputLock.Holder != "deploy bot" {
+		t.Fatalf("expected lock in Info, got %+v", snapshot.InputLock)
+	}
+
+	holder := InputSource{ClientID: "agent-1", LockToken: token}
+	if err := coord.SendAs(info.ID, holder, []byte("mine\n")); err != nil {
+		t.Fatalf("SendAs holder: %v", err)
+	}
+	// The client id of the holder is not a credential.
+	err = coord.SendAs(info.ID, InputSource{ClientID: "agent-1"}, []byte("theirs\n"))
+	if !errors.Is(err, ErrInputLocked) || !strings.Contains(err.Error(), "deploy bot") {
+		t.Fatalf("expected ErrInputLocked naming the holder, got %v", err)
+	}
+	if err := coord.Send(info.ID, []byte("anonymous\n")); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected anonymous Send to be locked out, got %v", err)
+	}
+	if _, _, err := coord.ResizeFrom(info.ID, InputSource{ClientID: "human"}, 100, 30); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected resize to be locked out, got %v", err)
+	}
+	if _, _, err := coord.ResizeFrom(info.ID, holder, 100, 30); err != nil {
+		t.Fatalf("ResizeFrom holder: %v", err)
+	}
+	if _, _, err := coord.AcquireInputLock(info.ID, "", "", 0); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected second acquire to fail, got %v", err)
+	}
+	if _, _, err := coord.AcquireInputLock(info.ID, "guess", "", 0); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected acquire with a wrong token to fail, got %v", err)
+	}
+	if err := coord.ReleaseInputLock(info.ID, "guess"); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected release by non-holder to fail, got %v", err)
+	}
+
+	if err := coord.ReleaseInputLock(info.ID, token); err != nil {
+		t.Fatalf("ReleaseInputLock: %v", err)
+	}
+	if err := coord.SendAs(info.ID, InputSource{ClientID: "human"}, []byte("free\n")); err != nil {
+		t.Fatalf("SendAs after release: %v", err)
+	}
+	if err := coord.ReleaseInputLock(info.ID, token); err != nil {
+		t.Fatalf("expected release of unlocked session to be a no-op, got %v", err)
+	}
+}
+
+func TestInputLockLeaseExpires(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("lease", SpawnOptions{Command: []string{"/bin/sh", "-c", "cat"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	first, token, err := coord.AcquireInputLock(info.ID, "", "", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireInputLock: %v", err)
+	}
+	if first.Holder != "anonymous" {
+		t.Fatalf("expected a default holder name, got %q", first.Holder)
+	}
+	renewed, renewedToken, err := coord.AcquireInputLock(info.ID, token, "", 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("renew: %v", err)
+	}
+	if !renewed.ExpiresAt.After(first.ExpiresAt) || renewedToken != token {
+		t.Fatalf("expected renewal to extend the lease and keep the token")
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if err := coord.SendAs(info.ID, InputSource{ClientID: "human"}, []byte("after\n")); err != nil {
+		t.Fatalf("expected expired lock to allow input, got %v", err)
+	}
+	snapshot, err := coord.Info(info.ID)
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if snapshot.InputLock != nil {
+		t.Fatalf("expected expired lock to be hidden, got %+v", snapshot.InputLock)
+	}
+	_, next, err := coord.AcquireInputLock(info.ID, token, "", 0)
+	if err != nil {
+		t.Fatalf("acquire after expiry: %v", err)
+	}
+	if next == token {
+		t.Fatalf("expected a lapsed token to be replaced")
+	}
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"slices"
+)
+
+// Isolation confines a session process. Zero values disable each control.
+// Isolation is only implemented on Linux; other platforms reject it with
+// ErrIsolationUnavailable.
+type Isolation struct {
+	// cgroup v2 limits applied to a per-session leaf under
+	// CoordinatorOptions.CgroupRoot.
+	CPUMax    float64 // CPUs, e.g. 0.5
+	MemoryMax int64   // bytes
+	PidsMax   int64
+
+	UserNS  bool
+	MountNS bool
+	PIDNS   bool
+	NetNS   bool
+
+	Rlimits []Rlimit
+
+	// UID and GID run the process as another user. With UserNS they are ids
+	// inside the namespace, mapped to the coordinator user.
+	UID *uint32
+	GID *uint32
+}
+
+// Rlimit is a resource limit applied to the session process.
+type Rlimit struct {
+	Resource string
+	Soft     uint64
+	Hard     uint64
+}
+
+func (iso *Isolation) needsCgroup() bool {
+	return iso != nil && (iso.CPUMax > 0 || iso.MemoryMax > 0 || iso.PidsMax > 0)
+}
+
+func validateIsolation(iso *Isolation) error {
+	if iso == nil {
+		return nil
+	}
+	if iso.CPUMax < 0 || math.IsNaN(iso.CPUMax) || math.IsInf(iso.CPUMax, 0) {
+		return fmt.Errorf("%w: cpu_max must be >= 0", ErrInvalidIsolation)
+	}
+	if iso.MemoryMax < 0 {
+		return fmt.Errorf("%w: memory_max must be >= 0", ErrInvalidIsolation)
+	}
+	if iso.PidsMax < 0 {
+		return fmt.Errorf("%w: pids_max must be >= 0", ErrInvalidIsolation)
+	}
+	for _, limit := range iso.Rlimits {
+		if _, ok := rlimitNames[limit.Resource]; !ok {
+			return fmt.Errorf("%w: unknown rlimit %q", ErrInvalidIsolation, limit.Resource)
+		}
+		if limit.Hard != 0 && limit.Soft > limit.Hard {
+			return fmt.Errorf("%w: rlimit %q soft limit exceeds hard limit", ErrInvalidIsolation, limit.Resource)
+		}
+	}
+	return nil
+}
+
+// checkIsolationIDs rejects uid/gid requests outside the coordinator's
+// allowlists (CoordinatorOptions.AllowedUIDs/AllowedGIDs).
+func checkIsolationIDs(iso *Isolation, uids, gids []uint32) error {
+	if iso == nil {
+		return nil
+	}
+	if iso.UID != nil && !slices.Contains(uids, *iso.UID) {
+		return fmt.Errorf("%w: uid %d is not allowed on this coordinator (--allow-uid)", ErrIsolationUnavailable, *iso.UID)
+	}
+	if iso.GID != nil && !slices.Contains(gids, *iso.GID) {
+		return fmt.Errorf("%w: gid %d is not allowed on this coordinator (--allow-gid)", ErrIsolationUnavailable, *iso.GID)
+	}
+	return nil
+}
+
+// rlimitNames lists the supported rlimit resources.
+var rlimitNames = map[string]struct{}{
+	"cpu":     {},
+	"fsize":   {},
+	"data":    {},
+	"stack":   {},
+	"core":    {},
+	"nproc":   {},
+	"nofile":  {},
+	"memlock": {},
+	"as":      {},
+}
+
+// startSandboxedPTY starts a fresh command from newCmd inside sb.
+func startSandboxedPTY(newCmd func() *exec.Cmd, sb *sandbox, cols, rows uint16) (*PTY, error) {
+	cmd := newCmd()
+	sb.prepare(cmd)
+	return startPTY(cmd, cols, rows)
+}
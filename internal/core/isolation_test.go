@@ -0,0 +1,108 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateIsolation(t *testing.T) {
+	valid := &Isolation{CPUMax: 0.5, MemoryMax: 1 << 20, Rlimits: []Rlimit{{Resource: "nofile", Soft: 64, Hard: 128}}}
+	if err := validateIsolation(valid); err != nil {
+		t.Fatalf("validateIsolation: %v", err)
+	}
+	invalid := []*Isolation{
+		{CPUMax: -1},
+		{PidsMax: -1},
+		{Rlimits: []Rlimit{{Resource: "bogus", Soft: 1}}},
+		{Rlimits: []Rlimit{{Resource: "nofile", Soft: 10, Hard: 5}}},
+	}
+	for _, iso := range invalid {
+		if err := validateIsolation(iso); !errors.Is(err, ErrInvalidIsolation) {
+			t.Fatalf("expected ErrInvalidIsolation for %+v, got %v", iso, err)
+		}
+	}
+}
+
+func TestSpawnIsolationRlimit(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("isolation requires linux")
+	}
+
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("limited", SpawnOptions{
+		Command:   []string{"/bin/sh", "-c", "echo nofile=$(ulimit -n); sleep 5"},
+		Isolation: &Isolation{Rlimits: []Rlimit{{Resource: "nofile", Soft: 64}}},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	waitForDumpContains(t, coord, info.ID, "nofile=64", 2*time.Second)
+
+	_, err = coord.Spawn("cgroup", SpawnOptions{
+		Command:   []string{"/bin/sh", "-c", "sleep 5"},
+		Isolation: &Isolation{PidsMax: 10},
+	})
+	if !errors.Is(err, ErrIsolationUnavailable) {
+		t.Fatalf("expected ErrIsolationUnavailable without a cgroup root, got %v", err)
+	}
+	if _, err := coord.LookupIDByLabel("cgroup"); err == nil {
+		t.Fatalf("expected failed spawn to release its label")
+	}
+}
+
+func TestSpawnIsolationPIDNamespace(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("isolation requires linux")
+	}
+	if os.Getuid() != 0 {
+		t.Skip("pid namespaces without a user namespace require root")
+	}
+
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("pidns", SpawnOptions{
+		Command:   []string{"/bin/sh", "-c", "echo pid=$$ procs=$(ls /proc | grep -c '^[0-9]'); sleep 30"},
+		Isolation: &Isolation{PIDNS: true},
+	})
+	if err != nil {
+		t.Skipf("pid namespace unavailable: %v", err)
+	}
+	waitForDumpContains(t, coord, info.ID, "procs=", 2*time.Second)
+	dump, err := coord.Dump(info.ID, DumpViewport, false)
+	if err != nil {
+		t.Fatalf("Dump: %v", err)
+	}
+	var pid, procs int
+	if _, err := fmt.Sscanf(dump[strings.Index(dump, "pid="):], "pid=%d procs=%d", &pid, &procs); err != nil || pid > 64 || procs > 5 {
+		t.Fatalf("expected a fresh /proc with only the session's processes, got %q", dump)
+	}
+
+	// The init forwards SIGTERM, so Kill works although the target is not
+	// pid 1 of the namespace.
+	if err := coord.Kill(info.ID, nil); err != nil {
+		t.Fatalf("Kill: %v", err)
+	}
+	waitForState(t, coord, info.ID, SessionExited, 2*time.Second)
+}
+
+func TestSpawnIsolationUIDAllowlist(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	root := uint32(0)
+	_, err := coord.Spawn("root", SpawnOptions{
+		Command:   []string{"/bin/sh", "-c", "sleep 5"},
+		Isolation: &Isolation{UID: &root},
+	})
+	if !errors.Is(err, ErrIsolationUnavailable) || !strings.Contains(err.Error(), "uid 0") {
+		t.Fatalf("expected uid 0 to be rejected without --allow-uid, got %v", err)
+	}
+}
@@ -0,0 +1,304 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxMacroBytes caps the input one macro recording may hold.
+const MaxMacroBytes = 4 << 20
+
+var macroNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// MacroStep is one input write. Delay is the time since the previous step.
+type MacroStep struct {
+	Delay time.Duration `json:"delay"`
+	Data  []byte        `json:"data"`
+}
+
+// Macro is recorded session input that can be replayed into any session.
+type Macro struct {
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"created_at"`
+	Steps     []MacroStep `json:"steps"`
+}
+
+// MacroInfo summarizes a stored macro.
+type MacroInfo struct {
+	Name      string
+	CreatedAt time.Time
+	Steps     int
+	Bytes     int
+	Duration  time.Duration
+}
+
+// PlayMacroOptions configures Coordinator.PlayMacro.
+type PlayMacroOptions struct {
+	From InputSource
+	// Speed scales the recorded delays: 2 plays twice as fast. Zero means 1.
+	Speed float64
+	// StepDelay, when set, replaces the recorded delays with a fixed wait
+	// between steps.
+	StepDelay time.Duration
+}
+
+type macroRecorder struct {
+	name     string
+	clientID string
+	started  time.Time
+	last     time.Time
+	steps    []MacroStep
+	bytes    int
+	full     bool
+}
+
+// Info summarizes the macro.
+func (m Macro) Info() MacroInfo {
+	info := MacroInfo{Name: m.Name, CreatedAt: m.CreatedAt, Steps: len(m.Steps)}
+	for _, step := range m.Steps {
+		info.Bytes += len(step.Data)
+		info.Duration += step.Delay
+	}
+	return info
+}
+
+// StartMacro begins recording the input from.ClientID writes to a session
+// into the macro name. Every input path is recorded, including keys, pastes
+// and mouse events, with the time between writes; other clients' input is
+// not. Starting fails with ErrInputLocked while another token holds the
+// session's input lock.
+func (c *Coordinator) StartMacro(id, name string, from InputSource) error {
+	if _, err := c.macroPath(name); err != nil {
+		return err
+	}
+	clientID := strings.TrimSpace(from.ClientID)
+	if clientID == "" {
+		return fmt.Errorf("%w: client id is required to record", ErrInvalidMacro)
+	}
+	session, err := c.getSession(id)
+	if err != nil {
+		return err
+	}
+	if err := session.checkLock(from); err != nil {
+		return err
+	}
+	session.macroMu.Lock()
+	defer session.macroMu.Unlock()
+	if session.macro != nil {
+		return fmt.Errorf("%w: already recording %q", ErrMacroRecording, session.macro.name)
+	}
+	now := time.Now()
+	session.macro = &macroRecorder{name: name, clientID: clientID, started: now, last: now}
+	return nil
+}
+
+// StopMacro ends the recording from.ClientID started in a session and stores
+// the macro, replacing one with the same name.
+func (c *Coordinator) StopMacro(id string, from InputSource) (MacroInfo, error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return MacroInfo{}, err
+	}
+	if err := session.checkLock(from); err != nil {
+		return MacroInfo{}, err
+	}
+	session.macroMu.Lock()
+	rec := session.macro
+	if rec == nil {
+		session.macroMu.Unlock()
+		return MacroInfo{}, ErrNotRecording
+	}
+	if rec.clientID != strings.TrimSpace(from.ClientID) {
+		session.macroMu.Unlock()
+		return MacroInfo{}, fmt.Errorf("%w: %q is recorded by another client", ErrMacroRecording, rec.name)
+	}
+	session.macro = nil
+	session.macroMu.Unlock()
+	if rec.full {
+		return MacroInfo{}, fmt.Errorf("%w: recording exceeded %d bytes", ErrInvalidMacro, MaxMacroBytes)
+	}
+	macro := Macro{Name: rec.name, CreatedAt: rec.started, Steps: rec.steps}
+	if macro.Steps == nil {
+		macro.Steps = []MacroStep{}
+	}
+	if err := c.SaveMacro(macro); err != nil {
+		return MacroInfo{}, err
+	}
+	return macro.Info(), nil
+}
+
+// SaveMacro stores a macro in the coordinator's macro directory.
+func (c *Coordinator) SaveMacro(macro Macro) error {
+	path, err := c.macroPath(macro.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(macro, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadMacro reads a stored macro.
+func (c *Coordinator) LoadMacro(name string) (Macro, error) {
+	path, err := c.macroPath(name)
+	if err != nil {
+		return Macro{}, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Macro{}, fmt.Errorf("%w: %s", ErrMacroNotFound, name)
+	}
+	if err != nil {
+		return Macro{}, err
+	}
+	var macro Macro
+	if err := json.Unmarshal(data, &macro); err != nil {
+		return Macro{}, fmt.Errorf("%w: %s: %v", ErrInvalidMacro, name, err)
+	}
+	macro.Name = name
+	return macro, nil
+}
+
+// Macros lists the stored macros by name.
+func (c *Coordinator) Macros() ([]MacroInfo, error) {
+	dir := strings.TrimSpace(c.opts.MacroDir)
+	if dir == "" {
+		return nil, ErrMacrosDisabled
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]MacroInfo, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() || !macroNamePattern.MatchString(name) {
+			continue
+		}
+		macro, err := c.LoadMacro(name)
+		if err != nil {
+			continue
+		}
+		out = append(out, macro.Info())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// DeleteMacro removes a stored macro.
+func (c *Coordinator) DeleteMacro(name string) error {
+	path, err := c.macroPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrMacroNotFound, name)
+		}
+		return err
+	}
+	return nil
+}
+
+// PlayMacro replays a stored macro into a running session, waiting between
+// steps as recorded (scaled by Speed) or by StepDelay. It returns the number
+// of steps written.
+func (c *Coordinator) PlayMacro(ctx context.Context, id, name string, opts PlayMacroOptions) (int, error) {
+	if opts.Speed < 0 || opts.StepDelay < 0 {
+		return 0, fmt.Errorf("%w: speed and step delay must be >= 0", ErrInvalidMacro)
+	}
+	speed := opts.Speed
+	if speed == 0 {
+		speed = 1
+	}
+	macro, err := c.LoadMacro(name)
+	if err != nil {
+		return 0, err
+	}
+	session, err := c.getSession(id)
+	if err != nil {
+		return 0, err
+	}
+	for i, step := range macro.Steps {
+		delay := time.Duration(float64(step.Delay) / speed)
+		if opts.StepDelay > 0 {
+			delay = opts.StepDelay
+		}
+		if i > 0 && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return i, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		if !session.IsRunning() {
+			return i, ErrSessionNotRunning
+		}
+		if err := c.acceptInput(session, opts.From); err != nil {
+			return i, err
+		}
+		if _, err := session.ptyHandle().Write(step.Data); err != nil {
+			return i, err
+		}
+		session.recordInput(opts.From, step.Data)
+		session.recordActivity()
+	}
+	return len(macro.Steps), nil
+}
+
+// recordInput appends input written to the PTY to the macro being recorded
+// when it came from the recording client.
+func (s *Session) recordInput(from InputSource, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	s.macroMu.Lock()
+	defer s.macroMu.Unlock()
+	rec := s.macro
+	if rec == nil || rec.full || rec.clientID != strings.TrimSpace(from.ClientID) {
+		return
+	}
+	if rec.bytes+len(data) > MaxMacroBytes {
+		rec.full = true
+		return
+	}
+	now := time.Now()
+	delay := now.Sub(rec.last)
+	if len(rec.steps) == 0 {
+		delay = 0
+	}
+	rec.steps = append(rec.steps, MacroStep{Delay: delay, Data: append([]byte(nil), data...)})
+	rec.bytes += len(data)
+	rec.last = now
+}
+
+func (c *Coordinator) macroPath(name string) (string, error) {
+	dir := strings.TrimSpace(c.opts.MacroDir)
+	if dir == "" {
+		return "", ErrMacrosDisabled
+	}
+	if !macroNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: name %q must be letters, digits, '.', '_' or '-'", ErrInvalidMacro, name)
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMacroRecordAndPlay(t *testing.T) {
+	coord := NewCoordinator(CoordinatorOptions{
+		DefaultShell: "/bin/sh",
+		DefaultCols:  80,
+		DefaultRows:  24,
+		KillTimeout:  500 * time.Millisecond,
+		MacroDir:     t.TempDir(),
+	})
+	defer coord.CloseAll()
+
+	source, err := coord.Spawn("source", SpawnOptions{Command: []string{"/bin/sh", "-c", "stty raw -echo; exec cat > /dev/null"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	agent := InputSource{ClientID: "agent"}
+	if _, err := coord.StopMacro(source.ID, agent); !errors.Is(err, ErrNotRecording) {
+		t.Fatalf("expected ErrNotRecording, got %v", err)
+	}
+	if err := coord.StartMacro(source.ID, "../escape", agent); !errors.Is(err, ErrInvalidMacro) {
+		t.Fatalf("expected ErrInvalidMacro, got %v", err)
+	}
+	if err := coord.StartMacro(source.ID, "login", InputSource{}); !errors.Is(err, ErrInvalidMacro) {
+		t.Fatalf("expected ErrInvalidMacro without a client id, got %v", err)
+	}
+	if err := coord.StartMacro(source.ID, "login", agent); err != nil {
+		t.Fatalf("StartMacro: %v", err)
+	}
+	if err := coord.StartMacro(source.ID, "other", agent); !errors.Is(err, ErrMacroRecording) {
+		t.Fatalf("expected ErrMacroRecording, got %v", err)
+	}
+	if err := coord.SendAs(source.ID, agent, []byte("user\r")); err != nil {
+		t.Fatalf("SendAs: %v", err)
+	}
+	// Input from other clients is not recorded.
+	if err := coord.SendAs(source.ID, InputSource{ClientID: "bystander"}, []byte("password\r")); err != nil {
+		t.Fatalf("SendAs: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if _, err := coord.Paste(context.Background(), source.ID, []byte("secret\n"), PasteOptions{From: agent}); err != nil {
+		t.Fatalf("Paste: %v", err)
+	}
+	if _, err := coord.StopMacro(source.ID, InputSource{ClientID: "bystander"}); !errors.Is(err, ErrMacroRecording) {
+		t.Fatalf("expected ErrMacroRecording stopping another client's recording, got %v", err)
+	}
+	info, err := coord.StopMacro(source.ID, agent)
+	if err != nil {
+		t.Fatalf("StopMacro: %v", err)
+	}
+	if info.Name != "login" || info.Steps != 2 || info.Bytes != 12 || info.Duration < 200*time.Millisecond {
+		t.Fatalf("unexpected macro %+v", info)
+	}
+	if err := coord.SendAs(source.ID, agent, []byte("ignored")); err != nil {
+		t.Fatalf("SendAs: %v", err)
+	}
+
+	macros, err := coord.Macros()
+	if err != nil || len(macros) != 1 || macros[0].Steps != 2 {
+		t.Fatalf("Macros=%+v, %v", macros, err)
+	}
+
+	out := filepath.Join(t.TempDir(), "play.out")
+	target, err := coord.Spawn("target", SpawnOptions{Command: []string{"/bin/sh", "-c", "stty raw -echo; exec cat > " + out}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	steps, err := coord.PlayMacro(context.Background(), target.ID, "login", PlayMacroOptions{Speed: 100})
+	if err != nil || steps != 2 {
+		t.Fatalf("PlayMacro=%d, %v", steps, err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Fatalf("PlayMacro at speed 100 took %s", elapsed)
+	}
+	want := "user\rsecret\r"
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		data, _ := os.ReadFile(out)
+		if string(data) == want {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("played %q, want %q", data, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	steps, err = coord.PlayMacro(ctx, target.ID, "login", PlayMacroOptions{StepDelay: time.Second})
+	if !errors.Is(err, context.DeadlineExceeded) || steps != 1 {
+		t.Fatalf("PlayMacro with step delay=%d, %v", steps, err)
+	}
+
+	if err := coord.DeleteMacro("login"); err != nil {
+		t.Fatalf("DeleteMacro: %v", err)
+	}
+	if _, err := coord.PlayMacro(context.Background(), target.ID, "login", PlayMacroOptions{}); !errors.Is(err, ErrMacroNotFound) {
+		t.Fatalf("expected ErrMacroNotFound, got %v", err)
+	}
+}
+
+func TestMacrosDisabledWithoutDir(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("plain", SpawnOptions{Command: []string{"/bin/sh", "-c", "sleep 5"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if err := coord.StartMacro(info.ID, "login", InputSource{ClientID: "agent"}); !errors.Is(err, ErrMacrosDisabled) {
+		t.Fatalf("expected ErrMacrosDisabled, got %v", err)
+	}
+	if _, err := coord.Macros(); !errors.Is(err, ErrMacrosDisabled) {
+		t.Fatalf("expected ErrMacrosDisabled, got %v", err)
+	}
+}
+
+func TestMacroRecordFollowsInputLock(t *testing.T) {
+	coord := NewCoordinator(CoordinatorOptions{
+		DefaultShell: "/bin/sh",
+		DefaultCols:  80,
+		DefaultRows:  24,
+		KillTimeout:  500 * time.Millisecond,
+		MacroDir:     t.TempDir(),
+	})
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("locked", SpawnOptions{Command: []string{"/bin/sh", "-c", "sleep 5"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	_, token, err := coord.AcquireInputLock(info.ID, "", "owner", time.Minute)
+	if err != nil {
+		t.Fatalf("AcquireInputLock: %v", err)
+	}
+	if err := coord.StartMacro(info.ID, "spy", InputSource{ClientID: "other"}); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected ErrInputLocked, got %v", err)
+	}
+	holder := InputSource{ClientID: "owner", LockToken: token}
+	if err := coord.StartMacro(info.ID, "mine", holder); err != nil {
+		t.Fatalf("StartMacro: %v", err)
+	}
+	if _, err := coord.StopMacro(info.ID, InputSource{ClientID: "owner"}); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected ErrInputLocked without the token, got %v", err)
+	}
+	if _, err := coord.StopMacro(info.ID, holder); err != nil {
+		t.Fatalf("StopMacro: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/advait/vtrpc/internal/vt"
+)
+
+// MouseButton identifies the button in a mouse event.
+type MouseButton int
+
+const (
+	// MouseNoButton is used for motion with no button held.
+	MouseNoButton MouseButton = iota
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseWheelUp
+	MouseWheelDown
+	MouseWheelLeft
+	MouseWheelRight
+)
+
+// MouseAction is what happened to the button.
+type MouseAction int
+
+const (
+	MousePress MouseAction = iota
+	MouseRelease
+	// MouseMotion moves the pointer, dragging when a button is set.
+	MouseMotion
+	// MouseClick is a press followed by a release.
+	MouseClick
+)
+
+// MouseEvent is a mouse event at a 0-based cell position.
+type MouseEvent struct {
+	Button MouseButton
+	Action MouseAction
+	X      int
+	Y      int
+	Shift  bool
+	Alt    bool
+	Ctrl   bool
+}
+
+func (b MouseButton) wheel() bool {
+	return b >= MouseWheelUp && b <= MouseWheelRight
+}
+
+// SendMouse encodes ev for the mouse tracking and encoding modes the session
+// program enabled and writes it to the PTY. It reports false, without error,
+// when the program does not track that kind of event.
+func (c *Coordinator) SendMouse(id string, from InputSource, ev MouseEvent) (bool, error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return false, err
+	}
+	if !session.IsRunning() {
+		return false, ErrSessionNotRunning
+	}
+	if err := validateMouseEvent(ev, session.Info()); err != nil {
+		return false, err
+	}
+	if err := c.acceptInput(session, from); err != nil {
+		return false, err
+	}
+	modes, err := session.vt.Modes()
+	if err != nil {
+		return false, err
+	}
+	var data []byte
+	if ev.Action == MouseClick {
+		press, release := ev, ev
+		press.Action, release.Action = MousePress, MouseRelease
+		if seq, ok := EncodeMouse(modes, press); ok {
+			data = append(data, seq...)
+		}
+		if seq, ok := EncodeMouse(modes, release); ok {
+			data = append(data, seq...)
+		}
+	} else if seq, ok := EncodeMouse(modes, ev); ok {
+		data = seq
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+	if _, err := session.ptyHandle().Write(data); err != nil {
+		return false, err
+	}
+	session.recordInput(from, data)
+	session.recordActivity()
+	return true, nil
+}
+
+func validateMouseEvent(ev MouseEvent, info SessionInfo) error {
+	if ev.Button < MouseNoButton || ev.Button > MouseWheelRight {
+		return fmt.Errorf("%w: unknown button", ErrInvalidMouse)
+	}
+	if ev.Action < MousePress || ev.Action > MouseClick {
+		return fmt.Errorf("%w: unknown action", ErrInvalidMouse)
+	}
+	if ev.Button == MouseNoButton && ev.Action != MouseMotion {
+		return fmt.Errorf("%w: button is required for press, release and click", ErrInvalidMouse)
+	}
+	if ev.X < 0 || ev.Y < 0 || ev.X >= int(info.Cols) || ev.Y >= int(info.Rows) {
+		return fmt.Errorf("%w: cell %d,%d is outside %dx%d", ErrInvalidMouse, ev.X, ev.Y, info.Cols, info.Rows)
+	}
+	return nil
+}
+
+// EncodeMouse returns the report xterm would send for ev under modes. It
+// reports false when the tracking mode ignores the event or the encoding
+// cannot express it.
+func EncodeMouse(modes vt.Modes, ev MouseEvent) ([]byte, bool) {
+	if !mouseTracked(modes.MouseEvents, ev) {
+		return nil, false
+	}
+	code := mouseButtonCode(ev.Button)
+	if ev.Action == MouseRelease && modes.MouseFormat != vt.MouseFormatSGR {
+		// Legacy encodings cannot say which button was released.
+		code = 3
+	}
+	if ev.Action == MouseMotion {
+		code += 32
+	}
+	if modes.MouseEvents != vt.MouseEventsX10 {
+		if ev.Shift {
+			code += 4
+		}
+		if ev.Alt {
+			code += 8
+		}
+		if ev.Ctrl {
+			code += 16
+		}
+	}
+	x, y := ev.X+1, ev.Y+1
+
+	switch modes.MouseFormat {
+	case vt.MouseFormatSGR:
+		final := byte('M')
+		if ev.Action == MouseRelease {
+			final = 'm'
+		}
+		buf := []byte("\x1b[<")
+		buf = strconv.AppendInt(buf, int64(code), 10)
+		buf = append(buf, ';')
+		buf = strconv.AppendInt(buf, int64(x), 10)
+		buf = append(buf, ';')
+		buf = strconv.AppendInt(buf, int64(y), 10)
+		return append(buf, final), true
+	case vt.MouseFormatURXVT:
+		buf := []byte("\x1b[")
+		buf = strconv.AppendInt(buf, int64(code+32), 10)
+		buf = append(buf, ';')
+		buf = strconv.AppendInt(buf, int64(x), 10)
+		buf = append(buf, ';')
+		buf = strconv.AppendInt(buf, int64(y), 10)
+		return append(buf, 'M'), true
+	case vt.MouseFormatUTF8:
+		const maxUTF8 = 2047 - 32
+		if x > maxUTF8 || y > maxUTF8 {
+			return nil, false
+		}
+		buf := []byte("\x1b[M")
+		buf = utf8.AppendRune(buf, rune(code+32))
+		buf = utf8.AppendRune(buf, rune(x+32))
+		return utf8.AppendRune(buf, rune(y+32)), true
+	case vt.MouseFormatX10:
+		const maxX10 = 255 - 32
+		if x > maxX10 || y > maxX10 {
+			return nil, false
+		}
+		return []byte{0x1b, '[', 'M', byte(code + 32), byte(x + 32), byte(y + 32)}, true
+	default:
+		// SGR-Pixels needs pixel positions, which vtr does not know.
+		return nil, false
+	}
+}
+
+func mouseTracked(events vt.MouseEvents, ev MouseEvent) bool {
+	switch events {
+	case vt.MouseEventsX10:
+		return ev.Action == MousePress && ev.Button >= MouseLeft && ev.Button <= MouseRight
+	case vt.MouseEventsNormal:
+		return ev.Action == MousePress || (ev.Action == MouseRelease && !ev.Button.wheel())
+	case vt.MouseEventsButton:
+		if ev.Action == MouseMotion {
+			return ev.Button != MouseNoButton && !ev.Button.wheel()
+		}
+		return ev.Action == MousePress || (ev.Action == MouseRelease && !ev.Button.wheel())
+	case vt.MouseEventsAny:
+		if ev.Action == MouseMotion {
+			return !ev.Button.wheel()
+		}
+		return ev.Action == MousePress || (ev.Action == MouseRelease && !ev.Button.wheel())
+	default:
+		return false
+	}
+}
+
+func mouseButtonCode(button MouseButton) int {
+	switch button {
+	case MouseLeft:
+		return 0
+	case MouseMiddle:
+		return 1
+	case MouseRight:
+		return 2
+	case MouseWheelUp:
+		return 64
+	case MouseWheelDown:
+		return 65
+	case MouseWheelLeft:
+		return 66
+	case MouseWheelRight:
+		return 67
+	default:
+		// Motion with no button held.
+		return 3
+	}
+}
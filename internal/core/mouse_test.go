@@ -0,0 +1,117 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/advait/vtrpc/internal/vt"
+)
+
+func TestEncodeMouse(t *testing.T) {
+	sgr := vt.Modes{MouseEvents: vt.MouseEventsNormal, MouseFormat: vt.MouseFormatSGR}
+	x10 := vt.Modes{MouseEvents: vt.MouseEventsNormal, MouseFormat: vt.MouseFormatX10}
+	cases := []struct {
+		name  string
+		modes vt.Modes
+		ev    MouseEvent
+		want  string
+	}{
+		{"sgr press", sgr, MouseEvent{Button: MouseLeft, Action: MousePress, X: 2, Y: 1}, "\x1b[<0;3;2M"},
+		{"sgr release", sgr, MouseEvent{Button: MouseRight, Action: MouseRelease, X: 2, Y: 1}, "\x1b[<2;3;2m"},
+		{"sgr modifiers", sgr, MouseEvent{Button: MouseLeft, Action: MousePress, Shift: true, Ctrl: true}, "\x1b[<20;1;1M"},
+		{"sgr wheel", sgr, MouseEvent{Button: MouseWheelDown, Action: MousePress, X: 9, Y: 4}, "\x1b[<65;10;5M"},
+		{"x10 press", x10, MouseEvent{Button: MouseMiddle, Action: MousePress, X: 0, Y: 0}, "\x1b[M!!!"},
+		{"x10 release", x10, MouseEvent{Button: MouseMiddle, Action: MouseRelease, X: 0, Y: 0}, "\x1b[M#!!"},
+		{"urxvt", vt.Modes{MouseEvents: vt.MouseEventsNormal, MouseFormat: vt.MouseFormatURXVT},
+			MouseEvent{Button: MouseLeft, Action: MousePress, X: 299, Y: 0}, "\x1b[32;300;1M"},
+		{"utf8 wide", vt.Modes{MouseEvents: vt.MouseEventsNormal, MouseFormat: vt.MouseFormatUTF8},
+			MouseEvent{Button: MouseLeft, Action: MousePress, X: 299, Y: 0}, "\x1b[M Ō!"},
+		{"drag", vt.Modes{MouseEvents: vt.MouseEventsButton, MouseFormat: vt.MouseFormatSGR},
+			MouseEvent{Button: MouseLeft, Action: MouseMotion, X: 4, Y: 4}, "\x1b[<32;5;5M"},
+		{"hover", vt.Modes{MouseEvents: vt.MouseEventsAny, MouseFormat: vt.MouseFormatSGR},
+			MouseEvent{Action: MouseMotion, X: 4, Y: 4}, "\x1b[<35;5;5M"},
+	}
+	for _, tc := range cases {
+		got, ok := EncodeMouse(tc.modes, tc.ev)
+		if !ok || string(got) != tc.want {
+			t.Fatalf("%s: EncodeMouse=%q, %v; want %q", tc.name, got, ok, tc.want)
+		}
+	}
+
+	dropped := []struct {
+		name  string
+		modes vt.Modes
+		ev    MouseEvent
+	}{
+		{"tracking off", vt.Modes{MouseFormat: vt.MouseFormatSGR}, MouseEvent{Button: MouseLeft, Action: MousePress}},
+		{"x10 release", vt.Modes{MouseEvents: vt.MouseEventsX10}, MouseEvent{Button: MouseLeft, Action: MouseRelease}},
+		{"x10 wheel", vt.Modes{MouseEvents: vt.MouseEventsX10}, MouseEvent{Button: MouseWheelUp, Action: MousePress}},
+		{"normal motion", sgr, MouseEvent{Button: MouseLeft, Action: MouseMotion}},
+		{"button hover", vt.Modes{MouseEvents: vt.MouseEventsButton}, MouseEvent{Action: MouseMotion}},
+		{"wheel release", sgr, MouseEvent{Button: MouseWheelUp, Action: MouseRelease}},
+		{"x10 overflow", x10, MouseEvent{Button: MouseLeft, Action: MousePress, X: 300}},
+		{"sgr pixels", vt.Modes{MouseEvents: vt.MouseEventsNormal, MouseFormat: vt.MouseFormatSGRPixels},
+			MouseEvent{Button: MouseLeft, Action: MousePress}},
+	}
+	for _, tc := range dropped {
+		if got, ok := EncodeMouse(tc.modes, tc.ev); ok {
+			t.Fatalf("%s: expected event to be dropped, got %q", tc.name, got)
+		}
+	}
+}
+
+func TestSendMouseFollowsTrackingMode(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	out := filepath.Join(t.TempDir(), "mouse.out")
+	info, err := coord.Spawn("mouse", SpawnOptions{
+		Command: []string{"/bin/sh", "-c", "stty raw -echo; sleep 0.2; printf '\\033[?1000h\\033[?1006h'; exec cat > " + out},
+		Cols:    80,
+		Rows:    24,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	sent, err := coord.SendMouse(info.ID, InputSource{}, MouseEvent{Button: MouseLeft, Action: MouseClick})
+	if err != nil || sent {
+		t.Fatalf("SendMouse before tracking: sent=%v err=%v", sent, err)
+	}
+	if _, err := coord.SendMouse(info.ID, InputSource{}, MouseEvent{Button: MouseLeft, Action: MouseClick, X: 80}); !errors.Is(err, ErrInvalidMouse) {
+		t.Fatalf("expected ErrInvalidMouse, got %v", err)
+	}
+	if _, err := coord.SendMouse(info.ID, InputSource{}, MouseEvent{Action: MousePress}); !errors.Is(err, ErrInvalidMouse) {
+		t.Fatalf("expected ErrInvalidMouse, got %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		sent, err = coord.SendMouse(info.ID, InputSource{}, MouseEvent{Button: MouseLeft, Action: MouseClick, X: 4, Y: 2})
+		if err != nil {
+			t.Fatalf("SendMouse: %v", err)
+		}
+		if sent {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for mouse tracking")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	want := "\x1b[<0;5;3M\x1b[<0;5;3m"
+	for {
+		data, _ := os.ReadFile(out)
+		if string(data) == want {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("wrote %q, want %q", data, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,6 +1,10 @@
 package core
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 const MaxOutputBuffer = 1 << 20
 
@@ -58,3 +62,92 @@ func (s *Session) outputSnapshot(offset int64) ([]byte, int64, <-chan struct{},
 func (s *Session) OutputSnapshot(offset int64) ([]byte, int64, <-chan struct{}, bool) {
 	return s.outputSnapshot(offset)
 }
+
+// OutputChunk is a slice of a session's buffered output. Offsets count bytes
+// since the session started.
+type OutputChunk struct {
+	Data []byte
+	// Offset is the stream offset of Data[0].
+	Offset int64
+	// Next is the offset to read from to continue after Data.
+	Next int64
+	// Dropped counts bytes between the requested offset and Offset that have
+	// already left the buffer.
+	Dropped int64
+	// Exited reports that the session has exited, so no output follows Next.
+	Exited bool
+}
+
+// ReadOutput returns buffered output from offset; a negative offset counts
+// back from the end of the output. When nothing is buffered past offset and
+// wait is positive, it blocks until output arrives, the session exits or wait
+// elapses.
+func (c *Coordinator) ReadOutput(ctx context.Context, name string, offset int64, wait time.Duration) (OutputChunk, error) {
+	session, err := c.getSession(name)
+	if err != nil {
+		return OutputChunk{}, err
+	}
+	return session.readOutput(ctx, offset, wait)
+}
+
+func (s *Session) readOutput(ctx context.Context, offset int64, wait time.Duration) (OutputChunk, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if wait < 0 {
+		return OutputChunk{}, errors.New("wait must be >= 0")
+	}
+	if offset < 0 {
+		total, _, _ := s.outputState()
+		offset = max(total+offset, 0)
+	}
+	var timeoutCh <-chan time.Time
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+	for {
+		// Output read after the process exits is still buffered once the
+		// PTY reader is done.
+		exited := s.IsExited()
+		if exited {
+			s.waitIODone(ctx, 500*time.Millisecond)
+		}
+		s.outputMu.Lock()
+		start := s.outputTotal - int64(len(s.outputBuf))
+		chunk := OutputChunk{Offset: min(max(offset, start), s.outputTotal), Next: s.outputTotal}
+		if offset < start {
+			chunk.Dropped = start - offset
+		}
+		chunk.Data = append([]byte(nil), s.outputBuf[chunk.Offset-start:]...)
+		ch := s.outputCh
+		s.outputMu.Unlock()
+		chunk.Exited = exited
+		if len(chunk.Data) > 0 || chunk.Exited || timeoutCh == nil {
+			return chunk, nil
+		}
+		select {
+		case <-ctx.Done():
+			return OutputChunk{}, ctx.Err()
+		case <-timeoutCh:
+			return chunk, nil
+		case <-s.exitCh:
+		case <-ch:
+		}
+	}
+}
+
+func (s *Session) waitIODone(ctx context.Context, timeout time.Duration) {
+	ioDone := s.ioDoneCh()
+	if ioDone == nil {
+		return
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ioDone:
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
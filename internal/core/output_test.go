@@ -2,7 +2,10 @@ package core
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
 func newOutputTestSession() *Session {
@@ -47,3 +50,55 @@ func TestOutputSnapshotNoGapAtLatestOffset(t *testing.T) {
 		t.Fatalf("expected no data at latest offset, got %d bytes", len(data))
 	}
 }
+
+func TestReadOutputOffsets(t *testing.T) {
+	session := newOutputTestSession()
+	payload := bytes.Repeat([]byte("a"), MaxOutputBuffer+64)
+	session.recordOutput(payload)
+	session.recordOutput([]byte("tail"))
+	total := int64(len(payload) + 4)
+
+	chunk, err := session.readOutput(context.Background(), 10, 0)
+	if err != nil {
+		t.Fatalf("readOutput: %v", err)
+	}
+	if chunk.Dropped != 68-10 || chunk.Offset != 68 || chunk.Next != total || len(chunk.Data) != MaxOutputBuffer {
+		t.Fatalf("unexpected gap chunk offset=%d next=%d dropped=%d len=%d", chunk.Offset, chunk.Next, chunk.Dropped, len(chunk.Data))
+	}
+
+	chunk, err = session.readOutput(context.Background(), -4, 0)
+	if err != nil || string(chunk.Data) != "tail" || chunk.Dropped != 0 || chunk.Offset != total-4 {
+		t.Fatalf("readOutput(-4)=%q offset=%d dropped=%d, %v", chunk.Data, chunk.Offset, chunk.Dropped, err)
+	}
+
+	chunk, err = session.readOutput(context.Background(), total+100, 0)
+	if err != nil || len(chunk.Data) != 0 || chunk.Offset != total || chunk.Next != total {
+		t.Fatalf("readOutput past end offset=%d next=%d len=%d, %v", chunk.Offset, chunk.Next, len(chunk.Data), err)
+	}
+}
+
+func TestReadOutputWaits(t *testing.T) {
+	session := newOutputTestSession()
+	session.recordOutput([]byte("one"))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		session.recordOutput([]byte("two"))
+	}()
+	chunk, err := session.readOutput(context.Background(), 3, time.Second)
+	if err != nil || string(chunk.Data) != "two" || chunk.Next != 6 {
+		t.Fatalf("readOutput=%q next=%d, %v", chunk.Data, chunk.Next, err)
+	}
+
+	start := time.Now()
+	chunk, err = session.readOutput(context.Background(), 6, 30*time.Millisecond)
+	if err != nil || len(chunk.Data) != 0 || time.Since(start) < 30*time.Millisecond {
+		t.Fatalf("expected empty chunk after wait, got %q, %v", chunk.Data, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := session.readOutput(ctx, 6, time.Second); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
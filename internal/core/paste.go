@@ -0,0 +1,126 @@
+package core
+
+import (
+	"bytes"
+	"context"
+)
+
+const (
+	// DefaultPasteChunk is the PTY write size used when PasteOptions.ChunkSize
+	// is zero.
+	DefaultPasteChunk = 4 << 10
+	// MaxPasteChunk caps PasteOptions.ChunkSize.
+	MaxPasteChunk = 64 << 10
+)
+
+var (
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// PasteOptions configures Coordinator.Paste.
+type PasteOptions struct {
+	From InputSource
+	// Bracketed forces bracketed paste markers on or off. Nil follows the
+	// mode the session's program enabled (DECSET 2004).
+	Bracketed *bool
+	ChunkSize int
+	// Progress is called after each chunk is written. Returning an error
+	// stops the paste.
+	Progress func(PasteProgress) error
+}
+
+// PasteProgress reports how much of a paste reached the PTY.
+type PasteProgress struct {
+	Written   int
+	Total     int
+	Bracketed bool
+}
+
+// Paste writes data into a running session as a paste. Newlines are sent as
+// carriage returns and embedded paste markers are removed so the content
+// cannot end the paste early. The data is written in chunks; each write
+// blocks until the PTY accepts it, so a program that reads slowly throttles
+// the paste. Pastes into the same session do not interleave.
+func (c *Coordinator) Paste(ctx context.Context, id string, data []byte, opts PasteOptions) (PasteProgress, error) {
+	session, err := c.getSession(id)
+	if err != nil {
+		return PasteProgress{}, err
+	}
+	if !session.IsRunning() {
+		return PasteProgress{}, ErrSessionNotRunning
+	}
+	if err := c.acceptInput(session, opts.From); err != nil {
+		return PasteProgress{}, err
+	}
+	chunk := opts.ChunkSize
+	if chunk <= 0 {
+		chunk = DefaultPasteChunk
+	}
+	chunk = min(chunk, MaxPasteChunk)
+
+	payload := sanitizePaste(data)
+	progress := PasteProgress{Total: len(payload), Bracketed: session.BracketedPaste()}
+	if opts.Bracketed != nil {
+		progress.Bracketed = *opts.Bracketed
+	}
+
+	session.pasteMu.Lock()
+	defer session.pasteMu.Unlock()
+	handle := session.ptyHandle()
+	if progress.Bracketed {
+		if _, err := handle.Write(pasteStart); err != nil {
+			return progress, err
+		}
+		session.recordInput(opts.From, pasteStart)
+		// Always close the paste so the program does not stay in paste mode
+		// when the context ends or a write fails midway.
+		defer func() {
+			if _, err := handle.Write(pasteEnd); err == nil {
+				session.recordInput(opts.From, pasteEnd)
+			}
+		}()
+	}
+	for progress.Written < len(payload) {
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+		if !session.IsRunning() {
+			return progress, ErrSessionNotRunning
+		}
+		end := min(progress.Written+chunk, len(payload))
+		n, err := handle.Write(payload[progress.Written:end])
+		session.recordInput(opts.From, payload[progress.Written:progress.Written+n])
+		progress.Written += n
+		if err != nil {
+			return progress, err
+		}
+		session.recordActivity()
+		if opts.Progress != nil {
+			if err := opts.Progress(progress); err != nil {
+				return progress, err
+			}
+		}
+	}
+	return progress, nil
+}
+
+// BracketedPaste reports whether the program in the session enabled
+// bracketed paste mode.
+func (s *Session) BracketedPaste() bool {
+	modes, err := s.vt.Modes()
+	return err == nil && modes.BracketedPaste
+}
+
+// sanitizePaste removes paste markers and converts newlines to carriage
+// returns, as a terminal does for pasted text. Markers are stripped until
+// none remain, since removing one can join its neighbours into another.
+func sanitizePaste(data []byte) []byte {
+	out := data
+	for bytes.Contains(out, pasteStart) || bytes.Contains(out, pasteEnd) {
+		out = bytes.ReplaceAll(out, pasteStart, nil)
+		out = bytes.ReplaceAll(out, pasteEnd, nil)
+	}
+	out = bytes.ReplaceAll(out, []byte("\r\n"), []byte("\r"))
+	return bytes.ReplaceAll(out, []byte("\n"), []byte("\r"))
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSessionBracketedPasteFollowsVT(t *testing.T) {
+	vt, err := NewVT(80, 24, 100)
+	if err != nil {
+		t.Fatalf("NewVT: %v", err)
+	}
+	defer vt.Close()
+	session := newOutputTestSession()
+	session.vt = vt
+	steps := []struct {
+		output string
+		want   bool
+	}{
+		{"\x1b[?20", false},
+		{"04h", true},
+		{"\x1b[2004l", true},
+		{"\x1b[?1049;2004l", false},
+		{"\x1b[?2004h", true},
+		{"\x1bc", false},
+	}
+	for _, step := range steps {
+		if _, err := vt.Feed([]byte(step.output)); err != nil {
+			t.Fatalf("Feed: %v", err)
+		}
+		if got := session.BracketedPaste(); got != step.want {
+			t.Fatalf("after %q BracketedPaste=%v, want %v", step.output, got, step.want)
+		}
+	}
+}
+
+func TestPasteBracketsAndSanitizes(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	out := filepath.Join(t.TempDir(), "paste.out")
+	info, err := coord.Spawn("paste", SpawnOptions{Command: []string{
+		"/bin/sh", "-c", "stty raw -echo; printf '\\033[?2004h'; exec cat > " + out,
+	}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	session, err := coord.GetSession(info.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for !session.BracketedPaste() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for bracketed paste mode")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var events []PasteProgress
+	result, err := coord.Paste(context.Background(), info.ID, []byte("one\ntwo\x1b[201~\r\nthree"), PasteOptions{
+		ChunkSize: 4,
+		Progress: func(p PasteProgress) error {
+			events = append(events, p)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Paste: %v", err)
+	}
+	if !result.Bracketed || result.Written != 13 || result.Total != 13 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if len(events) != 4 || events[0].Written != 4 {
+		t.Fatalf("unexpected progress %+v", events)
+	}
+
+	want := "\x1b[200~one\rtwo\rthree\x1b[201~"
+	for {
+		data, _ := os.ReadFile(out)
+		if string(data) == want {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pasted %q, want %q", data, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	off := false
+	result, err = coord.Paste(context.Background(), info.ID, []byte("x"), PasteOptions{Bracketed: &off})
+	if err != nil || result.Bracketed {
+		t.Fatalf("Paste with bracketed off: %+v, %v", result, err)
+	}
+
+	if _, _, err := coord.AcquireInputLock(info.ID, "", "agent one", time.Minute); err != nil {
+		t.Fatalf("AcquireInputLock: %v", err)
+	}
+	if _, err := coord.Paste(context.Background(), info.ID, []byte("y"), PasteOptions{From: InputSource{ClientID: "other"}}); !errors.Is(err, ErrInputLocked) {
+		t.Fatalf("expected ErrInputLocked, got %v", err)
+	}
+}
+
+func TestSanitizePasteNestedMarkers(t *testing.T) {
+	for input, want := range map[string]string{
+		"\x1b[20" + "\x1b[201~" + "1~":           "",
+		"a\x1b[2\x1b[200~00~b":                   "ab",
+		"x\x1b[20\x1b[20\x1b[201~1~0~y\n":        "xy\r",
+		"\x1b[2" + "\x1b[201~" + "01~tail\x1b[A": "tail\x1b[A",
+	} {
+		got := string(sanitizePaste([]byte(input)))
+		if got != want {
+			t.Fatalf("sanitizePaste(%q)=%q, want %q", input, got, want)
+		}
+	}
+}
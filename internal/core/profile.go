@@ -0,0 +1,44 @@
+package core
+
+import (
+	"sort"
+	"time"
+)
+
+// SpawnProfile is a named set of spawn defaults configured on the
+// coordinator. Fields left empty fall back to coordinator defaults.
+type SpawnProfile struct {
+	// Command is run through the coordinator shell with -c.
+	Command       string
+	WorkingDir    string
+	Env           map[string]string
+	Cols          uint16
+	Rows          uint16
+	Tags          map[string]string
+	IdleThreshold time.Duration
+	Restart       RestartPolicy
+	Term          string
+	Locale        string
+	// ShellIntegration overrides the coordinator default when set.
+	ShellIntegration *bool
+	ResizePolicy     ResizePolicy
+}
+
+// Profile returns the named spawn profile.
+func (c *Coordinator) Profile(name string) (SpawnProfile, error) {
+	profile, ok := c.opts.Profiles[name]
+	if !ok {
+		return SpawnProfile{}, ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+// ProfileNames returns the configured profile names in sorted order.
+func (c *Coordinator) ProfileNames() []string {
+	names := make([]string, 0, len(c.opts.Profiles))
+	for name := range c.opts.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResizePolicy decides how client viewports map to a session's PTY size.
+type ResizePolicy string
+
+const (
+	// ResizeLatest sizes the session to the client that last sent input.
+	ResizeLatest ResizePolicy = "latest"
+	// ResizeSmallest sizes the session to fit every attached viewport.
+	ResizeSmallest ResizePolicy = "smallest"
+	// ResizeLargest sizes the session to the largest attached viewport.
+	ResizeLargest ResizePolicy = "largest"
+	// ResizeFixed keeps the spawn size; Resize calls only record viewports.
+	ResizeFixed ResizePolicy = "fixed"
+	// ResizeOwner applies Resize calls from the session owner only, or from
+	// the oldest attached client while the owner is not attached.
+	ResizeOwner ResizePolicy = "owner"
+)
+
+// ParseResizePolicy parses a resize policy. An empty value parses to "",
+// which means the coordinator default.
+func ParseResizePolicy(value string) (ResizePolicy, error) {
+	policy := ResizePolicy(strings.ToLower(strings.TrimSpace(value)))
+	switch policy {
+	case "", ResizeLatest, ResizeSmallest, ResizeLargest, ResizeFixed, ResizeOwner:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w %q (expected latest, smallest, largest, fixed or owner)", ErrInvalidResizePolicy, value)
+	}
+}
+
+// ResizeFrom handles a Resize call from a client under the session's resize
+// policy and returns the resulting session size. from.ClientID may be empty
+// for callers that are not attached. Like input, resizes fail with
+// ErrInputLocked while the session is locked to another token.
+func (c *Coordinator) ResizeFrom(id string, from InputSource, cols, rows uint16) (uint16, uint16, error) {
+	if cols == 0 || rows == 0 {
+		return 0, 0, ErrInvalidSize
+	}
+	session, err := c.getSession(id)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !session.IsRunning() {
+		return 0, 0, ErrSessionNotRunning
+	}
+	if err := session.checkLock(from); err != nil {
+		return 0, 0, err
+	}
+	clientID := strings.TrimSpace(from.ClientID)
+	attached, err := c.UpdateClientSize(id, clientID, cols, rows)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	session.mu.Lock()
+	policy := session.resizePolicy
+	session.mu.Unlock()
+
+	switch policy {
+	case ResizeFixed:
+	case ResizeOwner:
+		if session.isResizeOwner(clientID) {
+			err = c.Resize(id, cols, rows)
+		}
+	case ResizeSmallest, ResizeLargest:
+		if fitCols, fitRows, ok := session.viewportFit(policy); ok {
+			cols, rows = fitCols, fitRows
+		}
+		err = c.Resize(id, cols, rows)
+	default:
+		// Attached clients only record their viewport; the session follows
+		// the client that last sent input. Callers that are not attached
+		// resize directly.
+		if fitCols, fitRows, ok := session.viewportFit(ResizeLatest); ok && attached {
+			cols, rows = fitCols, fitRows
+		}
+		err = c.Resize(id, cols, rows)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	info := session.Info()
+	return info.Cols, info.Rows, nil
+}
+
+// isResizeOwner reports whether clientID may resize an owner-policy session.
+// While the owner is not attached, for instance after its TUI reattached
+// under a new id or when the session has no owner, the oldest writable
+// attachment acts as owner. Sessions without an owner or attachments accept
+// any caller.
+func (s *Session) isResizeOwner(clientID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owner != "" && clientID == s.owner {
+		return true
+	}
+	var oldest *attachedClient
+	for _, client := range s.clients {
+		if client.info.ClientID == s.owner && s.owner != "" {
+			return false
+		}
+		if client.info.ReadOnly || client.info.ClientID == "" {
+			continue
+		}
+		if oldest == nil || client.info.ConnectedAt.Before(oldest.info.ConnectedAt) {
+			oldest = client
+		}
+	}
+	if oldest == nil {
+		return s.owner == ""
+	}
+	return clientID == oldest.info.ClientID
+}
+
+// applyViewportPolicy refits latest, smallest and largest sessions after
+// clients attach, detach, send input or report a new viewport.
+func (c *Coordinator) applyViewportPolicy(session *Session) {
+	session.mu.Lock()
+	policy := session.resizePolicy
+	session.mu.Unlock()
+	if policy != ResizeLatest && policy != ResizeSmallest && policy != ResizeLargest {
+		return
+	}
+	cols, rows, ok := session.viewportFit(policy)
+	if !ok || !session.IsRunning() {
+		return
+	}
+	info := session.Info()
+	if info.Cols == cols && info.Rows == rows {
+		return
+	}
+	_ = c.Resize(info.ID, cols, rows)
+}
+
+// viewportFit returns the size the policy derives from attached clients that
+// reported a viewport: the smallest or largest in each dimension, or for
+// latest the viewport of the writable client that most recently sent input.
+func (s *Session) viewportFit(policy ResizePolicy) (uint16, uint16, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if policy == ResizeLatest {
+		return s.latestViewportLocked()
+	}
+	var cols, rows uint16
+	found := false
+	for _, client := range s.clients {
+		if client.info.Cols == 0 || client.info.Rows == 0 {
+			continue
+		}
+		if !found {
+			cols, rows = client.info.Cols, client.info.Rows
+			found = true
+			continue
+		}
+		if policy == ResizeSmallest {
+			cols = min(cols, client.info.Cols)
+			rows = min(rows, client.info.Rows)
+		} else {
+			cols = max(cols, client.info.Cols)
+			rows = max(rows, client.info.Rows)
+		}
+	}
+	return cols, rows, found
+}
+
+// latestViewportLocked returns the viewport of the writable client that most
+// recently sent input. Attaching is not input, so a viewer that connects
+// without typing never takes over; until anyone types, the oldest writable
+// client's viewport is used.
+func (s *Session) latestViewportLocked() (uint16, uint16, bool) {
+	var typed, oldest *attachedClient
+	for _, client := range s.clients {
+		if client.info.Cols == 0 || client.info.Rows == 0 || client.info.ReadOnly {
+			continue
+		}
+		if !client.lastInput.IsZero() && (typed == nil || client.lastInput.After(typed.lastInput)) {
+			typed = client
+		}
+		if oldest == nil || client.info.ConnectedAt.Before(oldest.info.ConnectedAt) {
+			oldest = client
+		}
+	}
+	if typed == nil {
+		typed = oldest
+	}
+	if typed == nil {
+		return 0, 0, false
+	}
+	return typed.info.Cols, typed.info.Rows, true
+}
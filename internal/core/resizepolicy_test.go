@@ -0,0 +1,222 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResizePolicySmallestAndLargest(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("shared", SpawnOptions{
+		Command:      []string{"/bin/sh", "-c", "sleep 5"},
+		ResizePolicy: ResizeSmallest,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if info.ResizePolicy != ResizeSmallest {
+		t.Fatalf("ResizePolicy=%q, want smallest", info.ResizePolicy)
+	}
+	wide, err := coord.AttachClient(info.ID, ClientInfo{ClientID: "wide", Cols: 200, Rows: 50})
+	if err != nil {
+		t.Fatalf("AttachClient: %v", err)
+	}
+	narrow, err := coord.AttachClient(info.ID, ClientInfo{ClientID: "narrow", Cols: 100, Rows: 60})
+	if err != nil {
+		t.Fatalf("AttachClient: %v", err)
+	}
+	assertSize(t, coord, info.ID, 100, 50)
+
+	cols, rows, err := coord.ResizeFrom(info.ID, InputSource{ClientID: "wide"}, 300, 70)
+	if err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	if cols != 100 || rows != 60 {
+		t.Fatalf("ResizeFrom=%dx%d, want 100x60", cols, rows)
+	}
+
+	narrow.Detach()
+	assertSize(t, coord, info.ID, 300, 70)
+	wide.Detach()
+
+	largest, err := coord.Spawn("largest", SpawnOptions{
+		Command:      []string{"/bin/sh", "-c", "sleep 5"},
+		ResizePolicy: ResizeLargest,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	for _, client := range []ClientInfo{{ClientID: "a", Cols: 90, Rows: 40}, {ClientID: "b", Cols: 120, Rows: 30}} {
+		attachment, err := coord.AttachClient(largest.ID, client)
+		if err != nil {
+			t.Fatalf("AttachClient: %v", err)
+		}
+		defer attachment.Detach()
+	}
+	assertSize(t, coord, largest.ID, 120, 40)
+}
+
+func TestResizePolicyFixedAndOwner(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	fixed, err := coord.Spawn("fixed", SpawnOptions{
+		Command:      []string{"/bin/sh", "-c", "sleep 5"},
+		Cols:         80,
+		Rows:         24,
+		ResizePolicy: ResizeFixed,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	cols, rows, err := coord.ResizeFrom(fixed.ID, InputSource{ClientID: "anyone"}, 120, 40)
+	if err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	if cols != 80 || rows != 24 {
+		t.Fatalf("fixed ResizeFrom=%dx%d, want 80x24", cols, rows)
+	}
+
+	owned, err := coord.Spawn("owned", SpawnOptions{
+		Command:      []string{"/bin/sh", "-c", "sleep 5"},
+		Cols:         80,
+		Rows:         24,
+		ResizePolicy: ResizeOwner,
+		Owner:        "alice",
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if _, _, err := coord.ResizeFrom(owned.ID, InputSource{ClientID: "bob"}, 120, 40); err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	assertSize(t, coord, owned.ID, 80, 24)
+	if _, _, err := coord.ResizeFrom(owned.ID, InputSource{ClientID: "alice"}, 120, 40); err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	assertSize(t, coord, owned.ID, 120, 40)
+
+	if _, _, err := coord.ResizeFrom(owned.ID, InputSource{ClientID: "alice"}, 0, 40); !errors.Is(err, ErrInvalidSize) {
+		t.Fatalf("expected ErrInvalidSize, got %v", err)
+	}
+}
+
+func TestResizePolicyLatestFollowsInput(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("latest", SpawnOptions{
+		Command: []string{"/bin/sh", "-c", "sleep 5"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	attach := func(client ClientInfo) {
+		t.Helper()
+		attachment, err := coord.AttachClient(info.ID, client)
+		if err != nil {
+			t.Fatalf("AttachClient: %v", err)
+		}
+		t.Cleanup(attachment.Detach)
+	}
+	// Until anyone types, the first writable client sets the size.
+	attach(ClientInfo{ClientID: "a", Cols: 90, Rows: 40})
+	assertSize(t, coord, info.ID, 90, 40)
+
+	// Connecting is not input: a second client that attaches without typing
+	// does not resize the session, nor does a read-only viewer.
+	attach(ClientInfo{ClientID: "b", Cols: 120, Rows: 30})
+	assertSize(t, coord, info.ID, 90, 40)
+	attach(ClientInfo{ClientID: "viewer", Cols: 60, Rows: 20, ReadOnly: true})
+	assertSize(t, coord, info.ID, 90, 40)
+	if _, _, err := coord.ResizeFrom(info.ID, InputSource{ClientID: "b"}, 140, 35); err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	assertSize(t, coord, info.ID, 90, 40)
+
+	if err := coord.SendAs(info.ID, InputSource{ClientID: "b"}, []byte("x")); err != nil {
+		t.Fatalf("SendAs: %v", err)
+	}
+	assertSize(t, coord, info.ID, 140, 35)
+
+	// A resize from an attached client that is not typing only updates its
+	// viewport.
+	if _, _, err := coord.ResizeFrom(info.ID, InputSource{ClientID: "a"}, 100, 35); err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	assertSize(t, coord, info.ID, 140, 35)
+	if err := coord.SendAs(info.ID, InputSource{ClientID: "a"}, []byte("y")); err != nil {
+		t.Fatalf("SendAs: %v", err)
+	}
+	assertSize(t, coord, info.ID, 100, 35)
+}
+
+func TestResizePolicyOwnerFallback(t *testing.T) {
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	owned, err := coord.Spawn("owned", SpawnOptions{
+		Command:      []string{"/bin/sh", "-c", "sleep 5"},
+		Cols:         80,
+		Rows:         24,
+		ResizePolicy: ResizeOwner,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	// Without an owner or attachments anyone may resize.
+	if _, _, err := coord.ResizeFrom(owned.ID, InputSource{}, 100, 30); err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	assertSize(t, coord, owned.ID, 100, 30)
+
+	first, err := coord.AttachClient(owned.ID, ClientInfo{ClientID: "tui-2", Cols: 100, Rows: 30})
+	if err != nil {
+		t.Fatalf("AttachClient: %v", err)
+	}
+	defer first.Detach()
+	second, err := coord.AttachClient(owned.ID, ClientInfo{ClientID: "web", Cols: 100, Rows: 30})
+	if err != nil {
+		t.Fatalf("AttachClient: %v", err)
+	}
+	defer second.Detach()
+
+	if _, _, err := coord.ResizeFrom(owned.ID, InputSource{ClientID: "web"}, 60, 20); err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	assertSize(t, coord, owned.ID, 100, 30)
+	if _, _, err := coord.ResizeFrom(owned.ID, InputSource{ClientID: "tui-2"}, 120, 40); err != nil {
+		t.Fatalf("ResizeFrom: %v", err)
+	}
+	assertSize(t, coord, owned.ID, 120, 40)
+}
+
+func TestParseResizePolicy(t *testing.T) {
+	for value, want := range map[string]ResizePolicy{
+		"":          "",
+		"latest":    ResizeLatest,
+		" Smallest": ResizeSmallest,
+		"OWNER":     ResizeOwner,
+	} {
+		got, err := ParseResizePolicy(value)
+		if err != nil || got != want {
+			t.Fatalf("ParseResizePolicy(%q)=%q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseResizePolicy("biggest"); !errors.Is(err, ErrInvalidResizePolicy) {
+		t.Fatalf("expected ErrInvalidResizePolicy, got %v", err)
+	}
+}
+
+func assertSize(t *testing.T, coord *Coordinator, id string, cols, rows uint16) {
+	t.Helper()
+	info, err := coord.Info(id)
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.Cols != cols || info.Rows != rows {
+		t.Fatalf("size=%dx%d, want %dx%d", info.Cols, info.Rows, cols, rows)
+	}
+}
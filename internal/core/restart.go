@@ -0,0 +1,141 @@
+package core
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RestartPolicy controls whether a session restarts its process on exit.
+type RestartPolicy string
+
+const (
+	RestartNever     RestartPolicy = "never"
+	RestartOnFailure RestartPolicy = "on-failure"
+	RestartAlways    RestartPolicy = "always"
+)
+
+// restartDelay spaces out restarts so crash loops do not spin.
+var restartDelay = time.Second
+
+// ParseRestartPolicy parses a restart policy. An empty value means never.
+func ParseRestartPolicy(value string) (RestartPolicy, error) {
+	switch RestartPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case "", RestartNever:
+		return RestartNever, nil
+	case RestartOnFailure:
+		return RestartOnFailure, nil
+	case RestartAlways:
+		return RestartAlways, nil
+	default:
+		return "", fmt.Errorf("invalid restart policy %q (expected never, on-failure or always)", value)
+	}
+}
+
+func (p RestartPolicy) shouldRestart(exitCode int) bool {
+	switch p {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return exitCode != 0
+	default:
+		return false
+	}
+}
+
+func (s *Session) ptyHandle() *PTY {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pty
+}
+
+func (s *Session) ioDoneCh() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ioDone
+}
+
+// disableRestart stops any further restarts, e.g. before Remove tears the
+// session down.
+func (s *Session) disableRestart() {
+	s.mu.Lock()
+	s.restart = RestartNever
+	s.mu.Unlock()
+}
+
+// Restarts reports how many times the session process was restarted.
+func (s *Session) Restarts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.restarts
+}
+
+// restartAfterExit replaces the exited process with a fresh one on a new PTY,
+// feeding the same VT, when the restart policy allows it. It returns false
+// when the session should be marked exited instead.
+func (s *Session) restartAfterExit(exitCode int) bool {
+	s.mu.Lock()
+	allowed := s.state == SessionRunning && s.newCmd != nil && s.restart.shouldRestart(exitCode)
+	old := s.pty
+	ioDone := s.ioDone
+	s.mu.Unlock()
+	if !allowed {
+		return false
+	}
+
+	if ioDone != nil {
+		select {
+		case <-ioDone:
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+	_ = old.Close()
+	time.Sleep(restartDelay)
+
+	s.mu.Lock()
+	allowed = s.state == SessionRunning && s.restart.shouldRestart(exitCode)
+	cols, rows := s.cols, s.rows
+	newCmd := s.newCmd
+	sb := s.sandbox
+	s.mu.Unlock()
+	if !allowed {
+		return false
+	}
+	next, err := startSandboxedPTY(newCmd, sb, cols, rows)
+	if err != nil {
+		return false
+	}
+
+	s.mu.Lock()
+	if s.state != SessionRunning || !s.restart.shouldRestart(exitCode) {
+		s.mu.Unlock()
+		_ = next.SignalGroup(syscall.SIGKILL)
+		_ = next.Close()
+		go func() { _ = next.Wait() }()
+		return false
+	}
+	s.pty = next
+	s.ioDone = next.StartReadLoop(s.vt, s.recordOutput, nil)
+	s.restarts++
+	s.mu.Unlock()
+	s.recordActivity()
+	if s.onListChange != nil {
+		s.onListChange()
+	}
+	return true
+}
+
+func commandFactory(args []string, dir string, env []string) func() *exec.Cmd {
+	args = append([]string(nil), args...)
+	env = append([]string(nil), env...)
+	return func() *exec.Cmd {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		if len(env) > 0 {
+			cmd.Env = env
+		}
+		return cmd
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRestartPolicy(t *testing.T) {
+	cases := map[string]RestartPolicy{
+		"":           RestartNever,
+		"never":      RestartNever,
+		"on-failure": RestartOnFailure,
+		"Always":     RestartAlways,
+	}
+	for input, want := range cases {
+		got, err := ParseRestartPolicy(input)
+		if err != nil {
+			t.Fatalf("ParseRestartPolicy(%q): %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("ParseRestartPolicy(%q)=%q, want %q", input, got, want)
+		}
+	}
+	if _, err := ParseRestartPolicy("sometimes"); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+}
+
+func TestRestartOnFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+	prevDelay := restartDelay
+	restartDelay = 10 * time.Millisecond
+	defer func() { restartDelay = prevDelay }()
+
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	counter := filepath.Join(t.TempDir(), "runs")
+	script := `n=$(cat "$0" 2>/dev/null || echo 0); n=$((n+1)); echo $n > "$0"; echo "run:$n"; sleep 0.1; [ "$n" -ge 2 ] && exit 0; exit 3`
+	info, err := coord.Spawn("flaky", SpawnOptions{
+		Command:       []string{"/bin/sh", "-c", script, counter},
+		Restart:       RestartOnFailure,
+		IdleThreshold: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	exited := waitForState(t, coord, info.ID, SessionExited, 5*time.Second)
+	if exited.ExitCode != 0 {
+		t.Fatalf("exit code=%d", exited.ExitCode)
+	}
+	if exited.Restarts != 1 {
+		t.Fatalf("restarts=%d, want 1", exited.Restarts)
+	}
+	runs, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.TrimSpace(string(runs)) != "2" {
+		t.Fatalf("runs=%q, want 2", runs)
+	}
+}
+
+func TestSpawnProfiles(t *testing.T) {
+	coord := NewCoordinator(CoordinatorOptions{
+		Profiles: map[string]SpawnProfile{
+			"codex": {Command: "codex", Restart: RestartAlways},
+			"build": {Command: "make"},
+		},
+	})
+	profile, err := coord.Profile("codex")
+	if err != nil {
+		t.Fatalf("Profile: %v", err)
+	}
+	if profile.Command != "codex" || profile.Restart != RestartAlways {
+		t.Fatalf("profile=%+v", profile)
+	}
+	if _, err := coord.Profile("missing"); err != ErrProfileNotFound {
+		t.Fatalf("expected ErrProfileNotFound, got %v", err)
+	}
+	names := coord.ProfileNames()
+	if len(names) != 2 || names[0] != "build" || names[1] != "codex" {
+		t.Fatalf("names=%v", names)
+	}
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const cgroupCPUPeriod = 100000
+
+var rlimitResources = map[string]int{
+	"cpu":     unix.RLIMIT_CPU,
+	"fsize":   unix.RLIMIT_FSIZE,
+	"data":    unix.RLIMIT_DATA,
+	"stack":   unix.RLIMIT_STACK,
+	"core":    unix.RLIMIT_CORE,
+	"nproc":   unix.RLIMIT_NPROC,
+	"nofile":  unix.RLIMIT_NOFILE,
+	"memlock": unix.RLIMIT_MEMLOCK,
+	"as":      unix.RLIMIT_AS,
+}
+
+// sandbox holds the per-session isolation state: the clone flags and
+// credentials applied to each started process and an optional cgroup leaf
+// that outlives restarts.
+type sandbox struct {
+	iso       *Isolation
+	cgroupDir string
+	cgroupFD  *os.File
+}
+
+func newSandbox(id string, iso *Isolation, cgroupRoot string) (*sandbox, error) {
+	if iso == nil {
+		return nil, nil
+	}
+	sb := &sandbox{iso: iso}
+	if !iso.needsCgroup() {
+		return sb, nil
+	}
+	if cgroupRoot == "" {
+		return nil, fmt.Errorf("%w: cgroup limits require a cgroup root (--cgroup-root)", ErrIsolationUnavailable)
+	}
+	// Controllers may already be enabled; failures surface when the limit
+	// files are missing below.
+	for _, controller := range []string{"+cpu", "+memory", "+pids"} {
+		_ = os.WriteFile(filepath.Join(cgroupRoot, "cgroup.subtree_control"), []byte(controller), 0)
+	}
+	dir := filepath.Join(cgroupRoot, "vtr-"+id)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIsolationUnavailable, err)
+	}
+	sb.cgroupDir = dir
+	limits := map[string]string{}
+	if iso.CPUMax > 0 {
+		quota := int64(iso.CPUMax * cgroupCPUPeriod)
+		if quota < 1000 {
+			quota = 1000
+		}
+		limits["cpu.max"] = fmt.Sprintf("%d %d", quota, cgroupCPUPeriod)
+	}
+	if iso.MemoryMax > 0 {
+		limits["memory.max"] = strconv.FormatInt(iso.MemoryMax, 10)
+	}
+	if iso.PidsMax > 0 {
+		limits["pids.max"] = strconv.FormatInt(iso.PidsMax, 10)
+	}
+	for file, value := range limits {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(value), 0); err != nil {
+			sb.release()
+			return nil, fmt.Errorf("%w: %s: %v", ErrIsolationUnavailable, file, err)
+		}
+	}
+	fd, err := os.Open(dir)
+	if err != nil {
+		sb.release()
+		return nil, fmt.Errorf("%w: %v", ErrIsolationUnavailable, err)
+	}
+	sb.cgroupFD = fd
+	return sb, nil
+}
+
+// prepare applies namespaces, credentials and the cgroup to cmd before it is
+// started. The process is placed in the cgroup atomically via clone3. When
+// the sandbox needs rlimits or a pid namespace, cmd is rewritten to start
+// through the sandbox init helper (see sandboxinit_linux.go), which applies
+// them before the target is exec'd.
+func (sb *sandbox) prepare(cmd *exec.Cmd) {
+	if sb == nil {
+		return
+	}
+	iso := sb.iso
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	attr := cmd.SysProcAttr
+	if sb.cgroupFD != nil {
+		attr.UseCgroupFD = true
+		attr.CgroupFD = int(sb.cgroupFD.Fd())
+	}
+	uid := uint32(os.Getuid())
+	gid := uint32(os.Getgid())
+	if iso.UID != nil {
+		uid = *iso.UID
+	}
+	if iso.GID != nil {
+		gid = *iso.GID
+	}
+	if iso.UserNS {
+		attr.Cloneflags |= syscall.CLONE_NEWUSER
+		attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: int(uid), HostID: os.Getuid(), Size: 1}}
+		attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: int(gid), HostID: os.Getgid(), Size: 1}}
+		attr.GidMappingsEnableSetgroups = false
+	}
+	// A pid namespace gets its own mount namespace so the helper can mount
+	// a /proc that only shows the session's processes.
+	if iso.MountNS || iso.PIDNS {
+		attr.Cloneflags |= syscall.CLONE_NEWNS
+	}
+	if iso.PIDNS {
+		attr.Cloneflags |= syscall.CLONE_NEWPID
+	}
+	if iso.NetNS {
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	if len(iso.Rlimits) == 0 && !iso.PIDNS {
+		if iso.UID != nil || iso.GID != nil {
+			attr.Credential = &syscall.Credential{Uid: uid, Gid: gid, NoSetGroups: iso.UserNS}
+		}
+		return
+	}
+
+	spec := sandboxInitSpec{init: iso.PIDNS, mountProc: iso.PIDNS, rlimits: iso.Rlimits}
+	if iso.UserNS {
+		// The helper already runs as the mapped ids; it needs
+		// CAP_SYS_ADMIN in the namespace to mount /proc and drops it
+		// before exec.
+		if iso.PIDNS && uid != 0 {
+			attr.AmbientCaps = []uintptr{unix.CAP_SYS_ADMIN}
+		}
+	} else if iso.UID != nil || iso.GID != nil {
+		// The helper switches ids after mounting /proc.
+		spec.uid, spec.gid = iso.UID, iso.GID
+	}
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(append([]string(nil), env...), sandboxInitEnv+"="+spec.String())
+	cmd.Args = append([]string{sandboxInitArg0, cmd.Path}, cmd.Args...)
+	cmd.Path = "/proc/self/exe"
+}
+
+// release kills anything left in the cgroup and removes it.
+func (sb *sandbox) release() {
+	if sb == nil || sb.cgroupDir == "" {
+		return
+	}
+	if sb.cgroupFD != nil {
+		_ = sb.cgroupFD.Close()
+		sb.cgroupFD = nil
+	}
+	_ = os.WriteFile(filepath.Join(sb.cgroupDir, "cgroup.kill"), []byte("1"), 0)
+	for attempt := 0; attempt < 20; attempt++ {
+		err := os.Remove(sb.cgroupDir)
+		if err == nil || errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	sb.cgroupDir = ""
+}
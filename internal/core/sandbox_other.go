@@ -0,0 +1,21 @@
+//go:build !linux
+
+package core
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+type sandbox struct{}
+
+func newSandbox(id string, iso *Isolation, cgroupRoot string) (*sandbox, error) {
+	if iso == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: isolation requires a linux coordinator", ErrIsolationUnavailable)
+}
+
+func (sb *sandbox) prepare(cmd *exec.Cmd) {}
+
+func (sb *sandbox) release() {}
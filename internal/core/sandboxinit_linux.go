@@ -0,0 +1,220 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Sandboxed sessions that need rlimits or a pid namespace start through the
+// coordinator binary itself: prepare rewrites the command to
+// /proc/self/exe with argv[0] sandboxInitArg0 and the settings in
+// sandboxInitEnv, and the init func below takes over before main runs. It
+// applies the settings and then execs the target, so nothing the target runs
+// escapes them.
+const (
+	sandboxInitArg0 = "vtr-sandbox-init"
+	sandboxInitEnv  = "VTR_SANDBOX_INIT"
+)
+
+func init() {
+	spec, ok := os.LookupEnv(sandboxInitEnv)
+	if !ok || len(os.Args) < 3 || os.Args[0] != sandboxInitArg0 {
+		return
+	}
+	err := runSandboxInit(spec, os.Args[1], os.Args[2:])
+	fmt.Fprintf(os.Stderr, "vtr: sandbox: %v\r\n", err)
+	os.Exit(126)
+}
+
+// sandboxInitSpec is what the helper does before exec'ing the target.
+type sandboxInitSpec struct {
+	// init keeps the helper running as pid 1 of a pid namespace: it
+	// forwards termination signals, reaps orphans and exits with the
+	// target's status.
+	init      bool
+	mountProc bool
+	uid       *uint32
+	gid       *uint32
+	rlimits   []Rlimit
+}
+
+// String encodes the spec as comma-separated fields: "init", "proc",
+// "uid=N", "gid=N" and "<resource>=<soft>:<hard>".
+func (s sandboxInitSpec) String() string {
+	var fields []string
+	if s.init {
+		fields = append(fields, "init")
+	}
+	if s.mountProc {
+		fields = append(fields, "proc")
+	}
+	if s.uid != nil {
+		fields = append(fields, "uid="+strconv.FormatUint(uint64(*s.uid), 10))
+	}
+	if s.gid != nil {
+		fields = append(fields, "gid="+strconv.FormatUint(uint64(*s.gid), 10))
+	}
+	for _, limit := range s.rlimits {
+		hard := limit.Hard
+		if hard == 0 {
+			hard = limit.Soft
+		}
+		fields = append(fields, fmt.Sprintf("%s=%d:%d", limit.Resource, limit.Soft, hard))
+	}
+	return strings.Join(fields, ",")
+}
+
+func parseSandboxInitSpec(value string) (sandboxInitSpec, error) {
+	var spec sandboxInitSpec
+	for _, field := range strings.Split(value, ",") {
+		key, arg, _ := strings.Cut(field, "=")
+		switch key {
+		case "":
+		case "init":
+			spec.init = true
+		case "proc":
+			spec.mountProc = true
+		case "uid", "gid":
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				return spec, fmt.Errorf("invalid %s %q", key, arg)
+			}
+			id32 := uint32(id)
+			if key == "uid" {
+				spec.uid = &id32
+			} else {
+				spec.gid = &id32
+			}
+		default:
+			if _, ok := rlimitResources[key]; !ok {
+				return spec, fmt.Errorf("unknown setting %q", key)
+			}
+			softText, hardText, _ := strings.Cut(arg, ":")
+			soft, err := strconv.ParseUint(softText, 10, 64)
+			if err != nil {
+				return spec, fmt.Errorf("invalid rlimit %q", field)
+			}
+			hard, err := strconv.ParseUint(hardText, 10, 64)
+			if err != nil {
+				return spec, fmt.Errorf("invalid rlimit %q", field)
+			}
+			spec.rlimits = append(spec.rlimits, Rlimit{Resource: key, Soft: soft, Hard: hard})
+		}
+	}
+	return spec, nil
+}
+
+// runSandboxInit only returns on failure.
+func runSandboxInit(value, path string, argv []string) error {
+	spec, err := parseSandboxInitSpec(value)
+	if err != nil {
+		return err
+	}
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, sandboxInitEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+	if spec.mountProc {
+		if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+			return fmt.Errorf("make mounts private: %w", err)
+		}
+		if err := unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+			return fmt.Errorf("mount /proc: %w", err)
+		}
+	}
+	if spec.init {
+		// The target starts through a second helper that applies the
+		// remaining settings, so the init itself runs without them.
+		child := spec
+		child.init, child.mountProc = false, false
+		return runSandboxPID1(child, path, argv, env)
+	}
+
+	// Capabilities and ids belong to the exec'ing thread.
+	runtime.LockOSThread()
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil && !errors.Is(err, unix.EINVAL) {
+		return fmt.Errorf("drop capabilities: %w", err)
+	}
+	if spec.uid != nil || spec.gid != nil {
+		if err := syscall.Setgroups(nil); err != nil {
+			return fmt.Errorf("setgroups: %w", err)
+		}
+	}
+	if spec.gid != nil {
+		if err := syscall.Setgid(int(*spec.gid)); err != nil {
+			return fmt.Errorf("setgid: %w", err)
+		}
+	}
+	if spec.uid != nil {
+		if err := syscall.Setuid(int(*spec.uid)); err != nil {
+			return fmt.Errorf("setuid: %w", err)
+		}
+	}
+	for _, limit := range spec.rlimits {
+		// syscall.Setrlimit also stops the runtime from restoring its
+		// startup RLIMIT_NOFILE on exec.
+		rlimit := syscall.Rlimit{Cur: limit.Soft, Max: limit.Hard}
+		if err := syscall.Setrlimit(rlimitResources[limit.Resource], &rlimit); err != nil {
+			return fmt.Errorf("rlimit %s: %w", limit.Resource, err)
+		}
+	}
+	return syscall.Exec(path, argv, env)
+}
+
+// runSandboxPID1 runs the target as the only child of the namespace init. A
+// pid 1 ignores signals it has no handler for, so SIGTERM and SIGHUP from
+// Kill and Close are forwarded; terminal signals already reach the target
+// through the foreground process group. When the target exits the init
+// exits with its status, which kills everything left in the namespace.
+func runSandboxPID1(child sandboxInitSpec, path string, argv []string, env []string) error {
+	signals := make(chan os.Signal, 8)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT,
+		syscall.SIGTSTP, syscall.SIGWINCH, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	// The target always starts through the exec helper, which also drops
+	// any ambient capabilities the init needed to mount /proc.
+	args := append([]string{sandboxInitArg0, path}, argv...)
+	env = append(env, sandboxInitEnv+"="+child.String())
+	proc, err := os.StartProcess("/proc/self/exe", args, &os.ProcAttr{
+		Env:   env,
+		Files: []*os.File{os.Stdin, os.Stdout, os.Stderr},
+	})
+	if err != nil {
+		return err
+	}
+	go func() {
+		for sig := range signals {
+			switch sig {
+			case syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR1, syscall.SIGUSR2:
+				_ = proc.Signal(sig)
+			}
+		}
+	}()
+	for {
+		var ws syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &ws, 0, nil)
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("wait: %w", err)
+		}
+		if pid != proc.Pid {
+			continue
+		}
+		if ws.Signaled() {
+			os.Exit(128 + int(ws.Signal()))
+		}
+		os.Exit(ws.ExitStatus())
+	}
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxTagKeyLen   = 63
+	maxTagValueLen = 256
+)
+
+// Built-in selector keys. They describe session state rather than user
+// metadata, so tags may not shadow them.
+const (
+	SelectorKeyName        = "name"
+	SelectorKeyID          = "id"
+	SelectorKeyStatus      = "status"
+	SelectorKeyIdle        = "idle"
+	SelectorKeyCoordinator = "coordinator"
+)
+
+var reservedTagKeys = map[string]struct{}{
+	SelectorKeyName:        {},
+	SelectorKeyID:          {},
+	SelectorKeyStatus:      {},
+	SelectorKeyIdle:        {},
+	SelectorKeyCoordinator: {},
+}
+
+type selectorOp int
+
+const (
+	selectorEquals selectorOp = iota
+	selectorNotEquals
+	selectorExists
+	selectorNotExists
+)
+
+type selectorRequirement struct {
+	key   string
+	op    selectorOp
+	value string
+}
+
+// Selector filters sessions using label-selector style requirements, e.g.
+// "owner=agent-7,idle=true". Supported terms are key=value, key==value,
+// key!=value, key (exists) and !key (absent). All terms must match.
+type Selector struct {
+	requirements []selectorRequirement
+}
+
+// ParseSelector parses a comma-separated selector. An empty string matches
+// every session.
+func ParseSelector(value string) (Selector, error) {
+	var sel Selector
+	for _, term := range strings.Split(value, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		req, err := parseSelectorTerm(term)
+		if err != nil {
+			return Selector{}, err
+		}
+		sel.requirements = append(sel.requirements, req)
+	}
+	return sel, nil
+}
+
+func parseSelectorTerm(term string) (selectorRequirement, error) {
+	if idx := strings.Index(term, "!="); idx >= 0 {
+		return newSelectorRequirement(term, term[:idx], selectorNotEquals, term[idx+2:])
+	}
+	if idx := strings.Index(term, "=="); idx >= 0 {
+		return newSelectorRequirement(term, term[:idx], selectorEquals, term[idx+2:])
+	}
+	if idx := strings.Index(term, "="); idx >= 0 {
+		return newSelectorRequirement(term, term[:idx], selectorEquals, term[idx+1:])
+	}
+	if strings.HasPrefix(term, "!") {
+		return newSelectorRequirement(term, term[1:], selectorNotExists, "")
+	}
+	return newSelectorRequirement(term, term, selectorExists, "")
+}
+
+func newSelectorRequirement(term, key string, op selectorOp, value string) (selectorRequirement, error) {
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+	if err := validateTagKey(key); err != nil {
+		return selectorRequirement{}, fmt.Errorf("invalid selector %q: %w", term, err)
+	}
+	if strings.ContainsAny(value, "=!") {
+		return selectorRequirement{}, fmt.Errorf("invalid selector %q: value must not contain '=' or '!'", term)
+	}
+	return selectorRequirement{key: key, op: op, value: value}, nil
+}
+
+// Empty reports whether the selector has no requirements.
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// Split separates requirements on key from the rest. Hubs use it to evaluate
+// coordinator terms themselves and forward the remainder to each coordinator.
+func (s Selector) Split(key string) (Selector, Selector) {
+	var matched, rest Selector
+	for _, req := range s.requirements {
+		if req.key == key {
+			matched.requirements = append(matched.requirements, req)
+			continue
+		}
+		rest.requirements = append(rest.requirements, req)
+	}
+	return matched, rest
+}
+
+// String formats the selector in its canonical comma-separated form.
+func (s Selector) String() string {
+	terms := make([]string, 0, len(s.requirements))
+	for _, req := range s.requirements {
+		switch req.op {
+		case selectorEquals:
+			terms = append(terms, req.key+"="+req.value)
+		case selectorNotEquals:
+			terms = append(terms, req.key+"!="+req.value)
+		case selectorExists:
+			terms = append(terms, req.key)
+		case selectorNotExists:
+			terms = append(terms, "!"+req.key)
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// Matches reports whether fields satisfy every requirement.
+func (s Selector) Matches(fields map[string]string) bool {
+	for _, req := range s.requirements {
+		value, ok := fields[req.key]
+		switch req.op {
+		case selectorEquals:
+			if !ok || value != req.value {
+				return false
+			}
+		case selectorNotEquals:
+			if ok && value == req.value {
+				return false
+			}
+		case selectorExists:
+			if !ok {
+				return false
+			}
+		case selectorNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MatchesInfo reports whether a session matches the selector. Tags are
+// combined with the built-in name, id, status and idle fields.
+func (s Selector) MatchesInfo(info SessionInfo) bool {
+	if s.Empty() {
+		return true
+	}
+	return s.Matches(info.SelectorFields())
+}
+
+// SelectorFields returns the fields a selector is evaluated against.
+func (info SessionInfo) SelectorFields() map[string]string {
+	fields := make(map[string]string, len(info.Tags)+4)
+	for key, value := range info.Tags {
+		fields[key] = value
+	}
+	fields[SelectorKeyName] = info.Label
+	fields[SelectorKeyID] = info.ID
+	fields[SelectorKeyStatus] = info.State.String()
+	fields[SelectorKeyIdle] = strconv.FormatBool(info.Idle)
+	return fields
+}
+
+func validateTags(tags map[string]string) error {
+	for key, value := range tags {
+		if err := validateTagKey(key); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidTags, err)
+		}
+		if _, ok := reservedTagKeys[key]; ok {
+			return fmt.Errorf("%w: key %q is reserved", ErrInvalidTags, key)
+		}
+		if len(value) > maxTagValueLen {
+			return fmt.Errorf("%w: value for %q exceeds %d bytes", ErrInvalidTags, key, maxTagValueLen)
+		}
+		if strings.ContainsAny(value, ",=!") || strings.TrimSpace(value) != value {
+			return fmt.Errorf("%w: value for %q must not contain ',', '=', '!' or surrounding whitespace", ErrInvalidTags, key)
+		}
+	}
+	return nil
+}
+
+func validateTagKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("key is required")
+	}
+	if len(key) > maxTagKeyLen {
+		return fmt.Errorf("key %q exceeds %d bytes", key, maxTagKeyLen)
+	}
+	for i, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case i > 0 && (r == '-' || r == '_' || r == '.' || r == '/'):
+		default:
+			return fmt.Errorf("key %q must be alphanumeric with '-', '_', '.' or '/'", key)
+		}
+	}
+	return nil
+}
+
+func cloneTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(tags))
+	for key, value := range tags {
+		out[key] = value
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSelectorMatches(t *testing.T) {
+	fields := map[string]string{"owner": "agent-7", "idle": "true", "repo": "api"}
+	cases := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"owner=agent-7", true},
+		{"owner==agent-7,idle=true", true},
+		{"owner=agent-8", false},
+		{"owner!=agent-8", true},
+		{"repo", true},
+		{"!repo", false},
+		{"!task", true},
+		{"task!=1234", true},
+		{"owner=agent-7, idle=false", false},
+	}
+	for _, tc := range cases {
+		sel, err := ParseSelector(tc.selector)
+		if err != nil {
+			t.Fatalf("ParseSelector(%q): %v", tc.selector, err)
+		}
+		if got := sel.Matches(fields); got != tc.want {
+			t.Fatalf("ParseSelector(%q).Matches=%v, want %v", tc.selector, got, tc.want)
+		}
+	}
+}
+
+func TestParseSelectorInvalid(t *testing.T) {
+	for _, value := range []string{"=x", "bad key=x", "owner=a=b", "-owner"} {
+		if _, err := ParseSelector(value); err == nil {
+			t.Fatalf("ParseSelector(%q) expected error", value)
+		}
+	}
+}
+
+func TestSelectorSplitAndString(t *testing.T) {
+	sel, err := ParseSelector("coordinator=spoke-a,owner!=x,!task,repo")
+	if err != nil {
+		t.Fatalf("ParseSelector: %v", err)
+	}
+	coord, rest := sel.Split(SelectorKeyCoordinator)
+	if got := coord.String(); got != "coordinator=spoke-a" {
+		t.Fatalf("coordinator selector=%q", got)
+	}
+	if got := rest.String(); got != "owner!=x,!task,repo" {
+		t.Fatalf("rest selector=%q", got)
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	if err := validateTags(map[string]string{"owner": "agent-7", "team/name": ""}); err != nil {
+		t.Fatalf("validateTags: %v", err)
+	}
+	invalid := []map[string]string{
+		{"idle": "true"},
+		{"": "x"},
+		{"owner": "a,b"},
+		{"owner": " padded"},
+	}
+	for _, tags := range invalid {
+		if err := validateTags(tags); !errors.Is(err, ErrInvalidTags) {
+			t.Fatalf("validateTags(%v) err=%v, want ErrInvalidTags", tags, err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed all:shellintegration
+var shellIntegrationFS embed.FS
+
+// shellIntegrationDir writes the bundled integration scripts once per
+// coordinator and returns their directory.
+func (c *Coordinator) shellIntegrationDir() (string, error) {
+	c.shellMu.Lock()
+	defer c.shellMu.Unlock()
+	if c.shellDir != "" {
+		return c.shellDir, nil
+	}
+	dir, err := os.MkdirTemp("", "vtr-shell-")
+	if err != nil {
+		return "", err
+	}
+	err = fs.WalkDir(shellIntegrationFS, "shellintegration", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, strings.TrimPrefix(path, "shellintegration"))
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := shellIntegrationFS.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	c.shellDir = dir
+	return dir, nil
+}
+
+// removeShellIntegrationDir deletes the scripts written by shellIntegrationDir.
+func (c *Coordinator) removeShellIntegrationDir() {
+	c.shellMu.Lock()
+	defer c.shellMu.Unlock()
+	if c.shellDir != "" {
+		_ = os.RemoveAll(c.shellDir)
+		c.shellDir = ""
+	}
+}
+
+// interactiveShell returns bash, zsh or fish when args start that shell
+// interactively without a command or script, and "" otherwise.
+func interactiveShell(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	name := strings.TrimPrefix(filepath.Base(args[0]), "-")
+	switch name {
+	case "bash", "zsh", "fish":
+	default:
+		return ""
+	}
+	for _, arg := range args[1:] {
+		switch {
+		case arg == "-i", arg == "--interactive":
+		case name == "bash" && (arg == "--noprofile" || arg == "--noediting"):
+		default:
+			// Commands, scripts, login shells and rc overrides are left alone.
+			return ""
+		}
+	}
+	return name
+}
+
+// injectShellIntegration rewrites args and env so the shell loads vtr's
+// integration while still reading the user's own startup files. It reports
+// false when args are not an interactive bash, zsh or fish.
+func (c *Coordinator) injectShellIntegration(args, env []string) ([]string, []string, bool) {
+	shell := interactiveShell(args)
+	if shell == "" {
+		return args, env, false
+	}
+	dir, err := c.shellIntegrationDir()
+	if err != nil {
+		return args, env, false
+	}
+	switch shell {
+	case "bash":
+		out := append([]string{args[0], "--rcfile", filepath.Join(dir, "bash", "vtr.bash")}, args[1:]...)
+		return out, env, true
+	case "zsh":
+		extra := []string{"ZDOTDIR=" + filepath.Join(dir, "zsh")}
+		if value, ok := lookupEnv(env, "ZDOTDIR"); ok {
+			extra = append(extra, "VTR_ZDOTDIR="+value)
+		}
+		return args, mergeEnv(env, extra), true
+	case "fish":
+		value, ok := lookupEnv(env, "XDG_DATA_DIRS")
+		dirs := value
+		if !ok || dirs == "" {
+			dirs = "/usr/local/share:/usr/share"
+		}
+		// fish loads <entry>/fish/vendor_conf.d from each XDG_DATA_DIRS entry.
+		extra := []string{"XDG_DATA_DIRS=" + dir + ":" + dirs}
+		if ok {
+			extra = append(extra, "VTR_XDG_DATA_DIRS="+value)
+		}
+		return args, mergeEnv(env, extra), true
+	}
+	return args, env, false
+}
+
+func lookupEnv(env []string, key string) (string, bool) {
+	for i := len(env) - 1; i >= 0; i-- {
+		if name, value, ok := strings.Cut(env[i], "="); ok && name == key {
+			return value, true
+		}
+	}
+	return "", false
+}
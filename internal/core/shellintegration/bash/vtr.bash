@@ -0,0 +1,34 @@
+# vtr shell integration for bash, loaded with --rcfile in place of ~/.bashrc.
+# Emits OSC 133 prompt/command markers and OSC 7 cwd reports.
+
+if [ -r "$HOME/.bashrc" ]; then
+    builtin source "$HOME/.bashrc"
+fi
+
+if [ -z "${__vtr_loaded-}" ]; then
+    __vtr_loaded=1
+    __vtr_ran=0
+
+    __vtr_precmd() {
+        local ret=$?
+        if [ "$__vtr_ran" = 1 ]; then
+            builtin printf '\e]133;D;%s\a' "$ret"
+        fi
+        __vtr_ran=0
+        builtin printf '\e]7;file://%s%s\a' "$HOSTNAME" "$PWD"
+        case "$PS1" in
+        *'133;A'*) ;;
+        *) PS1='\[\e]133;A\a\]'"$PS1"'\[\e]133;B\a\]' ;;
+        esac
+        case "${PS0-}" in
+        *'133;C'*) ;;
+        # The subscript assignment marks that a command ran without printing.
+        *) PS0="${PS0-}"'${__vtr_nil[__vtr_ran=1]}\e]133;C\a' ;;
+        esac
+        return $ret
+    }
+
+    if [[ ";${PROMPT_COMMAND[*]-};" != *";__vtr_precmd;"* ]]; then
+        PROMPT_COMMAND="__vtr_precmd${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+    fi
+fi
@@ -0,0 +1,33 @@
+# vtr shell integration for fish, found through XDG_DATA_DIRS.
+# Emits OSC 133 prompt/command markers and OSC 7 cwd reports.
+
+# Restore XDG_DATA_DIRS so child processes do not load this file.
+if set -q VTR_XDG_DATA_DIRS
+    set -gx XDG_DATA_DIRS $VTR_XDG_DATA_DIRS
+    set -e VTR_XDG_DATA_DIRS
+else
+    set -e XDG_DATA_DIRS
+end
+
+status is-interactive; or exit
+
+function __vtr_prompt --on-event fish_prompt
+    printf '\e]7;file://%s%s\a' $hostname $PWD
+    printf '\e]133;A\a'
+    # Wrap the prompt lazily so config.fish can define it first.
+    if not functions -q __vtr_user_prompt
+        functions -c fish_prompt __vtr_user_prompt
+        function fish_prompt
+            __vtr_user_prompt
+            printf '\e]133;B\a'
+        end
+    end
+end
+
+function __vtr_preexec --on-event fish_preexec
+    printf '\e]133;C\a'
+end
+
+function __vtr_postexec --on-event fish_postexec
+    printf '\e]133;D;%s\a' $status
+end
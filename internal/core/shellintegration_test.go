@@ -0,0 +1,92 @@
+package core
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestInteractiveShell(t *testing.T) {
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"/bin/bash"}, "bash"},
+		{[]string{"/usr/bin/zsh", "-i"}, "zsh"},
+		{[]string{"fish"}, "fish"},
+		{[]string{"-bash"}, "bash"},
+		{[]string{"/bin/bash", "-c", "echo hi"}, ""},
+		{[]string{"/bin/bash", "-l"}, ""},
+		{[]string{"/bin/bash", "script.sh"}, ""},
+		{[]string{"/bin/sh"}, ""},
+		{nil, ""},
+	}
+	for _, tc := range cases {
+		if got := interactiveShell(tc.args); got != tc.want {
+			t.Fatalf("interactiveShell(%q) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestSpawnShellIntegrationBash(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	enabled := true
+	info, err := coord.Spawn("integrated", SpawnOptions{
+		Command:          []string{bash},
+		ShellIntegration: &enabled,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if !info.ShellIntegration {
+		t.Fatalf("expected shell integration to be reported in Info")
+	}
+	session, err := coord.GetSession(info.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if err := coord.Send(info.ID, []byte("false\n")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	wants := [][]byte{
+		[]byte("\x1b]133;A\a"),
+		[]byte("\x1b]133;B\a"),
+		[]byte("\x1b]133;C\a"),
+		[]byte("\x1b]133;D;1\a"),
+		[]byte("\x1b]7;file://"),
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		data, _, _, _ := session.OutputSnapshot(0)
+		missing := 0
+		for _, want := range wants {
+			if !bytes.Contains(data, want) {
+				missing++
+			}
+		}
+		if missing == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for shell integration markers, output %q", data)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	plain, err := coord.Spawn("plain", SpawnOptions{Command: []string{bash}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if plain.ShellIntegration {
+		t.Fatalf("expected shell integration to be off by default")
+	}
+}
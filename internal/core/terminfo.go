@@ -0,0 +1,124 @@
+package core
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// GhosttyTerm is the TERM name of the bundled terminfo entry.
+	GhosttyTerm = "xterm-ghostty"
+	// FallbackTerm is used when the bundled entry is not installed.
+	FallbackTerm = "xterm-256color"
+
+	defaultColorTerm = "truecolor"
+	defaultLocale    = "C.UTF-8"
+)
+
+//go:embed terminfo/xterm-ghostty.terminfo
+var ghosttyTerminfo []byte
+
+// SystemTerminfo reports whether an xterm-ghostty entry is already installed
+// on the host (infocmp finds it). A real entry is used as is and takes
+// precedence over the bundled one.
+func SystemTerminfo() bool {
+	infocmp, err := exec.LookPath("infocmp")
+	if err != nil {
+		return false
+	}
+	return exec.Command(infocmp, GhosttyTerm).Run() == nil
+}
+
+// InstallTerminfo compiles the bundled xterm-ghostty entry into dir with tic.
+// Pass dir as CoordinatorOptions.TerminfoDir once it succeeds. Callers check
+// SystemTerminfo first.
+func InstallTerminfo(dir string) error {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return fmt.Errorf("terminfo dir is required")
+	}
+	tic, err := exec.LookPath("tic")
+	if err != nil {
+		return fmt.Errorf("install terminfo: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("install terminfo: %w", err)
+	}
+	src, err := os.CreateTemp("", "vtr-terminfo-*.src")
+	if err != nil {
+		return fmt.Errorf("install terminfo: %w", err)
+	}
+	defer os.Remove(src.Name())
+	if _, err := src.Write(ghosttyTerminfo); err != nil {
+		_ = src.Close()
+		return fmt.Errorf("install terminfo: %w", err)
+	}
+	if err := src.Close(); err != nil {
+		return fmt.Errorf("install terminfo: %w", err)
+	}
+	out, err := exec.Command(tic, "-x", "-o", dir, src.Name()).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("install terminfo: tic: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	if !terminfoInstalled(dir) {
+		return fmt.Errorf("install terminfo: %s entry missing after tic", GhosttyTerm)
+	}
+	return nil
+}
+
+// terminfoInstalled reports whether dir holds a compiled xterm-ghostty entry,
+// in either the letter or the hex directory layout.
+func terminfoInstalled(dir string) bool {
+	for _, sub := range []string{"x", fmt.Sprintf("%02x", 'x')} {
+		if _, err := os.Stat(filepath.Join(dir, sub, GhosttyTerm)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hostLocale returns the coordinator's LANG when it is a UTF-8 locale.
+func hostLocale() string {
+	lang := strings.TrimSpace(os.Getenv("LANG"))
+	lower := strings.ToLower(lang)
+	if strings.Contains(lower, "utf-8") || strings.Contains(lower, "utf8") {
+		return lang
+	}
+	return ""
+}
+
+// sessionEnv builds the environment for a session: the coordinator
+// environment, then TERM/COLORTERM/LANG defaults, then the spawn env.
+func (c *Coordinator) sessionEnv(opts SpawnOptions) []string {
+	term := strings.TrimSpace(opts.Term)
+	if term == "" {
+		term = c.opts.Term
+	}
+	locale := strings.TrimSpace(opts.Locale)
+	if locale == "" {
+		locale = c.opts.Locale
+	}
+	base := make([]string, 0, len(os.Environ()))
+	for _, entry := range os.Environ() {
+		// LC_ALL and LC_CTYPE & co. would override the session locale;
+		// the spawn env can still set them.
+		if strings.HasPrefix(entry, "LC_") {
+			continue
+		}
+		base = append(base, entry)
+	}
+	defaults := []string{
+		"TERM=" + term,
+		"COLORTERM=" + c.opts.ColorTerm,
+		"LANG=" + locale,
+	}
+	if c.opts.TerminfoDir != "" {
+		// The trailing empty entry keeps the system terminfo database.
+		defaults = append(defaults, "TERMINFO_DIRS="+c.opts.TerminfoDir+":"+os.Getenv("TERMINFO_DIRS"))
+	}
+	return mergeEnv(mergeEnv(base, defaults), opts.Env)
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSessionEnvDefaults(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	t.Setenv("LC_ALL", "C")
+	t.Setenv("LC_CTYPE", "C")
+	t.Setenv("LANG", "")
+
+	coord := newTestCoordinator()
+	defer coord.CloseAll()
+
+	info, err := coord.Spawn("env", SpawnOptions{
+		Command: []string{"/bin/sh", "-c", "echo term=$TERM color=$COLORTERM lang=$LANG lcall=${LC_ALL:-unset} ctype=${LC_CTYPE:-unset}; sleep 5"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	waitForDumpContains(t, coord, info.ID, "term=xterm-256color color=truecolor lang=C.UTF-8 lcall=unset ctype=unset", 2*time.Second)
+
+	info, err = coord.Spawn("override", SpawnOptions{
+		Command: []string{"/bin/sh", "-c", "echo term=$TERM lang=$LANG color=$COLORTERM; sleep 5"},
+		Term:    "screen-256color",
+		Locale:  "en_US.UTF-8",
+		Env:     []string{"COLORTERM=24bit"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	waitForDumpContains(t, coord, info.ID, "term=screen-256color lang=en_US.UTF-8 color=24bit", 2*time.Second)
+}
+
+func TestInstallTerminfo(t *testing.T) {
+	if _, err := exec.LookPath("tic"); err != nil {
+		t.Skip("tic not available")
+	}
+	dir := filepath.Join(t.TempDir(), "terminfo")
+	if err := InstallTerminfo(dir); err != nil {
+		t.Fatalf("InstallTerminfo: %v", err)
+	}
+	if !terminfoInstalled(dir) {
+		t.Fatalf("expected %s entry under %s", GhosttyTerm, dir)
+	}
+
+	coord := NewCoordinator(CoordinatorOptions{DefaultShell: "/bin/sh", TerminfoDir: dir})
+	defer coord.CloseAll()
+	env := coord.sessionEnv(SpawnOptions{})
+	want := map[string]string{
+		"TERM=" + GhosttyTerm: "",
+		"TERMINFO_DIRS=" + dir + ":" + os.Getenv("TERMINFO_DIRS"): "",
+	}
+	for _, entry := range env {
+		delete(want, entry)
+	}
+	if len(want) != 0 {
+		t.Fatalf("missing env entries %v in %v", want, env)
+	}
+}
@@ -229,17 +229,21 @@ func (s *Server) Spawn(ctx context.Context, req *proto.SpawnRequest) (*proto.Spa
 	return s.local.Spawn(ctx, req)
 }
 
-func (s *Server) List(ctx context.Context, _ *proto.ListRequest) (*proto.ListResponse, error) {
+func (s *Server) List(ctx context.Context, req *proto.ListRequest) (*proto.ListResponse, error) {
+	query, err := newSessionsQuery(false, req.GetSelector())
+	if err != nil {
+		return nil, err
+	}
 	sessions := make([]*proto.Session, 0)
-	if s.localActive() {
-		localResp, err := s.local.List(ctx, &proto.ListRequest{})
+	if s.localActive() && query.matchesCoordinator(s.localName) {
+		localResp, err := s.local.List(ctx, &proto.ListRequest{Selector: query.selector})
 		if err != nil {
 			return nil, err
 		}
 		sessions = append(sessions, localResp.GetSessions()...)
 	}
 
-	targets := s.spokeTargets()
+	targets := query.filterTargets(s.spokeTargets())
 	if len(targets) == 0 {
 		return &proto.ListResponse{Sessions: sessions}, nil
 	}
@@ -251,7 +255,7 @@ func (s *Server) List(ctx context.Context, _ *proto.ListRequest) (*proto.ListRes
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			resp, err := s.callList(ctx, target)
+			resp, err := s.callList(ctx, target, query.selector)
 			if err != nil {
 				s.logger.Warn("hub: spoke list failed", "spoke", target.Name, "addr", target.Addr, "err", err)
 				return
@@ -273,20 +277,24 @@ func (s *Server) List(ctx context.Context, _ *proto.ListRequest) (*proto.ListRes
 
 func (s *Server) SubscribeSessions(req *proto.SubscribeSessionsRequest, stream proto.VTR_SubscribeSessionsServer) error {
 	ctx := stream.Context()
-	excludeExited := req != nil && req.ExcludeExited
+	query, err := newSessionsQuery(req != nil && req.ExcludeExited, req.GetSelector())
+	if err != nil {
+		return err
+	}
 	state := newFederatedSessionState()
 	signal := newSignal()
+	localMatches := s.localActive() && query.matchesCoordinator(s.localName)
 
-	if s.localActive() && s.localName != "" {
+	if localMatches && s.localName != "" {
 		state.ensureCoordinator(s.localName, s.localPath)
-		if localSessions, err := s.local.List(ctx, &proto.ListRequest{}); err == nil {
-			state.setCoordinatorSessions(s.localName, s.localPath, filterSessions(localSessions.GetSessions(), excludeExited))
+		if localSessions, err := s.local.List(ctx, &proto.ListRequest{Selector: query.selector}); err == nil {
+			state.setCoordinatorSessions(s.localName, s.localPath, filterSessions(localSessions.GetSessions(), query.excludeExited))
 		} else {
 			state.setCoordinatorError(s.localName, s.localPath, err)
 		}
 	}
 
-	targets := s.spokeTargets()
+	targets := query.filterTargets(s.spokeTargets())
 	for _, target := range targets {
 		state.ensureCoordinator(target.Name, target.Addr)
 	}
@@ -298,12 +306,12 @@ func (s *Server) SubscribeSessions(req *proto.SubscribeSessionsRequest, stream p
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				resp, err := s.callList(ctx, target)
+				resp, err := s.callList(ctx, target, query.selector)
 				if err != nil {
 					state.setCoordinatorError(target.Name, target.Addr, err)
 					return
 				}
-				state.setCoordinatorSessions(target.Name, target.Addr, filterSessions(resp.GetSessions(), excludeExited))
+				state.setCoordinatorSessions(target.Name, target.Addr, filterSessions(resp.GetSessions(), query.excludeExited))
 			}()
 		}
 		wg.Wait()
@@ -313,16 +321,16 @@ func (s *Server) SubscribeSessions(req *proto.SubscribeSessionsRequest, stream p
 		return err
 	}
 
-	if s.localActive() {
-		go s.watchLocalSessions(ctx, excludeExited, state, signal)
+	if localMatches {
+		go s.watchLocalSessions(ctx, query, state, signal)
 	}
 
 	for _, target := range targets {
-		go s.watchSpokeSessions(ctx, target, excludeExited, state, signal)
+		go s.watchSpokeSessions(ctx, target, query, state, signal)
 	}
 
 	if s.registry != nil {
-		go s.watchRegistry(ctx, excludeExited, state, signal)
+		go s.watchRegistry(ctx, query, state, signal)
 	}
 
 	for {
@@ -337,7 +345,7 @@ func (s *Server) SubscribeSessions(req *proto.SubscribeSessionsRequest, stream p
 	}
 }
 
-func (s *Server) watchLocalSessions(ctx context.Context, excludeExited bool, state *federatedSessionState, signal *updateSignal) {
+func (s *Server) watchLocalSessions(ctx context.Context, query sessionsQuery, state *federatedSessionState, signal *updateSignal) {
 	stream := &localSessionsStream{
 		ctx: ctx,
 		send: func(snapshot *proto.SessionsSnapshot) error {
@@ -345,7 +353,7 @@ func (s *Server) watchLocalSessions(ctx context.Context, excludeExited bool, sta
 				return nil
 			}
 			sessions := snapshotSessions(snapshot, s.localName)
-			if excludeExited {
+			if query.excludeExited {
 				sessions = filterSessions(sessions, true)
 			}
 			state.setCoordinatorSessions(s.localName, s.localPath, sessions)
@@ -353,7 +361,10 @@ func (s *Server) watchLocalSessions(ctx context.Context, excludeExited bool, sta
 			return nil
 		},
 	}
-	err := s.local.SubscribeSessions(&proto.SubscribeSessionsRequest{ExcludeExited: excludeExited}, stream)
+	err := s.local.SubscribeSessions(&proto.SubscribeSessionsRequest{
+		ExcludeExited: query.excludeExited,
+		Selector:      query.selector,
+	}, stream)
 	if err != nil && ctx.Err() == nil {
 		state.setCoordinatorError(s.localName, s.localPath, err)
 		signal.pulse()
@@ -364,12 +375,12 @@ func (s *Server) Info(ctx context.Context, req *proto.InfoRequest) (*proto.InfoR
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -387,12 +398,12 @@ func (s *Server) Kill(ctx context.Context, req *proto.KillRequest) (*proto.KillR
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -406,12 +417,12 @@ func (s *Server) Close(ctx context.Context, req *proto.CloseRequest) (*proto.Clo
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -425,12 +436,12 @@ func (s *Server) Remove(ctx context.Context, req *proto.RemoveRequest) (*proto.R
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -444,7 +455,7 @@ func (s *Server) Rename(ctx context.Context, req *proto.RenameRequest) (*proto.R
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
@@ -453,7 +464,7 @@ func (s *Server) Rename(ctx context.Context, req *proto.RenameRequest) (*proto.R
 			return nil, s.localDisabledError()
 		}
 		reqCopy := *req
-		reqCopy.Session = &proto.SessionRef{Id: sessionID}
+		reqCopy.Session = sessionRef
 		return s.local.Rename(ctx, &reqCopy)
 	}
 	newName := strings.TrimSpace(req.NewName)
@@ -461,21 +472,59 @@ func (s *Server) Rename(ctx context.Context, req *proto.RenameRequest) (*proto.R
 		return nil, status.Error(codes.InvalidArgument, "new name is required")
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	reqCopy.NewName = newName
 	return s.callRename(ctx, spoke, &reqCopy)
 }
 
+func (s *Server) UpdateSession(ctx context.Context, req *proto.UpdateSessionRequest) (*proto.UpdateSessionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.UpdateSession(ctx, &reqCopy)
+	}
+	return s.callUpdateSession(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) Clone(ctx context.Context, req *proto.CloneRequest) (*proto.CloneResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.Clone(ctx, &reqCopy)
+	}
+	return s.callClone(ctx, spoke, &reqCopy)
+}
+
 func (s *Server) GetScreen(ctx context.Context, req *proto.GetScreenRequest) (*proto.GetScreenResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -493,12 +542,12 @@ func (s *Server) Grep(ctx context.Context, req *proto.GrepRequest) (*proto.GrepR
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -508,16 +557,73 @@ func (s *Server) Grep(ctx context.Context, req *proto.GrepRequest) (*proto.GrepR
 	return s.callGrep(ctx, spoke, &reqCopy)
 }
 
+func (s *Server) FindOnScreen(ctx context.Context, req *proto.FindOnScreenRequest) (*proto.FindOnScreenResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.FindOnScreen(ctx, &reqCopy)
+	}
+	return s.callFindOnScreen(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) ExportScreen(ctx context.Context, req *proto.ExportScreenRequest) (*proto.ExportScreenResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.ExportScreen(ctx, &reqCopy)
+	}
+	return s.callExportScreen(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) ReadOutput(ctx context.Context, req *proto.ReadOutputRequest) (*proto.ReadOutputResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.ReadOutput(ctx, &reqCopy)
+	}
+	return s.callReadOutput(ctx, spoke, &reqCopy)
+}
+
 func (s *Server) SendText(ctx context.Context, req *proto.SendTextRequest) (*proto.SendTextResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -531,12 +637,12 @@ func (s *Server) SendKey(ctx context.Context, req *proto.SendKeyRequest) (*proto
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -550,12 +656,12 @@ func (s *Server) SendBytes(ctx context.Context, req *proto.SendBytesRequest) (*p
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -565,16 +671,35 @@ func (s *Server) SendBytes(ctx context.Context, req *proto.SendBytesRequest) (*p
 	return s.callSendBytes(ctx, spoke, &reqCopy)
 }
 
+func (s *Server) SendMouse(ctx context.Context, req *proto.SendMouseRequest) (*proto.SendMouseResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.SendMouse(ctx, &reqCopy)
+	}
+	return s.callSendMouse(ctx, spoke, &reqCopy)
+}
+
 func (s *Server) Resize(ctx context.Context, req *proto.ResizeRequest) (*proto.ResizeResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -584,16 +709,221 @@ func (s *Server) Resize(ctx context.Context, req *proto.ResizeRequest) (*proto.R
 	return s.callResize(ctx, spoke, &reqCopy)
 }
 
+func (s *Server) AcquireInputLock(ctx context.Context, req *proto.AcquireInputLockRequest) (*proto.AcquireInputLockResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.AcquireInputLock(ctx, &reqCopy)
+	}
+	return s.callAcquireInputLock(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) ReleaseInputLock(ctx context.Context, req *proto.ReleaseInputLockRequest) (*proto.ReleaseInputLockResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.ReleaseInputLock(ctx, &reqCopy)
+	}
+	return s.callReleaseInputLock(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) ListClients(ctx context.Context, req *proto.ListClientsRequest) (*proto.ListClientsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.ListClients(ctx, &reqCopy)
+	}
+	return s.callListClients(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) DisconnectClient(ctx context.Context, req *proto.DisconnectClientRequest) (*proto.DisconnectClientResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.DisconnectClient(ctx, &reqCopy)
+	}
+	return s.callDisconnectClient(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) StartMacro(ctx context.Context, req *proto.StartMacroRequest) (*proto.StartMacroResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.StartMacro(ctx, &reqCopy)
+	}
+	return s.callStartMacro(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) StopMacro(ctx context.Context, req *proto.StopMacroRequest) (*proto.StopMacroResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.StopMacro(ctx, &reqCopy)
+	}
+	return s.callStopMacro(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) PlayMacro(ctx context.Context, req *proto.PlayMacroRequest) (*proto.PlayMacroResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.PlayMacro(ctx, &reqCopy)
+	}
+	return s.callPlayMacro(ctx, spoke, &reqCopy)
+}
+
+func (s *Server) ListMacros(ctx context.Context, req *proto.ListMacrosRequest) (*proto.ListMacrosResponse, error) {
+	want := strings.TrimSpace(req.GetCoordinator())
+	macros := make([]*proto.MacroInfo, 0)
+	if s.localActive() && (want == "" || want == s.localName) {
+		localResp, err := s.local.ListMacros(ctx, &proto.ListMacrosRequest{})
+		if err != nil && status.Code(err) != codes.FailedPrecondition {
+			return nil, err
+		}
+		for _, macro := range localResp.GetMacros() {
+			macro.Coordinator = s.localName
+			macros = append(macros, macro)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, target := range s.spokeTargets() {
+		if want != "" && target.Name != want {
+			continue
+		}
+		target := target
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := s.callListMacros(ctx, target.Name, &proto.ListMacrosRequest{})
+			if err != nil {
+				if status.Code(err) != codes.FailedPrecondition {
+					s.logger.Warn("hub: spoke macro list failed", "spoke", target.Name, "addr", target.Addr, "err", err)
+				}
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, macro := range resp.GetMacros() {
+				if macro == nil {
+					continue
+				}
+				macro.Coordinator = target.Name
+				macros = append(macros, macro)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(macros, func(i, j int) bool {
+		if macros[i].Name == macros[j].Name {
+			return macros[i].Coordinator < macros[j].Coordinator
+		}
+		return macros[i].Name < macros[j].Name
+	})
+	return &proto.ListMacrosResponse{Macros: macros}, nil
+}
+
+func (s *Server) DeleteMacro(ctx context.Context, req *proto.DeleteMacroRequest) (*proto.DeleteMacroResponse, error) {
+	if req == nil || strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "macro name is required")
+	}
+	coord := strings.TrimSpace(req.Coordinator)
+	reqCopy := *req
+	reqCopy.Coordinator = ""
+	if coord == "" || coord == s.localName {
+		if !s.localActive() {
+			return nil, s.localDisabledError()
+		}
+		return s.local.DeleteMacro(ctx, &reqCopy)
+	}
+	if _, ok := s.resolveSpoke(coord); !ok {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("unknown coordinator %q", coord))
+	}
+	return s.callDeleteMacro(ctx, coord, &reqCopy)
+}
+
 func (s *Server) WaitFor(ctx context.Context, req *proto.WaitForRequest) (*proto.WaitForResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -607,12 +937,12 @@ func (s *Server) WaitForIdle(ctx context.Context, req *proto.WaitForIdleRequest)
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -626,12 +956,12 @@ func (s *Server) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Subscri
 	if req == nil {
 		return status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(stream.Context(), req.Session)
 	if err != nil {
 		return err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return s.localDisabledError()
@@ -659,7 +989,8 @@ func (s *Server) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Subscri
 			s.logger.Warn(
 				"hub subscribe stream failed",
 				"spoke", spoke,
-				"session_id", sessionID,
+				"session_id", sessionRef.GetId(),
+				"session_label", sessionRef.GetLabel(),
 				"reason", reason,
 				"err", err,
 			)
@@ -669,16 +1000,46 @@ func (s *Server) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Subscri
 	return nil
 }
 
+func (s *Server) Paste(req *proto.PasteRequest, stream proto.VTR_PasteServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "session id is required")
+	}
+	spoke, sessionRef, routed, err := s.routeSessionRef(stream.Context(), req.Session)
+	if err != nil {
+		return err
+	}
+	reqCopy := *req
+	reqCopy.Session = sessionRef
+	if !routed {
+		if !s.localActive() {
+			return s.localDisabledError()
+		}
+		return s.local.Paste(&reqCopy, stream)
+	}
+
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return err
+	}
+	return tunnel.CallStream(stream.Context(), tunnelMethodPaste, &reqCopy, func(payload []byte) error {
+		progress := &proto.PasteProgress{}
+		if err := goproto.Unmarshal(payload, progress); err != nil {
+			return err
+		}
+		return stream.Send(progress)
+	})
+}
+
 func (s *Server) DumpAsciinema(ctx context.Context, req *proto.DumpAsciinemaRequest) (*proto.DumpAsciinemaResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
-	spoke, sessionID, routed, err := s.routeSessionRef(req.Session)
+	spoke, sessionRef, routed, err := s.routeSessionRef(ctx, req.Session)
 	if err != nil {
 		return nil, err
 	}
 	reqCopy := *req
-	reqCopy.Session = &proto.SessionRef{Id: sessionID}
+	reqCopy.Session = sessionRef
 	if !routed {
 		if !s.localActive() {
 			return nil, s.localDisabledError()
@@ -688,27 +1049,125 @@ func (s *Server) DumpAsciinema(ctx context.Context, req *proto.DumpAsciinemaRequ
 	return s.callDumpAsciinema(ctx, spoke, &reqCopy)
 }
 
-func (s *Server) routeSessionRef(ref *proto.SessionRef) (string, string, bool, error) {
-	if ref == nil || strings.TrimSpace(ref.Id) == "" {
-		return "", "", false, status.Error(codes.InvalidArgument, "session id is required")
+// routeSessionRef picks the coordinator for ref and returns the reference to
+// forward to it. Labels are forwarded as-is so the owning coordinator resolves
+// them atomically; without a coordinator the hub first locates the label.
+func (s *Server) routeSessionRef(ctx context.Context, ref *proto.SessionRef) (string, *proto.SessionRef, bool, error) {
+	if ref == nil {
+		return "", nil, false, status.Error(codes.InvalidArgument, "session id or label is required")
 	}
 	coord := strings.TrimSpace(ref.Coordinator)
-	session := strings.TrimSpace(ref.Id)
+	id := strings.TrimSpace(ref.Id)
+	label := strings.TrimSpace(ref.Label)
+	forward := &proto.SessionRef{Id: id}
+	if id == "" {
+		if label == "" {
+			return "", nil, false, status.Error(codes.InvalidArgument, "session id or label is required")
+		}
+		forward = &proto.SessionRef{Label: label}
+		if coord == "" {
+			located, err := s.locateSessionLabel(ctx, label)
+			if err != nil {
+				return "", nil, false, err
+			}
+			coord = located.coordinator
+			forward.Label = located.label
+		}
+	}
 	if coord == "" || coord == s.localName {
 		if s.localActive() {
-			return "", session, false, nil
+			return "", forward, false, nil
 		}
 		if coord != "" {
-			return "", "", false, s.localDisabledError()
+			return "", nil, false, s.localDisabledError()
 		}
 	}
 	if coord == "" {
-		return "", "", false, s.localDisabledError()
+		return "", nil, false, s.localDisabledError()
 	}
 	if _, ok := s.resolveSpoke(coord); ok {
-		return coord, session, true, nil
+		return coord, forward, true, nil
+	}
+	return "", nil, false, status.Error(codes.NotFound, fmt.Sprintf("unknown coordinator %q", coord))
+}
+
+type labelLocation struct {
+	coordinator string
+	label       string
+}
+
+// locateSessionLabel finds the coordinator that owns label. A label written
+// as "coordinator:label" for a known coordinator is routed there directly;
+// otherwise every coordinator is asked. When no owner answers but a spoke
+// failed to, the label may live there, so the lookup is Unavailable rather
+// than NotFound.
+func (s *Server) locateSessionLabel(ctx context.Context, label string) (labelLocation, error) {
+	if coord, name, ok := parseSessionRef(label); ok {
+		if coord == s.localName || s.hasSpoke(coord) {
+			return labelLocation{coordinator: coord, label: name}, nil
+		}
+	}
+	owners, failed := s.sessionLabelOwners(ctx, label)
+	switch len(owners) {
+	case 1:
+		return labelLocation{coordinator: owners[0], label: label}, nil
+	case 0:
+		if len(failed) > 0 {
+			return labelLocation{}, status.Error(codes.Unavailable, fmt.Sprintf("session %q not found; coordinators unavailable: %s", label, strings.Join(failed, ", ")))
+		}
+		return labelLocation{}, status.Error(codes.NotFound, fmt.Sprintf("session %q not found", label))
+	default:
+		return labelLocation{}, status.Error(codes.FailedPrecondition, fmt.Sprintf("session %q is ambiguous (coordinators: %s); specify a coordinator", label, strings.Join(owners, ", ")))
+	}
+}
+
+// sessionLabelOwners returns the coordinators that have a session named
+// label, and the spokes that could not be asked.
+func (s *Server) sessionLabelOwners(ctx context.Context, label string) ([]string, []string) {
+	var mu sync.Mutex
+	owners := make([]string, 0, 1)
+	var failed []string
+	addIfFound := func(name string, sessions []*proto.Session) {
+		for _, session := range sessions {
+			if session != nil && session.GetName() == label {
+				mu.Lock()
+				owners = append(owners, name)
+				mu.Unlock()
+				return
+			}
+		}
+	}
+	if s.localActive() {
+		if resp, err := s.local.List(ctx, &proto.ListRequest{}); err == nil {
+			addIfFound(s.localName, resp.GetSessions())
+		}
+	}
+	var wg sync.WaitGroup
+	for _, target := range s.spokeTargets() {
+		target := target
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := s.callList(ctx, target, "")
+			if err != nil {
+				s.logger.Warn("hub: spoke label lookup failed", "spoke", target.Name, "label", label, "err", err)
+				mu.Lock()
+				failed = append(failed, target.Name)
+				mu.Unlock()
+				return
+			}
+			addIfFound(target.Name, resp.GetSessions())
+		}()
 	}
-	return "", "", false, status.Error(codes.NotFound, fmt.Sprintf("unknown coordinator %q", coord))
+	wg.Wait()
+	sort.Strings(owners)
+	sort.Strings(failed)
+	return owners, failed
+}
+
+func (s *Server) hasSpoke(name string) bool {
+	_, ok := s.resolveSpoke(name)
+	return ok
 }
 
 func (s *Server) resolveSpoke(name string) (spokeTarget, bool) {
@@ -739,13 +1198,13 @@ func (s *Server) requireTunnel(name string) (*tunnelEndpoint, error) {
 	return tunnel, nil
 }
 
-func (s *Server) callList(ctx context.Context, target spokeTarget) (*proto.ListResponse, error) {
+func (s *Server) callList(ctx context.Context, target spokeTarget, selector string) (*proto.ListResponse, error) {
 	tunnel, err := s.requireTunnel(target.Name)
 	if err != nil {
 		return nil, err
 	}
 	resp := &proto.ListResponse{}
-	if err := tunnel.CallUnary(ctx, tunnelMethodList, &proto.ListRequest{}, resp); err != nil {
+	if err := tunnel.CallUnary(ctx, tunnelMethodList, &proto.ListRequest{Selector: selector}, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
@@ -823,6 +1282,30 @@ func (s *Server) callRename(ctx context.Context, spoke string, req *proto.Rename
 	return resp, nil
 }
 
+func (s *Server) callUpdateSession(ctx context.Context, spoke string, req *proto.UpdateSessionRequest) (*proto.UpdateSessionResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.UpdateSessionResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodUpdateSession, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callClone(ctx context.Context, spoke string, req *proto.CloneRequest) (*proto.CloneResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.CloneResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodClone, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (s *Server) callGetScreen(ctx context.Context, spoke string, req *proto.GetScreenRequest) (*proto.GetScreenResponse, error) {
 	tunnel, err := s.requireTunnel(spoke)
 	if err != nil {
@@ -847,6 +1330,42 @@ func (s *Server) callGrep(ctx context.Context, spoke string, req *proto.GrepRequ
 	return resp, nil
 }
 
+func (s *Server) callFindOnScreen(ctx context.Context, spoke string, req *proto.FindOnScreenRequest) (*proto.FindOnScreenResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.FindOnScreenResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodFindOnScreen, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callExportScreen(ctx context.Context, spoke string, req *proto.ExportScreenRequest) (*proto.ExportScreenResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ExportScreenResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodExportScreen, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callReadOutput(ctx context.Context, spoke string, req *proto.ReadOutputRequest) (*proto.ReadOutputResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ReadOutputResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodReadOutput, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (s *Server) callSendText(ctx context.Context, spoke string, req *proto.SendTextRequest) (*proto.SendTextResponse, error) {
 	tunnel, err := s.requireTunnel(spoke)
 	if err != nil {
@@ -883,6 +1402,18 @@ func (s *Server) callSendBytes(ctx context.Context, spoke string, req *proto.Sen
 	return resp, nil
 }
 
+func (s *Server) callSendMouse(ctx context.Context, spoke string, req *proto.SendMouseRequest) (*proto.SendMouseResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.SendMouseResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodSendMouse, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (s *Server) callResize(ctx context.Context, spoke string, req *proto.ResizeRequest) (*proto.ResizeResponse, error) {
 	tunnel, err := s.requireTunnel(spoke)
 	if err != nil {
@@ -895,6 +1426,114 @@ func (s *Server) callResize(ctx context.Context, spoke string, req *proto.Resize
 	return resp, nil
 }
 
+func (s *Server) callAcquireInputLock(ctx context.Context, spoke string, req *proto.AcquireInputLockRequest) (*proto.AcquireInputLockResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.AcquireInputLockResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodAcquireInputLock, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callReleaseInputLock(ctx context.Context, spoke string, req *proto.ReleaseInputLockRequest) (*proto.ReleaseInputLockResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ReleaseInputLockResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodReleaseInputLock, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callListClients(ctx context.Context, spoke string, req *proto.ListClientsRequest) (*proto.ListClientsResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ListClientsResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodListClients, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callDisconnectClient(ctx context.Context, spoke string, req *proto.DisconnectClientRequest) (*proto.DisconnectClientResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.DisconnectClientResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodDisconnectClient, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callStartMacro(ctx context.Context, spoke string, req *proto.StartMacroRequest) (*proto.StartMacroResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.StartMacroResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodStartMacro, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callStopMacro(ctx context.Context, spoke string, req *proto.StopMacroRequest) (*proto.StopMacroResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.StopMacroResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodStopMacro, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callPlayMacro(ctx context.Context, spoke string, req *proto.PlayMacroRequest) (*proto.PlayMacroResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.PlayMacroResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodPlayMacro, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callListMacros(ctx context.Context, spoke string, req *proto.ListMacrosRequest) (*proto.ListMacrosResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ListMacrosResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodListMacros, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) callDeleteMacro(ctx context.Context, spoke string, req *proto.DeleteMacroRequest) (*proto.DeleteMacroResponse, error) {
+	tunnel, err := s.requireTunnel(spoke)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.DeleteMacroResponse{}
+	if err := tunnel.CallUnary(ctx, tunnelMethodDeleteMacro, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (s *Server) callWaitFor(ctx context.Context, spoke string, req *proto.WaitForRequest) (*proto.WaitForResponse, error) {
 	tunnel, err := s.requireTunnel(spoke)
 	if err != nil {
@@ -1079,7 +1718,7 @@ func waitOrDone(ctx context.Context, d time.Duration) bool {
 	}
 }
 
-func (s *Server) watchSpokeSessions(ctx context.Context, target spokeTarget, excludeExited bool, state *federatedSessionState, signal *updateSignal) {
+func (s *Server) watchSpokeSessions(ctx context.Context, target spokeTarget, query sessionsQuery, state *federatedSessionState, signal *updateSignal) {
 	backoff := time.Second
 	for {
 		if ctx.Err() != nil {
@@ -1090,14 +1729,15 @@ func (s *Server) watchSpokeSessions(ctx context.Context, target spokeTarget, exc
 			state.setCoordinatorError(target.Name, target.Addr, nil)
 			signal.pulse()
 			err := tunnel.CallStream(ctx, tunnelMethodSubscribeSessions, &proto.SubscribeSessionsRequest{
-				ExcludeExited: excludeExited,
+				ExcludeExited: query.excludeExited,
+				Selector:      query.selector,
 			}, func(payload []byte) error {
 				snapshot := &proto.SessionsSnapshot{}
 				if err := goproto.Unmarshal(payload, snapshot); err != nil {
 					return err
 				}
 				sessions := snapshotSessions(snapshot, target.Name)
-				if excludeExited {
+				if query.excludeExited {
 					sessions = filterSessions(sessions, true)
 				}
 				state.setCoordinatorSessions(target.Name, target.Addr, sessions)
@@ -1126,12 +1766,12 @@ func (s *Server) watchSpokeSessions(ctx context.Context, target spokeTarget, exc
 	}
 }
 
-func (s *Server) watchRegistry(ctx context.Context, excludeExited bool, state *federatedSessionState, signal *updateSignal) {
+func (s *Server) watchRegistry(ctx context.Context, query sessionsQuery, state *federatedSessionState, signal *updateSignal) {
 	if s.registry == nil {
 		return
 	}
 	known := make(map[string]struct{})
-	for _, target := range s.spokeTargets() {
+	for _, target := range query.filterTargets(s.spokeTargets()) {
 		known[target.Name] = struct{}{}
 		state.ensureCoordinator(target.Name, target.Addr)
 	}
@@ -1142,20 +1782,62 @@ func (s *Server) watchRegistry(ctx context.Context, excludeExited bool, state *f
 			return
 		case <-ch:
 			ch = s.registry.Changed()
-			for _, target := range s.spokeTargets() {
+			for _, target := range query.filterTargets(s.spokeTargets()) {
 				state.ensureCoordinator(target.Name, target.Addr)
 				if _, ok := known[target.Name]; ok {
 					continue
 				}
 				known[target.Name] = struct{}{}
 				signal.pulse()
-				go s.watchSpokeSessions(ctx, target, excludeExited, state, signal)
+				go s.watchSpokeSessions(ctx, target, query, state, signal)
 			}
 			signal.pulse()
 		}
 	}
 }
 
+// sessionsQuery carries session list filters across the hub. Coordinator
+// terms are evaluated here because spokes only know their own name; the
+// remaining selector is forwarded to every coordinator unchanged.
+type sessionsQuery struct {
+	excludeExited bool
+	coordinators  server.Selector
+	selector      string
+}
+
+func newSessionsQuery(excludeExited bool, selector string) (sessionsQuery, error) {
+	parsed, err := server.ParseSelector(selector)
+	if err != nil {
+		return sessionsQuery{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	coordinators, rest := parsed.Split("coordinator")
+	return sessionsQuery{
+		excludeExited: excludeExited,
+		coordinators:  coordinators,
+		selector:      rest.String(),
+	}, nil
+}
+
+func (q sessionsQuery) matchesCoordinator(name string) bool {
+	if q.coordinators.Empty() {
+		return true
+	}
+	return q.coordinators.Matches(map[string]string{"coordinator": name})
+}
+
+func (q sessionsQuery) filterTargets(targets []spokeTarget) []spokeTarget {
+	if q.coordinators.Empty() {
+		return targets
+	}
+	out := make([]spokeTarget, 0, len(targets))
+	for _, target := range targets {
+		if q.matchesCoordinator(target.Name) {
+			out = append(out, target)
+		}
+	}
+	return out
+}
+
 func filterSessions(sessions []*proto.Session, excludeExited bool) []*proto.Session {
 	if !excludeExited || len(sessions) == 0 {
 		return sessions
@@ -21,16 +21,24 @@ const bufSize = 1024 * 1024
 type fakeVTRServer struct {
 	proto.UnimplementedVTRServer
 	listSessions             []*proto.Session
+	listHandler              func(req *proto.ListRequest) (*proto.ListResponse, error)
 	infoHandler              func(id string) (*proto.InfoResponse, error)
+	infoRefs                 chan<- *proto.SessionRef
 	subscribeSessionsName    string
 	subscribeSessionsHandler func(req *proto.SubscribeSessionsRequest, stream proto.VTR_SubscribeSessionsServer) error
 }
 
-func (f *fakeVTRServer) List(_ context.Context, _ *proto.ListRequest) (*proto.ListResponse, error) {
+func (f *fakeVTRServer) List(_ context.Context, req *proto.ListRequest) (*proto.ListResponse, error) {
+	if f.listHandler != nil {
+		return f.listHandler(req)
+	}
 	return &proto.ListResponse{Sessions: f.listSessions}, nil
 }
 
 func (f *fakeVTRServer) Info(_ context.Context, req *proto.InfoRequest) (*proto.InfoResponse, error) {
+	if f.infoRefs != nil {
+		f.infoRefs <- req.GetSession()
+	}
 	if f.infoHandler != nil {
 		return f.infoHandler(req.GetSession().GetId())
 	}
@@ -41,6 +49,13 @@ func (f *fakeVTRServer) Info(_ context.Context, req *proto.InfoRequest) (*proto.
 	return &proto.InfoResponse{Session: &proto.Session{Id: req.GetSession().GetId(), Name: name}}, nil
 }
 
+func (f *fakeVTRServer) Clone(_ context.Context, req *proto.CloneRequest) (*proto.CloneResponse, error) {
+	if req.GetSession().GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	return &proto.CloneResponse{Session: &proto.Session{Id: req.GetSession().GetId() + "-clone", Name: req.GetName()}}, nil
+}
+
 func (f *fakeVTRServer) SubscribeSessions(req *proto.SubscribeSessionsRequest, stream proto.VTR_SubscribeSessionsServer) error {
 	if f.subscribeSessionsHandler != nil {
 		return f.subscribeSessionsHandler(req, stream)
@@ -145,6 +160,266 @@ func TestTunnelListAndInfoRoutesToSpoke(t *testing.T) {
 	if infoResp.GetSession().GetName() != "alpha" {
 		t.Fatalf("expected unprefixed info, got %q", infoResp.GetSession().GetName())
 	}
+
+	cloneResp, err := client.Clone(ctx, &proto.CloneRequest{
+		Session: &proto.SessionRef{Id: "sess-1", Coordinator: "spoke-a"},
+		Name:    "alpha-2",
+	})
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if cloneResp.GetSession().GetId() != "sess-1-clone" || cloneResp.GetSession().GetName() != "alpha-2" {
+		t.Fatalf("expected clone from spoke, got %#v", cloneResp.GetSession())
+	}
+}
+
+func TestFederatedListForwardsSelector(t *testing.T) {
+	coord := server.NewCoordinator(server.CoordinatorOptions{})
+	defer coord.CloseAll()
+	local := server.NewGRPCServer(coord)
+
+	selectors := make(chan string, 4)
+	spoke := &fakeVTRServer{
+		listHandler: func(req *proto.ListRequest) (*proto.ListResponse, error) {
+			selectors <- req.GetSelector()
+			return &proto.ListResponse{Sessions: []*proto.Session{{
+				Id:   "sess-1",
+				Name: "alpha",
+				Tags: map[string]string{"owner": "agent-7"},
+			}}}, nil
+		},
+	}
+
+	federated := NewServer(local, "hub", "", true, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	client := startTunnelTestHub(ctx, t, federated, "spoke-a", spoke)
+
+	listResp, err := client.List(ctx, &proto.ListRequest{Selector: "coordinator=spoke-a,owner=agent-7"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := <-selectors; got != "owner=agent-7" {
+		t.Fatalf("spoke selector=%q, want owner=agent-7", got)
+	}
+	if len(listResp.GetSessions()) != 1 || listResp.GetSessions()[0].GetTags()["owner"] != "agent-7" {
+		t.Fatalf("expected tagged spoke session, got %#v", listResp.GetSessions())
+	}
+
+	listResp, err = client.List(ctx, &proto.ListRequest{Selector: "coordinator=hub"})
+	if err != nil {
+		t.Fatalf("List hub only: %v", err)
+	}
+	if len(listResp.GetSessions()) != 0 {
+		t.Fatalf("expected no sessions for hub selector, got %#v", listResp.GetSessions())
+	}
+	select {
+	case got := <-selectors:
+		t.Fatalf("spoke should not be queried, got selector %q", got)
+	default:
+	}
+
+	_, err = client.List(ctx, &proto.ListRequest{Selector: "bad key"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestFederatedRoutesSessionLabels(t *testing.T) {
+	coord := server.NewCoordinator(server.CoordinatorOptions{})
+	defer coord.CloseAll()
+	if _, err := coord.Spawn("shared", server.SpawnOptions{Command: []string{"/bin/sh", "-c", "sleep 5"}}); err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	local := server.NewGRPCServer(coord)
+
+	refs := make(chan *proto.SessionRef, 4)
+	spoke := &fakeVTRServer{
+		listSessions: []*proto.Session{{Id: "sess-1", Name: "alpha"}, {Id: "sess-2", Name: "shared"}},
+		infoHandler: func(id string) (*proto.InfoResponse, error) {
+			return &proto.InfoResponse{Session: &proto.Session{Id: "sess-1", Name: "alpha"}}, nil
+		},
+	}
+	spoke.infoRefs = refs
+
+	federated := NewServer(local, "hub", "", true, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	client := startTunnelTestHub(ctx, t, federated, "spoke-a", spoke)
+
+	for _, ref := range []*proto.SessionRef{
+		{Label: "alpha"},
+		{Label: "alpha", Coordinator: "spoke-a"},
+		{Label: "spoke-a:alpha"},
+	} {
+		infoResp, err := client.Info(ctx, &proto.InfoRequest{Session: ref})
+		if err != nil {
+			t.Fatalf("Info(%v): %v", ref, err)
+		}
+		if infoResp.GetSession().GetName() != "alpha" {
+			t.Fatalf("Info(%v) name=%q", ref, infoResp.GetSession().GetName())
+		}
+		forwarded := <-refs
+		if forwarded.GetLabel() != "alpha" || forwarded.GetId() != "" {
+			t.Fatalf("Info(%v) forwarded %v, want label alpha", ref, forwarded)
+		}
+	}
+
+	_, err := client.Info(ctx, &proto.InfoRequest{Session: &proto.SessionRef{Label: "shared"}})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous FailedPrecondition, got %v", err)
+	}
+	infoResp, err := client.Info(ctx, &proto.InfoRequest{Session: &proto.SessionRef{Label: "shared", Coordinator: "hub"}})
+	if err != nil {
+		t.Fatalf("Info local label: %v", err)
+	}
+	if infoResp.GetSession().GetName() != "shared" {
+		t.Fatalf("Info local label name=%q", infoResp.GetSession().GetName())
+	}
+	_, err = client.Info(ctx, &proto.InfoRequest{Session: &proto.SessionRef{Label: "missing"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestFederatedLabelLookupReportsUnavailableSpoke(t *testing.T) {
+	coord := server.NewCoordinator(server.CoordinatorOptions{})
+	defer coord.CloseAll()
+	local := server.NewGRPCServer(coord)
+	spoke := &fakeVTRServer{
+		listHandler: func(req *proto.ListRequest) (*proto.ListResponse, error) {
+			return nil, status.Error(codes.Unavailable, "spoke is restarting")
+		},
+	}
+
+	federated := NewServer(local, "hub", "", true, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	client := startTunnelTestHub(ctx, t, federated, "spoke-a", spoke)
+
+	_, err := client.Info(ctx, &proto.InfoRequest{Session: &proto.SessionRef{Label: "missing"}})
+	if status.Code(err) != codes.Unavailable || !strings.Contains(err.Error(), "spoke-a") {
+		t.Fatalf("expected Unavailable naming spoke-a, got %v", err)
+	}
+}
+
+func TestTunnelForwardsClientPeer(t *testing.T) {
+	hubCoord := server.NewCoordinator(server.CoordinatorOptions{})
+	defer hubCoord.CloseAll()
+	spokeCoord := server.NewCoordinator(server.CoordinatorOptions{})
+	defer spokeCoord.CloseAll()
+	spoke := server.NewGRPCServer(spokeCoord)
+	info, err := spokeCoord.Spawn("remote", server.SpawnOptions{Command: []string{"/bin/sh", "-c", "cat"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	federated := NewServer(server.NewGRPCServer(hubCoord), "hub", "", true, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	client := startTunnelTestHub(ctx, t, federated, "spoke-a", spoke)
+
+	ref := &proto.SessionRef{Id: info.ID, Coordinator: "spoke-a"}
+	streamCtx, streamCancel := context.WithCancel(ctx)
+	defer streamCancel()
+	stream, err := client.Subscribe(streamCtx, &proto.SubscribeRequest{Session: ref, IncludeScreenUpdates: true, ClientId: "viewer"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+
+	resp, err := client.ListClients(ctx, &proto.ListClientsRequest{Session: ref})
+	if err != nil {
+		t.Fatalf("ListClients: %v", err)
+	}
+	if len(resp.GetClients()) != 1 {
+		t.Fatalf("expected one client, got %#v", resp.GetClients())
+	}
+	// The spoke sees the hub's client, not its own end of the tunnel.
+	if got := resp.GetClients()[0].GetPeer(); got != testClientAddr.String() {
+		t.Fatalf("expected the hub client's peer, got %q", got)
+	}
+
+	fwd := server.WithForwardedPeer(context.Background(), "10.0.0.7:5000", "alice")
+	if addr, identity := server.CallerPeer(fwd); addr != "10.0.0.7:5000" || identity != "alice" {
+		t.Fatalf("expected forwarded peer, got %q %q", addr, identity)
+	}
+}
+
+// testClientAddr is the remote address the test hub sees for its clients,
+// including the spoke's tunnel.
+var testClientAddr = &net.TCPAddr{IP: net.IPv4(203, 0, 113, 7), Port: 5000}
+
+type remoteAddrListener struct {
+	net.Listener
+	addr net.Addr
+}
+
+func (l remoteAddrListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return remoteAddrConn{Conn: conn, addr: l.addr}, nil
+}
+
+type remoteAddrConn struct {
+	net.Conn
+	addr net.Addr
+}
+
+func (c remoteAddrConn) RemoteAddr() net.Addr { return c.addr }
+
+func startTunnelTestHub(ctx context.Context, t *testing.T, federated *Server, spokeName string, spoke proto.VTRServer) proto.VTRClient {
+	t.Helper()
+	listener := bufconn.Listen(bufSize)
+	grpcServer := grpc.NewServer()
+	proto.RegisterVTRServer(grpcServer, federated)
+	go func() {
+		_ = grpcServer.Serve(remoteAddrListener{Listener: listener, addr: testClientAddr})
+	}()
+	t.Cleanup(func() {
+		grpcServer.Stop()
+		_ = listener.Close()
+	})
+
+	conn, err := grpc.DialContext(ctx, "hub",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("dial hub: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client := proto.NewVTRClient(conn)
+	stream, err := client.Tunnel(ctx)
+	if err != nil {
+		t.Fatalf("tunnel: %v", err)
+	}
+	tunnel := &tunnelSpoke{
+		ctx:     ctx,
+		stream:  stream,
+		service: spoke,
+		calls:   make(map[string]context.CancelFunc),
+	}
+	go func() {
+		_ = tunnel.serve(spokeName, &proto.SpokeInfo{Name: spokeName})
+	}()
+
+	deadline := time.After(500 * time.Millisecond)
+	for federated.tunnels.Get(spokeName) == nil {
+		select {
+		case <-deadline:
+			t.Fatal("tunnel did not register")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return client
 }
 
 func TestTunnelRegistersAndRemovesSpoke(t *testing.T) {
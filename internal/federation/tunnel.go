@@ -12,10 +12,12 @@ import (
 	"time"
 
 	proto "github.com/advait/vtrpc/proto"
+	"github.com/advait/vtrpc/server"
 	"github.com/advait/vtrpc/tracing"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -32,12 +34,28 @@ const (
 	tunnelMethodClose             = "Close"
 	tunnelMethodRemove            = "Remove"
 	tunnelMethodRename            = "Rename"
+	tunnelMethodUpdateSession     = "UpdateSession"
+	tunnelMethodClone             = "Clone"
 	tunnelMethodGetScreen         = "GetScreen"
 	tunnelMethodGrep              = "Grep"
+	tunnelMethodFindOnScreen      = "FindOnScreen"
+	tunnelMethodExportScreen      = "ExportScreen"
+	tunnelMethodReadOutput        = "ReadOutput"
 	tunnelMethodSendText          = "SendText"
 	tunnelMethodSendKey           = "SendKey"
 	tunnelMethodSendBytes         = "SendBytes"
+	tunnelMethodSendMouse         = "SendMouse"
+	tunnelMethodPaste             = "Paste"
 	tunnelMethodResize            = "Resize"
+	tunnelMethodAcquireInputLock  = "AcquireInputLock"
+	tunnelMethodReleaseInputLock  = "ReleaseInputLock"
+	tunnelMethodListClients       = "ListClients"
+	tunnelMethodDisconnectClient  = "DisconnectClient"
+	tunnelMethodStartMacro        = "StartMacro"
+	tunnelMethodStopMacro         = "StopMacro"
+	tunnelMethodPlayMacro         = "PlayMacro"
+	tunnelMethodListMacros        = "ListMacros"
+	tunnelMethodDeleteMacro       = "DeleteMacro"
 	tunnelMethodWaitFor           = "WaitFor"
 	tunnelMethodWaitForIdle       = "WaitForIdle"
 	tunnelMethodSubscribe         = "Subscribe"
@@ -413,6 +431,7 @@ func (e *tunnelEndpoint) CallUnary(ctx context.Context, method string, req gopro
 		Stream:  false,
 	}
 	injectTunnelTrace(reqFrame, ctx)
+	injectTunnelPeer(reqFrame, ctx)
 	frame := &proto.TunnelFrame{
 		CallId: call.id,
 		Kind: &proto.TunnelFrame_Request{
@@ -490,6 +509,7 @@ func (e *tunnelEndpoint) CallStream(ctx context.Context, method string, req gopr
 		Stream:  true,
 	}
 	injectTunnelTrace(reqFrame, ctx)
+	injectTunnelPeer(reqFrame, ctx)
 	frame := &proto.TunnelFrame{
 		CallId: call.id,
 		Kind: &proto.TunnelFrame_Request{
@@ -592,7 +612,7 @@ func tunnelErrorFrom(err error) *proto.TunnelError {
 		return nil
 	}
 	if st, ok := status.FromError(err); ok {
-		return &proto.TunnelError{Code: int32(st.Code()), Message: st.Message()}
+		return &proto.TunnelError{Code: int32(st.Code()), Message: st.Message(), Details: st.Proto().GetDetails()}
 	}
 	return &proto.TunnelError{Code: int32(codes.Unknown), Message: err.Error()}
 }
@@ -605,7 +625,7 @@ func tunnelErrorToStatus(err *proto.TunnelError) error {
 	if code == codes.OK {
 		code = codes.Unknown
 	}
-	return status.Error(code, err.Message)
+	return status.FromProto(&statuspb.Status{Code: int32(code), Message: err.Message, Details: err.Details}).Err()
 }
 
 func injectTunnelTrace(req *proto.TunnelRequest, ctx context.Context) {
@@ -645,6 +665,15 @@ func extractTunnelTrace(ctx context.Context, req *proto.TunnelRequest) context.C
 	return otel.GetTextMapPropagator().Extract(ctx, carrier)
 }
 
+// injectTunnelPeer forwards the hub's caller; through a chain of hubs it is
+// the original client.
+func injectTunnelPeer(req *proto.TunnelRequest, ctx context.Context) {
+	if req == nil || ctx == nil {
+		return
+	}
+	req.Peer, req.Identity = server.CallerPeer(ctx)
+}
+
 type tunnelSpoke struct {
 	ctx     context.Context
 	stream  proto.VTR_TunnelClient
@@ -821,6 +850,7 @@ func (t *tunnelSpoke) handleRequest(callID string, req *proto.TunnelRequest) {
 	}
 	ctx, cancel := context.WithCancel(t.stream.Context())
 	ctx = extractTunnelTrace(ctx, req)
+	ctx = server.WithForwardedPeer(ctx, req.Peer, req.Identity)
 	t.registerCall(key, cancel)
 	if req.Stream {
 		go func() {
@@ -896,6 +926,20 @@ func (t *tunnelSpoke) handleUnary(ctx context.Context, callID string, req *proto
 		}
 		resp, err := t.service.Rename(ctx, payload)
 		t.sendUnary(callID, resp, err)
+	case tunnelMethodUpdateSession:
+		payload := &proto.UpdateSessionRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.UpdateSession(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodClone:
+		payload := &proto.CloneRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.Clone(ctx, payload)
+		t.sendUnary(callID, resp, err)
 	case tunnelMethodGetScreen:
 		payload := &proto.GetScreenRequest{}
 		if !t.decode(req.Payload, payload, callID) {
@@ -910,6 +954,27 @@ func (t *tunnelSpoke) handleUnary(ctx context.Context, callID string, req *proto
 		}
 		resp, err := t.service.Grep(ctx, payload)
 		t.sendUnary(callID, resp, err)
+	case tunnelMethodFindOnScreen:
+		payload := &proto.FindOnScreenRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.FindOnScreen(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodExportScreen:
+		payload := &proto.ExportScreenRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.ExportScreen(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodReadOutput:
+		payload := &proto.ReadOutputRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.ReadOutput(ctx, payload)
+		t.sendUnary(callID, resp, err)
 	case tunnelMethodSendText:
 		payload := &proto.SendTextRequest{}
 		if !t.decode(req.Payload, payload, callID) {
@@ -931,6 +996,13 @@ func (t *tunnelSpoke) handleUnary(ctx context.Context, callID string, req *proto
 		}
 		resp, err := t.service.SendBytes(ctx, payload)
 		t.sendUnary(callID, resp, err)
+	case tunnelMethodSendMouse:
+		payload := &proto.SendMouseRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.SendMouse(ctx, payload)
+		t.sendUnary(callID, resp, err)
 	case tunnelMethodResize:
 		payload := &proto.ResizeRequest{}
 		if !t.decode(req.Payload, payload, callID) {
@@ -938,6 +1010,69 @@ func (t *tunnelSpoke) handleUnary(ctx context.Context, callID string, req *proto
 		}
 		resp, err := t.service.Resize(ctx, payload)
 		t.sendUnary(callID, resp, err)
+	case tunnelMethodAcquireInputLock:
+		payload := &proto.AcquireInputLockRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.AcquireInputLock(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodReleaseInputLock:
+		payload := &proto.ReleaseInputLockRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.ReleaseInputLock(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodListClients:
+		payload := &proto.ListClientsRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.ListClients(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodDisconnectClient:
+		payload := &proto.DisconnectClientRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.DisconnectClient(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodStartMacro:
+		payload := &proto.StartMacroRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.StartMacro(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodStopMacro:
+		payload := &proto.StopMacroRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.StopMacro(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodPlayMacro:
+		payload := &proto.PlayMacroRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.PlayMacro(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodListMacros:
+		payload := &proto.ListMacrosRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.ListMacros(ctx, payload)
+		t.sendUnary(callID, resp, err)
+	case tunnelMethodDeleteMacro:
+		payload := &proto.DeleteMacroRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		resp, err := t.service.DeleteMacro(ctx, payload)
+		t.sendUnary(callID, resp, err)
 	case tunnelMethodWaitFor:
 		payload := &proto.WaitForRequest{}
 		if !t.decode(req.Payload, payload, callID) {
@@ -1000,6 +1135,19 @@ func (t *tunnelSpoke) handleStream(ctx context.Context, callID string, req *prot
 		}
 		err := t.service.SubscribeSessions(payload, stream)
 		t.finishStream(callID, err)
+	case tunnelMethodPaste:
+		payload := &proto.PasteRequest{}
+		if !t.decode(req.Payload, payload, callID) {
+			return
+		}
+		stream := &tunnelPasteStream{
+			ctx: ctx,
+			send: func(progress *proto.PasteProgress) error {
+				return t.sendEvent(callID, progress)
+			},
+		}
+		err := t.service.Paste(payload, stream)
+		t.finishStream(callID, err)
 	default:
 		t.sendError(callID, status.Error(codes.Unimplemented, fmt.Sprintf("unsupported method %q", method)))
 	}
@@ -1134,3 +1282,28 @@ func (s *tunnelSessionsStream) SendHeader(metadata.MD) error { return nil }
 func (s *tunnelSessionsStream) SetTrailer(metadata.MD)       {}
 func (s *tunnelSessionsStream) SendMsg(interface{}) error    { return nil }
 func (s *tunnelSessionsStream) RecvMsg(interface{}) error    { return nil }
+
+type tunnelPasteStream struct {
+	ctx  context.Context
+	send func(*proto.PasteProgress) error
+}
+
+func (s *tunnelPasteStream) Send(progress *proto.PasteProgress) error {
+	if s.send == nil {
+		return nil
+	}
+	return s.send(progress)
+}
+
+func (s *tunnelPasteStream) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return context.Background()
+}
+
+func (s *tunnelPasteStream) SetHeader(metadata.MD) error  { return nil }
+func (s *tunnelPasteStream) SendHeader(metadata.MD) error { return nil }
+func (s *tunnelPasteStream) SetTrailer(metadata.MD)       {}
+func (s *tunnelPasteStream) SendMsg(interface{}) error    { return nil }
+func (s *tunnelPasteStream) RecvMsg(interface{}) error    { return nil }
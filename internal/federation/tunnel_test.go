@@ -11,6 +11,7 @@ import (
 	"time"
 
 	proto "github.com/advait/vtrpc/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -348,3 +349,21 @@ func TestTunnelSpokeFinishStreamPropagatesContextCancellation(t *testing.T) {
 		})
 	}
 }
+
+func TestTunnelErrorKeepsStatusDetails(t *testing.T) {
+	st, err := status.New(codes.FailedPrecondition, "input is locked").WithDetails(&errdetails.ErrorInfo{Reason: "INPUT_LOCKED", Domain: "vtrpc"})
+	if err != nil {
+		t.Fatalf("WithDetails: %v", err)
+	}
+	got := status.Convert(tunnelErrorToStatus(tunnelErrorFrom(st.Err())))
+	if got.Code() != codes.FailedPrecondition || got.Message() != "input is locked" {
+		t.Fatalf("unexpected status %v", got)
+	}
+	details := got.Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one detail, got %v", details)
+	}
+	if info, ok := details[0].(*errdetails.ErrorInfo); !ok || info.GetReason() != "INPUT_LOCKED" {
+		t.Fatalf("unexpected detail %#v", details[0])
+	}
+}
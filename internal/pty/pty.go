@@ -10,6 +10,7 @@ import (
 
 	"github.com/advait/vtrpc/internal/vt"
 	"github.com/creack/pty"
+	"golang.org/x/sys/unix"
 )
 
 type VT = vt.VT
@@ -36,6 +37,14 @@ func Start(cmd *exec.Cmd, cols, rows uint16) (*PTY, error) {
 	return p, nil
 }
 
+// Pid returns the process id of the started command.
+func (p *PTY) Pid() int {
+	if p == nil || p.cmd == nil || p.cmd.Process == nil {
+		return 0
+	}
+	return p.cmd.Process.Pid
+}
+
 func (p *PTY) Read(buf []byte) (int, error) {
 	if p == nil || p.file == nil {
 		return 0, io.EOF
@@ -96,6 +105,28 @@ func (p *PTY) SignalGroup(sig os.Signal) error {
 	return syscall.Kill(-pid, signal)
 }
 
+// ForegroundPgrp returns the process group in the foreground of the
+// terminal, which is the command itself unless it started a job.
+func (p *PTY) ForegroundPgrp() (int, error) {
+	if p == nil || p.file == nil {
+		return 0, errors.New("pty: not started")
+	}
+	conn, err := p.file.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+	var pgrp int
+	var ioctlErr error
+	// SyscallConn, unlike Fd, leaves the file in non-blocking mode so Close
+	// still interrupts the read loop.
+	if err := conn.Control(func(fd uintptr) {
+		pgrp, ioctlErr = unix.IoctlGetInt(int(fd), unix.TIOCGPGRP)
+	}); err != nil {
+		return 0, err
+	}
+	return pgrp, ioctlErr
+}
+
 func (p *PTY) Close() error {
 	if p == nil || p.file == nil {
 		return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"image/color"
 	"log/slog"
 	"net"
@@ -19,6 +20,7 @@ import (
 	core "github.com/advait/vtrpc/internal/core"
 	proto "github.com/advait/vtrpc/proto"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -36,6 +38,9 @@ type Session = core.Session
 type SessionInfo = core.SessionInfo
 type SessionState = core.SessionState
 type SpawnOptions = core.SpawnOptions
+type SpawnProfile = core.SpawnProfile
+type CloneOptions = core.CloneOptions
+type ClientInfo = core.ClientInfo
 type GrepMatch = core.GrepMatch
 type SpokeRegistry = core.SpokeRegistry
 type SpokeRecord = core.SpokeRecord
@@ -54,6 +59,26 @@ var (
 	ErrSessionNotRunning = core.ErrSessionNotRunning
 	ErrInvalidName       = core.ErrInvalidName
 	ErrInvalidSize       = core.ErrInvalidSize
+	ErrInvalidTags       = core.ErrInvalidTags
+	ErrProfileNotFound   = core.ErrProfileNotFound
+	ErrInputLocked       = core.ErrInputLocked
+	ErrInvalidClientID   = core.ErrInvalidClientID
+	ErrClientNotFound    = core.ErrClientNotFound
+
+	ErrInvalidResizePolicy = core.ErrInvalidResizePolicy
+	ErrInvalidMouse        = core.ErrInvalidMouse
+	ErrInvalidRect         = core.ErrInvalidRect
+
+	ErrInvalidMacro   = core.ErrInvalidMacro
+	ErrMacroNotFound  = core.ErrMacroNotFound
+	ErrMacroRecording = core.ErrMacroRecording
+	ErrNotRecording   = core.ErrNotRecording
+	ErrMacrosDisabled = core.ErrMacrosDisabled
+
+	ErrWorkingDirUnavailable = core.ErrWorkingDirUnavailable
+
+	ErrInvalidIsolation     = core.ErrInvalidIsolation
+	ErrIsolationUnavailable = core.ErrIsolationUnavailable
 )
 
 func NewSpokeRegistry() *SpokeRegistry {
@@ -62,7 +87,11 @@ func NewSpokeRegistry() *SpokeRegistry {
 
 const (
 	maxRawInputBytes = 1 << 20
+	maxPasteBytes    = 3 << 20
 	keyframeRingSize = 4
+	// GetScreen frames kept per session as delta bases. Each poller only
+	// needs its latest frame, so this bounds concurrent pollers per session.
+	frameBaseCacheSize = 16
 	subscribeSenderDrainTimeout = 2 * time.Second
 )
 
@@ -96,6 +125,7 @@ type GRPCServer struct {
 
 	keyframeMu   sync.Mutex
 	keyframeRing map[string]*keyframeRing
+	frameBases   map[string]*frameBaseCache
 	spokes       *SpokeRegistry
 
 	coordinatorName string
@@ -114,15 +144,50 @@ type keyframeRing struct {
 	count   int
 }
 
+// frameBaseCache holds the snapshots behind GetScreen frame IDs, least
+// recently used first.
+type frameBaseCache struct {
+	entries []frameBase
+	size    int
+}
+
+type frameBase struct {
+	frameID  uint64
+	snapshot *Snapshot
+}
+
 func (s *GRPCServer) requireSessionID(ref *proto.SessionRef) (string, error) {
 	if ref == nil {
-		return "", status.Error(codes.InvalidArgument, "session id is required")
+		return "", status.Error(codes.InvalidArgument, "session id or label is required")
 	}
-	id := strings.TrimSpace(ref.Id)
-	if id == "" {
-		return "", status.Error(codes.InvalidArgument, "session id is required")
+	if id := strings.TrimSpace(ref.Id); id != "" {
+		return id, nil
 	}
-	return id, nil
+	label := strings.TrimSpace(ref.Label)
+	if label == "" {
+		return "", status.Error(codes.InvalidArgument, "session id or label is required")
+	}
+	return s.lookupSessionLabel(label)
+}
+
+// lookupSessionLabel resolves a label to a session ID. Labels written as
+// "coordinator:label" are accepted when the prefix names this coordinator.
+func (s *GRPCServer) lookupSessionLabel(label string) (string, error) {
+	id, err := s.coord.LookupIDByLabel(label)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, ErrSessionNotFound) {
+		return "", mapCoordinatorErr(err)
+	}
+	if coordName, name, ok := strings.Cut(label, ":"); ok {
+		if self, _ := s.coordinatorInfo(); strings.TrimSpace(coordName) == self {
+			if id, err := s.coord.LookupIDByLabel(name); err == nil {
+				return id, nil
+			}
+		}
+	}
+	return "", status.Errorf(codes.NotFound, "session %q not found", label)
 }
 
 func (s *GRPCServer) resolveSession(ref *proto.SessionRef) (*Session, error) {
@@ -147,6 +212,7 @@ func NewGRPCServer(coord *Coordinator) *GRPCServer {
 		coord:           coord,
 		shell:           shell,
 		keyframeRing:    make(map[string]*keyframeRing),
+		frameBases:      make(map[string]*frameBaseCache),
 		spokes:          NewSpokeRegistry(),
 		coordinatorName: "local",
 	}
@@ -333,6 +399,10 @@ func (s *GRPCServer) Spawn(_ context.Context, req *proto.SpawnRequest) (*proto.S
 	if strings.TrimSpace(req.Name) == "" {
 		return nil, status.Error(codes.InvalidArgument, "session name is required")
 	}
+	req, profile, err := s.applySpawnProfile(req)
+	if err != nil {
+		return nil, err
+	}
 
 	var cmd []string
 	if strings.TrimSpace(req.Command) != "" {
@@ -347,6 +417,10 @@ func (s *GRPCServer) Spawn(_ context.Context, req *proto.SpawnRequest) (*proto.S
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	resizePolicy, err := core.ParseResizePolicy(req.ResizePolicy)
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
 
 	info, err := s.coord.Spawn(req.Name, SpawnOptions{
 		Command:    cmd,
@@ -354,6 +428,17 @@ func (s *GRPCServer) Spawn(_ context.Context, req *proto.SpawnRequest) (*proto.S
 		Env:        flattenEnv(req.Env),
 		Cols:       cols,
 		Rows:       rows,
+		Tags:       req.Tags,
+
+		IdleThreshold: profile.IdleThreshold,
+		Restart:       profile.Restart,
+		Isolation:     isolationFromProto(req.GetIsolation()),
+		Term:          req.Term,
+		Locale:        req.Locale,
+
+		ShellIntegration: req.ShellIntegration,
+		ResizePolicy:     resizePolicy,
+		Owner:            req.ClientId,
 	})
 	if err != nil {
 		return nil, mapCoordinatorErr(err)
@@ -363,10 +448,114 @@ func (s *GRPCServer) Spawn(_ context.Context, req *proto.SpawnRequest) (*proto.S
 	return &proto.SpawnResponse{Session: toProtoSession(info)}, nil
 }
 
-func (s *GRPCServer) List(_ context.Context, _ *proto.ListRequest) (*proto.ListResponse, error) {
+// applySpawnProfile fills unset request fields from the named profile. Env
+// and tags are merged with request values taking precedence.
+func (s *GRPCServer) applySpawnProfile(req *proto.SpawnRequest) (*proto.SpawnRequest, SpawnProfile, error) {
+	name := strings.TrimSpace(req.GetProfile())
+	if name == "" {
+		return req, SpawnProfile{}, nil
+	}
+	profile, err := s.coord.Profile(name)
+	if err != nil {
+		return nil, SpawnProfile{}, mapCoordinatorErr(fmt.Errorf("%w: %s", err, name))
+	}
+	out := &proto.SpawnRequest{
+		Name:       req.Name,
+		Command:    req.Command,
+		WorkingDir: req.WorkingDir,
+		Env:        mergeStringMaps(profile.Env, req.Env),
+		Cols:       req.Cols,
+		Rows:       req.Rows,
+		Tags:       mergeStringMaps(profile.Tags, req.Tags),
+		Profile:    name,
+		Isolation:  req.Isolation,
+		Term:       req.Term,
+		Locale:     req.Locale,
+
+		ShellIntegration: req.ShellIntegration,
+		ResizePolicy:     req.ResizePolicy,
+		ClientId:         req.ClientId,
+	}
+	if strings.TrimSpace(out.Command) == "" {
+		out.Command = profile.Command
+	}
+	if strings.TrimSpace(out.WorkingDir) == "" {
+		out.WorkingDir = profile.WorkingDir
+	}
+	if out.Cols == 0 {
+		out.Cols = int32(profile.Cols)
+	}
+	if out.Rows == 0 {
+		out.Rows = int32(profile.Rows)
+	}
+	if strings.TrimSpace(out.Term) == "" {
+		out.Term = profile.Term
+	}
+	if strings.TrimSpace(out.Locale) == "" {
+		out.Locale = profile.Locale
+	}
+	if out.ShellIntegration == nil {
+		out.ShellIntegration = profile.ShellIntegration
+	}
+	if strings.TrimSpace(out.ResizePolicy) == "" {
+		out.ResizePolicy = string(profile.ResizePolicy)
+	}
+	return out, profile, nil
+}
+
+func isolationFromProto(iso *proto.SpawnIsolation) *core.Isolation {
+	if iso == nil {
+		return nil
+	}
+	out := &core.Isolation{
+		CPUMax:    iso.GetCpuMax(),
+		MemoryMax: iso.GetMemoryMaxBytes(),
+		PidsMax:   iso.GetPidsMax(),
+		UserNS:    iso.GetUserNs(),
+		MountNS:   iso.GetMountNs(),
+		PIDNS:     iso.GetPidNs(),
+		NetNS:     iso.GetNetNs(),
+		UID:       iso.Uid,
+		GID:       iso.Gid,
+	}
+	for _, limit := range iso.GetRlimits() {
+		if limit == nil {
+			continue
+		}
+		out.Rlimits = append(out.Rlimits, core.Rlimit{
+			Resource: limit.GetResource(),
+			Soft:     limit.GetSoft(),
+			Hard:     limit.GetHard(),
+		})
+	}
+	return out
+}
+
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(base)+len(overrides))
+	for key, value := range base {
+		out[key] = value
+	}
+	for key, value := range overrides {
+		out[key] = value
+	}
+	return out
+}
+
+func (s *GRPCServer) List(_ context.Context, req *proto.ListRequest) (*proto.ListResponse, error) {
+	selector, err := parseSessionSelector(req.GetSelector())
+	if err != nil {
+		return nil, err
+	}
 	sessions := s.coord.List()
 	out := make([]*proto.Session, 0, len(sessions))
 	for _, info := range sessions {
+		if !s.selectorMatches(selector, info) {
+			continue
+		}
 		infoCopy := info
 		out = append(out, toProtoSession(&infoCopy))
 	}
@@ -378,6 +567,10 @@ func (s *GRPCServer) SubscribeSessions(req *proto.SubscribeSessionsRequest, stre
 	if req != nil {
 		excludeExited = req.ExcludeExited
 	}
+	selector, err := parseSessionSelector(req.GetSelector())
+	if err != nil {
+		return err
+	}
 	coordName, coordPath := s.coordinatorInfo()
 	sendSnapshot := func() error {
 		sessions := s.coord.List()
@@ -386,6 +579,9 @@ func (s *GRPCServer) SubscribeSessions(req *proto.SubscribeSessionsRequest, stre
 			if excludeExited && info.State == SessionExited {
 				continue
 			}
+			if !s.selectorMatches(selector, info) {
+				continue
+			}
 			infoCopy := info
 			out = append(out, toProtoSession(&infoCopy))
 		}
@@ -417,6 +613,26 @@ func (s *GRPCServer) SubscribeSessions(req *proto.SubscribeSessionsRequest, stre
 	}
 }
 
+func parseSessionSelector(value string) (core.Selector, error) {
+	selector, err := core.ParseSelector(value)
+	if err != nil {
+		return core.Selector{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return selector, nil
+}
+
+// selectorMatches evaluates selector against the session fields plus the
+// coordinator name, so hubs can forward a selector to every spoke unchanged.
+func (s *GRPCServer) selectorMatches(selector core.Selector, info SessionInfo) bool {
+	if selector.Empty() {
+		return true
+	}
+	fields := info.SelectorFields()
+	coordName, _ := s.coordinatorInfo()
+	fields[core.SelectorKeyCoordinator] = coordName
+	return selector.Matches(fields)
+}
+
 func (s *GRPCServer) coordinatorInfo() (string, string) {
 	if s == nil {
 		return "local", ""
@@ -537,10 +753,54 @@ func (s *GRPCServer) Rename(_ context.Context, req *proto.RenameRequest) (*proto
 	return &proto.RenameResponse{}, nil
 }
 
+func (s *GRPCServer) UpdateSession(_ context.Context, req *proto.UpdateSessionRequest) (*proto.UpdateSessionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	info, err := s.coord.UpdateTags(sessionID, req.SetTags, req.RemoveTags)
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.UpdateSessionResponse{Session: toProtoSession(info)}, nil
+}
+
+func (s *GRPCServer) Clone(_ context.Context, req *proto.CloneRequest) (*proto.CloneResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "session name is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	info, dirErr, err := s.coord.Clone(sessionID, req.Name, CloneOptions{
+		CopySize: req.CopySize,
+		CopyTags: req.CopyTags,
+	})
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	s.clearKeyframes(info.ID)
+	resp := &proto.CloneResponse{Session: toProtoSession(info)}
+	if dirErr != nil {
+		resp.WorkingDirFallback = dirErr.Error()
+	}
+	return resp, nil
+}
+
 func (s *GRPCServer) GetScreen(_ context.Context, req *proto.GetScreenRequest) (*proto.GetScreenResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
 	}
+	if req.HistoryLimit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "history_limit must be >= 0")
+	}
 	session, err := s.resolveSession(req.Session)
 	if err != nil {
 		return nil, err
@@ -549,7 +809,57 @@ func (s *GRPCServer) GetScreen(_ context.Context, req *proto.GetScreenRequest) (
 	if err != nil {
 		return nil, mapCoordinatorErr(err)
 	}
-	return screenResponseFromSnapshot(session.ID(), session.Label(), snap), nil
+	resp := screenResponseFromSnapshot(session.ID(), session.Label(), snap)
+	if req.TrackFrame || req.SinceFrameId != 0 {
+		resp = s.trackScreenFrame(session, resp, snap, req.SinceFrameId)
+	}
+	if req.IncludeScrollbackLines {
+		// Exited sessions may have released their terminal; report 0.
+		if lines, err := session.ScrollbackLines(); err == nil {
+			resp.ScrollbackLines = int32(lines)
+		}
+	}
+	if req.IncludeForegroundProcess {
+		resp.ForegroundProcess = session.ForegroundProcess()
+	}
+	if req.IncludeHistory {
+		// Like scrollback_lines, exited sessions may have no history left.
+		if lines, err := session.HistoryLines(); err == nil {
+			if limit := int(req.HistoryLimit); limit > 0 && len(lines) > limit {
+				lines = lines[len(lines)-limit:]
+			}
+			resp.HistoryLines = lines
+		}
+	}
+	return resp, nil
+}
+
+// trackScreenFrame assigns resp a frame ID and caches snap under it. When
+// base is still cached and compatible, the screen rows are replaced by a delta
+// against it; otherwise resp is returned as a full keyframe.
+func (s *GRPCServer) trackScreenFrame(session *Session, resp *proto.GetScreenResponse, snap *Snapshot, base uint64) *proto.GetScreenResponse {
+	id := session.ID()
+	resp.FrameId = session.NextFrameID()
+	s.keyframeMu.Lock()
+	cache := s.frameBases[id]
+	if cache == nil {
+		cache = newFrameBaseCache(frameBaseCacheSize)
+		s.frameBases[id] = cache
+	}
+	baseSnap, found := cache.Find(base)
+	cache.Add(resp.FrameId, snap)
+	s.keyframeMu.Unlock()
+	if !found || !snapshotDeltaSafe(baseSnap, snap) {
+		return resp
+	}
+	delta, _, err := screenDeltaFromSnapshots(baseSnap, snap)
+	if err != nil {
+		return resp
+	}
+	resp.ScreenRows = nil
+	resp.BaseFrameId = base
+	resp.Delta = delta
+	return resp
 }
 
 func (s *GRPCServer) Grep(_ context.Context, req *proto.GrepRequest) (*proto.GrepResponse, error) {
@@ -591,6 +901,102 @@ func (s *GRPCServer) Grep(_ context.Context, req *proto.GrepRequest) (*proto.Gre
 	return &proto.GrepResponse{Matches: out}, nil
 }
 
+func (s *GRPCServer) FindOnScreen(_ context.Context, req *proto.FindOnScreenRequest) (*proto.FindOnScreenResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	if strings.TrimSpace(req.Pattern) == "" {
+		return nil, status.Error(codes.InvalidArgument, "pattern is required")
+	}
+	re, err := regexp.Compile(req.Pattern)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	var rect core.ScreenRect
+	if r := req.GetRect(); r != nil {
+		rect = core.ScreenRect{X: int(r.X), Y: int(r.Y), Width: int(r.Width), Height: int(r.Height)}
+	}
+	matches, err := s.coord.FindOnScreen(sessionID, re, rect, int(req.MaxMatches))
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	out := make([]*proto.ScreenMatch, 0, len(matches))
+	for _, match := range matches {
+		out = append(out, &proto.ScreenMatch{
+			Row:        int32(match.Row),
+			Col:        int32(match.Col),
+			EndCol:     int32(match.EndCol),
+			Text:       match.Text,
+			FgColor:    packRGB(match.Fg),
+			BgColor:    packRGB(match.Bg),
+			Attributes: uint32(match.Attrs),
+		})
+	}
+	return &proto.FindOnScreenResponse{Matches: out}, nil
+}
+
+func (s *GRPCServer) ExportScreen(_ context.Context, req *proto.ExportScreenRequest) (*proto.ExportScreenResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	opts := core.ExportOptions{
+		History:   req.History,
+		StartLine: int(req.StartLine),
+		EndLine:   int(req.EndLine),
+	}
+	contentType := "text/html; charset=utf-8"
+	switch req.Format {
+	case proto.ExportFormat_EXPORT_FORMAT_UNSPECIFIED, proto.ExportFormat_EXPORT_FORMAT_HTML:
+		opts.Format = core.ExportHTML
+	case proto.ExportFormat_EXPORT_FORMAT_SVG:
+		opts.Format = core.ExportSVG
+		contentType = "image/svg+xml"
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown export format %d", req.Format)
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.coord.ExportScreen(sessionID, opts)
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.ExportScreenResponse{Data: data, ContentType: contentType}, nil
+}
+
+func (s *GRPCServer) ReadOutput(ctx context.Context, req *proto.ReadOutputRequest) (*proto.ReadOutputResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	wait, err := durationFromProto(req.Wait)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	chunk, err := s.coord.ReadOutput(ctx, sessionID, req.Offset, wait)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.ReadOutputResponse{
+		Data:         chunk.Data,
+		Offset:       chunk.Offset,
+		NextOffset:   chunk.Next,
+		DroppedBytes: chunk.Dropped,
+		Exited:       chunk.Exited,
+	}, nil
+}
+
 func (s *GRPCServer) SendText(_ context.Context, req *proto.SendTextRequest) (*proto.SendTextResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
@@ -602,7 +1008,7 @@ func (s *GRPCServer) SendText(_ context.Context, req *proto.SendTextRequest) (*p
 	if err != nil {
 		return nil, err
 	}
-	if err := s.coord.Send(sessionID, normalizeTextInput(req.Text)); err != nil {
+	if err := s.coord.SendAs(sessionID, core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken}, normalizeTextInput(req.Text)); err != nil {
 		return nil, mapCoordinatorErr(err)
 	}
 	return &proto.SendTextResponse{}, nil
@@ -620,7 +1026,7 @@ func (s *GRPCServer) SendKey(_ context.Context, req *proto.SendKeyRequest) (*pro
 	if err != nil {
 		return nil, err
 	}
-	if err := s.coord.Send(sessionID, seq); err != nil {
+	if err := s.coord.SendAs(sessionID, core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken}, seq); err != nil {
 		return nil, mapCoordinatorErr(err)
 	}
 	return &proto.SendKeyResponse{}, nil
@@ -637,12 +1043,118 @@ func (s *GRPCServer) SendBytes(_ context.Context, req *proto.SendBytesRequest) (
 	if err != nil {
 		return nil, err
 	}
-	if err := s.coord.Send(sessionID, req.Data); err != nil {
+	if err := s.coord.SendAs(sessionID, core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken}, req.Data); err != nil {
 		return nil, mapCoordinatorErr(err)
 	}
 	return &proto.SendBytesResponse{}, nil
 }
 
+func (s *GRPCServer) SendMouse(_ context.Context, req *proto.SendMouseRequest) (*proto.SendMouseResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	ev, err := mouseEventFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := s.coord.SendMouse(sessionID, core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken}, ev)
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.SendMouseResponse{Sent: sent}, nil
+}
+
+func mouseEventFromProto(req *proto.SendMouseRequest) (core.MouseEvent, error) {
+	ev := core.MouseEvent{
+		X:     int(req.X),
+		Y:     int(req.Y),
+		Shift: req.Shift,
+		Alt:   req.Alt,
+		Ctrl:  req.Ctrl,
+	}
+	switch req.Button {
+	case proto.MouseButton_MOUSE_BUTTON_UNSPECIFIED:
+		ev.Button = core.MouseNoButton
+	case proto.MouseButton_MOUSE_BUTTON_LEFT:
+		ev.Button = core.MouseLeft
+	case proto.MouseButton_MOUSE_BUTTON_MIDDLE:
+		ev.Button = core.MouseMiddle
+	case proto.MouseButton_MOUSE_BUTTON_RIGHT:
+		ev.Button = core.MouseRight
+	case proto.MouseButton_MOUSE_BUTTON_WHEEL_UP:
+		ev.Button = core.MouseWheelUp
+	case proto.MouseButton_MOUSE_BUTTON_WHEEL_DOWN:
+		ev.Button = core.MouseWheelDown
+	case proto.MouseButton_MOUSE_BUTTON_WHEEL_LEFT:
+		ev.Button = core.MouseWheelLeft
+	case proto.MouseButton_MOUSE_BUTTON_WHEEL_RIGHT:
+		ev.Button = core.MouseWheelRight
+	default:
+		return ev, fmt.Errorf("unknown mouse button %d", req.Button)
+	}
+	switch req.Action {
+	case proto.MouseAction_MOUSE_ACTION_PRESS:
+		ev.Action = core.MousePress
+	case proto.MouseAction_MOUSE_ACTION_RELEASE:
+		ev.Action = core.MouseRelease
+	case proto.MouseAction_MOUSE_ACTION_MOTION:
+		ev.Action = core.MouseMotion
+	case proto.MouseAction_MOUSE_ACTION_CLICK:
+		ev.Action = core.MouseClick
+	case proto.MouseAction_MOUSE_ACTION_UNSPECIFIED:
+		return ev, errors.New("mouse action is required")
+	default:
+		return ev, fmt.Errorf("unknown mouse action %d", req.Action)
+	}
+	return ev, nil
+}
+
+func (s *GRPCServer) Paste(req *proto.PasteRequest, stream proto.VTR_PasteServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "session id is required")
+	}
+	if len(req.Data) > maxPasteBytes {
+		return status.Errorf(codes.InvalidArgument, "data exceeds %d bytes", maxPasteBytes)
+	}
+	if req.ChunkSize < 0 {
+		return status.Error(codes.InvalidArgument, "chunk_size must be >= 0")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return err
+	}
+	var sendErr error
+	progress, err := s.coord.Paste(stream.Context(), sessionID, req.Data, core.PasteOptions{
+		From:      core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken},
+		Bracketed: req.Bracketed,
+		ChunkSize: int(req.ChunkSize),
+		Progress: func(p core.PasteProgress) error {
+			sendErr = stream.Send(pasteProgressToProto(p, false))
+			return sendErr
+		},
+	})
+	if err != nil {
+		if sendErr != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		return mapCoordinatorErr(err)
+	}
+	return stream.Send(pasteProgressToProto(progress, true))
+}
+
+func pasteProgressToProto(p core.PasteProgress, done bool) *proto.PasteProgress {
+	return &proto.PasteProgress{
+		Written:   int64(p.Written),
+		Total:     int64(p.Total),
+		Bracketed: p.Bracketed,
+		Done:      done,
+	}
+}
+
 func (s *GRPCServer) Resize(ctx context.Context, req *proto.ResizeRequest) (*proto.ResizeResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "session id is required")
@@ -680,10 +1192,193 @@ func (s *GRPCServer) Resize(ctx context.Context, req *proto.ResizeRequest) (*pro
 			"peer", peerAddr,
 		)
 	}
-	if err := s.coord.Resize(sessionID, cols, rows); err != nil {
+	cols, rows, err = s.coord.ResizeFrom(sessionID, core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken}, cols, rows)
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.ResizeResponse{Cols: int32(cols), Rows: int32(rows)}, nil
+}
+
+func (s *GRPCServer) AcquireInputLock(_ context.Context, req *proto.AcquireInputLockRequest) (*proto.AcquireInputLockResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	lease := time.Duration(0)
+	if req.Lease != nil {
+		if err := req.Lease.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid lease")
+		}
+		lease = req.Lease.AsDuration()
+		if lease < 0 {
+			return nil, status.Error(codes.InvalidArgument, "lease must be >= 0")
+		}
+	}
+	lock, token, err := s.coord.AcquireInputLock(sessionID, req.LockToken, req.Holder, lease)
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.AcquireInputLockResponse{Lock: toProtoInputLock(lock), LockToken: token}, nil
+}
+
+func (s *GRPCServer) ReleaseInputLock(_ context.Context, req *proto.ReleaseInputLockRequest) (*proto.ReleaseInputLockResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.coord.ReleaseInputLock(sessionID, req.LockToken); err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.ReleaseInputLockResponse{}, nil
+}
+
+func (s *GRPCServer) ListClients(_ context.Context, req *proto.ListClientsRequest) (*proto.ListClientsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := s.coord.ListClients(sessionID)
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	out := make([]*proto.AttachedClient, 0, len(clients))
+	for _, client := range clients {
+		out = append(out, toProtoAttachedClient(client))
+	}
+	return &proto.ListClientsResponse{Clients: out}, nil
+}
+
+func (s *GRPCServer) DisconnectClient(_ context.Context, req *proto.DisconnectClientRequest) (*proto.DisconnectClientResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	if strings.TrimSpace(req.Id) == "" {
+		return nil, status.Error(codes.InvalidArgument, "client id is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.coord.DisconnectClient(sessionID, req.Id); err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.DisconnectClientResponse{}, nil
+}
+
+func (s *GRPCServer) StartMacro(_ context.Context, req *proto.StartMacroRequest) (*proto.StartMacroResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "macro name is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	from := core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken}
+	if err := s.coord.StartMacro(sessionID, req.Name, from); err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.StartMacroResponse{}, nil
+}
+
+func (s *GRPCServer) StopMacro(_ context.Context, req *proto.StopMacroRequest) (*proto.StopMacroResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	info, err := s.coord.StopMacro(sessionID, core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken})
+	if err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.StopMacroResponse{Macro: s.macroToProto(info)}, nil
+}
+
+func (s *GRPCServer) PlayMacro(ctx context.Context, req *proto.PlayMacroRequest) (*proto.PlayMacroResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "macro name is required")
+	}
+	if req.Speed < 0 {
+		return nil, status.Error(codes.InvalidArgument, "speed must be >= 0")
+	}
+	stepDelay, err := durationFromProto(req.StepDelay)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sessionID, err := s.requireSessionID(req.Session)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := s.coord.PlayMacro(ctx, sessionID, req.Name, core.PlayMacroOptions{
+		From:      core.InputSource{ClientID: req.ClientId, LockToken: req.LockToken},
+		Speed:     req.Speed,
+		StepDelay: stepDelay,
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.PlayMacroResponse{Steps: int32(steps)}, nil
+}
+
+func (s *GRPCServer) ListMacros(_ context.Context, req *proto.ListMacrosRequest) (*proto.ListMacrosResponse, error) {
+	coordName, _ := s.coordinatorInfo()
+	if want := strings.TrimSpace(req.GetCoordinator()); want != "" && want != coordName {
+		return &proto.ListMacrosResponse{}, nil
+	}
+	macros, err := s.coord.Macros()
+	if err != nil {
 		return nil, mapCoordinatorErr(err)
 	}
-	return &proto.ResizeResponse{}, nil
+	out := make([]*proto.MacroInfo, 0, len(macros))
+	for _, info := range macros {
+		out = append(out, s.macroToProto(info))
+	}
+	return &proto.ListMacrosResponse{Macros: out}, nil
+}
+
+func (s *GRPCServer) DeleteMacro(_ context.Context, req *proto.DeleteMacroRequest) (*proto.DeleteMacroResponse, error) {
+	if req == nil || strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "macro name is required")
+	}
+	coordName, _ := s.coordinatorInfo()
+	if want := strings.TrimSpace(req.Coordinator); want != "" && want != coordName {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("unknown coordinator %q", want))
+	}
+	if err := s.coord.DeleteMacro(req.Name); err != nil {
+		return nil, mapCoordinatorErr(err)
+	}
+	return &proto.DeleteMacroResponse{}, nil
+}
+
+func (s *GRPCServer) macroToProto(info core.MacroInfo) *proto.MacroInfo {
+	coordName, _ := s.coordinatorInfo()
+	return &proto.MacroInfo{
+		Name:        info.Name,
+		Coordinator: coordName,
+		CreatedAt:   timestamppb.New(info.CreatedAt),
+		Steps:       int32(info.Steps),
+		Bytes:       int64(info.Bytes),
+		Duration:    durationpb.New(info.Duration),
+	}
 }
 
 func (s *GRPCServer) WaitFor(ctx context.Context, req *proto.WaitForRequest) (*proto.WaitForResponse, error) {
@@ -767,7 +1462,7 @@ func (s *GRPCServer) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Sub
 	if req == nil {
 		return status.Error(codes.InvalidArgument, "session id is required")
 	}
-	if !req.IncludeScreenUpdates && !req.IncludeRawOutput {
+	if !req.IncludeScreenUpdates && !req.IncludeRawOutput && !req.IncludeInitialScreen {
 		return status.Error(codes.InvalidArgument, "subscribe requires screen updates or raw output")
 	}
 
@@ -776,12 +1471,18 @@ func (s *GRPCServer) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Sub
 		return err
 	}
 	sessionID := session.ID()
+	attachment, err := s.coord.AttachClient(sessionID, subscribeClientInfo(stream.Context(), req))
+	if err != nil {
+		return mapCoordinatorErr(err)
+	}
+	defer attachment.Detach()
 	startedAt := time.Now()
 	startCount := subscribeStreamStartedCount.Add(1)
 	slog.Info(
 		"subscribe stream start",
 		"session_id", sessionID,
 		"include_screen", req.IncludeScreenUpdates,
+		"include_initial_screen", req.IncludeInitialScreen,
 		"include_raw", req.IncludeRawOutput,
 		"stream_started_count", startCount,
 	)
@@ -916,6 +1617,26 @@ func (s *GRPCServer) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Sub
 				screenBuilder.primeFromKeyframe(cached, nil)
 			}
 		}
+	if req.IncludeInitialScreen && !includeScreen {
+		// Sent before the sender starts so it always precedes raw output, which
+		// resumes from the output offset the keyframe covers.
+		initial, err := makeScreenSnapshot(true, "initial_subscribe")
+		if err != nil {
+			return mapCoordinatorErr(err)
+		}
+		update, err := screenBuilder.Build(initial)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&proto.SubscribeEvent{
+			Event: &proto.SubscribeEvent_ScreenUpdate{ScreenUpdate: update},
+		}); err != nil {
+			return err
+		}
+		if initial.outputStable {
+			offset = initial.outputTotal
+		}
+	}
 
 	go func() {
 		sendErrCh <- runSubscribeSender(
@@ -996,6 +1717,8 @@ func (s *GRPCServer) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Sub
 			return err
 		case <-ctx.Done():
 			return ctx.Err()
+		case <-attachment.Done():
+			return status.Error(codes.Aborted, "client disconnected")
 		case <-idleCh:
 			idleState, nextCh := session.IdleState()
 			idleCh = nextCh
@@ -1079,6 +1802,73 @@ func (s *GRPCServer) Subscribe(req *proto.SubscribeRequest, stream proto.VTR_Sub
 	}
 }
 
+// subscribeClientInfo describes a Subscribe caller for the presence registry.
+func subscribeClientInfo(ctx context.Context, req *proto.SubscribeRequest) ClientInfo {
+	info := ClientInfo{
+		ClientID: req.ClientId,
+		Kind:     req.ClientKind,
+		Name:     strings.TrimSpace(req.ClientName),
+		ReadOnly: req.ReadOnly,
+	}
+	if cols, err := optionalUint16(req.Cols); err == nil {
+		info.Cols = cols
+	}
+	if rows, err := optionalUint16(req.Rows); err == nil {
+		info.Rows = rows
+	}
+	info.Peer, info.Identity = CallerPeer(ctx)
+	return info
+}
+
+type forwardedPeerKey struct{}
+
+type forwardedPeer struct {
+	addr     string
+	identity string
+}
+
+// WithForwardedPeer records the client behind a request a hub tunnelled to
+// this coordinator. The tunnel's own gRPC peer is the hub.
+func WithForwardedPeer(ctx context.Context, addr, identity string) context.Context {
+	if addr == "" && identity == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, forwardedPeerKey{}, forwardedPeer{addr: addr, identity: identity})
+}
+
+// CallerPeer returns the remote address and verified identity of the client
+// behind ctx, preferring the original client a hub forwarded.
+func CallerPeer(ctx context.Context) (string, string) {
+	if fwd, ok := ctx.Value(forwardedPeerKey{}).(forwardedPeer); ok {
+		return fwd.addr, fwd.identity
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p == nil {
+		return "", ""
+	}
+	addr := ""
+	if p.Addr != nil {
+		addr = p.Addr.String()
+	}
+	return addr, peerIdentity(p)
+}
+
+// peerIdentity returns the name on a verified client certificate.
+func peerIdentity(p *peer.Peer) string {
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
 type exitPayload struct {
 	exit        *proto.SessionExited
 	finalScreen *subscribeScreenSnapshot
@@ -1393,6 +2183,8 @@ func subscribeStreamEndReason(err error) string {
 		return "context_canceled"
 	case errors.Is(err, context.DeadlineExceeded):
 		return "context_deadline"
+	case status.Code(err) == codes.Aborted:
+		return "client_disconnected"
 	default:
 		return "error"
 	}
@@ -1499,9 +2291,44 @@ func (s *GRPCServer) cachedKeyframe(session *Session, id string) *proto.ScreenUp
 func (s *GRPCServer) clearKeyframes(id string) {
 	s.keyframeMu.Lock()
 	delete(s.keyframeRing, id)
+	delete(s.frameBases, id)
 	s.keyframeMu.Unlock()
 }
 
+func newFrameBaseCache(size int) *frameBaseCache {
+	if size < 1 {
+		size = 1
+	}
+	return &frameBaseCache{size: size}
+}
+
+// Find returns the snapshot for frameID and marks it most recently used.
+func (c *frameBaseCache) Find(frameID uint64) (*Snapshot, bool) {
+	if c == nil || frameID == 0 {
+		return nil, false
+	}
+	for i, entry := range c.entries {
+		if entry.frameID != frameID {
+			continue
+		}
+		copy(c.entries[i:], c.entries[i+1:])
+		c.entries[len(c.entries)-1] = entry
+		return entry.snapshot, true
+	}
+	return nil, false
+}
+
+// Add stores snap under frameID, evicting the least recently used base.
+func (c *frameBaseCache) Add(frameID uint64, snap *Snapshot) {
+	if c == nil || snap == nil {
+		return
+	}
+	if len(c.entries) >= c.size {
+		c.entries = c.entries[1:]
+	}
+	c.entries = append(c.entries, frameBase{frameID: frameID, snapshot: snap})
+}
+
 func toProtoSession(info *SessionInfo) *proto.Session {
 	if info == nil {
 		return nil
@@ -1516,6 +2343,13 @@ func toProtoSession(info *SessionInfo) *proto.Session {
 		Idle:      info.Idle,
 		Order:     info.Order,
 		Id:        info.ID,
+		Tags:      info.Tags,
+
+		ShellIntegration: info.ShellIntegration,
+		InputLock:        toProtoInputLock(info.InputLock),
+		AttachedClients:  int32(info.AttachedClients),
+		ResizePolicy:     string(info.ResizePolicy),
+		BracketedPaste:   info.BracketedPaste,
 	}
 	if info.State != SessionExited {
 		session.ExitCode = 0
@@ -1526,6 +2360,31 @@ func toProtoSession(info *SessionInfo) *proto.Session {
 	return session
 }
 
+func toProtoInputLock(lock *core.InputLock) *proto.InputLock {
+	if lock == nil {
+		return nil
+	}
+	return &proto.InputLock{
+		Holder:    lock.Holder,
+		ExpiresAt: timestamppb.New(lock.ExpiresAt),
+	}
+}
+
+func toProtoAttachedClient(info ClientInfo) *proto.AttachedClient {
+	return &proto.AttachedClient{
+		Id:          info.ID,
+		ClientId:    info.ClientID,
+		Kind:        info.Kind,
+		Name:        info.Name,
+		Peer:        info.Peer,
+		Identity:    info.Identity,
+		ReadOnly:    info.ReadOnly,
+		Cols:        int32(info.Cols),
+		Rows:        int32(info.Rows),
+		ConnectedAt: timestamppb.New(info.ConnectedAt),
+	}
+}
+
 func toProtoStatus(state SessionState) proto.SessionStatus {
 	switch state {
 	case SessionRunning:
@@ -1543,19 +2402,36 @@ func mapCoordinatorErr(err error) error {
 	switch {
 	case err == nil:
 		return nil
-	case errors.Is(err, ErrSessionNotFound):
+	case errors.Is(err, ErrSessionNotFound), errors.Is(err, ErrProfileNotFound), errors.Is(err, ErrClientNotFound),
+		errors.Is(err, ErrMacroNotFound):
 		return status.Error(codes.NotFound, err.Error())
 	case errors.Is(err, ErrSessionExists):
 		return status.Error(codes.AlreadyExists, err.Error())
-	case errors.Is(err, ErrSessionNotRunning):
+	case errors.Is(err, ErrInputLocked):
+		return statusWithReason(codes.FailedPrecondition, err.Error(), proto.ErrorReason_ERROR_REASON_INPUT_LOCKED)
+	case errors.Is(err, ErrSessionNotRunning), errors.Is(err, ErrIsolationUnavailable),
+		errors.Is(err, ErrMacroRecording), errors.Is(err, ErrNotRecording), errors.Is(err, ErrMacrosDisabled),
+		errors.Is(err, ErrWorkingDirUnavailable):
 		return status.Error(codes.FailedPrecondition, err.Error())
-	case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidSize):
+	case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidSize), errors.Is(err, ErrInvalidTags),
+		errors.Is(err, ErrInvalidIsolation), errors.Is(err, ErrInvalidClientID), errors.Is(err, ErrInvalidResizePolicy),
+		errors.Is(err, ErrInvalidMouse), errors.Is(err, ErrInvalidRect), errors.Is(err, ErrInvalidMacro):
 		return status.Error(codes.InvalidArgument, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
 }
 
+// statusWithReason returns a status carrying an ErrorInfo with reason, so
+// clients can tell the error apart without matching its message.
+func statusWithReason(code codes.Code, msg string, reason proto.ErrorReason) error {
+	st := status.New(code, msg)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason.String(), Domain: "vtrpc"}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
 func flattenEnv(env map[string]string) []string {
 	if len(env) == 0 {
 		return nil
@@ -1610,15 +2486,25 @@ func screenResponseFromSnapshot(id, label string, snap *Snapshot) *proto.GetScre
 		}
 		rows[row] = &proto.ScreenRow{Cells: cells}
 	}
-	return &proto.GetScreenResponse{
-		Name:       label,
-		Cols:       int32(snap.Cols),
-		Rows:       int32(snap.Rows),
-		CursorX:    int32(snap.CursorX),
-		CursorY:    int32(snap.CursorY),
-		ScreenRows: rows,
-		Id:         id,
-	}
+	resp := &proto.GetScreenResponse{
+		Name:                  label,
+		Cols:                  int32(snap.Cols),
+		Rows:                  int32(snap.Rows),
+		CursorX:               int32(snap.CursorX),
+		CursorY:               int32(snap.CursorY),
+		ScreenRows:            rows,
+		Id:                    id,
+		CursorHidden:          !snap.CursorVisible,
+		MouseTracking:         proto.MouseTracking(snap.Modes.MouseEvents),
+		MouseEncoding:         proto.MouseEncoding(snap.Modes.MouseFormat),
+		BracketedPaste:        snap.Modes.BracketedPaste,
+		ApplicationCursorKeys: snap.Modes.CursorKeys,
+	}
+	if snap.ScrollBottom > snap.ScrollTop && (snap.ScrollTop > 0 || snap.ScrollBottom < snap.Rows-1) {
+		resp.ScrollTop = int32(snap.ScrollTop + 1)
+		resp.ScrollBottom = int32(snap.ScrollBottom + 1)
+	}
+	return resp
 }
 
 func keyframeUpdateFromSnapshot(session *Session, id, label string, snap *Snapshot) *proto.ScreenUpdate {
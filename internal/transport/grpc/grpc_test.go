@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"image/color"
+	"io"
 	"net"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -15,6 +17,7 @@ import (
 
 	core "github.com/advait/vtrpc/internal/core"
 	proto "github.com/advait/vtrpc/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
@@ -36,8 +39,12 @@ func newTestCoordinator() *Coordinator {
 
 func startGRPCTestServer(t *testing.T) (proto.VTRClient, func()) {
 	t.Helper()
+	return startGRPCTestServerWithCoordinator(t, newTestCoordinator())
+}
+
+func startGRPCTestServerWithCoordinator(t *testing.T, coord *Coordinator) (proto.VTRClient, func()) {
+	t.Helper()
 
-	coord := newTestCoordinator()
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
 		coord.CloseAll()
@@ -414,6 +421,212 @@ func TestGRPCSubscribeSessions(t *testing.T) {
 	cancel()
 }
 
+func TestGRPCSessionTagsSelector(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	mine, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-tag-mine",
+		Command: "sleep 5",
+		Tags:    map[string]string{"owner": "agent-7"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn mine: %v", err)
+	}
+	if _, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-tag-other",
+		Command: "sleep 5",
+		Tags:    map[string]string{"owner": "agent-8"},
+	}); err != nil {
+		t.Fatalf("Spawn other: %v", err)
+	}
+
+	listResp, err := client.List(ctx, &proto.ListRequest{Selector: "owner=agent-7"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(listResp.Sessions) != 1 || listResp.Sessions[0].Name != "grpc-tag-mine" {
+		t.Fatalf("List selector returned %v", listResp.Sessions)
+	}
+	if got := listResp.Sessions[0].GetTags()["owner"]; got != "agent-7" {
+		t.Fatalf("List tags owner=%q", got)
+	}
+
+	updateResp, err := client.UpdateSession(ctx, &proto.UpdateSessionRequest{
+		Session: &proto.SessionRef{Id: mine.GetSession().GetId()},
+		SetTags: map[string]string{"task": "1234"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSession: %v", err)
+	}
+	if got := updateResp.GetSession().GetTags()["task"]; got != "1234" {
+		t.Fatalf("UpdateSession tags=%v", updateResp.GetSession().GetTags())
+	}
+
+	stream, err := client.SubscribeSessions(ctx, &proto.SubscribeSessionsRequest{Selector: "task=1234,coordinator=local"})
+	if err != nil {
+		t.Fatalf("SubscribeSessions: %v", err)
+	}
+	snapshot, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("SubscribeSessions recv: %v", err)
+	}
+	if !snapshotHasSession(snapshot, "grpc-tag-mine") || snapshotHasSession(snapshot, "grpc-tag-other") {
+		t.Fatalf("SubscribeSessions selector snapshot=%v", snapshot)
+	}
+
+	_, err = client.List(ctx, &proto.ListRequest{Selector: "bad key=x"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("List invalid selector err=%v", err)
+	}
+	_, err = client.UpdateSession(ctx, &proto.UpdateSessionRequest{
+		Session: &proto.SessionRef{Id: mine.GetSession().GetId()},
+		SetTags: map[string]string{"idle": "true"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("UpdateSession reserved tag err=%v", err)
+	}
+}
+
+func TestGRPCSessionRefLabel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "grpc-label", Command: "sleep 5"})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	wantID := spawnResp.GetSession().GetId()
+
+	for _, label := range []string{"grpc-label", "local:grpc-label"} {
+		infoResp, err := client.Info(ctx, &proto.InfoRequest{Session: &proto.SessionRef{Label: label}})
+		if err != nil {
+			t.Fatalf("Info(%q): %v", label, err)
+		}
+		if infoResp.GetSession().GetId() != wantID {
+			t.Fatalf("Info(%q) id=%q, want %q", label, infoResp.GetSession().GetId(), wantID)
+		}
+	}
+
+	if _, err := client.Rename(ctx, &proto.RenameRequest{
+		Session: &proto.SessionRef{Label: "grpc-label"},
+		NewName: "grpc-label-renamed",
+	}); err != nil {
+		t.Fatalf("Rename by label: %v", err)
+	}
+	_, err = client.Info(ctx, &proto.InfoRequest{Session: &proto.SessionRef{Label: "grpc-label"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for old label, got %v", err)
+	}
+	_, err = client.Info(ctx, &proto.InfoRequest{Session: &proto.SessionRef{}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for empty ref, got %v", err)
+	}
+}
+
+func TestGRPCSpawnProfile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	coord := core.NewCoordinator(core.CoordinatorOptions{
+		DefaultShell: "/bin/sh",
+		KillTimeout:  500 * time.Millisecond,
+		Profiles: map[string]core.SpawnProfile{
+			"worker": {
+				Command: "printf 'role:%s\\n' \"$ROLE\"; sleep 5",
+				Env:     map[string]string{"ROLE": "worker"},
+				Cols:    100,
+				Rows:    30,
+				Tags:    map[string]string{"kind": "worker", "owner": "ops"},
+			},
+		},
+	})
+	client, cleanup := startGRPCTestServerWithCoordinator(t, coord)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	resp, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "profiled",
+		Profile: "worker",
+		Rows:    20,
+		Tags:    map[string]string{"owner": "agent-7"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	session := resp.GetSession()
+	if session.GetCols() != 100 || session.GetRows() != 20 {
+		t.Fatalf("size=%dx%d, want 100x20", session.GetCols(), session.GetRows())
+	}
+	if session.GetTags()["kind"] != "worker" || session.GetTags()["owner"] != "agent-7" {
+		t.Fatalf("tags=%v", session.GetTags())
+	}
+	waitForScreenContains(t, client, session.GetId(), "role:worker", 2*time.Second)
+
+	_, err = client.Spawn(ctx, &proto.SpawnRequest{Name: "missing", Profile: "nope"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown profile, got %v", err)
+	}
+}
+
+func TestGRPCClone(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "origin",
+		Command: "printf 'role:%s\\n' \"$ROLE\"; sleep 5",
+		Env:     map[string]string{"ROLE": "builder"},
+		Tags:    map[string]string{"owner": "agent-7"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	resp, err := client.Clone(ctx, &proto.CloneRequest{
+		Session:  &proto.SessionRef{Label: "origin"},
+		Name:     "origin-2",
+		CopyTags: true,
+	})
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	session := resp.GetSession()
+	if session.GetName() != "origin-2" || session.GetTags()["owner"] != "agent-7" {
+		t.Fatalf("unexpected clone %#v", session)
+	}
+	waitForScreenContains(t, client, session.GetId(), "role:builder", 2*time.Second)
+
+	_, err = client.Clone(ctx, &proto.CloneRequest{Session: &proto.SessionRef{Label: "origin"}, Name: "origin-2"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists for duplicate clone name, got %v", err)
+	}
+	_, err = client.Clone(ctx, &proto.CloneRequest{Session: &proto.SessionRef{Label: "origin"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without a name, got %v", err)
+	}
+}
+
 func snapshotHasSession(snapshot *proto.SessionsSnapshot, name string) bool {
 	if snapshot == nil {
 		return false
@@ -599,6 +812,342 @@ func TestGRPCGrep(t *testing.T) {
 	}
 }
 
+func TestGRPCFindOnScreen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-find",
+		Command: "printf 'menu\\n  Open  Save\\n'; sleep 5",
+		Cols:    40,
+		Rows:    10,
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	sessionID := spawnResp.GetSession().GetId()
+
+	waitForScreenContains(t, client, sessionID, "Save", 2*time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := client.FindOnScreen(ctx, &proto.FindOnScreenRequest{
+		Session: &proto.SessionRef{Id: sessionID},
+		Pattern: "Save|Open",
+	})
+	if err != nil {
+		t.Fatalf("FindOnScreen: %v", err)
+	}
+	if len(resp.Matches) != 2 {
+		t.Fatalf("expected 2 matches, got %v", resp.Matches)
+	}
+	save := resp.Matches[1]
+	if save.Text != "Save" || save.Row != 1 || save.Col != 8 || save.EndCol != 12 {
+		t.Fatalf("unexpected match %#v", save)
+	}
+
+	resp, err = client.FindOnScreen(ctx, &proto.FindOnScreenRequest{
+		Session: &proto.SessionRef{Id: sessionID},
+		Pattern: "Save|Open",
+		Rect:    &proto.ScreenRect{X: 6, Y: 1, Height: 1},
+	})
+	if err != nil {
+		t.Fatalf("FindOnScreen rect: %v", err)
+	}
+	if len(resp.Matches) != 1 || resp.Matches[0].Text != "Save" {
+		t.Fatalf("unexpected rect matches %v", resp.Matches)
+	}
+
+	_, err = client.FindOnScreen(ctx, &proto.FindOnScreenRequest{
+		Session: &proto.SessionRef{Id: sessionID},
+		Pattern: "Save",
+		Rect:    &proto.ScreenRect{Y: 10},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for rect outside the screen, got %v", err)
+	}
+}
+
+func TestGRPCExportScreen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-export",
+		Command: "printf 'first\\nsecond <b>\\n'; sleep 5",
+		Cols:    40,
+		Rows:    10,
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	sessionID := spawnResp.GetSession().GetId()
+
+	waitForScreenContains(t, client, sessionID, "second", 2*time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := client.ExportScreen(ctx, &proto.ExportScreenRequest{Session: &proto.SessionRef{Id: sessionID}})
+	if err != nil {
+		t.Fatalf("ExportScreen: %v", err)
+	}
+	page := string(resp.Data)
+	if resp.ContentType != "text/html; charset=utf-8" || !strings.HasPrefix(page, "<!DOCTYPE html>") ||
+		!strings.Contains(page, "<title>grpc-export</title>") || !strings.Contains(page, "second &lt;b&gt;") {
+		t.Fatalf("unexpected html export %q:\n%s", resp.ContentType, page)
+	}
+
+	resp, err = client.ExportScreen(ctx, &proto.ExportScreenRequest{
+		Session:   &proto.SessionRef{Id: sessionID},
+		Format:    proto.ExportFormat_EXPORT_FORMAT_SVG,
+		History:   true,
+		StartLine: 1,
+		EndLine:   2,
+	})
+	if err != nil {
+		t.Fatalf("ExportScreen svg: %v", err)
+	}
+	image := string(resp.Data)
+	if resp.ContentType != "image/svg+xml" || !strings.HasPrefix(image, "<svg ") ||
+		!strings.Contains(image, ">second &lt;b&gt;") || strings.Contains(image, "first") {
+		t.Fatalf("unexpected svg export %q:\n%s", resp.ContentType, image)
+	}
+
+	_, err = client.ExportScreen(ctx, &proto.ExportScreenRequest{
+		Session: &proto.SessionRef{Id: sessionID},
+		Format:  proto.ExportFormat(99),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for unknown format, got %v", err)
+	}
+}
+
+func TestGRPCGetScreenSinceFrame(t *testing.T) {
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-screen-since",
+		Command: "printf 'ready\\n'; read line; echo \"got $line\"; exec sleep 5",
+		Cols:    20,
+		Rows:    5,
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	ref := &proto.SessionRef{Id: spawnResp.GetSession().GetId()}
+
+	waitForScreenContains(t, client, ref.Id, "ready", 2*time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	plain, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref})
+	if err != nil {
+		t.Fatalf("GetScreen: %v", err)
+	}
+	if plain.FrameId != 0 || plain.Delta != nil {
+		t.Fatalf("expected untracked screen, got frame %d", plain.FrameId)
+	}
+
+	first, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref, TrackFrame: true})
+	if err != nil {
+		t.Fatalf("GetScreen: %v", err)
+	}
+	if first.FrameId == 0 || first.Delta != nil || len(first.ScreenRows) != 5 {
+		t.Fatalf("expected full keyframe, got frame %d delta %v rows %d", first.FrameId, first.Delta, len(first.ScreenRows))
+	}
+
+	if _, err := client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: "hi\n"}); err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+	waitForScreenContains(t, client, ref.Id, "got hi", 2*time.Second)
+
+	diff, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref, SinceFrameId: first.FrameId})
+	if err != nil {
+		t.Fatalf("GetScreen since: %v", err)
+	}
+	if diff.FrameId <= first.FrameId || diff.BaseFrameId != first.FrameId || diff.Delta == nil {
+		t.Fatalf("expected delta from %d, got frame %d base %d", first.FrameId, diff.FrameId, diff.BaseFrameId)
+	}
+	if len(diff.ScreenRows) != 0 {
+		t.Fatalf("expected no screen rows with a delta, got %d", len(diff.ScreenRows))
+	}
+	var changed []string
+	for _, row := range diff.Delta.RowDeltas {
+		if row.Row == 0 {
+			t.Fatalf("unchanged row 0 in delta")
+		}
+		var b strings.Builder
+		for _, cell := range row.RowData.GetCells() {
+			b.WriteString(cell.GetChar())
+		}
+		changed = append(changed, strings.TrimSpace(b.String()))
+	}
+	if !strings.Contains(strings.Join(changed, "\n"), "got hi") {
+		t.Fatalf("expected changed rows to include output, got %q", changed)
+	}
+
+	// More pollers than the subscribe keyframe ring holds each keep their base.
+	pollers := make([]uint64, keyframeRingSize+2)
+	for i := range pollers {
+		resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref, TrackFrame: true})
+		if err != nil {
+			t.Fatalf("GetScreen: %v", err)
+		}
+		pollers[i] = resp.FrameId
+	}
+	for i, frameID := range pollers {
+		resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref, SinceFrameId: frameID})
+		if err != nil {
+			t.Fatalf("GetScreen poller %d: %v", i, err)
+		}
+		if resp.BaseFrameId != frameID || resp.Delta == nil {
+			t.Fatalf("poller %d: expected delta from %d, got base %d", i, frameID, resp.BaseFrameId)
+		}
+	}
+
+	for i := 0; i < frameBaseCacheSize; i++ {
+		if _, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref, TrackFrame: true}); err != nil {
+			t.Fatalf("GetScreen: %v", err)
+		}
+	}
+	evicted, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: ref, SinceFrameId: diff.FrameId})
+	if err != nil {
+		t.Fatalf("GetScreen evicted: %v", err)
+	}
+	if evicted.Delta != nil || evicted.BaseFrameId != 0 || len(evicted.ScreenRows) != 5 {
+		t.Fatalf("expected keyframe fallback, got base %d delta %v rows %d", evicted.BaseFrameId, evicted.Delta, len(evicted.ScreenRows))
+	}
+}
+
+func TestGRPCGetScreenContext(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("foreground process names are read from /proc")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-screen-context",
+		Command: "seq 1 12; exec sleep 5",
+		Cols:    20,
+		Rows:    5,
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	sessionID := spawnResp.GetSession().GetId()
+
+	waitForScreenContains(t, client, sessionID, "12", 2*time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: &proto.SessionRef{Id: sessionID}})
+	if err != nil {
+		t.Fatalf("GetScreen: %v", err)
+	}
+	if resp.ScrollbackLines != 0 || resp.ForegroundProcess != "" {
+		t.Fatalf("expected no context without include flags, got %d %q", resp.ScrollbackLines, resp.ForegroundProcess)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err = client.GetScreen(ctx, &proto.GetScreenRequest{
+			Session:                  &proto.SessionRef{Id: sessionID},
+			IncludeScrollbackLines:   true,
+			IncludeForegroundProcess: true,
+		})
+		if err != nil {
+			t.Fatalf("GetScreen: %v", err)
+		}
+		if resp.ForegroundProcess == "sleep" || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if resp.ScrollbackLines < 7 || resp.ForegroundProcess != "sleep" {
+		t.Fatalf("unexpected context: scrollback %d, process %q", resp.ScrollbackLines, resp.ForegroundProcess)
+	}
+}
+
+func TestGRPCGetScreenHistory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-screen-history",
+		Command: "seq 1 12; exec sleep 5",
+		Cols:    20,
+		Rows:    5,
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	sessionID := spawnResp.GetSession().GetId()
+
+	waitForScreenContains(t, client, sessionID, "12", 2*time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := client.GetScreen(ctx, &proto.GetScreenRequest{Session: &proto.SessionRef{Id: sessionID}})
+	if err != nil {
+		t.Fatalf("GetScreen: %v", err)
+	}
+	if len(resp.HistoryLines) != 0 {
+		t.Fatalf("expected no history without include_history, got %q", resp.HistoryLines)
+	}
+
+	resp, err = client.GetScreen(ctx, &proto.GetScreenRequest{
+		Session:        &proto.SessionRef{Id: sessionID},
+		IncludeHistory: true,
+		HistoryLimit:   3,
+	})
+	if err != nil {
+		t.Fatalf("GetScreen: %v", err)
+	}
+	firstRow, _, _ := strings.Cut(screenToString(resp), "\n")
+	top, err := strconv.Atoi(strings.TrimSpace(firstRow))
+	if err != nil {
+		t.Fatalf("unexpected first screen row: %v", err)
+	}
+	want := []string{strconv.Itoa(top - 3), strconv.Itoa(top - 2), strconv.Itoa(top - 1)}
+	if strings.Join(resp.HistoryLines, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected history %q, got %q", want, resp.HistoryLines)
+	}
+
+	_, err = client.GetScreen(ctx, &proto.GetScreenRequest{
+		Session:        &proto.SessionRef{Id: sessionID},
+		IncludeHistory: true,
+		HistoryLimit:   -1,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for negative limit, got %v", err)
+	}
+}
+
 func TestGRPCWaitFor(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("pty tests not supported on windows")
@@ -1430,6 +1979,69 @@ func TestGRPCSubscribeRawOutput(t *testing.T) {
 	}
 }
 
+func TestGRPCSubscribeInitialScreen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	spawnResp, err := client.Spawn(ctx, &proto.SpawnRequest{
+		Name:    "grpc-subscribe-initial",
+		Command: "printf '\\033[?2004h\\033[?1hready\\n'; read line; printf 'after\\n'; exec sleep 5",
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	ref := &proto.SessionRef{Id: spawnResp.GetSession().GetId()}
+
+	waitForScreenContains(t, client, ref.Id, "ready", 2*time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	stream, err := client.Subscribe(ctx, &proto.SubscribeRequest{
+		Session:              ref,
+		IncludeInitialScreen: true,
+		IncludeRawOutput:     true,
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	update := event.GetScreenUpdate()
+	if !update.GetIsKeyframe() || !strings.Contains(screenToString(update.GetScreen()), "ready") {
+		t.Fatalf("expected initial keyframe with ready, got %+v", event)
+	}
+	if screen := update.GetScreen(); !screen.GetBracketedPaste() || !screen.GetApplicationCursorKeys() {
+		t.Fatalf("expected bracketed paste and application cursor keys, got %+v", screen)
+	}
+
+	if _, err := client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: "go\n"}); err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+	var raw strings.Builder
+	for !strings.Contains(raw.String(), "after") {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if update := event.GetScreenUpdate(); update != nil {
+			t.Fatalf("unexpected screen update after the initial keyframe: %+v", update)
+		}
+		raw.Write(event.GetRawOutput())
+	}
+	if strings.Contains(raw.String(), "ready") {
+		t.Fatalf("raw output repeats the keyframe: %q", raw.String())
+	}
+}
+
 func TestGRPCSubscribeRawOverflowSignalsClient(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("pty tests not supported on windows")
@@ -1836,3 +2448,368 @@ func TestGRPCSendKeyCtrlCExits(t *testing.T) {
 
 	waitForSessionStatus(t, client, sessionID, proto.SessionStatus_SESSION_STATUS_EXITED, 2*time.Second)
 }
+
+func TestGRPCInputLock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	spawned, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "locked", Command: "cat"})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	ref := &proto.SessionRef{Label: "locked"}
+
+	resp, err := client.AcquireInputLock(ctx, &proto.AcquireInputLockRequest{
+		Session: ref,
+		Holder:  "agent one",
+		Lease:   durationpb.New(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("AcquireInputLock: %v", err)
+	}
+	if resp.GetLock().GetHolder() != "agent one" || resp.GetLock().GetExpiresAt() == nil || resp.GetLockToken() == "" {
+		t.Fatalf("unexpected lock %#v", resp)
+	}
+	token := resp.GetLockToken()
+	info, err := client.Info(ctx, &proto.InfoRequest{Session: ref})
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.GetSession().GetInputLock().GetHolder() != "agent one" {
+		t.Fatalf("expected lock on session, got %#v", info.GetSession().GetInputLock())
+	}
+
+	_, err = client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: "intruder\n", ClientId: "human"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for non-holder, got %v", err)
+	}
+	if reason := errorInfoReason(err); reason != proto.ErrorReason_ERROR_REASON_INPUT_LOCKED.String() {
+		t.Fatalf("expected input locked reason, got %q", reason)
+	}
+	_, err = client.SendKey(ctx, &proto.SendKeyRequest{Session: ref, Key: "enter"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for anonymous key, got %v", err)
+	}
+	_, err = client.Resize(ctx, &proto.ResizeRequest{Session: ref, Cols: 100, Rows: 30, ClientId: "human"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for non-holder resize, got %v", err)
+	}
+	if _, err := client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: "holder\n", LockToken: token}); err != nil {
+		t.Fatalf("SendText holder: %v", err)
+	}
+	waitForScreenContains(t, client, spawned.GetSession().GetId(), "holder", 2*time.Second)
+
+	if _, err := client.ReleaseInputLock(ctx, &proto.ReleaseInputLockRequest{Session: ref, LockToken: token}); err != nil {
+		t.Fatalf("ReleaseInputLock: %v", err)
+	}
+	if _, err := client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: "open\n", ClientId: "human"}); err != nil {
+		t.Fatalf("SendText after release: %v", err)
+	}
+}
+
+func errorInfoReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func TestGRPCListAndDisconnectClients(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "watched", Command: "sleep 5"}); err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	ref := &proto.SessionRef{Label: "watched"}
+
+	stream, err := client.Subscribe(ctx, &proto.SubscribeRequest{
+		Session:              ref,
+		IncludeScreenUpdates: true,
+		ClientId:             "alice@laptop",
+		ClientKind:           "tui",
+		ClientName:           "alice",
+		Cols:                 100,
+		Rows:                 30,
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+
+	resp, err := client.ListClients(ctx, &proto.ListClientsRequest{Session: ref})
+	if err != nil {
+		t.Fatalf("ListClients: %v", err)
+	}
+	if len(resp.Clients) != 1 {
+		t.Fatalf("expected 1 client, got %d", len(resp.Clients))
+	}
+	attached := resp.Clients[0]
+	if attached.GetKind() != "tui" || attached.GetName() != "alice" || attached.GetCols() != 100 || attached.GetPeer() == "" {
+		t.Fatalf("unexpected client %#v", attached)
+	}
+	info, err := client.Info(ctx, &proto.InfoRequest{Session: ref})
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.GetSession().GetAttachedClients() != 1 {
+		t.Fatalf("attached_clients=%d, want 1", info.GetSession().GetAttachedClients())
+	}
+
+	_, err = client.DisconnectClient(ctx, &proto.DisconnectClientRequest{Session: ref, Id: "nope"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown client, got %v", err)
+	}
+	if _, err := client.DisconnectClient(ctx, &proto.DisconnectClientRequest{Session: ref, Id: attached.GetId()}); err != nil {
+		t.Fatalf("DisconnectClient: %v", err)
+	}
+	for {
+		_, err := stream.Recv()
+		if err == nil {
+			continue
+		}
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("expected Aborted after disconnect, got %v", err)
+		}
+		break
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		resp, err := client.ListClients(ctx, &proto.ListClientsRequest{Session: ref})
+		if err != nil {
+			t.Fatalf("ListClients: %v", err)
+		}
+		if len(resp.Clients) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected client to detach, still have %d", len(resp.Clients))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestGRPCResizePolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "bad", Command: "sleep 5", ResizePolicy: "biggest"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for unknown policy, got %v", err)
+	}
+	spawned, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "shared", Command: "sleep 5", ResizePolicy: "smallest"})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if spawned.GetSession().GetResizePolicy() != "smallest" {
+		t.Fatalf("resize_policy=%q, want smallest", spawned.GetSession().GetResizePolicy())
+	}
+	ref := &proto.SessionRef{Label: "shared"}
+
+	stream, err := client.Subscribe(ctx, &proto.SubscribeRequest{
+		Session:              ref,
+		IncludeScreenUpdates: true,
+		ClientId:             "small",
+		Cols:                 90,
+		Rows:                 20,
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+
+	resp, err := client.Resize(ctx, &proto.ResizeRequest{Session: ref, Cols: 120, Rows: 40, ClientId: "other"})
+	if err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	if resp.GetCols() != 90 || resp.GetRows() != 20 {
+		t.Fatalf("Resize=%dx%d, want 90x20", resp.GetCols(), resp.GetRows())
+	}
+}
+
+func TestGRPCPaste(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "paste", Command: "cat > /dev/null"}); err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	ref := &proto.SessionRef{Label: "paste"}
+
+	stream, err := client.Paste(ctx, &proto.PasteRequest{Session: ref, Data: []byte("line one\nline two\n"), ChunkSize: 8})
+	if err != nil {
+		t.Fatalf("Paste: %v", err)
+	}
+	var events []*proto.PasteProgress
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 4 {
+		t.Fatalf("expected 3 chunks and a final event, got %d", len(events))
+	}
+	last := events[len(events)-1]
+	if !last.GetDone() || last.GetWritten() != 18 || last.GetTotal() != 18 || last.GetBracketed() {
+		t.Fatalf("unexpected final event %#v", last)
+	}
+
+	stream, err = client.Paste(ctx, &proto.PasteRequest{Session: ref, Data: make([]byte, maxPasteBytes+1)})
+	if err != nil {
+		t.Fatalf("Paste: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for oversized paste, got %v", err)
+	}
+}
+
+func TestGRPCSendMouse(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	client, cleanup := startGRPCTestServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "mouse", Command: "cat > /dev/null", Cols: 80, Rows: 24}); err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	ref := &proto.SessionRef{Label: "mouse"}
+
+	resp, err := client.SendMouse(ctx, &proto.SendMouseRequest{
+		Session: ref,
+		Button:  proto.MouseButton_MOUSE_BUTTON_LEFT,
+		Action:  proto.MouseAction_MOUSE_ACTION_CLICK,
+		X:       3,
+		Y:       2,
+	})
+	if err != nil {
+		t.Fatalf("SendMouse: %v", err)
+	}
+	if resp.GetSent() {
+		t.Fatalf("expected click to be dropped without mouse tracking")
+	}
+
+	_, err = client.SendMouse(ctx, &proto.SendMouseRequest{Session: ref, Button: proto.MouseButton_MOUSE_BUTTON_LEFT})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for missing action, got %v", err)
+	}
+	_, err = client.SendMouse(ctx, &proto.SendMouseRequest{
+		Session: ref,
+		Button:  proto.MouseButton_MOUSE_BUTTON_LEFT,
+		Action:  proto.MouseAction_MOUSE_ACTION_PRESS,
+		X:       80,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for out-of-bounds cell, got %v", err)
+	}
+}
+
+func TestGRPCMacros(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pty tests not supported on windows")
+	}
+
+	coord := core.NewCoordinator(core.CoordinatorOptions{
+		DefaultShell: "/bin/sh",
+		DefaultCols:  80,
+		DefaultRows:  24,
+		KillTimeout:  500 * time.Millisecond,
+		MacroDir:     t.TempDir(),
+	})
+	client, cleanup := startGRPCTestServerWithCoordinator(t, coord)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := client.Spawn(ctx, &proto.SpawnRequest{Name: "macro", Command: "cat > /dev/null"}); err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	ref := &proto.SessionRef{Label: "macro"}
+
+	if _, err := client.StopMacro(ctx, &proto.StopMacroRequest{Session: ref, ClientId: "agent"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition when not recording, got %v", err)
+	}
+	if _, err := client.StartMacro(ctx, &proto.StartMacroRequest{Session: ref, Name: "a/b", ClientId: "agent"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for macro name, got %v", err)
+	}
+	if _, err := client.StartMacro(ctx, &proto.StartMacroRequest{Session: ref, Name: "hello", ClientId: "agent"}); err != nil {
+		t.Fatalf("StartMacro: %v", err)
+	}
+	if _, err := client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: "hello", ClientId: "agent"}); err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+	if _, err := client.SendKey(ctx, &proto.SendKeyRequest{Session: ref, Key: "enter", ClientId: "agent"}); err != nil {
+		t.Fatalf("SendKey: %v", err)
+	}
+	if _, err := client.SendText(ctx, &proto.SendTextRequest{Session: ref, Text: "other"}); err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+	stopResp, err := client.StopMacro(ctx, &proto.StopMacroRequest{Session: ref, ClientId: "agent"})
+	if err != nil {
+		t.Fatalf("StopMacro: %v", err)
+	}
+	if macro := stopResp.GetMacro(); macro.GetName() != "hello" || macro.GetSteps() != 2 || macro.GetBytes() != 6 {
+		t.Fatalf("unexpected macro %#v", macro)
+	}
+
+	listResp, err := client.ListMacros(ctx, &proto.ListMacrosRequest{})
+	if err != nil {
+		t.Fatalf("ListMacros: %v", err)
+	}
+	if len(listResp.GetMacros()) != 1 || listResp.GetMacros()[0].GetCoordinator() == "" {
+		t.Fatalf("unexpected macros %v", listResp.GetMacros())
+	}
+
+	playResp, err := client.PlayMacro(ctx, &proto.PlayMacroRequest{Session: ref, Name: "hello", Speed: 10})
+	if err != nil {
+		t.Fatalf("PlayMacro: %v", err)
+	}
+	if playResp.GetSteps() != 2 {
+		t.Fatalf("played %d steps, want 2", playResp.GetSteps())
+	}
+
+	if _, err := client.DeleteMacro(ctx, &proto.DeleteMacroRequest{Name: "hello"}); err != nil {
+		t.Fatalf("DeleteMacro: %v", err)
+	}
+	if _, err := client.PlayMacro(ctx, &proto.PlayMacroRequest{Session: ref, Name: "hello"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for deleted macro, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/fs"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -20,13 +21,16 @@ import (
 
 	proto "github.com/advait/vtrpc/proto"
 	webassets "github.com/advait/vtrpc/web"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	statuspb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	goproto "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"nhooyr.io/websocket"
 )
 
@@ -37,6 +41,8 @@ type Options struct {
 	Version    string
 	RPCTimeout time.Duration
 	LogResize  bool
+	// Profiles lists spawn profile names offered by the new-session form.
+	Profiles []string
 }
 
 type HubTarget struct {
@@ -337,10 +343,24 @@ func handleWebsocket(d deps) http.HandlerFunc {
 
 		streamCtx, streamCancel := context.WithCancel(ctx)
 		defer streamCancel()
+		input := webInputMode{
+			ClientID: strings.TrimSpace(hello.GetClientId()),
+			ReadOnly: hello.GetReadOnly(),
+		}
+		if input.ClientID == "" {
+			// Presence and resize tracking need a stable id per connection.
+			input.ClientID = "web-" + uuid.NewString()
+		}
 		stream, err := client.Subscribe(streamCtx, &proto.SubscribeRequest{
 			Session:              sessionRef,
 			IncludeScreenUpdates: hello.GetIncludeScreenUpdates(),
 			IncludeRawOutput:     hello.GetIncludeRawOutput(),
+			ReadOnly:             input.ReadOnly,
+			ClientId:             input.ClientID,
+			ClientKind:           "web",
+			ClientName:           webLockHolder(r),
+			Cols:                 hello.GetCols(),
+			Rows:                 hello.GetRows(),
 		})
 		if err != nil {
 			_ = sendWSError(ctx, sender, err)
@@ -352,7 +372,7 @@ func handleWebsocket(d deps) http.HandlerFunc {
 			errCh <- streamToWeb(ctx, sender, stream)
 		}()
 		go func() {
-			errCh <- handleWebInput(ctx, conn, client, sessionRef, d.opts.RPCTimeout, d.opts.LogResize)
+			errCh <- handleWebInput(ctx, conn, client, sessionRef, input, d.opts.RPCTimeout, d.opts.LogResize)
 		}()
 
 		err = <-errCh
@@ -430,14 +450,23 @@ func handleWebSessionsStream(d deps) http.HandlerFunc {
 }
 
 type webSession struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Status   string `json:"status"`
-	Cols     int32  `json:"cols"`
-	Rows     int32  `json:"rows"`
-	Idle     bool   `json:"idle"`
-	ExitCode int32  `json:"exit_code,omitempty"`
-	Order    uint32 `json:"order"`
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Status   string            `json:"status"`
+	Cols     int32             `json:"cols"`
+	Rows     int32             `json:"rows"`
+	Idle     bool              `json:"idle"`
+	ExitCode int32             `json:"exit_code,omitempty"`
+	Order    uint32            `json:"order"`
+	Tags     map[string]string `json:"tags,omitempty"`
+
+	InputLock       *webInputLock `json:"input_lock,omitempty"`
+	AttachedClients int32         `json:"attached_clients"`
+}
+
+type webInputLock struct {
+	Holder    string `json:"holder"`
+	ExpiresAt string `json:"expires_at,omitempty"`
 }
 
 type webCoordinator struct {
@@ -452,12 +481,14 @@ type webSessionsResponse struct {
 }
 
 type webSessionCreateRequest struct {
-	Name        string `json:"name"`
-	Coordinator string `json:"coordinator,omitempty"`
-	Command     string `json:"command,omitempty"`
-	WorkingDir  string `json:"working_dir,omitempty"`
-	Cols        int32  `json:"cols,omitempty"`
-	Rows        int32  `json:"rows,omitempty"`
+	Name        string            `json:"name"`
+	Coordinator string            `json:"coordinator,omitempty"`
+	Command     string            `json:"command,omitempty"`
+	WorkingDir  string            `json:"working_dir,omitempty"`
+	Cols        int32             `json:"cols,omitempty"`
+	Rows        int32             `json:"rows,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Profile     string            `json:"profile,omitempty"`
 }
 
 type webSessionCreateResponse struct {
@@ -472,17 +503,26 @@ type webSessionActionRequest struct {
 	Key         string `json:"key,omitempty"`
 	Signal      string `json:"signal,omitempty"`
 	NewName     string `json:"new_name,omitempty"`
+	ClientID    string `json:"client_id,omitempty"`
+	LockToken   string `json:"lock_token,omitempty"`
+	Holder      string `json:"holder,omitempty"`
+	Lease       string `json:"lease,omitempty"`
 }
 
+// webSessionActionResponse carries the lock and its token after a lock
+// action; the browser passes lock_token on writes while it holds the lock.
 type webSessionActionResponse struct {
-	OK bool `json:"ok"`
+	OK        bool          `json:"ok"`
+	InputLock *webInputLock `json:"input_lock,omitempty"`
+	LockToken string        `json:"lock_token,omitempty"`
 }
 
 type webInfoResponse struct {
-	Version string        `json:"version"`
-	Web     webServerInfo `json:"web"`
-	Hub     webHubInfo    `json:"hub"`
-	Errors  webInfoErrors `json:"errors,omitempty"`
+	Version  string        `json:"version"`
+	Web      webServerInfo `json:"web"`
+	Hub      webHubInfo    `json:"hub"`
+	Profiles []string      `json:"profiles,omitempty"`
+	Errors   webInfoErrors `json:"errors,omitempty"`
 }
 
 type webServerInfo struct {
@@ -512,6 +552,15 @@ func handleWebSessions(d deps) http.HandlerFunc {
 	}
 }
 
+// webLockHolder names a browser lock holder by its remote host.
+func webLockHolder(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil || host == "" {
+		return "web"
+	}
+	return "web@" + host
+}
+
 func handleWebInfo(d deps) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -524,6 +573,7 @@ func handleWebInfo(d deps) http.HandlerFunc {
 				Addr: d.opts.Addr,
 				Dev:  d.opts.Dev,
 			},
+			Profiles: d.opts.Profiles,
 		}
 		hub, err := d.resolver.HubTarget()
 		if err != nil {
@@ -642,6 +692,8 @@ func handleWebSessionCreate(w http.ResponseWriter, r *http.Request, d deps) {
 		WorkingDir: strings.TrimSpace(req.WorkingDir),
 		Cols:       req.Cols,
 		Rows:       req.Rows,
+		Tags:       req.Tags,
+		Profile:    strings.TrimSpace(req.Profile),
 	})
 	cancel()
 	if err != nil {
@@ -701,6 +753,7 @@ func handleWebSessionAction(d deps) http.HandlerFunc {
 		client := proto.NewVTRClient(conn)
 		coordName := strings.TrimSpace(req.Coordinator)
 		sessionRef := &proto.SessionRef{Id: targetID, Coordinator: coordName}
+		var resp webSessionActionResponse
 		switch action {
 		case "send_key":
 			key := strings.TrimSpace(req.Key)
@@ -710,8 +763,42 @@ func handleWebSessionAction(d deps) http.HandlerFunc {
 			}
 			ctx, cancel = context.WithTimeout(r.Context(), d.opts.RPCTimeout)
 			_, err = client.SendKey(ctx, &proto.SendKeyRequest{
-				Session: sessionRef,
-				Key:     key,
+				Session:   sessionRef,
+				Key:       key,
+				ClientId:  req.ClientID,
+				LockToken: req.LockToken,
+			})
+			cancel()
+		case "lock":
+			lockReq := &proto.AcquireInputLockRequest{
+				Session:   sessionRef,
+				LockToken: req.LockToken,
+				Holder:    req.Holder,
+			}
+			if strings.TrimSpace(lockReq.Holder) == "" {
+				lockReq.Holder = webLockHolder(r)
+			}
+			if value := strings.TrimSpace(req.Lease); value != "" {
+				lease, parseErr := time.ParseDuration(value)
+				if parseErr != nil || lease < 0 {
+					http.Error(w, "invalid lease", http.StatusBadRequest)
+					return
+				}
+				lockReq.Lease = durationpb.New(lease)
+			}
+			ctx, cancel = context.WithTimeout(r.Context(), d.opts.RPCTimeout)
+			var lockResp *proto.AcquireInputLockResponse
+			lockResp, err = client.AcquireInputLock(ctx, lockReq)
+			cancel()
+			if err == nil {
+				resp.InputLock = webInputLockFromProto(lockResp.GetLock())
+				resp.LockToken = lockResp.GetLockToken()
+			}
+		case "unlock":
+			ctx, cancel = context.WithTimeout(r.Context(), d.opts.RPCTimeout)
+			_, err = client.ReleaseInputLock(ctx, &proto.ReleaseInputLockRequest{
+				Session:   sessionRef,
+				LockToken: req.LockToken,
 			})
 			cancel()
 		case "signal":
@@ -754,7 +841,8 @@ func handleWebSessionAction(d deps) http.HandlerFunc {
 			clearWebOwnedSession(targetID)
 		}
 
-		writeWebJSON(w, webSessionActionResponse{OK: true})
+		resp.OK = true
+		writeWebJSON(w, resp)
 	}
 }
 
@@ -771,7 +859,22 @@ func webSessionFromProto(session *proto.Session) webSession {
 		Idle:     session.GetIdle(),
 		ExitCode: session.GetExitCode(),
 		Order:    session.GetOrder(),
+		Tags:     session.GetTags(),
+
+		InputLock:       webInputLockFromProto(session.GetInputLock()),
+		AttachedClients: session.GetAttachedClients(),
+	}
+}
+
+func webInputLockFromProto(lock *proto.InputLock) *webInputLock {
+	if lock == nil {
+		return nil
 	}
+	out := &webInputLock{Holder: lock.GetHolder()}
+	if ts := lock.GetExpiresAt(); ts != nil {
+		out.ExpiresAt = ts.AsTime().UTC().Format(time.RFC3339)
+	}
+	return out
 }
 
 func sessionStatusLabel(session *proto.Session) string {
@@ -911,21 +1014,45 @@ func wrapResolveError(err error) error {
 	}
 }
 
-func resizeSession(ctx context.Context, client proto.VTRClient, sessionRef *proto.SessionRef, cols, rows int32, timeout time.Duration) error {
+func resizeSession(ctx context.Context, client proto.VTRClient, sessionRef *proto.SessionRef, clientID, lockToken string, cols, rows int32, timeout time.Duration) error {
 	if cols <= 0 || rows <= 0 {
 		return wsProtocolError{Code: codes.InvalidArgument, Message: "resize requires cols and rows"}
 	}
 	ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	_, err := client.Resize(ctxTimeout, &proto.ResizeRequest{
-		Session: sessionRef,
-		Cols:    cols,
-		Rows:    rows,
+		Session:   sessionRef,
+		Cols:      cols,
+		Rows:      rows,
+		ClientId:  clientID,
+		LockToken: lockToken,
 	})
 	return err
 }
 
-func handleWebInput(ctx context.Context, conn *websocket.Conn, client proto.VTRClient, sessionRef *proto.SessionRef, timeout time.Duration, logResize bool) error {
+// webInputMode describes how a WebSocket connection may write, as set by the
+// client_id and read_only fields of its SubscribeRequest.
+type webInputMode struct {
+	ClientID string
+	ReadOnly bool
+}
+
+// isInputLocked reports whether err is a write rejected by another client's
+// input lock. Such writes are dropped instead of closing the stream.
+func isInputLocked(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == proto.ErrorReason_ERROR_REASON_INPUT_LOCKED.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func handleWebInput(ctx context.Context, conn *websocket.Conn, client proto.VTRClient, sessionRef *proto.SessionRef, input webInputMode, timeout time.Duration, logResize bool) error {
 	for {
 		msgType, data, err := conn.Read(ctx)
 		if err != nil {
@@ -938,35 +1065,45 @@ func handleWebInput(ctx context.Context, conn *websocket.Conn, client proto.VTRC
 		if err != nil {
 			return err
 		}
+		if input.ReadOnly {
+			// Read-only viewers never write; drop input and resize frames.
+			continue
+		}
 		switch m := msg.(type) {
 		case *proto.SendTextRequest:
 			ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
 			_, err := client.SendText(ctxTimeout, &proto.SendTextRequest{
-				Session: sessionRef,
-				Text:    m.GetText(),
+				Session:   sessionRef,
+				Text:      m.GetText(),
+				ClientId:  input.ClientID,
+				LockToken: m.GetLockToken(),
 			})
 			cancel()
-			if err != nil {
+			if err != nil && !isInputLocked(err) {
 				return err
 			}
 		case *proto.SendKeyRequest:
 			ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
 			_, err := client.SendKey(ctxTimeout, &proto.SendKeyRequest{
-				Session: sessionRef,
-				Key:     m.GetKey(),
+				Session:   sessionRef,
+				Key:       m.GetKey(),
+				ClientId:  input.ClientID,
+				LockToken: m.GetLockToken(),
 			})
 			cancel()
-			if err != nil {
+			if err != nil && !isInputLocked(err) {
 				return err
 			}
 		case *proto.SendBytesRequest:
 			ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
 			_, err := client.SendBytes(ctxTimeout, &proto.SendBytesRequest{
-				Session: sessionRef,
-				Data:    m.GetData(),
+				Session:   sessionRef,
+				Data:      m.GetData(),
+				ClientId:  input.ClientID,
+				LockToken: m.GetLockToken(),
 			})
 			cancel()
-			if err != nil {
+			if err != nil && !isInputLocked(err) {
 				return err
 			}
 		case *proto.ResizeRequest:
@@ -978,7 +1115,7 @@ func handleWebInput(ctx context.Context, conn *websocket.Conn, client proto.VTRC
 					"rows", m.GetRows(),
 				)
 			}
-			if err := resizeSession(ctx, client, sessionRef, m.GetCols(), m.GetRows(), timeout); err != nil {
+			if err := resizeSession(ctx, client, sessionRef, input.ClientID, m.GetLockToken(), m.GetCols(), m.GetRows(), timeout); err != nil && !isInputLocked(err) {
 				return err
 			}
 		default:
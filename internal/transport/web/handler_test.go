@@ -1,6 +1,13 @@
 package webtransport
 
-import "testing"
+import (
+	"testing"
+
+	proto "github.com/advait/vtrpc/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
 
 func TestWebOwnedSessionReferenceCounting(t *testing.T) {
 	const sessionID = "owned-session-refcount"
@@ -34,3 +41,19 @@ func TestAcquireWebOwnedSessionRequiresOwnership(t *testing.T) {
 		t.Fatalf("expected acquire to fail for unowned session")
 	}
 }
+
+func TestIsInputLockedChecksReason(t *testing.T) {
+	locked, err := status.New(codes.FailedPrecondition, "session is busy").WithDetails(&errdetails.ErrorInfo{
+		Reason: proto.ErrorReason_ERROR_REASON_INPUT_LOCKED.String(),
+		Domain: "vtrpc",
+	})
+	if err != nil {
+		t.Fatalf("WithDetails: %v", err)
+	}
+	if !isInputLocked(locked.Err()) {
+		t.Fatalf("expected ErrorInfo reason to mark input as locked")
+	}
+	if isInputLocked(status.Error(codes.FailedPrecondition, "input is locked by agent")) {
+		t.Fatalf("expected message text alone not to mark input as locked")
+	}
+}
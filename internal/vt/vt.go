@@ -22,6 +22,54 @@ type Snapshot = ghostty.Snapshot
 // Cell mirrors the snapshot cell data.
 type Cell = ghostty.Cell
 
+// Attrs is the cell attribute bitmask.
+type Attrs = ghostty.Attrs
+
+// Wide is the cell width category.
+type Wide = ghostty.Wide
+
+const (
+	AttrBold          = ghostty.AttrBold
+	AttrItalic        = ghostty.AttrItalic
+	AttrUnderline     = ghostty.AttrUnderline
+	AttrFaint         = ghostty.AttrFaint
+	AttrBlink         = ghostty.AttrBlink
+	AttrInverse       = ghostty.AttrInverse
+	AttrInvisible     = ghostty.AttrInvisible
+	AttrStrikethrough = ghostty.AttrStrikethrough
+	AttrOverline      = ghostty.AttrOverline
+)
+
+const (
+	WideNarrow     = ghostty.WideNarrow
+	WideWide       = ghostty.WideWide
+	WideSpacerTail = ghostty.WideSpacerTail
+	WideSpacerHead = ghostty.WideSpacerHead
+)
+
+// Modes reports terminal modes that affect input encoding.
+type Modes = ghostty.Modes
+
+// MouseEvents is the mouse tracking mode.
+type MouseEvents = ghostty.MouseEvents
+
+// MouseFormat is the mouse report encoding.
+type MouseFormat = ghostty.MouseFormat
+
+const (
+	MouseEventsNone   = ghostty.MouseEventsNone
+	MouseEventsX10    = ghostty.MouseEventsX10
+	MouseEventsNormal = ghostty.MouseEventsNormal
+	MouseEventsButton = ghostty.MouseEventsButton
+	MouseEventsAny    = ghostty.MouseEventsAny
+
+	MouseFormatX10       = ghostty.MouseFormatX10
+	MouseFormatUTF8      = ghostty.MouseFormatUTF8
+	MouseFormatSGR       = ghostty.MouseFormatSGR
+	MouseFormatURXVT     = ghostty.MouseFormatURXVT
+	MouseFormatSGRPixels = ghostty.MouseFormatSGRPixels
+)
+
 // VT wraps the Ghostty terminal with a mutex for safe concurrent access.
 type VT struct {
 	mu   sync.Mutex
@@ -97,6 +145,48 @@ func (v *VT) Snapshot() (*Snapshot, error) {
 	return v.term.Snapshot()
 }
 
+// ScrollbackRows returns the number of scrollback rows above the viewport.
+func (v *VT) ScrollbackRows() (int, error) {
+	if v == nil {
+		return 0, errVTClosed
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.term == nil {
+		return 0, errVTClosed
+	}
+	return v.term.ScrollbackRows()
+}
+
+// ScreenSnapshot returns styled screen rows [start, end), counted from the
+// oldest scrollback row like a slice: negative values count from the last
+// row and end 0 means through the last row.
+func (v *VT) ScreenSnapshot(start, end int) (*Snapshot, error) {
+	if v == nil {
+		return nil, errVTClosed
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.term == nil {
+		return nil, errVTClosed
+	}
+	total, err := v.term.ScreenRows()
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 {
+		start += total
+	}
+	if end < 0 {
+		end += total
+	} else if end == 0 {
+		end = total
+	}
+	start = min(max(start, 0), total)
+	end = min(max(end, start), total)
+	return v.term.ScreenSnapshot(start, end)
+}
+
 // Dump returns a text dump for the specified scope.
 func (v *VT) Dump(scope DumpScope, unwrap bool) (string, error) {
 	if v == nil {
@@ -109,3 +199,16 @@ func (v *VT) Dump(scope DumpScope, unwrap bool) (string, error) {
 	}
 	return v.term.Dump(scope, unwrap)
 }
+
+// Modes returns the input-related terminal modes.
+func (v *VT) Modes() (Modes, error) {
+	if v == nil {
+		return Modes{}, errVTClosed
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.term == nil {
+		return Modes{}, errVTClosed
+	}
+	return v.term.Modes()
+}
@@ -25,6 +25,11 @@ var (
 	ErrSessionNotRunning = corepkg.ErrSessionNotRunning
 	ErrInvalidName       = corepkg.ErrInvalidName
 	ErrInvalidSize       = corepkg.ErrInvalidSize
+	ErrInvalidTags       = corepkg.ErrInvalidTags
+	ErrProfileNotFound   = corepkg.ErrProfileNotFound
+
+	ErrInvalidIsolation     = corepkg.ErrInvalidIsolation
+	ErrIsolationUnavailable = corepkg.ErrIsolationUnavailable
 )
 
 type CoordinatorOptions = corepkg.CoordinatorOptions
@@ -33,6 +38,11 @@ type SessionInfo = corepkg.SessionInfo
 type Coordinator = corepkg.Coordinator
 type Session = corepkg.Session
 type GrepMatch = corepkg.GrepMatch
+type Selector = corepkg.Selector
+type SpawnProfile = corepkg.SpawnProfile
+type CloneOptions = corepkg.CloneOptions
+type RestartPolicy = corepkg.RestartPolicy
+type ResizePolicy = corepkg.ResizePolicy
 
 type DumpScope = vtpkg.DumpScope
 
@@ -49,10 +59,43 @@ type GRPCServer = transportgrpc.GRPCServer
 type SpokeRecord = transportgrpc.SpokeRecord
 type SpokeRegistry = transportgrpc.SpokeRegistry
 
+// WithForwardedPeer records the client behind a tunnelled request.
+func WithForwardedPeer(ctx context.Context, addr, identity string) context.Context {
+	return transportgrpc.WithForwardedPeer(ctx, addr, identity)
+}
+
+// CallerPeer returns the address and verified identity of the client behind
+// ctx.
+func CallerPeer(ctx context.Context) (string, string) {
+	return transportgrpc.CallerPeer(ctx)
+}
+
 func NewCoordinator(opts CoordinatorOptions) *Coordinator {
 	return corepkg.NewCoordinator(opts)
 }
 
+func ParseSelector(value string) (Selector, error) {
+	return corepkg.ParseSelector(value)
+}
+
+func ParseRestartPolicy(value string) (RestartPolicy, error) {
+	return corepkg.ParseRestartPolicy(value)
+}
+
+func ParseResizePolicy(value string) (ResizePolicy, error) {
+	return corepkg.ParseResizePolicy(value)
+}
+
+const GhosttyTerm = corepkg.GhosttyTerm
+
+func InstallTerminfo(dir string) error {
+	return corepkg.InstallTerminfo(dir)
+}
+
+func SystemTerminfo() bool {
+	return corepkg.SystemTerminfo()
+}
+
 func NewGRPCServer(coord *Coordinator) *GRPCServer {
 	return transportgrpc.NewGRPCServer(coord)
 }
@@ -15,7 +15,15 @@ import { TerminalView } from "./components/TerminalView";
 import { Badge } from "./components/ui/Badge";
 import { Button } from "./components/ui/Button";
 import { ScrollArea } from "./components/ui/ScrollArea";
-import { createSession, fetchWebInfo, sendSessionAction, type WebInfoResponse } from "./lib/api";
+import {
+  createSession,
+  fetchSessionPresence,
+  fetchWebInfo,
+  ownsInputLock,
+  sendSessionAction,
+  type SessionPresence,
+  type WebInfoResponse,
+} from "./lib/api";
 import { loadPreferences, type TerminalRenderer, updatePreferences } from "./lib/preferences";
 import type { SubscribeEvent } from "./lib/proto";
 import {
@@ -176,6 +184,9 @@ function findSession(coordinators: CoordinatorInfo[], ref: SessionRef): Selected
   };
 }
 
+const inputLockLease = "2m";
+const inputLockRenewMs = 60_000;
+
 export default function App() {
   const [coordinators, setCoordinators] = useState<CoordinatorInfo[]>([]);
   const [activeSession, setActiveSession] = useState<SessionRef | null>(null);
@@ -202,7 +213,10 @@ export default function App() {
     () => initialPreferences.showClosedSessions,
   );
   const [autoResize, setAutoResize] = useState(() => initialPreferences.autoResize ?? false);
+  const [readOnly, setReadOnly] = useState(() => initialPreferences.readOnly ?? false);
+  const [presence, setPresence] = useState<Record<string, SessionPresence>>({});
   const [createBusy, setCreateBusy] = useState(false);
+  const [createProfile, setCreateProfile] = useState("");
   const [contextMenu, setContextMenu] = useState<{
     x: number;
     y: number;
@@ -306,6 +320,7 @@ export default function App() {
   const { state, setEventHandler, sendText, sendKey, sendTextTo, sendKeyTo, resize, close, restart } =
     useVtrStream(activeSession, {
       includeRawOutput: false,
+      readOnly,
     });
 
   useEffect(() => {
@@ -462,6 +477,43 @@ export default function App() {
     applySessions(streamCoordinators);
   }, [applySessions, streamCoordinators]);
 
+  useEffect(() => {
+    // Lock and attach changes publish a new sessions snapshot; the details
+    // are read from the JSON session list.
+    let cancelled = false;
+    fetchSessionPresence()
+      .then((next) => {
+        if (!cancelled) {
+          setPresence(next);
+        }
+      })
+      .catch(() => {});
+    return () => {
+      cancelled = true;
+    };
+  }, [streamCoordinators]);
+
+  const activePresence = activeSession ? presence[activeSession.id] : undefined;
+  const activeLock = activePresence?.inputLock;
+  const otherViewers = Math.max(0, (activePresence?.attachedClients ?? 0) - 1);
+  const activeLockOwned = activeSession ? ownsInputLock(activeSession.id, activeLock) : false;
+
+  useEffect(() => {
+    if (!activeSession || !activeLockOwned) {
+      return;
+    }
+    // Renew well before the lease runs out while this tab holds the lock.
+    const timer = window.setInterval(() => {
+      sendSessionAction({
+        id: activeSession.id,
+        coordinator: activeSession.coordinator,
+        action: "lock",
+        lease: inputLockLease,
+      }).catch(() => {});
+    }, inputLockRenewMs);
+    return () => window.clearInterval(timer);
+  }, [activeSession, activeLockOwned]);
+
   useEffect(() => {
     if (!selectedSession) {
       return;
@@ -802,7 +854,11 @@ export default function App() {
       }
       setCreateBusy(true);
       try {
-        const result = await createSession({ name, coordinator });
+        const result = await createSession({
+          name,
+          coordinator,
+          profile: createProfile || undefined,
+        });
         const ref = sessionRefFromSession(result.coordinator, result.session);
         setSelectedSession({
           ref,
@@ -819,7 +875,7 @@ export default function App() {
         setCreateBusy(false);
       }
     },
-    [activeSession, coordinatorOptions, coordinators, createBusy],
+    [activeSession, coordinatorOptions, coordinators, createBusy, createProfile],
   );
   const runSessionAction = useCallback(async (payload: Parameters<typeof sendSessionAction>[0]) => {
     try {
@@ -955,6 +1011,7 @@ export default function App() {
   const sessionsStreamStatus = statusLabels[sessionsState.status] || statusLabels.idle;
   const webAddress = typeof window === "undefined" ? "" : window.location.host;
   const hubName = webInfo?.hub?.name?.trim() || "";
+  const profileOptions = webInfo?.profiles ?? [];
   const hubPath = webInfo?.hub?.path?.trim() || "";
   const hubError = webInfo?.errors?.hub || webInfoError;
 
@@ -1044,6 +1101,29 @@ export default function App() {
                           Use ?renderer=canvas for a URL override.
                         </span>
                       </div>
+                      {profileOptions.length > 0 && (
+                        <div className="flex flex-col gap-2">
+                          <span className="text-xs font-semibold uppercase tracking-wide text-tn-muted">
+                            New sessions
+                          </span>
+                          <select
+                            className="h-9 w-full rounded-md border border-tn-border bg-tn-panel-2 px-3 text-sm text-tn-text focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-tn-accent"
+                            value={createProfile}
+                            onChange={(event) => setCreateProfile(event.target.value)}
+                            aria-label="Select spawn profile"
+                          >
+                            <option value="">No profile</option>
+                            {profileOptions.map((profile) => (
+                              <option key={profile} value={profile}>
+                                {profile}
+                              </option>
+                            ))}
+                          </select>
+                          <span className="text-[11px] text-tn-text-dim">
+                            Profiles come from [profiles.&lt;name&gt;] in the coordinator config.
+                          </span>
+                        </div>
+                      )}
                       <div className="flex flex-col gap-2">
                         <span className="text-xs font-semibold uppercase tracking-wide text-tn-muted">
                           Resizing
@@ -1065,6 +1145,27 @@ export default function App() {
                           When off, the session keeps its current size.
                         </span>
                       </div>
+                      <div className="flex flex-col gap-2">
+                        <span className="text-xs font-semibold uppercase tracking-wide text-tn-muted">
+                          Input
+                        </span>
+                        <label className="flex items-center justify-between gap-3 text-sm text-tn-text">
+                          <span>Read-only viewer</span>
+                          <input
+                            type="checkbox"
+                            className="h-4 w-4 accent-tn-accent"
+                            checked={readOnly}
+                            onChange={(event) => {
+                              const next = event.target.checked;
+                              setReadOnly(next);
+                              updatePreferences({ readOnly: next });
+                            }}
+                          />
+                        </label>
+                        <span className="text-[11px] text-tn-text-dim">
+                          Watch sessions without sending keystrokes.
+                        </span>
+                      </div>
                       <div className="flex flex-col gap-2">
                         <span className="text-xs font-semibold uppercase tracking-wide text-tn-muted">
                           Sessions
@@ -1148,6 +1249,32 @@ export default function App() {
                   onCreate={handleCreateSession}
                   isFocused={terminalFocused}
                 />
+                {(activeLock || otherViewers > 0) && (
+                  <div className="flex items-center justify-between gap-3 border-x border-tn-border bg-tn-panel px-3 py-1 text-xs text-tn-text-dim">
+                    <span>
+                      {activeLock &&
+                        (activeLockOwned ? "You hold the input lock" : `Input locked by ${activeLock.holder}`)}
+                      {activeLock && otherViewers > 0 && " · "}
+                      {otherViewers > 0 &&
+                        `${otherViewers} other client${otherViewers === 1 ? "" : "s"} attached`}
+                    </span>
+                    {activeLockOwned && activeSession && (
+                      <button
+                        type="button"
+                        className="text-tn-text transition-colors hover:text-tn-accent"
+                        onClick={() =>
+                          runSessionAction({
+                            id: activeSession.id,
+                            coordinator: activeSession.coordinator,
+                            action: "unlock",
+                          })
+                        }
+                      >
+                        Release
+                      </button>
+                    )}
+                  </div>
+                )}
                 <div className="flex-1 min-h-[360px] md:min-h-[420px]">
                   <TerminalView
                     screen={screen}
@@ -1439,6 +1566,34 @@ export default function App() {
                   Rename session
                 </button>
               )}
+              <button
+                type="button"
+                className={`px-3 py-2 text-left transition-colors ${
+                  contextRunning && !readOnly
+                    ? "text-tn-text hover:bg-tn-panel-2"
+                    : "cursor-not-allowed text-tn-muted"
+                }`}
+                onClick={() => {
+                  if (!contextRunning || readOnly) {
+                    return;
+                  }
+                  const held = ownsInputLock(
+                    contextMenu.sessionRef.id,
+                    presence[contextMenu.sessionRef.id]?.inputLock,
+                  );
+                  runSessionAction({
+                    id: contextMenu.sessionRef.id,
+                    coordinator: contextMenu.sessionRef.coordinator,
+                    action: held ? "unlock" : "lock",
+                    lease: held ? undefined : inputLockLease,
+                  });
+                }}
+                disabled={!contextRunning || readOnly}
+              >
+                {ownsInputLock(contextMenu.sessionRef.id, presence[contextMenu.sessionRef.id]?.inputLock)
+                  ? "Release input lock"
+                  : "Take input lock"}
+              </button>
               <div className="my-1 h-px bg-tn-border" />
               <button
                 type="button"
@@ -11,6 +11,12 @@ export namespace vtr {
         SESSION_STATUS_EXITED = 3
     }
 
+    /** ErrorReason enum. */
+    enum ErrorReason {
+        ERROR_REASON_UNSPECIFIED = 0,
+        ERROR_REASON_INPUT_LOCKED = 1
+    }
+
     /** Properties of a Session. */
     interface ISession {
 
@@ -43,6 +49,24 @@ export namespace vtr {
 
         /** Session id */
         id?: (string|null);
+
+        /** Session tags */
+        tags?: ({ [k: string]: string }|null);
+
+        /** Session shell_integration */
+        shell_integration?: (boolean|null);
+
+        /** Session input_lock */
+        input_lock?: (vtr.IInputLock|null);
+
+        /** Session attached_clients */
+        attached_clients?: (number|null);
+
+        /** Session resize_policy */
+        resize_policy?: (string|null);
+
+        /** Session bracketed_paste */
+        bracketed_paste?: (boolean|null);
     }
 
     /** Represents a Session. */
@@ -84,6 +108,24 @@ export namespace vtr {
         /** Session id. */
         public id: string;
 
+        /** Session tags. */
+        public tags: { [k: string]: string };
+
+        /** Session shell_integration. */
+        public shell_integration: boolean;
+
+        /** Session input_lock. */
+        public input_lock?: (vtr.IInputLock|null);
+
+        /** Session attached_clients. */
+        public attached_clients: number;
+
+        /** Session resize_policy. */
+        public resize_policy: string;
+
+        /** Session bracketed_paste. */
+        public bracketed_paste: boolean;
+
         /**
          * Creates a new Session instance using the specified properties.
          * @param [properties] Properties to set
@@ -162,6 +204,109 @@ export namespace vtr {
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
+    /** Properties of an InputLock. */
+    interface IInputLock {
+
+        /** InputLock holder */
+        holder?: (string|null);
+
+        /** InputLock expires_at */
+        expires_at?: (google.protobuf.ITimestamp|null);
+    }
+
+    /** Represents an InputLock. */
+    class InputLock implements IInputLock {
+
+        /**
+         * Constructs a new InputLock.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IInputLock);
+
+        /** InputLock holder. */
+        public holder: string;
+
+        /** InputLock expires_at. */
+        public expires_at?: (google.protobuf.ITimestamp|null);
+
+        /**
+         * Creates a new InputLock instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns InputLock instance
+         */
+        public static create(properties?: vtr.IInputLock): vtr.InputLock;
+
+        /**
+         * Encodes the specified InputLock message. Does not implicitly {@link vtr.InputLock.verify|verify} messages.
+         * @param message InputLock message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IInputLock, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified InputLock message, length delimited. Does not implicitly {@link vtr.InputLock.verify|verify} messages.
+         * @param message InputLock message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IInputLock, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes an InputLock message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns InputLock
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.InputLock;
+
+        /**
+         * Decodes an InputLock message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns InputLock
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.InputLock;
+
+        /**
+         * Verifies an InputLock message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates an InputLock message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns InputLock
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.InputLock;
+
+        /**
+         * Creates a plain object from an InputLock message. Also converts values to other types if specified.
+         * @param message InputLock
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.InputLock, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this InputLock to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for InputLock
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
     /** Properties of a SessionRef. */
     interface ISessionRef {
 
@@ -170,6 +315,9 @@ export namespace vtr {
 
         /** SessionRef coordinator */
         coordinator?: (string|null);
+
+        /** SessionRef label */
+        label?: (string|null);
     }
 
     /** Represents a SessionRef. */
@@ -187,6 +335,9 @@ export namespace vtr {
         /** SessionRef coordinator. */
         public coordinator: string;
 
+        /** SessionRef label. */
+        public label: string;
+
         /**
          * Creates a new SessionRef instance using the specified properties.
          * @param [properties] Properties to set
@@ -285,6 +436,30 @@ export namespace vtr {
 
         /** SpawnRequest rows */
         rows?: (number|null);
+
+        /** SpawnRequest tags */
+        tags?: ({ [k: string]: string }|null);
+
+        /** SpawnRequest profile */
+        profile?: (string|null);
+
+        /** SpawnRequest isolation */
+        isolation?: (vtr.ISpawnIsolation|null);
+
+        /** SpawnRequest term */
+        term?: (string|null);
+
+        /** SpawnRequest locale */
+        locale?: (string|null);
+
+        /** SpawnRequest shell_integration */
+        shell_integration?: (boolean|null);
+
+        /** SpawnRequest resize_policy */
+        resize_policy?: (string|null);
+
+        /** SpawnRequest client_id */
+        client_id?: (string|null);
     }
 
     /** Represents a SpawnRequest. */
@@ -314,6 +489,30 @@ export namespace vtr {
         /** SpawnRequest rows. */
         public rows: number;
 
+        /** SpawnRequest tags. */
+        public tags: { [k: string]: string };
+
+        /** SpawnRequest profile. */
+        public profile: string;
+
+        /** SpawnRequest isolation. */
+        public isolation?: (vtr.ISpawnIsolation|null);
+
+        /** SpawnRequest term. */
+        public term: string;
+
+        /** SpawnRequest locale. */
+        public locale: string;
+
+        /** SpawnRequest shell_integration. */
+        public shell_integration?: (boolean|null);
+
+        /** SpawnRequest resize_policy. */
+        public resize_policy: string;
+
+        /** SpawnRequest client_id. */
+        public client_id: string;
+
         /**
          * Creates a new SpawnRequest instance using the specified properties.
          * @param [properties] Properties to set
@@ -392,6 +591,266 @@ export namespace vtr {
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
+    /** Properties of a SpawnIsolation. */
+    interface ISpawnIsolation {
+
+        /** SpawnIsolation cpu_max */
+        cpu_max?: (number|null);
+
+        /** SpawnIsolation memory_max_bytes */
+        memory_max_bytes?: (number|Long|null);
+
+        /** SpawnIsolation pids_max */
+        pids_max?: (number|Long|null);
+
+        /** SpawnIsolation user_ns */
+        user_ns?: (boolean|null);
+
+        /** SpawnIsolation mount_ns */
+        mount_ns?: (boolean|null);
+
+        /** SpawnIsolation pid_ns */
+        pid_ns?: (boolean|null);
+
+        /** SpawnIsolation net_ns */
+        net_ns?: (boolean|null);
+
+        /** SpawnIsolation rlimits */
+        rlimits?: (vtr.IRlimit[]|null);
+
+        /** SpawnIsolation uid */
+        uid?: (number|null);
+
+        /** SpawnIsolation gid */
+        gid?: (number|null);
+    }
+
+    /** Represents a SpawnIsolation. */
+    class SpawnIsolation implements ISpawnIsolation {
+
+        /**
+         * Constructs a new SpawnIsolation.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISpawnIsolation);
+
+        /** SpawnIsolation cpu_max. */
+        public cpu_max: number;
+
+        /** SpawnIsolation memory_max_bytes. */
+        public memory_max_bytes: (number|Long);
+
+        /** SpawnIsolation pids_max. */
+        public pids_max: (number|Long);
+
+        /** SpawnIsolation user_ns. */
+        public user_ns: boolean;
+
+        /** SpawnIsolation mount_ns. */
+        public mount_ns: boolean;
+
+        /** SpawnIsolation pid_ns. */
+        public pid_ns: boolean;
+
+        /** SpawnIsolation net_ns. */
+        public net_ns: boolean;
+
+        /** SpawnIsolation rlimits. */
+        public rlimits: vtr.IRlimit[];
+
+        /** SpawnIsolation uid. */
+        public uid?: (number|null);
+
+        /** SpawnIsolation gid. */
+        public gid?: (number|null);
+
+        /**
+         * Creates a new SpawnIsolation instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SpawnIsolation instance
+         */
+        public static create(properties?: vtr.ISpawnIsolation): vtr.SpawnIsolation;
+
+        /**
+         * Encodes the specified SpawnIsolation message. Does not implicitly {@link vtr.SpawnIsolation.verify|verify} messages.
+         * @param message SpawnIsolation message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISpawnIsolation, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SpawnIsolation message, length delimited. Does not implicitly {@link vtr.SpawnIsolation.verify|verify} messages.
+         * @param message SpawnIsolation message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISpawnIsolation, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SpawnIsolation message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SpawnIsolation
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SpawnIsolation;
+
+        /**
+         * Decodes a SpawnIsolation message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SpawnIsolation
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SpawnIsolation;
+
+        /**
+         * Verifies a SpawnIsolation message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SpawnIsolation message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SpawnIsolation
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SpawnIsolation;
+
+        /**
+         * Creates a plain object from a SpawnIsolation message. Also converts values to other types if specified.
+         * @param message SpawnIsolation
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SpawnIsolation, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SpawnIsolation to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SpawnIsolation
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a Rlimit. */
+    interface IRlimit {
+
+        /** Rlimit resource */
+        resource?: (string|null);
+
+        /** Rlimit soft */
+        soft?: (number|Long|null);
+
+        /** Rlimit hard */
+        hard?: (number|Long|null);
+    }
+
+    /** Represents a Rlimit. */
+    class Rlimit implements IRlimit {
+
+        /**
+         * Constructs a new Rlimit.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IRlimit);
+
+        /** Rlimit resource. */
+        public resource: string;
+
+        /** Rlimit soft. */
+        public soft: (number|Long);
+
+        /** Rlimit hard. */
+        public hard: (number|Long);
+
+        /**
+         * Creates a new Rlimit instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns Rlimit instance
+         */
+        public static create(properties?: vtr.IRlimit): vtr.Rlimit;
+
+        /**
+         * Encodes the specified Rlimit message. Does not implicitly {@link vtr.Rlimit.verify|verify} messages.
+         * @param message Rlimit message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IRlimit, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified Rlimit message, length delimited. Does not implicitly {@link vtr.Rlimit.verify|verify} messages.
+         * @param message Rlimit message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IRlimit, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a Rlimit message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns Rlimit
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.Rlimit;
+
+        /**
+         * Decodes a Rlimit message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns Rlimit
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.Rlimit;
+
+        /**
+         * Verifies a Rlimit message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a Rlimit message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns Rlimit
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.Rlimit;
+
+        /**
+         * Creates a plain object from a Rlimit message. Also converts values to other types if specified.
+         * @param message Rlimit
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.Rlimit, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this Rlimit to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for Rlimit
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
     /** Properties of a SpawnResponse. */
     interface ISpawnResponse {
 
@@ -491,6 +950,9 @@ export namespace vtr {
 
     /** Properties of a ListRequest. */
     interface IListRequest {
+
+        /** ListRequest selector */
+        selector?: (string|null);
     }
 
     /** Represents a ListRequest. */
@@ -502,6 +964,9 @@ export namespace vtr {
          */
         constructor(properties?: vtr.IListRequest);
 
+        /** ListRequest selector. */
+        public selector: string;
+
         /**
          * Creates a new ListRequest instance using the specified properties.
          * @param [properties] Properties to set
@@ -682,6 +1147,9 @@ export namespace vtr {
 
         /** SubscribeSessionsRequest exclude_exited */
         exclude_exited?: (boolean|null);
+
+        /** SubscribeSessionsRequest selector */
+        selector?: (string|null);
     }
 
     /** Represents a SubscribeSessionsRequest. */
@@ -696,6 +1164,9 @@ export namespace vtr {
         /** SubscribeSessionsRequest exclude_exited. */
         public exclude_exited: boolean;
 
+        /** SubscribeSessionsRequest selector. */
+        public selector: string;
+
         /**
          * Creates a new SubscribeSessionsRequest instance using the specified properties.
          * @param [properties] Properties to set
@@ -1154,6 +1625,12 @@ export namespace vtr {
 
         /** TunnelRequest baggage */
         baggage?: (string|null);
+
+        /** TunnelRequest peer */
+        peer?: (string|null);
+
+        /** TunnelRequest identity */
+        identity?: (string|null);
     }
 
     /** Represents a TunnelRequest. */
@@ -1183,6 +1660,12 @@ export namespace vtr {
         /** TunnelRequest baggage. */
         public baggage: string;
 
+        /** TunnelRequest peer. */
+        public peer: string;
+
+        /** TunnelRequest identity. */
+        public identity: string;
+
         /**
          * Creates a new TunnelRequest instance using the specified properties.
          * @param [properties] Properties to set
@@ -1566,6 +2049,9 @@ export namespace vtr {
 
         /** TunnelError message */
         message?: (string|null);
+
+        /** TunnelError details */
+        details?: (google.protobuf.IAny[]|null);
     }
 
     /** Represents a TunnelError. */
@@ -1583,6 +2069,9 @@ export namespace vtr {
         /** TunnelError message. */
         public message: string;
 
+        /** TunnelError details. */
+        public details: google.protobuf.IAny[];
+
         /**
          * Creates a new TunnelError instance using the specified properties.
          * @param [properties] Properties to set
@@ -2928,1557 +3417,5707 @@ export namespace vtr {
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a GetScreenRequest. */
-    interface IGetScreenRequest {
+    /** Properties of an UpdateSessionRequest. */
+    interface IUpdateSessionRequest {
 
-        /** GetScreenRequest session */
+        /** UpdateSessionRequest session */
         session?: (vtr.ISessionRef|null);
+
+        /** UpdateSessionRequest set_tags */
+        set_tags?: ({ [k: string]: string }|null);
+
+        /** UpdateSessionRequest remove_tags */
+        remove_tags?: (string[]|null);
     }
 
-    /** Represents a GetScreenRequest. */
-    class GetScreenRequest implements IGetScreenRequest {
+    /** Represents an UpdateSessionRequest. */
+    class UpdateSessionRequest implements IUpdateSessionRequest {
 
         /**
-         * Constructs a new GetScreenRequest.
+         * Constructs a new UpdateSessionRequest.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IGetScreenRequest);
+        constructor(properties?: vtr.IUpdateSessionRequest);
 
-        /** GetScreenRequest session. */
+        /** UpdateSessionRequest session. */
         public session?: (vtr.ISessionRef|null);
 
+        /** UpdateSessionRequest set_tags. */
+        public set_tags: { [k: string]: string };
+
+        /** UpdateSessionRequest remove_tags. */
+        public remove_tags: string[];
+
         /**
-         * Creates a new GetScreenRequest instance using the specified properties.
+         * Creates a new UpdateSessionRequest instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns GetScreenRequest instance
+         * @returns UpdateSessionRequest instance
          */
-        public static create(properties?: vtr.IGetScreenRequest): vtr.GetScreenRequest;
+        public static create(properties?: vtr.IUpdateSessionRequest): vtr.UpdateSessionRequest;
 
         /**
-         * Encodes the specified GetScreenRequest message. Does not implicitly {@link vtr.GetScreenRequest.verify|verify} messages.
-         * @param message GetScreenRequest message or plain object to encode
+         * Encodes the specified UpdateSessionRequest message. Does not implicitly {@link vtr.UpdateSessionRequest.verify|verify} messages.
+         * @param message UpdateSessionRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IGetScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IUpdateSessionRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified GetScreenRequest message, length delimited. Does not implicitly {@link vtr.GetScreenRequest.verify|verify} messages.
-         * @param message GetScreenRequest message or plain object to encode
+         * Encodes the specified UpdateSessionRequest message, length delimited. Does not implicitly {@link vtr.UpdateSessionRequest.verify|verify} messages.
+         * @param message UpdateSessionRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IGetScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IUpdateSessionRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a GetScreenRequest message from the specified reader or buffer.
+         * Decodes an UpdateSessionRequest message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns GetScreenRequest
+         * @returns UpdateSessionRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GetScreenRequest;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.UpdateSessionRequest;
 
         /**
-         * Decodes a GetScreenRequest message from the specified reader or buffer, length delimited.
+         * Decodes an UpdateSessionRequest message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns GetScreenRequest
+         * @returns UpdateSessionRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GetScreenRequest;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.UpdateSessionRequest;
 
         /**
-         * Verifies a GetScreenRequest message.
+         * Verifies an UpdateSessionRequest message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a GetScreenRequest message from a plain object. Also converts values to their respective internal types.
+         * Creates an UpdateSessionRequest message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns GetScreenRequest
+         * @returns UpdateSessionRequest
          */
-        public static fromObject(object: { [k: string]: any }): vtr.GetScreenRequest;
+        public static fromObject(object: { [k: string]: any }): vtr.UpdateSessionRequest;
 
         /**
-         * Creates a plain object from a GetScreenRequest message. Also converts values to other types if specified.
-         * @param message GetScreenRequest
+         * Creates a plain object from an UpdateSessionRequest message. Also converts values to other types if specified.
+         * @param message UpdateSessionRequest
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.GetScreenRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.UpdateSessionRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this GetScreenRequest to JSON.
+         * Converts this UpdateSessionRequest to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for GetScreenRequest
+         * Gets the default type url for UpdateSessionRequest
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a ScreenCell. */
-    interface IScreenCell {
+    /** Properties of an UpdateSessionResponse. */
+    interface IUpdateSessionResponse {
 
-        /** ScreenCell char */
-        char?: (string|null);
+        /** UpdateSessionResponse session */
+        session?: (vtr.ISession|null);
+    }
 
-        /** ScreenCell fg_color */
-        fg_color?: (number|null);
+    /** Represents an UpdateSessionResponse. */
+    class UpdateSessionResponse implements IUpdateSessionResponse {
 
-        /** ScreenCell bg_color */
-        bg_color?: (number|null);
+        /**
+         * Constructs a new UpdateSessionResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IUpdateSessionResponse);
 
-        /** ScreenCell attributes */
-        attributes?: (number|null);
+        /** UpdateSessionResponse session. */
+        public session?: (vtr.ISession|null);
+
+        /**
+         * Creates a new UpdateSessionResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns UpdateSessionResponse instance
+         */
+        public static create(properties?: vtr.IUpdateSessionResponse): vtr.UpdateSessionResponse;
+
+        /**
+         * Encodes the specified UpdateSessionResponse message. Does not implicitly {@link vtr.UpdateSessionResponse.verify|verify} messages.
+         * @param message UpdateSessionResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IUpdateSessionResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified UpdateSessionResponse message, length delimited. Does not implicitly {@link vtr.UpdateSessionResponse.verify|verify} messages.
+         * @param message UpdateSessionResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IUpdateSessionResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes an UpdateSessionResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns UpdateSessionResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.UpdateSessionResponse;
+
+        /**
+         * Decodes an UpdateSessionResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns UpdateSessionResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.UpdateSessionResponse;
+
+        /**
+         * Verifies an UpdateSessionResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates an UpdateSessionResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns UpdateSessionResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.UpdateSessionResponse;
+
+        /**
+         * Creates a plain object from an UpdateSessionResponse message. Also converts values to other types if specified.
+         * @param message UpdateSessionResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.UpdateSessionResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this UpdateSessionResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for UpdateSessionResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Represents a ScreenCell. */
-    class ScreenCell implements IScreenCell {
+    /** Properties of a CloneRequest. */
+    interface ICloneRequest {
+
+        /** CloneRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** CloneRequest name */
+        name?: (string|null);
+
+        /** CloneRequest copy_size */
+        copy_size?: (boolean|null);
+
+        /** CloneRequest copy_tags */
+        copy_tags?: (boolean|null);
+    }
+
+    /** Represents a CloneRequest. */
+    class CloneRequest implements ICloneRequest {
 
         /**
-         * Constructs a new ScreenCell.
+         * Constructs a new CloneRequest.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IScreenCell);
+        constructor(properties?: vtr.ICloneRequest);
 
-        /** ScreenCell char. */
-        public char: string;
+        /** CloneRequest session. */
+        public session?: (vtr.ISessionRef|null);
 
-        /** ScreenCell fg_color. */
-        public fg_color: number;
+        /** CloneRequest name. */
+        public name: string;
 
-        /** ScreenCell bg_color. */
-        public bg_color: number;
+        /** CloneRequest copy_size. */
+        public copy_size: boolean;
 
-        /** ScreenCell attributes. */
-        public attributes: number;
+        /** CloneRequest copy_tags. */
+        public copy_tags: boolean;
 
         /**
-         * Creates a new ScreenCell instance using the specified properties.
+         * Creates a new CloneRequest instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns ScreenCell instance
+         * @returns CloneRequest instance
          */
-        public static create(properties?: vtr.IScreenCell): vtr.ScreenCell;
+        public static create(properties?: vtr.ICloneRequest): vtr.CloneRequest;
 
         /**
-         * Encodes the specified ScreenCell message. Does not implicitly {@link vtr.ScreenCell.verify|verify} messages.
-         * @param message ScreenCell message or plain object to encode
+         * Encodes the specified CloneRequest message. Does not implicitly {@link vtr.CloneRequest.verify|verify} messages.
+         * @param message CloneRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IScreenCell, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.ICloneRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified ScreenCell message, length delimited. Does not implicitly {@link vtr.ScreenCell.verify|verify} messages.
-         * @param message ScreenCell message or plain object to encode
+         * Encodes the specified CloneRequest message, length delimited. Does not implicitly {@link vtr.CloneRequest.verify|verify} messages.
+         * @param message CloneRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IScreenCell, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.ICloneRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a ScreenCell message from the specified reader or buffer.
+         * Decodes a CloneRequest message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns ScreenCell
+         * @returns CloneRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ScreenCell;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.CloneRequest;
 
         /**
-         * Decodes a ScreenCell message from the specified reader or buffer, length delimited.
+         * Decodes a CloneRequest message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns ScreenCell
+         * @returns CloneRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ScreenCell;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.CloneRequest;
 
         /**
-         * Verifies a ScreenCell message.
+         * Verifies a CloneRequest message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a ScreenCell message from a plain object. Also converts values to their respective internal types.
+         * Creates a CloneRequest message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns ScreenCell
+         * @returns CloneRequest
          */
-        public static fromObject(object: { [k: string]: any }): vtr.ScreenCell;
+        public static fromObject(object: { [k: string]: any }): vtr.CloneRequest;
 
         /**
-         * Creates a plain object from a ScreenCell message. Also converts values to other types if specified.
-         * @param message ScreenCell
+         * Creates a plain object from a CloneRequest message. Also converts values to other types if specified.
+         * @param message CloneRequest
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.ScreenCell, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.CloneRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this ScreenCell to JSON.
+         * Converts this CloneRequest to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for ScreenCell
+         * Gets the default type url for CloneRequest
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a ScreenRow. */
-    interface IScreenRow {
+    /** Properties of a CloneResponse. */
+    interface ICloneResponse {
 
-        /** ScreenRow cells */
-        cells?: (vtr.IScreenCell[]|null);
+        /** CloneResponse session */
+        session?: (vtr.ISession|null);
+
+        /** CloneResponse working_dir_fallback */
+        working_dir_fallback?: (string|null);
     }
 
-    /** Represents a ScreenRow. */
-    class ScreenRow implements IScreenRow {
+    /** Represents a CloneResponse. */
+    class CloneResponse implements ICloneResponse {
 
         /**
-         * Constructs a new ScreenRow.
+         * Constructs a new CloneResponse.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IScreenRow);
+        constructor(properties?: vtr.ICloneResponse);
 
-        /** ScreenRow cells. */
-        public cells: vtr.IScreenCell[];
+        /** CloneResponse session. */
+        public session?: (vtr.ISession|null);
+
+        /** CloneResponse working_dir_fallback. */
+        public working_dir_fallback: string;
 
         /**
-         * Creates a new ScreenRow instance using the specified properties.
+         * Creates a new CloneResponse instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns ScreenRow instance
+         * @returns CloneResponse instance
          */
-        public static create(properties?: vtr.IScreenRow): vtr.ScreenRow;
+        public static create(properties?: vtr.ICloneResponse): vtr.CloneResponse;
 
         /**
-         * Encodes the specified ScreenRow message. Does not implicitly {@link vtr.ScreenRow.verify|verify} messages.
-         * @param message ScreenRow message or plain object to encode
+         * Encodes the specified CloneResponse message. Does not implicitly {@link vtr.CloneResponse.verify|verify} messages.
+         * @param message CloneResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IScreenRow, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.ICloneResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified ScreenRow message, length delimited. Does not implicitly {@link vtr.ScreenRow.verify|verify} messages.
-         * @param message ScreenRow message or plain object to encode
+         * Encodes the specified CloneResponse message, length delimited. Does not implicitly {@link vtr.CloneResponse.verify|verify} messages.
+         * @param message CloneResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IScreenRow, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.ICloneResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a ScreenRow message from the specified reader or buffer.
+         * Decodes a CloneResponse message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns ScreenRow
+         * @returns CloneResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ScreenRow;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.CloneResponse;
 
         /**
-         * Decodes a ScreenRow message from the specified reader or buffer, length delimited.
+         * Decodes a CloneResponse message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns ScreenRow
+         * @returns CloneResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ScreenRow;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.CloneResponse;
 
         /**
-         * Verifies a ScreenRow message.
+         * Verifies a CloneResponse message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a ScreenRow message from a plain object. Also converts values to their respective internal types.
+         * Creates a CloneResponse message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns ScreenRow
+         * @returns CloneResponse
          */
-        public static fromObject(object: { [k: string]: any }): vtr.ScreenRow;
+        public static fromObject(object: { [k: string]: any }): vtr.CloneResponse;
 
         /**
-         * Creates a plain object from a ScreenRow message. Also converts values to other types if specified.
-         * @param message ScreenRow
+         * Creates a plain object from a CloneResponse message. Also converts values to other types if specified.
+         * @param message CloneResponse
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.ScreenRow, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.CloneResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this ScreenRow to JSON.
+         * Converts this CloneResponse to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for ScreenRow
+         * Gets the default type url for CloneResponse
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a GetScreenResponse. */
-    interface IGetScreenResponse {
+    /** Properties of a GetScreenRequest. */
+    interface IGetScreenRequest {
 
-        /** GetScreenResponse name */
-        name?: (string|null);
+        /** GetScreenRequest session */
+        session?: (vtr.ISessionRef|null);
 
-        /** GetScreenResponse cols */
-        cols?: (number|null);
+        /** GetScreenRequest include_scrollback_lines */
+        include_scrollback_lines?: (boolean|null);
 
-        /** GetScreenResponse rows */
-        rows?: (number|null);
+        /** GetScreenRequest include_foreground_process */
+        include_foreground_process?: (boolean|null);
 
-        /** GetScreenResponse cursor_x */
-        cursor_x?: (number|null);
+        /** GetScreenRequest track_frame */
+        track_frame?: (boolean|null);
 
-        /** GetScreenResponse cursor_y */
-        cursor_y?: (number|null);
+        /** GetScreenRequest since_frame_id */
+        since_frame_id?: (number|Long|null);
 
-        /** GetScreenResponse screen_rows */
-        screen_rows?: (vtr.IScreenRow[]|null);
+        /** GetScreenRequest include_history */
+        include_history?: (boolean|null);
 
-        /** GetScreenResponse id */
-        id?: (string|null);
+        /** GetScreenRequest history_limit */
+        history_limit?: (number|null);
+    }
+
+    /** Represents a GetScreenRequest. */
+    class GetScreenRequest implements IGetScreenRequest {
+
+        /**
+         * Constructs a new GetScreenRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IGetScreenRequest);
+
+        /** GetScreenRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** GetScreenRequest include_scrollback_lines. */
+        public include_scrollback_lines: boolean;
+
+        /** GetScreenRequest include_foreground_process. */
+        public include_foreground_process: boolean;
+
+        /** GetScreenRequest track_frame. */
+        public track_frame: boolean;
+
+        /** GetScreenRequest since_frame_id. */
+        public since_frame_id: (number|Long);
+
+        /** GetScreenRequest include_history. */
+        public include_history: boolean;
+
+        /** GetScreenRequest history_limit. */
+        public history_limit: number;
+
+        /**
+         * Creates a new GetScreenRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns GetScreenRequest instance
+         */
+        public static create(properties?: vtr.IGetScreenRequest): vtr.GetScreenRequest;
+
+        /**
+         * Encodes the specified GetScreenRequest message. Does not implicitly {@link vtr.GetScreenRequest.verify|verify} messages.
+         * @param message GetScreenRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IGetScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified GetScreenRequest message, length delimited. Does not implicitly {@link vtr.GetScreenRequest.verify|verify} messages.
+         * @param message GetScreenRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IGetScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a GetScreenRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns GetScreenRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GetScreenRequest;
+
+        /**
+         * Decodes a GetScreenRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns GetScreenRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GetScreenRequest;
+
+        /**
+         * Verifies a GetScreenRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a GetScreenRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns GetScreenRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.GetScreenRequest;
+
+        /**
+         * Creates a plain object from a GetScreenRequest message. Also converts values to other types if specified.
+         * @param message GetScreenRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.GetScreenRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this GetScreenRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for GetScreenRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ScreenCell. */
+    interface IScreenCell {
+
+        /** ScreenCell char */
+        char?: (string|null);
+
+        /** ScreenCell fg_color */
+        fg_color?: (number|null);
+
+        /** ScreenCell bg_color */
+        bg_color?: (number|null);
+
+        /** ScreenCell attributes */
+        attributes?: (number|null);
+    }
+
+    /** Represents a ScreenCell. */
+    class ScreenCell implements IScreenCell {
+
+        /**
+         * Constructs a new ScreenCell.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IScreenCell);
+
+        /** ScreenCell char. */
+        public char: string;
+
+        /** ScreenCell fg_color. */
+        public fg_color: number;
+
+        /** ScreenCell bg_color. */
+        public bg_color: number;
+
+        /** ScreenCell attributes. */
+        public attributes: number;
+
+        /**
+         * Creates a new ScreenCell instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ScreenCell instance
+         */
+        public static create(properties?: vtr.IScreenCell): vtr.ScreenCell;
+
+        /**
+         * Encodes the specified ScreenCell message. Does not implicitly {@link vtr.ScreenCell.verify|verify} messages.
+         * @param message ScreenCell message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IScreenCell, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ScreenCell message, length delimited. Does not implicitly {@link vtr.ScreenCell.verify|verify} messages.
+         * @param message ScreenCell message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IScreenCell, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ScreenCell message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ScreenCell
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ScreenCell;
+
+        /**
+         * Decodes a ScreenCell message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ScreenCell
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ScreenCell;
+
+        /**
+         * Verifies a ScreenCell message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ScreenCell message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ScreenCell
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ScreenCell;
+
+        /**
+         * Creates a plain object from a ScreenCell message. Also converts values to other types if specified.
+         * @param message ScreenCell
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ScreenCell, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ScreenCell to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ScreenCell
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ScreenRow. */
+    interface IScreenRow {
+
+        /** ScreenRow cells */
+        cells?: (vtr.IScreenCell[]|null);
+    }
+
+    /** Represents a ScreenRow. */
+    class ScreenRow implements IScreenRow {
+
+        /**
+         * Constructs a new ScreenRow.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IScreenRow);
+
+        /** ScreenRow cells. */
+        public cells: vtr.IScreenCell[];
+
+        /**
+         * Creates a new ScreenRow instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ScreenRow instance
+         */
+        public static create(properties?: vtr.IScreenRow): vtr.ScreenRow;
+
+        /**
+         * Encodes the specified ScreenRow message. Does not implicitly {@link vtr.ScreenRow.verify|verify} messages.
+         * @param message ScreenRow message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IScreenRow, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ScreenRow message, length delimited. Does not implicitly {@link vtr.ScreenRow.verify|verify} messages.
+         * @param message ScreenRow message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IScreenRow, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ScreenRow message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ScreenRow
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ScreenRow;
+
+        /**
+         * Decodes a ScreenRow message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ScreenRow
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ScreenRow;
+
+        /**
+         * Verifies a ScreenRow message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ScreenRow message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ScreenRow
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ScreenRow;
+
+        /**
+         * Creates a plain object from a ScreenRow message. Also converts values to other types if specified.
+         * @param message ScreenRow
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ScreenRow, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ScreenRow to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ScreenRow
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** MouseTracking enum. */
+    enum MouseTracking {
+        MOUSE_TRACKING_UNSPECIFIED = 0,
+        MOUSE_TRACKING_X10 = 1,
+        MOUSE_TRACKING_NORMAL = 2,
+        MOUSE_TRACKING_BUTTON = 3,
+        MOUSE_TRACKING_ANY = 4
+    }
+
+    /** MouseEncoding enum. */
+    enum MouseEncoding {
+        MOUSE_ENCODING_UNSPECIFIED = 0,
+        MOUSE_ENCODING_UTF8 = 1,
+        MOUSE_ENCODING_SGR = 2,
+        MOUSE_ENCODING_URXVT = 3,
+        MOUSE_ENCODING_SGR_PIXELS = 4
+    }
+
+    /** Properties of a GetScreenResponse. */
+    interface IGetScreenResponse {
+
+        /** GetScreenResponse name */
+        name?: (string|null);
+
+        /** GetScreenResponse cols */
+        cols?: (number|null);
+
+        /** GetScreenResponse rows */
+        rows?: (number|null);
+
+        /** GetScreenResponse cursor_x */
+        cursor_x?: (number|null);
+
+        /** GetScreenResponse cursor_y */
+        cursor_y?: (number|null);
+
+        /** GetScreenResponse screen_rows */
+        screen_rows?: (vtr.IScreenRow[]|null);
+
+        /** GetScreenResponse id */
+        id?: (string|null);
+
+        /** GetScreenResponse scrollback_lines */
+        scrollback_lines?: (number|null);
+
+        /** GetScreenResponse foreground_process */
+        foreground_process?: (string|null);
+
+        /** GetScreenResponse frame_id */
+        frame_id?: (number|Long|null);
+
+        /** GetScreenResponse base_frame_id */
+        base_frame_id?: (number|Long|null);
+
+        /** GetScreenResponse delta */
+        delta?: (vtr.IScreenDelta|null);
+
+        /** GetScreenResponse cursor_hidden */
+        cursor_hidden?: (boolean|null);
+
+        /** GetScreenResponse scroll_top */
+        scroll_top?: (number|null);
+
+        /** GetScreenResponse scroll_bottom */
+        scroll_bottom?: (number|null);
+
+        /** GetScreenResponse mouse_tracking */
+        mouse_tracking?: (vtr.MouseTracking|null);
+
+        /** GetScreenResponse mouse_encoding */
+        mouse_encoding?: (vtr.MouseEncoding|null);
+
+        /** GetScreenResponse bracketed_paste */
+        bracketed_paste?: (boolean|null);
+
+        /** GetScreenResponse application_cursor_keys */
+        application_cursor_keys?: (boolean|null);
+
+        /** GetScreenResponse history_lines */
+        history_lines?: (string[]|null);
+    }
+
+    /** Represents a GetScreenResponse. */
+    class GetScreenResponse implements IGetScreenResponse {
+
+        /**
+         * Constructs a new GetScreenResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IGetScreenResponse);
+
+        /** GetScreenResponse name. */
+        public name: string;
+
+        /** GetScreenResponse cols. */
+        public cols: number;
+
+        /** GetScreenResponse rows. */
+        public rows: number;
+
+        /** GetScreenResponse cursor_x. */
+        public cursor_x: number;
+
+        /** GetScreenResponse cursor_y. */
+        public cursor_y: number;
+
+        /** GetScreenResponse screen_rows. */
+        public screen_rows: vtr.IScreenRow[];
+
+        /** GetScreenResponse id. */
+        public id: string;
+
+        /** GetScreenResponse scrollback_lines. */
+        public scrollback_lines: number;
+
+        /** GetScreenResponse foreground_process. */
+        public foreground_process: string;
+
+        /** GetScreenResponse frame_id. */
+        public frame_id: (number|Long);
+
+        /** GetScreenResponse base_frame_id. */
+        public base_frame_id: (number|Long);
+
+        /** GetScreenResponse delta. */
+        public delta?: (vtr.IScreenDelta|null);
+
+        /** GetScreenResponse cursor_hidden. */
+        public cursor_hidden: boolean;
+
+        /** GetScreenResponse scroll_top. */
+        public scroll_top: number;
+
+        /** GetScreenResponse scroll_bottom. */
+        public scroll_bottom: number;
+
+        /** GetScreenResponse mouse_tracking. */
+        public mouse_tracking: vtr.MouseTracking;
+
+        /** GetScreenResponse mouse_encoding. */
+        public mouse_encoding: vtr.MouseEncoding;
+
+        /** GetScreenResponse bracketed_paste. */
+        public bracketed_paste: boolean;
+
+        /** GetScreenResponse application_cursor_keys. */
+        public application_cursor_keys: boolean;
+
+        /** GetScreenResponse history_lines. */
+        public history_lines: string[];
+
+        /**
+         * Creates a new GetScreenResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns GetScreenResponse instance
+         */
+        public static create(properties?: vtr.IGetScreenResponse): vtr.GetScreenResponse;
+
+        /**
+         * Encodes the specified GetScreenResponse message. Does not implicitly {@link vtr.GetScreenResponse.verify|verify} messages.
+         * @param message GetScreenResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IGetScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified GetScreenResponse message, length delimited. Does not implicitly {@link vtr.GetScreenResponse.verify|verify} messages.
+         * @param message GetScreenResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IGetScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a GetScreenResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns GetScreenResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GetScreenResponse;
+
+        /**
+         * Decodes a GetScreenResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns GetScreenResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GetScreenResponse;
+
+        /**
+         * Verifies a GetScreenResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a GetScreenResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns GetScreenResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.GetScreenResponse;
+
+        /**
+         * Creates a plain object from a GetScreenResponse message. Also converts values to other types if specified.
+         * @param message GetScreenResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.GetScreenResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this GetScreenResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for GetScreenResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a GrepRequest. */
+    interface IGrepRequest {
+
+        /** GrepRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** GrepRequest pattern */
+        pattern?: (string|null);
+
+        /** GrepRequest context_before */
+        context_before?: (number|null);
+
+        /** GrepRequest context_after */
+        context_after?: (number|null);
+
+        /** GrepRequest max_matches */
+        max_matches?: (number|null);
+    }
+
+    /** Represents a GrepRequest. */
+    class GrepRequest implements IGrepRequest {
+
+        /**
+         * Constructs a new GrepRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IGrepRequest);
+
+        /** GrepRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** GrepRequest pattern. */
+        public pattern: string;
+
+        /** GrepRequest context_before. */
+        public context_before: number;
+
+        /** GrepRequest context_after. */
+        public context_after: number;
+
+        /** GrepRequest max_matches. */
+        public max_matches: number;
+
+        /**
+         * Creates a new GrepRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns GrepRequest instance
+         */
+        public static create(properties?: vtr.IGrepRequest): vtr.GrepRequest;
+
+        /**
+         * Encodes the specified GrepRequest message. Does not implicitly {@link vtr.GrepRequest.verify|verify} messages.
+         * @param message GrepRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IGrepRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified GrepRequest message, length delimited. Does not implicitly {@link vtr.GrepRequest.verify|verify} messages.
+         * @param message GrepRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IGrepRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a GrepRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns GrepRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GrepRequest;
+
+        /**
+         * Decodes a GrepRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns GrepRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GrepRequest;
+
+        /**
+         * Verifies a GrepRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a GrepRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns GrepRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.GrepRequest;
+
+        /**
+         * Creates a plain object from a GrepRequest message. Also converts values to other types if specified.
+         * @param message GrepRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.GrepRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this GrepRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for GrepRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a GrepMatch. */
+    interface IGrepMatch {
+
+        /** GrepMatch line_number */
+        line_number?: (number|null);
+
+        /** GrepMatch line */
+        line?: (string|null);
+
+        /** GrepMatch context_before */
+        context_before?: (string[]|null);
+
+        /** GrepMatch context_after */
+        context_after?: (string[]|null);
+    }
+
+    /** Represents a GrepMatch. */
+    class GrepMatch implements IGrepMatch {
+
+        /**
+         * Constructs a new GrepMatch.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IGrepMatch);
+
+        /** GrepMatch line_number. */
+        public line_number: number;
+
+        /** GrepMatch line. */
+        public line: string;
+
+        /** GrepMatch context_before. */
+        public context_before: string[];
+
+        /** GrepMatch context_after. */
+        public context_after: string[];
+
+        /**
+         * Creates a new GrepMatch instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns GrepMatch instance
+         */
+        public static create(properties?: vtr.IGrepMatch): vtr.GrepMatch;
+
+        /**
+         * Encodes the specified GrepMatch message. Does not implicitly {@link vtr.GrepMatch.verify|verify} messages.
+         * @param message GrepMatch message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IGrepMatch, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified GrepMatch message, length delimited. Does not implicitly {@link vtr.GrepMatch.verify|verify} messages.
+         * @param message GrepMatch message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IGrepMatch, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a GrepMatch message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns GrepMatch
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GrepMatch;
+
+        /**
+         * Decodes a GrepMatch message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns GrepMatch
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GrepMatch;
+
+        /**
+         * Verifies a GrepMatch message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a GrepMatch message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns GrepMatch
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.GrepMatch;
+
+        /**
+         * Creates a plain object from a GrepMatch message. Also converts values to other types if specified.
+         * @param message GrepMatch
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.GrepMatch, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this GrepMatch to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for GrepMatch
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a GrepResponse. */
+    interface IGrepResponse {
+
+        /** GrepResponse matches */
+        matches?: (vtr.IGrepMatch[]|null);
+    }
+
+    /** Represents a GrepResponse. */
+    class GrepResponse implements IGrepResponse {
+
+        /**
+         * Constructs a new GrepResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IGrepResponse);
+
+        /** GrepResponse matches. */
+        public matches: vtr.IGrepMatch[];
+
+        /**
+         * Creates a new GrepResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns GrepResponse instance
+         */
+        public static create(properties?: vtr.IGrepResponse): vtr.GrepResponse;
+
+        /**
+         * Encodes the specified GrepResponse message. Does not implicitly {@link vtr.GrepResponse.verify|verify} messages.
+         * @param message GrepResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IGrepResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified GrepResponse message, length delimited. Does not implicitly {@link vtr.GrepResponse.verify|verify} messages.
+         * @param message GrepResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IGrepResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a GrepResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns GrepResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GrepResponse;
+
+        /**
+         * Decodes a GrepResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns GrepResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GrepResponse;
+
+        /**
+         * Verifies a GrepResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a GrepResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns GrepResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.GrepResponse;
+
+        /**
+         * Creates a plain object from a GrepResponse message. Also converts values to other types if specified.
+         * @param message GrepResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.GrepResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this GrepResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for GrepResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ScreenRect. */
+    interface IScreenRect {
+
+        /** ScreenRect x */
+        x?: (number|null);
+
+        /** ScreenRect y */
+        y?: (number|null);
+
+        /** ScreenRect width */
+        width?: (number|null);
+
+        /** ScreenRect height */
+        height?: (number|null);
+    }
+
+    /** Represents a ScreenRect. */
+    class ScreenRect implements IScreenRect {
+
+        /**
+         * Constructs a new ScreenRect.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IScreenRect);
+
+        /** ScreenRect x. */
+        public x: number;
+
+        /** ScreenRect y. */
+        public y: number;
+
+        /** ScreenRect width. */
+        public width: number;
+
+        /** ScreenRect height. */
+        public height: number;
+
+        /**
+         * Creates a new ScreenRect instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ScreenRect instance
+         */
+        public static create(properties?: vtr.IScreenRect): vtr.ScreenRect;
+
+        /**
+         * Encodes the specified ScreenRect message. Does not implicitly {@link vtr.ScreenRect.verify|verify} messages.
+         * @param message ScreenRect message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IScreenRect, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ScreenRect message, length delimited. Does not implicitly {@link vtr.ScreenRect.verify|verify} messages.
+         * @param message ScreenRect message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IScreenRect, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ScreenRect message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ScreenRect
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ScreenRect;
+
+        /**
+         * Decodes a ScreenRect message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ScreenRect
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ScreenRect;
+
+        /**
+         * Verifies a ScreenRect message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ScreenRect message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ScreenRect
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ScreenRect;
+
+        /**
+         * Creates a plain object from a ScreenRect message. Also converts values to other types if specified.
+         * @param message ScreenRect
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ScreenRect, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ScreenRect to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ScreenRect
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a FindOnScreenRequest. */
+    interface IFindOnScreenRequest {
+
+        /** FindOnScreenRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** FindOnScreenRequest pattern */
+        pattern?: (string|null);
+
+        /** FindOnScreenRequest rect */
+        rect?: (vtr.IScreenRect|null);
+
+        /** FindOnScreenRequest max_matches */
+        max_matches?: (number|null);
+    }
+
+    /** Represents a FindOnScreenRequest. */
+    class FindOnScreenRequest implements IFindOnScreenRequest {
+
+        /**
+         * Constructs a new FindOnScreenRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IFindOnScreenRequest);
+
+        /** FindOnScreenRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** FindOnScreenRequest pattern. */
+        public pattern: string;
+
+        /** FindOnScreenRequest rect. */
+        public rect?: (vtr.IScreenRect|null);
+
+        /** FindOnScreenRequest max_matches. */
+        public max_matches: number;
+
+        /**
+         * Creates a new FindOnScreenRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns FindOnScreenRequest instance
+         */
+        public static create(properties?: vtr.IFindOnScreenRequest): vtr.FindOnScreenRequest;
+
+        /**
+         * Encodes the specified FindOnScreenRequest message. Does not implicitly {@link vtr.FindOnScreenRequest.verify|verify} messages.
+         * @param message FindOnScreenRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IFindOnScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified FindOnScreenRequest message, length delimited. Does not implicitly {@link vtr.FindOnScreenRequest.verify|verify} messages.
+         * @param message FindOnScreenRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IFindOnScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a FindOnScreenRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns FindOnScreenRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.FindOnScreenRequest;
+
+        /**
+         * Decodes a FindOnScreenRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns FindOnScreenRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.FindOnScreenRequest;
+
+        /**
+         * Verifies a FindOnScreenRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a FindOnScreenRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns FindOnScreenRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.FindOnScreenRequest;
+
+        /**
+         * Creates a plain object from a FindOnScreenRequest message. Also converts values to other types if specified.
+         * @param message FindOnScreenRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.FindOnScreenRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this FindOnScreenRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for FindOnScreenRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ScreenMatch. */
+    interface IScreenMatch {
+
+        /** ScreenMatch row */
+        row?: (number|null);
+
+        /** ScreenMatch col */
+        col?: (number|null);
+
+        /** ScreenMatch end_col */
+        end_col?: (number|null);
+
+        /** ScreenMatch text */
+        text?: (string|null);
+
+        /** ScreenMatch fg_color */
+        fg_color?: (number|null);
+
+        /** ScreenMatch bg_color */
+        bg_color?: (number|null);
+
+        /** ScreenMatch attributes */
+        attributes?: (number|null);
+    }
+
+    /** Represents a ScreenMatch. */
+    class ScreenMatch implements IScreenMatch {
+
+        /**
+         * Constructs a new ScreenMatch.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IScreenMatch);
+
+        /** ScreenMatch row. */
+        public row: number;
+
+        /** ScreenMatch col. */
+        public col: number;
+
+        /** ScreenMatch end_col. */
+        public end_col: number;
+
+        /** ScreenMatch text. */
+        public text: string;
+
+        /** ScreenMatch fg_color. */
+        public fg_color: number;
+
+        /** ScreenMatch bg_color. */
+        public bg_color: number;
+
+        /** ScreenMatch attributes. */
+        public attributes: number;
+
+        /**
+         * Creates a new ScreenMatch instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ScreenMatch instance
+         */
+        public static create(properties?: vtr.IScreenMatch): vtr.ScreenMatch;
+
+        /**
+         * Encodes the specified ScreenMatch message. Does not implicitly {@link vtr.ScreenMatch.verify|verify} messages.
+         * @param message ScreenMatch message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IScreenMatch, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ScreenMatch message, length delimited. Does not implicitly {@link vtr.ScreenMatch.verify|verify} messages.
+         * @param message ScreenMatch message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IScreenMatch, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ScreenMatch message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ScreenMatch
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ScreenMatch;
+
+        /**
+         * Decodes a ScreenMatch message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ScreenMatch
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ScreenMatch;
+
+        /**
+         * Verifies a ScreenMatch message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ScreenMatch message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ScreenMatch
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ScreenMatch;
+
+        /**
+         * Creates a plain object from a ScreenMatch message. Also converts values to other types if specified.
+         * @param message ScreenMatch
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ScreenMatch, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ScreenMatch to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ScreenMatch
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a FindOnScreenResponse. */
+    interface IFindOnScreenResponse {
+
+        /** FindOnScreenResponse matches */
+        matches?: (vtr.IScreenMatch[]|null);
+    }
+
+    /** Represents a FindOnScreenResponse. */
+    class FindOnScreenResponse implements IFindOnScreenResponse {
+
+        /**
+         * Constructs a new FindOnScreenResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IFindOnScreenResponse);
+
+        /** FindOnScreenResponse matches. */
+        public matches: vtr.IScreenMatch[];
+
+        /**
+         * Creates a new FindOnScreenResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns FindOnScreenResponse instance
+         */
+        public static create(properties?: vtr.IFindOnScreenResponse): vtr.FindOnScreenResponse;
+
+        /**
+         * Encodes the specified FindOnScreenResponse message. Does not implicitly {@link vtr.FindOnScreenResponse.verify|verify} messages.
+         * @param message FindOnScreenResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IFindOnScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified FindOnScreenResponse message, length delimited. Does not implicitly {@link vtr.FindOnScreenResponse.verify|verify} messages.
+         * @param message FindOnScreenResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IFindOnScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a FindOnScreenResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns FindOnScreenResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.FindOnScreenResponse;
+
+        /**
+         * Decodes a FindOnScreenResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns FindOnScreenResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.FindOnScreenResponse;
+
+        /**
+         * Verifies a FindOnScreenResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a FindOnScreenResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns FindOnScreenResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.FindOnScreenResponse;
+
+        /**
+         * Creates a plain object from a FindOnScreenResponse message. Also converts values to other types if specified.
+         * @param message FindOnScreenResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.FindOnScreenResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this FindOnScreenResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for FindOnScreenResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** ExportFormat enum. */
+    enum ExportFormat {
+        EXPORT_FORMAT_UNSPECIFIED = 0,
+        EXPORT_FORMAT_HTML = 1,
+        EXPORT_FORMAT_SVG = 2
+    }
+
+    /** Properties of an ExportScreenRequest. */
+    interface IExportScreenRequest {
+
+        /** ExportScreenRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** ExportScreenRequest format */
+        format?: (vtr.ExportFormat|null);
+
+        /** ExportScreenRequest history */
+        history?: (boolean|null);
+
+        /** ExportScreenRequest start_line */
+        start_line?: (number|null);
+
+        /** ExportScreenRequest end_line */
+        end_line?: (number|null);
+    }
+
+    /** Represents an ExportScreenRequest. */
+    class ExportScreenRequest implements IExportScreenRequest {
+
+        /**
+         * Constructs a new ExportScreenRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IExportScreenRequest);
+
+        /** ExportScreenRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** ExportScreenRequest format. */
+        public format: vtr.ExportFormat;
+
+        /** ExportScreenRequest history. */
+        public history: boolean;
+
+        /** ExportScreenRequest start_line. */
+        public start_line: number;
+
+        /** ExportScreenRequest end_line. */
+        public end_line: number;
+
+        /**
+         * Creates a new ExportScreenRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ExportScreenRequest instance
+         */
+        public static create(properties?: vtr.IExportScreenRequest): vtr.ExportScreenRequest;
+
+        /**
+         * Encodes the specified ExportScreenRequest message. Does not implicitly {@link vtr.ExportScreenRequest.verify|verify} messages.
+         * @param message ExportScreenRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IExportScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ExportScreenRequest message, length delimited. Does not implicitly {@link vtr.ExportScreenRequest.verify|verify} messages.
+         * @param message ExportScreenRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IExportScreenRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes an ExportScreenRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ExportScreenRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ExportScreenRequest;
+
+        /**
+         * Decodes an ExportScreenRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ExportScreenRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ExportScreenRequest;
+
+        /**
+         * Verifies an ExportScreenRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates an ExportScreenRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ExportScreenRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ExportScreenRequest;
+
+        /**
+         * Creates a plain object from an ExportScreenRequest message. Also converts values to other types if specified.
+         * @param message ExportScreenRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ExportScreenRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ExportScreenRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ExportScreenRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of an ExportScreenResponse. */
+    interface IExportScreenResponse {
+
+        /** ExportScreenResponse data */
+        data?: (Uint8Array|null);
+
+        /** ExportScreenResponse content_type */
+        content_type?: (string|null);
+    }
+
+    /** Represents an ExportScreenResponse. */
+    class ExportScreenResponse implements IExportScreenResponse {
+
+        /**
+         * Constructs a new ExportScreenResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IExportScreenResponse);
+
+        /** ExportScreenResponse data. */
+        public data: Uint8Array;
+
+        /** ExportScreenResponse content_type. */
+        public content_type: string;
+
+        /**
+         * Creates a new ExportScreenResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ExportScreenResponse instance
+         */
+        public static create(properties?: vtr.IExportScreenResponse): vtr.ExportScreenResponse;
+
+        /**
+         * Encodes the specified ExportScreenResponse message. Does not implicitly {@link vtr.ExportScreenResponse.verify|verify} messages.
+         * @param message ExportScreenResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IExportScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ExportScreenResponse message, length delimited. Does not implicitly {@link vtr.ExportScreenResponse.verify|verify} messages.
+         * @param message ExportScreenResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IExportScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes an ExportScreenResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ExportScreenResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ExportScreenResponse;
+
+        /**
+         * Decodes an ExportScreenResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ExportScreenResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ExportScreenResponse;
+
+        /**
+         * Verifies an ExportScreenResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates an ExportScreenResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ExportScreenResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ExportScreenResponse;
+
+        /**
+         * Creates a plain object from an ExportScreenResponse message. Also converts values to other types if specified.
+         * @param message ExportScreenResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ExportScreenResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ExportScreenResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ExportScreenResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ReadOutputRequest. */
+    interface IReadOutputRequest {
+
+        /** ReadOutputRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** ReadOutputRequest offset */
+        offset?: (number|Long|null);
+
+        /** ReadOutputRequest wait */
+        wait?: (google.protobuf.IDuration|null);
+    }
+
+    /** Represents a ReadOutputRequest. */
+    class ReadOutputRequest implements IReadOutputRequest {
+
+        /**
+         * Constructs a new ReadOutputRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IReadOutputRequest);
+
+        /** ReadOutputRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** ReadOutputRequest offset. */
+        public offset: (number|Long);
+
+        /** ReadOutputRequest wait. */
+        public wait?: (google.protobuf.IDuration|null);
+
+        /**
+         * Creates a new ReadOutputRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ReadOutputRequest instance
+         */
+        public static create(properties?: vtr.IReadOutputRequest): vtr.ReadOutputRequest;
+
+        /**
+         * Encodes the specified ReadOutputRequest message. Does not implicitly {@link vtr.ReadOutputRequest.verify|verify} messages.
+         * @param message ReadOutputRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IReadOutputRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ReadOutputRequest message, length delimited. Does not implicitly {@link vtr.ReadOutputRequest.verify|verify} messages.
+         * @param message ReadOutputRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IReadOutputRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ReadOutputRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ReadOutputRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ReadOutputRequest;
+
+        /**
+         * Decodes a ReadOutputRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ReadOutputRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ReadOutputRequest;
+
+        /**
+         * Verifies a ReadOutputRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ReadOutputRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ReadOutputRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ReadOutputRequest;
+
+        /**
+         * Creates a plain object from a ReadOutputRequest message. Also converts values to other types if specified.
+         * @param message ReadOutputRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ReadOutputRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ReadOutputRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ReadOutputRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ReadOutputResponse. */
+    interface IReadOutputResponse {
+
+        /** ReadOutputResponse data */
+        data?: (Uint8Array|null);
+
+        /** ReadOutputResponse offset */
+        offset?: (number|Long|null);
+
+        /** ReadOutputResponse next_offset */
+        next_offset?: (number|Long|null);
+
+        /** ReadOutputResponse dropped_bytes */
+        dropped_bytes?: (number|Long|null);
+
+        /** ReadOutputResponse exited */
+        exited?: (boolean|null);
+    }
+
+    /** Represents a ReadOutputResponse. */
+    class ReadOutputResponse implements IReadOutputResponse {
+
+        /**
+         * Constructs a new ReadOutputResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IReadOutputResponse);
+
+        /** ReadOutputResponse data. */
+        public data: Uint8Array;
+
+        /** ReadOutputResponse offset. */
+        public offset: (number|Long);
+
+        /** ReadOutputResponse next_offset. */
+        public next_offset: (number|Long);
+
+        /** ReadOutputResponse dropped_bytes. */
+        public dropped_bytes: (number|Long);
+
+        /** ReadOutputResponse exited. */
+        public exited: boolean;
+
+        /**
+         * Creates a new ReadOutputResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ReadOutputResponse instance
+         */
+        public static create(properties?: vtr.IReadOutputResponse): vtr.ReadOutputResponse;
+
+        /**
+         * Encodes the specified ReadOutputResponse message. Does not implicitly {@link vtr.ReadOutputResponse.verify|verify} messages.
+         * @param message ReadOutputResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IReadOutputResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ReadOutputResponse message, length delimited. Does not implicitly {@link vtr.ReadOutputResponse.verify|verify} messages.
+         * @param message ReadOutputResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IReadOutputResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ReadOutputResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ReadOutputResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ReadOutputResponse;
+
+        /**
+         * Decodes a ReadOutputResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ReadOutputResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ReadOutputResponse;
+
+        /**
+         * Verifies a ReadOutputResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ReadOutputResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ReadOutputResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ReadOutputResponse;
+
+        /**
+         * Creates a plain object from a ReadOutputResponse message. Also converts values to other types if specified.
+         * @param message ReadOutputResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ReadOutputResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ReadOutputResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ReadOutputResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a SendTextRequest. */
+    interface ISendTextRequest {
+
+        /** SendTextRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** SendTextRequest text */
+        text?: (string|null);
+
+        /** SendTextRequest client_id */
+        client_id?: (string|null);
+
+        /** SendTextRequest lock_token */
+        lock_token?: (string|null);
+    }
+
+    /** Represents a SendTextRequest. */
+    class SendTextRequest implements ISendTextRequest {
+
+        /**
+         * Constructs a new SendTextRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendTextRequest);
+
+        /** SendTextRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** SendTextRequest text. */
+        public text: string;
+
+        /** SendTextRequest client_id. */
+        public client_id: string;
+
+        /** SendTextRequest lock_token. */
+        public lock_token: string;
+
+        /**
+         * Creates a new SendTextRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendTextRequest instance
+         */
+        public static create(properties?: vtr.ISendTextRequest): vtr.SendTextRequest;
+
+        /**
+         * Encodes the specified SendTextRequest message. Does not implicitly {@link vtr.SendTextRequest.verify|verify} messages.
+         * @param message SendTextRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendTextRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendTextRequest message, length delimited. Does not implicitly {@link vtr.SendTextRequest.verify|verify} messages.
+         * @param message SendTextRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendTextRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendTextRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendTextRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendTextRequest;
+
+        /**
+         * Decodes a SendTextRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendTextRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendTextRequest;
+
+        /**
+         * Verifies a SendTextRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendTextRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendTextRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendTextRequest;
+
+        /**
+         * Creates a plain object from a SendTextRequest message. Also converts values to other types if specified.
+         * @param message SendTextRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendTextRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendTextRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendTextRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a SendTextResponse. */
+    interface ISendTextResponse {
+    }
+
+    /** Represents a SendTextResponse. */
+    class SendTextResponse implements ISendTextResponse {
+
+        /**
+         * Constructs a new SendTextResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendTextResponse);
+
+        /**
+         * Creates a new SendTextResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendTextResponse instance
+         */
+        public static create(properties?: vtr.ISendTextResponse): vtr.SendTextResponse;
+
+        /**
+         * Encodes the specified SendTextResponse message. Does not implicitly {@link vtr.SendTextResponse.verify|verify} messages.
+         * @param message SendTextResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendTextResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendTextResponse message, length delimited. Does not implicitly {@link vtr.SendTextResponse.verify|verify} messages.
+         * @param message SendTextResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendTextResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendTextResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendTextResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendTextResponse;
+
+        /**
+         * Decodes a SendTextResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendTextResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendTextResponse;
+
+        /**
+         * Verifies a SendTextResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendTextResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendTextResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendTextResponse;
+
+        /**
+         * Creates a plain object from a SendTextResponse message. Also converts values to other types if specified.
+         * @param message SendTextResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendTextResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendTextResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendTextResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a SendKeyRequest. */
+    interface ISendKeyRequest {
+
+        /** SendKeyRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** SendKeyRequest key */
+        key?: (string|null);
+
+        /** SendKeyRequest client_id */
+        client_id?: (string|null);
+
+        /** SendKeyRequest lock_token */
+        lock_token?: (string|null);
+    }
+
+    /** Represents a SendKeyRequest. */
+    class SendKeyRequest implements ISendKeyRequest {
+
+        /**
+         * Constructs a new SendKeyRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendKeyRequest);
+
+        /** SendKeyRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** SendKeyRequest key. */
+        public key: string;
+
+        /** SendKeyRequest client_id. */
+        public client_id: string;
+
+        /** SendKeyRequest lock_token. */
+        public lock_token: string;
+
+        /**
+         * Creates a new SendKeyRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendKeyRequest instance
+         */
+        public static create(properties?: vtr.ISendKeyRequest): vtr.SendKeyRequest;
+
+        /**
+         * Encodes the specified SendKeyRequest message. Does not implicitly {@link vtr.SendKeyRequest.verify|verify} messages.
+         * @param message SendKeyRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendKeyRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendKeyRequest message, length delimited. Does not implicitly {@link vtr.SendKeyRequest.verify|verify} messages.
+         * @param message SendKeyRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendKeyRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendKeyRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendKeyRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendKeyRequest;
+
+        /**
+         * Decodes a SendKeyRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendKeyRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendKeyRequest;
+
+        /**
+         * Verifies a SendKeyRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendKeyRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendKeyRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendKeyRequest;
+
+        /**
+         * Creates a plain object from a SendKeyRequest message. Also converts values to other types if specified.
+         * @param message SendKeyRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendKeyRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendKeyRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendKeyRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a SendKeyResponse. */
+    interface ISendKeyResponse {
+    }
+
+    /** Represents a SendKeyResponse. */
+    class SendKeyResponse implements ISendKeyResponse {
+
+        /**
+         * Constructs a new SendKeyResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendKeyResponse);
+
+        /**
+         * Creates a new SendKeyResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendKeyResponse instance
+         */
+        public static create(properties?: vtr.ISendKeyResponse): vtr.SendKeyResponse;
+
+        /**
+         * Encodes the specified SendKeyResponse message. Does not implicitly {@link vtr.SendKeyResponse.verify|verify} messages.
+         * @param message SendKeyResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendKeyResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendKeyResponse message, length delimited. Does not implicitly {@link vtr.SendKeyResponse.verify|verify} messages.
+         * @param message SendKeyResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendKeyResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendKeyResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendKeyResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendKeyResponse;
+
+        /**
+         * Decodes a SendKeyResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendKeyResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendKeyResponse;
+
+        /**
+         * Verifies a SendKeyResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendKeyResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendKeyResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendKeyResponse;
+
+        /**
+         * Creates a plain object from a SendKeyResponse message. Also converts values to other types if specified.
+         * @param message SendKeyResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendKeyResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendKeyResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendKeyResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a SendBytesRequest. */
+    interface ISendBytesRequest {
+
+        /** SendBytesRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** SendBytesRequest data */
+        data?: (Uint8Array|null);
+
+        /** SendBytesRequest client_id */
+        client_id?: (string|null);
+
+        /** SendBytesRequest lock_token */
+        lock_token?: (string|null);
+    }
+
+    /** Represents a SendBytesRequest. */
+    class SendBytesRequest implements ISendBytesRequest {
+
+        /**
+         * Constructs a new SendBytesRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendBytesRequest);
+
+        /** SendBytesRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** SendBytesRequest data. */
+        public data: Uint8Array;
+
+        /** SendBytesRequest client_id. */
+        public client_id: string;
+
+        /** SendBytesRequest lock_token. */
+        public lock_token: string;
+
+        /**
+         * Creates a new SendBytesRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendBytesRequest instance
+         */
+        public static create(properties?: vtr.ISendBytesRequest): vtr.SendBytesRequest;
+
+        /**
+         * Encodes the specified SendBytesRequest message. Does not implicitly {@link vtr.SendBytesRequest.verify|verify} messages.
+         * @param message SendBytesRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendBytesRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendBytesRequest message, length delimited. Does not implicitly {@link vtr.SendBytesRequest.verify|verify} messages.
+         * @param message SendBytesRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendBytesRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendBytesRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendBytesRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendBytesRequest;
+
+        /**
+         * Decodes a SendBytesRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendBytesRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendBytesRequest;
+
+        /**
+         * Verifies a SendBytesRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendBytesRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendBytesRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendBytesRequest;
+
+        /**
+         * Creates a plain object from a SendBytesRequest message. Also converts values to other types if specified.
+         * @param message SendBytesRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendBytesRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendBytesRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendBytesRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a SendBytesResponse. */
+    interface ISendBytesResponse {
+    }
+
+    /** Represents a SendBytesResponse. */
+    class SendBytesResponse implements ISendBytesResponse {
+
+        /**
+         * Constructs a new SendBytesResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendBytesResponse);
+
+        /**
+         * Creates a new SendBytesResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendBytesResponse instance
+         */
+        public static create(properties?: vtr.ISendBytesResponse): vtr.SendBytesResponse;
+
+        /**
+         * Encodes the specified SendBytesResponse message. Does not implicitly {@link vtr.SendBytesResponse.verify|verify} messages.
+         * @param message SendBytesResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendBytesResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendBytesResponse message, length delimited. Does not implicitly {@link vtr.SendBytesResponse.verify|verify} messages.
+         * @param message SendBytesResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendBytesResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendBytesResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendBytesResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendBytesResponse;
+
+        /**
+         * Decodes a SendBytesResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendBytesResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendBytesResponse;
+
+        /**
+         * Verifies a SendBytesResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendBytesResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendBytesResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendBytesResponse;
+
+        /**
+         * Creates a plain object from a SendBytesResponse message. Also converts values to other types if specified.
+         * @param message SendBytesResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendBytesResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendBytesResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendBytesResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** MouseButton enum. */
+    enum MouseButton {
+        MOUSE_BUTTON_UNSPECIFIED = 0,
+        MOUSE_BUTTON_LEFT = 1,
+        MOUSE_BUTTON_MIDDLE = 2,
+        MOUSE_BUTTON_RIGHT = 3,
+        MOUSE_BUTTON_WHEEL_UP = 4,
+        MOUSE_BUTTON_WHEEL_DOWN = 5,
+        MOUSE_BUTTON_WHEEL_LEFT = 6,
+        MOUSE_BUTTON_WHEEL_RIGHT = 7
+    }
+
+    /** MouseAction enum. */
+    enum MouseAction {
+        MOUSE_ACTION_UNSPECIFIED = 0,
+        MOUSE_ACTION_PRESS = 1,
+        MOUSE_ACTION_RELEASE = 2,
+        MOUSE_ACTION_MOTION = 3,
+        MOUSE_ACTION_CLICK = 4
+    }
+
+    /** Properties of a SendMouseRequest. */
+    interface ISendMouseRequest {
+
+        /** SendMouseRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** SendMouseRequest button */
+        button?: (vtr.MouseButton|null);
+
+        /** SendMouseRequest action */
+        action?: (vtr.MouseAction|null);
+
+        /** SendMouseRequest x */
+        x?: (number|null);
+
+        /** SendMouseRequest y */
+        y?: (number|null);
+
+        /** SendMouseRequest shift */
+        shift?: (boolean|null);
+
+        /** SendMouseRequest alt */
+        alt?: (boolean|null);
+
+        /** SendMouseRequest ctrl */
+        ctrl?: (boolean|null);
+
+        /** SendMouseRequest client_id */
+        client_id?: (string|null);
+
+        /** SendMouseRequest lock_token */
+        lock_token?: (string|null);
+    }
+
+    /** Represents a SendMouseRequest. */
+    class SendMouseRequest implements ISendMouseRequest {
+
+        /**
+         * Constructs a new SendMouseRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendMouseRequest);
+
+        /** SendMouseRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** SendMouseRequest button. */
+        public button: vtr.MouseButton;
+
+        /** SendMouseRequest action. */
+        public action: vtr.MouseAction;
+
+        /** SendMouseRequest x. */
+        public x: number;
+
+        /** SendMouseRequest y. */
+        public y: number;
+
+        /** SendMouseRequest shift. */
+        public shift: boolean;
+
+        /** SendMouseRequest alt. */
+        public alt: boolean;
+
+        /** SendMouseRequest ctrl. */
+        public ctrl: boolean;
+
+        /** SendMouseRequest client_id. */
+        public client_id: string;
+
+        /** SendMouseRequest lock_token. */
+        public lock_token: string;
+
+        /**
+         * Creates a new SendMouseRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendMouseRequest instance
+         */
+        public static create(properties?: vtr.ISendMouseRequest): vtr.SendMouseRequest;
+
+        /**
+         * Encodes the specified SendMouseRequest message. Does not implicitly {@link vtr.SendMouseRequest.verify|verify} messages.
+         * @param message SendMouseRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendMouseRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendMouseRequest message, length delimited. Does not implicitly {@link vtr.SendMouseRequest.verify|verify} messages.
+         * @param message SendMouseRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendMouseRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendMouseRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendMouseRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendMouseRequest;
+
+        /**
+         * Decodes a SendMouseRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendMouseRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendMouseRequest;
+
+        /**
+         * Verifies a SendMouseRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendMouseRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendMouseRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendMouseRequest;
+
+        /**
+         * Creates a plain object from a SendMouseRequest message. Also converts values to other types if specified.
+         * @param message SendMouseRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendMouseRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendMouseRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendMouseRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a SendMouseResponse. */
+    interface ISendMouseResponse {
+
+        /** SendMouseResponse sent */
+        sent?: (boolean|null);
+    }
+
+    /** Represents a SendMouseResponse. */
+    class SendMouseResponse implements ISendMouseResponse {
+
+        /**
+         * Constructs a new SendMouseResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.ISendMouseResponse);
+
+        /** SendMouseResponse sent. */
+        public sent: boolean;
+
+        /**
+         * Creates a new SendMouseResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns SendMouseResponse instance
+         */
+        public static create(properties?: vtr.ISendMouseResponse): vtr.SendMouseResponse;
+
+        /**
+         * Encodes the specified SendMouseResponse message. Does not implicitly {@link vtr.SendMouseResponse.verify|verify} messages.
+         * @param message SendMouseResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.ISendMouseResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified SendMouseResponse message, length delimited. Does not implicitly {@link vtr.SendMouseResponse.verify|verify} messages.
+         * @param message SendMouseResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.ISendMouseResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a SendMouseResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns SendMouseResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendMouseResponse;
+
+        /**
+         * Decodes a SendMouseResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns SendMouseResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendMouseResponse;
+
+        /**
+         * Verifies a SendMouseResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a SendMouseResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns SendMouseResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.SendMouseResponse;
+
+        /**
+         * Creates a plain object from a SendMouseResponse message. Also converts values to other types if specified.
+         * @param message SendMouseResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.SendMouseResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this SendMouseResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for SendMouseResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a PasteRequest. */
+    interface IPasteRequest {
+
+        /** PasteRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** PasteRequest data */
+        data?: (Uint8Array|null);
+
+        /** PasteRequest bracketed */
+        bracketed?: (boolean|null);
+
+        /** PasteRequest chunk_size */
+        chunk_size?: (number|null);
+
+        /** PasteRequest client_id */
+        client_id?: (string|null);
+
+        /** PasteRequest lock_token */
+        lock_token?: (string|null);
+    }
+
+    /** Represents a PasteRequest. */
+    class PasteRequest implements IPasteRequest {
+
+        /**
+         * Constructs a new PasteRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IPasteRequest);
+
+        /** PasteRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** PasteRequest data. */
+        public data: Uint8Array;
+
+        /** PasteRequest bracketed. */
+        public bracketed?: (boolean|null);
+
+        /** PasteRequest chunk_size. */
+        public chunk_size: number;
+
+        /** PasteRequest client_id. */
+        public client_id: string;
+
+        /** PasteRequest lock_token. */
+        public lock_token: string;
+
+        /**
+         * Creates a new PasteRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns PasteRequest instance
+         */
+        public static create(properties?: vtr.IPasteRequest): vtr.PasteRequest;
+
+        /**
+         * Encodes the specified PasteRequest message. Does not implicitly {@link vtr.PasteRequest.verify|verify} messages.
+         * @param message PasteRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IPasteRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified PasteRequest message, length delimited. Does not implicitly {@link vtr.PasteRequest.verify|verify} messages.
+         * @param message PasteRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IPasteRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a PasteRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns PasteRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.PasteRequest;
+
+        /**
+         * Decodes a PasteRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns PasteRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.PasteRequest;
+
+        /**
+         * Verifies a PasteRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a PasteRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns PasteRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.PasteRequest;
+
+        /**
+         * Creates a plain object from a PasteRequest message. Also converts values to other types if specified.
+         * @param message PasteRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.PasteRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this PasteRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for PasteRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a PasteProgress. */
+    interface IPasteProgress {
+
+        /** PasteProgress written */
+        written?: (number|Long|null);
+
+        /** PasteProgress total */
+        total?: (number|Long|null);
+
+        /** PasteProgress bracketed */
+        bracketed?: (boolean|null);
+
+        /** PasteProgress done */
+        done?: (boolean|null);
+    }
+
+    /** Represents a PasteProgress. */
+    class PasteProgress implements IPasteProgress {
+
+        /**
+         * Constructs a new PasteProgress.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IPasteProgress);
+
+        /** PasteProgress written. */
+        public written: (number|Long);
+
+        /** PasteProgress total. */
+        public total: (number|Long);
+
+        /** PasteProgress bracketed. */
+        public bracketed: boolean;
+
+        /** PasteProgress done. */
+        public done: boolean;
+
+        /**
+         * Creates a new PasteProgress instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns PasteProgress instance
+         */
+        public static create(properties?: vtr.IPasteProgress): vtr.PasteProgress;
+
+        /**
+         * Encodes the specified PasteProgress message. Does not implicitly {@link vtr.PasteProgress.verify|verify} messages.
+         * @param message PasteProgress message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IPasteProgress, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified PasteProgress message, length delimited. Does not implicitly {@link vtr.PasteProgress.verify|verify} messages.
+         * @param message PasteProgress message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IPasteProgress, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a PasteProgress message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns PasteProgress
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.PasteProgress;
+
+        /**
+         * Decodes a PasteProgress message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns PasteProgress
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.PasteProgress;
+
+        /**
+         * Verifies a PasteProgress message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a PasteProgress message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns PasteProgress
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.PasteProgress;
+
+        /**
+         * Creates a plain object from a PasteProgress message. Also converts values to other types if specified.
+         * @param message PasteProgress
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.PasteProgress, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this PasteProgress to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for PasteProgress
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ResizeRequest. */
+    interface IResizeRequest {
+
+        /** ResizeRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** ResizeRequest cols */
+        cols?: (number|null);
+
+        /** ResizeRequest rows */
+        rows?: (number|null);
+
+        /** ResizeRequest lock_token */
+        lock_token?: (string|null);
+
+        /** ResizeRequest client_id */
+        client_id?: (string|null);
+    }
+
+    /** Represents a ResizeRequest. */
+    class ResizeRequest implements IResizeRequest {
+
+        /**
+         * Constructs a new ResizeRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IResizeRequest);
+
+        /** ResizeRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** ResizeRequest cols. */
+        public cols: number;
+
+        /** ResizeRequest rows. */
+        public rows: number;
+
+        /** ResizeRequest lock_token. */
+        public lock_token: string;
+
+        /** ResizeRequest client_id. */
+        public client_id: string;
+
+        /**
+         * Creates a new ResizeRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ResizeRequest instance
+         */
+        public static create(properties?: vtr.IResizeRequest): vtr.ResizeRequest;
+
+        /**
+         * Encodes the specified ResizeRequest message. Does not implicitly {@link vtr.ResizeRequest.verify|verify} messages.
+         * @param message ResizeRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IResizeRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ResizeRequest message, length delimited. Does not implicitly {@link vtr.ResizeRequest.verify|verify} messages.
+         * @param message ResizeRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IResizeRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ResizeRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ResizeRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ResizeRequest;
+
+        /**
+         * Decodes a ResizeRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ResizeRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ResizeRequest;
+
+        /**
+         * Verifies a ResizeRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ResizeRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ResizeRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ResizeRequest;
+
+        /**
+         * Creates a plain object from a ResizeRequest message. Also converts values to other types if specified.
+         * @param message ResizeRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ResizeRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ResizeRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ResizeRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ResizeResponse. */
+    interface IResizeResponse {
+
+        /** ResizeResponse cols */
+        cols?: (number|null);
+
+        /** ResizeResponse rows */
+        rows?: (number|null);
+    }
+
+    /** Represents a ResizeResponse. */
+    class ResizeResponse implements IResizeResponse {
+
+        /**
+         * Constructs a new ResizeResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IResizeResponse);
+
+        /** ResizeResponse cols. */
+        public cols: number;
+
+        /** ResizeResponse rows. */
+        public rows: number;
+
+        /**
+         * Creates a new ResizeResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ResizeResponse instance
+         */
+        public static create(properties?: vtr.IResizeResponse): vtr.ResizeResponse;
+
+        /**
+         * Encodes the specified ResizeResponse message. Does not implicitly {@link vtr.ResizeResponse.verify|verify} messages.
+         * @param message ResizeResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IResizeResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ResizeResponse message, length delimited. Does not implicitly {@link vtr.ResizeResponse.verify|verify} messages.
+         * @param message ResizeResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IResizeResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ResizeResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ResizeResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ResizeResponse;
+
+        /**
+         * Decodes a ResizeResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ResizeResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ResizeResponse;
+
+        /**
+         * Verifies a ResizeResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ResizeResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ResizeResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ResizeResponse;
+
+        /**
+         * Creates a plain object from a ResizeResponse message. Also converts values to other types if specified.
+         * @param message ResizeResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ResizeResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ResizeResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ResizeResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of an AcquireInputLockRequest. */
+    interface IAcquireInputLockRequest {
+
+        /** AcquireInputLockRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** AcquireInputLockRequest lock_token */
+        lock_token?: (string|null);
+
+        /** AcquireInputLockRequest holder */
+        holder?: (string|null);
+
+        /** AcquireInputLockRequest lease */
+        lease?: (google.protobuf.IDuration|null);
+    }
+
+    /** Represents an AcquireInputLockRequest. */
+    class AcquireInputLockRequest implements IAcquireInputLockRequest {
+
+        /**
+         * Constructs a new AcquireInputLockRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IAcquireInputLockRequest);
+
+        /** AcquireInputLockRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** AcquireInputLockRequest lock_token. */
+        public lock_token: string;
+
+        /** AcquireInputLockRequest holder. */
+        public holder: string;
+
+        /** AcquireInputLockRequest lease. */
+        public lease?: (google.protobuf.IDuration|null);
+
+        /**
+         * Creates a new AcquireInputLockRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns AcquireInputLockRequest instance
+         */
+        public static create(properties?: vtr.IAcquireInputLockRequest): vtr.AcquireInputLockRequest;
+
+        /**
+         * Encodes the specified AcquireInputLockRequest message. Does not implicitly {@link vtr.AcquireInputLockRequest.verify|verify} messages.
+         * @param message AcquireInputLockRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IAcquireInputLockRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified AcquireInputLockRequest message, length delimited. Does not implicitly {@link vtr.AcquireInputLockRequest.verify|verify} messages.
+         * @param message AcquireInputLockRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IAcquireInputLockRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes an AcquireInputLockRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns AcquireInputLockRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.AcquireInputLockRequest;
+
+        /**
+         * Decodes an AcquireInputLockRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns AcquireInputLockRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.AcquireInputLockRequest;
+
+        /**
+         * Verifies an AcquireInputLockRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates an AcquireInputLockRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns AcquireInputLockRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.AcquireInputLockRequest;
+
+        /**
+         * Creates a plain object from an AcquireInputLockRequest message. Also converts values to other types if specified.
+         * @param message AcquireInputLockRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.AcquireInputLockRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this AcquireInputLockRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for AcquireInputLockRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of an AcquireInputLockResponse. */
+    interface IAcquireInputLockResponse {
+
+        /** AcquireInputLockResponse lock */
+        lock?: (vtr.IInputLock|null);
+
+        /** AcquireInputLockResponse lock_token */
+        lock_token?: (string|null);
+    }
+
+    /** Represents an AcquireInputLockResponse. */
+    class AcquireInputLockResponse implements IAcquireInputLockResponse {
+
+        /**
+         * Constructs a new AcquireInputLockResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IAcquireInputLockResponse);
+
+        /** AcquireInputLockResponse lock. */
+        public lock?: (vtr.IInputLock|null);
+
+        /** AcquireInputLockResponse lock_token. */
+        public lock_token: string;
+
+        /**
+         * Creates a new AcquireInputLockResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns AcquireInputLockResponse instance
+         */
+        public static create(properties?: vtr.IAcquireInputLockResponse): vtr.AcquireInputLockResponse;
+
+        /**
+         * Encodes the specified AcquireInputLockResponse message. Does not implicitly {@link vtr.AcquireInputLockResponse.verify|verify} messages.
+         * @param message AcquireInputLockResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IAcquireInputLockResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified AcquireInputLockResponse message, length delimited. Does not implicitly {@link vtr.AcquireInputLockResponse.verify|verify} messages.
+         * @param message AcquireInputLockResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IAcquireInputLockResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes an AcquireInputLockResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns AcquireInputLockResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.AcquireInputLockResponse;
+
+        /**
+         * Decodes an AcquireInputLockResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns AcquireInputLockResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.AcquireInputLockResponse;
+
+        /**
+         * Verifies an AcquireInputLockResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates an AcquireInputLockResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns AcquireInputLockResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.AcquireInputLockResponse;
+
+        /**
+         * Creates a plain object from an AcquireInputLockResponse message. Also converts values to other types if specified.
+         * @param message AcquireInputLockResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.AcquireInputLockResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this AcquireInputLockResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for AcquireInputLockResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ReleaseInputLockRequest. */
+    interface IReleaseInputLockRequest {
+
+        /** ReleaseInputLockRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** ReleaseInputLockRequest lock_token */
+        lock_token?: (string|null);
+    }
+
+    /** Represents a ReleaseInputLockRequest. */
+    class ReleaseInputLockRequest implements IReleaseInputLockRequest {
+
+        /**
+         * Constructs a new ReleaseInputLockRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IReleaseInputLockRequest);
+
+        /** ReleaseInputLockRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** ReleaseInputLockRequest lock_token. */
+        public lock_token: string;
+
+        /**
+         * Creates a new ReleaseInputLockRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ReleaseInputLockRequest instance
+         */
+        public static create(properties?: vtr.IReleaseInputLockRequest): vtr.ReleaseInputLockRequest;
+
+        /**
+         * Encodes the specified ReleaseInputLockRequest message. Does not implicitly {@link vtr.ReleaseInputLockRequest.verify|verify} messages.
+         * @param message ReleaseInputLockRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IReleaseInputLockRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ReleaseInputLockRequest message, length delimited. Does not implicitly {@link vtr.ReleaseInputLockRequest.verify|verify} messages.
+         * @param message ReleaseInputLockRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IReleaseInputLockRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ReleaseInputLockRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ReleaseInputLockRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ReleaseInputLockRequest;
+
+        /**
+         * Decodes a ReleaseInputLockRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ReleaseInputLockRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ReleaseInputLockRequest;
+
+        /**
+         * Verifies a ReleaseInputLockRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ReleaseInputLockRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ReleaseInputLockRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ReleaseInputLockRequest;
+
+        /**
+         * Creates a plain object from a ReleaseInputLockRequest message. Also converts values to other types if specified.
+         * @param message ReleaseInputLockRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ReleaseInputLockRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ReleaseInputLockRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ReleaseInputLockRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ReleaseInputLockResponse. */
+    interface IReleaseInputLockResponse {
+    }
+
+    /** Represents a ReleaseInputLockResponse. */
+    class ReleaseInputLockResponse implements IReleaseInputLockResponse {
+
+        /**
+         * Constructs a new ReleaseInputLockResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IReleaseInputLockResponse);
+
+        /**
+         * Creates a new ReleaseInputLockResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ReleaseInputLockResponse instance
+         */
+        public static create(properties?: vtr.IReleaseInputLockResponse): vtr.ReleaseInputLockResponse;
+
+        /**
+         * Encodes the specified ReleaseInputLockResponse message. Does not implicitly {@link vtr.ReleaseInputLockResponse.verify|verify} messages.
+         * @param message ReleaseInputLockResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IReleaseInputLockResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ReleaseInputLockResponse message, length delimited. Does not implicitly {@link vtr.ReleaseInputLockResponse.verify|verify} messages.
+         * @param message ReleaseInputLockResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IReleaseInputLockResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ReleaseInputLockResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ReleaseInputLockResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ReleaseInputLockResponse;
+
+        /**
+         * Decodes a ReleaseInputLockResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ReleaseInputLockResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ReleaseInputLockResponse;
+
+        /**
+         * Verifies a ReleaseInputLockResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ReleaseInputLockResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ReleaseInputLockResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ReleaseInputLockResponse;
+
+        /**
+         * Creates a plain object from a ReleaseInputLockResponse message. Also converts values to other types if specified.
+         * @param message ReleaseInputLockResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ReleaseInputLockResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ReleaseInputLockResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ReleaseInputLockResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of an AttachedClient. */
+    interface IAttachedClient {
+
+        /** AttachedClient id */
+        id?: (string|null);
+
+        /** AttachedClient client_id */
+        client_id?: (string|null);
+
+        /** AttachedClient kind */
+        kind?: (string|null);
+
+        /** AttachedClient name */
+        name?: (string|null);
+
+        /** AttachedClient peer */
+        peer?: (string|null);
+
+        /** AttachedClient identity */
+        identity?: (string|null);
+
+        /** AttachedClient read_only */
+        read_only?: (boolean|null);
+
+        /** AttachedClient cols */
+        cols?: (number|null);
+
+        /** AttachedClient rows */
+        rows?: (number|null);
+
+        /** AttachedClient connected_at */
+        connected_at?: (google.protobuf.ITimestamp|null);
+    }
+
+    /** Represents an AttachedClient. */
+    class AttachedClient implements IAttachedClient {
+
+        /**
+         * Constructs a new AttachedClient.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IAttachedClient);
+
+        /** AttachedClient id. */
+        public id: string;
+
+        /** AttachedClient client_id. */
+        public client_id: string;
+
+        /** AttachedClient kind. */
+        public kind: string;
+
+        /** AttachedClient name. */
+        public name: string;
+
+        /** AttachedClient peer. */
+        public peer: string;
+
+        /** AttachedClient identity. */
+        public identity: string;
+
+        /** AttachedClient read_only. */
+        public read_only: boolean;
+
+        /** AttachedClient cols. */
+        public cols: number;
+
+        /** AttachedClient rows. */
+        public rows: number;
+
+        /** AttachedClient connected_at. */
+        public connected_at?: (google.protobuf.ITimestamp|null);
+
+        /**
+         * Creates a new AttachedClient instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns AttachedClient instance
+         */
+        public static create(properties?: vtr.IAttachedClient): vtr.AttachedClient;
+
+        /**
+         * Encodes the specified AttachedClient message. Does not implicitly {@link vtr.AttachedClient.verify|verify} messages.
+         * @param message AttachedClient message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IAttachedClient, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified AttachedClient message, length delimited. Does not implicitly {@link vtr.AttachedClient.verify|verify} messages.
+         * @param message AttachedClient message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IAttachedClient, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes an AttachedClient message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns AttachedClient
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.AttachedClient;
+
+        /**
+         * Decodes an AttachedClient message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns AttachedClient
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.AttachedClient;
+
+        /**
+         * Verifies an AttachedClient message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates an AttachedClient message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns AttachedClient
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.AttachedClient;
+
+        /**
+         * Creates a plain object from an AttachedClient message. Also converts values to other types if specified.
+         * @param message AttachedClient
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.AttachedClient, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this AttachedClient to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for AttachedClient
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ListClientsRequest. */
+    interface IListClientsRequest {
+
+        /** ListClientsRequest session */
+        session?: (vtr.ISessionRef|null);
+    }
+
+    /** Represents a ListClientsRequest. */
+    class ListClientsRequest implements IListClientsRequest {
+
+        /**
+         * Constructs a new ListClientsRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IListClientsRequest);
+
+        /** ListClientsRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /**
+         * Creates a new ListClientsRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ListClientsRequest instance
+         */
+        public static create(properties?: vtr.IListClientsRequest): vtr.ListClientsRequest;
+
+        /**
+         * Encodes the specified ListClientsRequest message. Does not implicitly {@link vtr.ListClientsRequest.verify|verify} messages.
+         * @param message ListClientsRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IListClientsRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ListClientsRequest message, length delimited. Does not implicitly {@link vtr.ListClientsRequest.verify|verify} messages.
+         * @param message ListClientsRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IListClientsRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ListClientsRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ListClientsRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ListClientsRequest;
+
+        /**
+         * Decodes a ListClientsRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ListClientsRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ListClientsRequest;
+
+        /**
+         * Verifies a ListClientsRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ListClientsRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ListClientsRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ListClientsRequest;
+
+        /**
+         * Creates a plain object from a ListClientsRequest message. Also converts values to other types if specified.
+         * @param message ListClientsRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ListClientsRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ListClientsRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ListClientsRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a ListClientsResponse. */
+    interface IListClientsResponse {
+
+        /** ListClientsResponse clients */
+        clients?: (vtr.IAttachedClient[]|null);
+    }
+
+    /** Represents a ListClientsResponse. */
+    class ListClientsResponse implements IListClientsResponse {
+
+        /**
+         * Constructs a new ListClientsResponse.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IListClientsResponse);
+
+        /** ListClientsResponse clients. */
+        public clients: vtr.IAttachedClient[];
+
+        /**
+         * Creates a new ListClientsResponse instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns ListClientsResponse instance
+         */
+        public static create(properties?: vtr.IListClientsResponse): vtr.ListClientsResponse;
+
+        /**
+         * Encodes the specified ListClientsResponse message. Does not implicitly {@link vtr.ListClientsResponse.verify|verify} messages.
+         * @param message ListClientsResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IListClientsResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified ListClientsResponse message, length delimited. Does not implicitly {@link vtr.ListClientsResponse.verify|verify} messages.
+         * @param message ListClientsResponse message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IListClientsResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a ListClientsResponse message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns ListClientsResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ListClientsResponse;
+
+        /**
+         * Decodes a ListClientsResponse message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns ListClientsResponse
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ListClientsResponse;
+
+        /**
+         * Verifies a ListClientsResponse message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a ListClientsResponse message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns ListClientsResponse
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.ListClientsResponse;
+
+        /**
+         * Creates a plain object from a ListClientsResponse message. Also converts values to other types if specified.
+         * @param message ListClientsResponse
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.ListClientsResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this ListClientsResponse to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for ListClientsResponse
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a DisconnectClientRequest. */
+    interface IDisconnectClientRequest {
+
+        /** DisconnectClientRequest session */
+        session?: (vtr.ISessionRef|null);
+
+        /** DisconnectClientRequest id */
+        id?: (string|null);
+    }
+
+    /** Represents a DisconnectClientRequest. */
+    class DisconnectClientRequest implements IDisconnectClientRequest {
+
+        /**
+         * Constructs a new DisconnectClientRequest.
+         * @param [properties] Properties to set
+         */
+        constructor(properties?: vtr.IDisconnectClientRequest);
+
+        /** DisconnectClientRequest session. */
+        public session?: (vtr.ISessionRef|null);
+
+        /** DisconnectClientRequest id. */
+        public id: string;
+
+        /**
+         * Creates a new DisconnectClientRequest instance using the specified properties.
+         * @param [properties] Properties to set
+         * @returns DisconnectClientRequest instance
+         */
+        public static create(properties?: vtr.IDisconnectClientRequest): vtr.DisconnectClientRequest;
+
+        /**
+         * Encodes the specified DisconnectClientRequest message. Does not implicitly {@link vtr.DisconnectClientRequest.verify|verify} messages.
+         * @param message DisconnectClientRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encode(message: vtr.IDisconnectClientRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Encodes the specified DisconnectClientRequest message, length delimited. Does not implicitly {@link vtr.DisconnectClientRequest.verify|verify} messages.
+         * @param message DisconnectClientRequest message or plain object to encode
+         * @param [writer] Writer to encode to
+         * @returns Writer
+         */
+        public static encodeDelimited(message: vtr.IDisconnectClientRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+
+        /**
+         * Decodes a DisconnectClientRequest message from the specified reader or buffer.
+         * @param reader Reader or buffer to decode from
+         * @param [length] Message length if known beforehand
+         * @returns DisconnectClientRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.DisconnectClientRequest;
+
+        /**
+         * Decodes a DisconnectClientRequest message from the specified reader or buffer, length delimited.
+         * @param reader Reader or buffer to decode from
+         * @returns DisconnectClientRequest
+         * @throws {Error} If the payload is not a reader or valid buffer
+         * @throws {$protobuf.util.ProtocolError} If required fields are missing
+         */
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.DisconnectClientRequest;
+
+        /**
+         * Verifies a DisconnectClientRequest message.
+         * @param message Plain object to verify
+         * @returns `null` if valid, otherwise the reason why it is not
+         */
+        public static verify(message: { [k: string]: any }): (string|null);
+
+        /**
+         * Creates a DisconnectClientRequest message from a plain object. Also converts values to their respective internal types.
+         * @param object Plain object
+         * @returns DisconnectClientRequest
+         */
+        public static fromObject(object: { [k: string]: any }): vtr.DisconnectClientRequest;
+
+        /**
+         * Creates a plain object from a DisconnectClientRequest message. Also converts values to other types if specified.
+         * @param message DisconnectClientRequest
+         * @param [options] Conversion options
+         * @returns Plain object
+         */
+        public static toObject(message: vtr.DisconnectClientRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+
+        /**
+         * Converts this DisconnectClientRequest to JSON.
+         * @returns JSON object
+         */
+        public toJSON(): { [k: string]: any };
+
+        /**
+         * Gets the default type url for DisconnectClientRequest
+         * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
+         * @returns The default type url
+         */
+        public static getTypeUrl(typeUrlPrefix?: string): string;
+    }
+
+    /** Properties of a DisconnectClientResponse. */
+    interface IDisconnectClientResponse {
     }
 
-    /** Represents a GetScreenResponse. */
-    class GetScreenResponse implements IGetScreenResponse {
+    /** Represents a DisconnectClientResponse. */
+    class DisconnectClientResponse implements IDisconnectClientResponse {
 
         /**
-         * Constructs a new GetScreenResponse.
+         * Constructs a new DisconnectClientResponse.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IGetScreenResponse);
-
-        /** GetScreenResponse name. */
-        public name: string;
-
-        /** GetScreenResponse cols. */
-        public cols: number;
-
-        /** GetScreenResponse rows. */
-        public rows: number;
-
-        /** GetScreenResponse cursor_x. */
-        public cursor_x: number;
-
-        /** GetScreenResponse cursor_y. */
-        public cursor_y: number;
-
-        /** GetScreenResponse screen_rows. */
-        public screen_rows: vtr.IScreenRow[];
-
-        /** GetScreenResponse id. */
-        public id: string;
+        constructor(properties?: vtr.IDisconnectClientResponse);
 
         /**
-         * Creates a new GetScreenResponse instance using the specified properties.
+         * Creates a new DisconnectClientResponse instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns GetScreenResponse instance
+         * @returns DisconnectClientResponse instance
          */
-        public static create(properties?: vtr.IGetScreenResponse): vtr.GetScreenResponse;
+        public static create(properties?: vtr.IDisconnectClientResponse): vtr.DisconnectClientResponse;
 
         /**
-         * Encodes the specified GetScreenResponse message. Does not implicitly {@link vtr.GetScreenResponse.verify|verify} messages.
-         * @param message GetScreenResponse message or plain object to encode
+         * Encodes the specified DisconnectClientResponse message. Does not implicitly {@link vtr.DisconnectClientResponse.verify|verify} messages.
+         * @param message DisconnectClientResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IGetScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IDisconnectClientResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified GetScreenResponse message, length delimited. Does not implicitly {@link vtr.GetScreenResponse.verify|verify} messages.
-         * @param message GetScreenResponse message or plain object to encode
+         * Encodes the specified DisconnectClientResponse message, length delimited. Does not implicitly {@link vtr.DisconnectClientResponse.verify|verify} messages.
+         * @param message DisconnectClientResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IGetScreenResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IDisconnectClientResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a GetScreenResponse message from the specified reader or buffer.
+         * Decodes a DisconnectClientResponse message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns GetScreenResponse
+         * @returns DisconnectClientResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GetScreenResponse;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.DisconnectClientResponse;
 
         /**
-         * Decodes a GetScreenResponse message from the specified reader or buffer, length delimited.
+         * Decodes a DisconnectClientResponse message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns GetScreenResponse
+         * @returns DisconnectClientResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GetScreenResponse;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.DisconnectClientResponse;
 
         /**
-         * Verifies a GetScreenResponse message.
+         * Verifies a DisconnectClientResponse message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a GetScreenResponse message from a plain object. Also converts values to their respective internal types.
+         * Creates a DisconnectClientResponse message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns GetScreenResponse
+         * @returns DisconnectClientResponse
          */
-        public static fromObject(object: { [k: string]: any }): vtr.GetScreenResponse;
+        public static fromObject(object: { [k: string]: any }): vtr.DisconnectClientResponse;
 
         /**
-         * Creates a plain object from a GetScreenResponse message. Also converts values to other types if specified.
-         * @param message GetScreenResponse
+         * Creates a plain object from a DisconnectClientResponse message. Also converts values to other types if specified.
+         * @param message DisconnectClientResponse
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.GetScreenResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.DisconnectClientResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this GetScreenResponse to JSON.
+         * Converts this DisconnectClientResponse to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for GetScreenResponse
+         * Gets the default type url for DisconnectClientResponse
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a GrepRequest. */
-    interface IGrepRequest {
+    /** Properties of a MacroInfo. */
+    interface IMacroInfo {
 
-        /** GrepRequest session */
-        session?: (vtr.ISessionRef|null);
+        /** MacroInfo name */
+        name?: (string|null);
 
-        /** GrepRequest pattern */
-        pattern?: (string|null);
+        /** MacroInfo coordinator */
+        coordinator?: (string|null);
 
-        /** GrepRequest context_before */
-        context_before?: (number|null);
+        /** MacroInfo created_at */
+        created_at?: (google.protobuf.ITimestamp|null);
 
-        /** GrepRequest context_after */
-        context_after?: (number|null);
+        /** MacroInfo steps */
+        steps?: (number|null);
 
-        /** GrepRequest max_matches */
-        max_matches?: (number|null);
+        /** MacroInfo bytes */
+        bytes?: (number|Long|null);
+
+        /** MacroInfo duration */
+        duration?: (google.protobuf.IDuration|null);
     }
 
-    /** Represents a GrepRequest. */
-    class GrepRequest implements IGrepRequest {
+    /** Represents a MacroInfo. */
+    class MacroInfo implements IMacroInfo {
 
         /**
-         * Constructs a new GrepRequest.
+         * Constructs a new MacroInfo.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IGrepRequest);
+        constructor(properties?: vtr.IMacroInfo);
 
-        /** GrepRequest session. */
-        public session?: (vtr.ISessionRef|null);
+        /** MacroInfo name. */
+        public name: string;
 
-        /** GrepRequest pattern. */
-        public pattern: string;
+        /** MacroInfo coordinator. */
+        public coordinator: string;
 
-        /** GrepRequest context_before. */
-        public context_before: number;
+        /** MacroInfo created_at. */
+        public created_at?: (google.protobuf.ITimestamp|null);
 
-        /** GrepRequest context_after. */
-        public context_after: number;
+        /** MacroInfo steps. */
+        public steps: number;
 
-        /** GrepRequest max_matches. */
-        public max_matches: number;
+        /** MacroInfo bytes. */
+        public bytes: (number|Long);
+
+        /** MacroInfo duration. */
+        public duration?: (google.protobuf.IDuration|null);
 
         /**
-         * Creates a new GrepRequest instance using the specified properties.
+         * Creates a new MacroInfo instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns GrepRequest instance
+         * @returns MacroInfo instance
          */
-        public static create(properties?: vtr.IGrepRequest): vtr.GrepRequest;
+        public static create(properties?: vtr.IMacroInfo): vtr.MacroInfo;
 
         /**
-         * Encodes the specified GrepRequest message. Does not implicitly {@link vtr.GrepRequest.verify|verify} messages.
-         * @param message GrepRequest message or plain object to encode
+         * Encodes the specified MacroInfo message. Does not implicitly {@link vtr.MacroInfo.verify|verify} messages.
+         * @param message MacroInfo message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IGrepRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IMacroInfo, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified GrepRequest message, length delimited. Does not implicitly {@link vtr.GrepRequest.verify|verify} messages.
-         * @param message GrepRequest message or plain object to encode
+         * Encodes the specified MacroInfo message, length delimited. Does not implicitly {@link vtr.MacroInfo.verify|verify} messages.
+         * @param message MacroInfo message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IGrepRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IMacroInfo, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a GrepRequest message from the specified reader or buffer.
+         * Decodes a MacroInfo message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns GrepRequest
+         * @returns MacroInfo
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GrepRequest;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.MacroInfo;
 
         /**
-         * Decodes a GrepRequest message from the specified reader or buffer, length delimited.
+         * Decodes a MacroInfo message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns GrepRequest
+         * @returns MacroInfo
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GrepRequest;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.MacroInfo;
 
         /**
-         * Verifies a GrepRequest message.
+         * Verifies a MacroInfo message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a GrepRequest message from a plain object. Also converts values to their respective internal types.
+         * Creates a MacroInfo message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns GrepRequest
+         * @returns MacroInfo
          */
-        public static fromObject(object: { [k: string]: any }): vtr.GrepRequest;
+        public static fromObject(object: { [k: string]: any }): vtr.MacroInfo;
 
         /**
-         * Creates a plain object from a GrepRequest message. Also converts values to other types if specified.
-         * @param message GrepRequest
+         * Creates a plain object from a MacroInfo message. Also converts values to other types if specified.
+         * @param message MacroInfo
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.GrepRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.MacroInfo, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this GrepRequest to JSON.
+         * Converts this MacroInfo to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for GrepRequest
+         * Gets the default type url for MacroInfo
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a GrepMatch. */
-    interface IGrepMatch {
+    /** Properties of a StartMacroRequest. */
+    interface IStartMacroRequest {
 
-        /** GrepMatch line_number */
-        line_number?: (number|null);
+        /** StartMacroRequest session */
+        session?: (vtr.ISessionRef|null);
 
-        /** GrepMatch line */
-        line?: (string|null);
+        /** StartMacroRequest name */
+        name?: (string|null);
 
-        /** GrepMatch context_before */
-        context_before?: (string[]|null);
+        /** StartMacroRequest client_id */
+        client_id?: (string|null);
 
-        /** GrepMatch context_after */
-        context_after?: (string[]|null);
+        /** StartMacroRequest lock_token */
+        lock_token?: (string|null);
     }
 
-    /** Represents a GrepMatch. */
-    class GrepMatch implements IGrepMatch {
+    /** Represents a StartMacroRequest. */
+    class StartMacroRequest implements IStartMacroRequest {
 
         /**
-         * Constructs a new GrepMatch.
+         * Constructs a new StartMacroRequest.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IGrepMatch);
+        constructor(properties?: vtr.IStartMacroRequest);
 
-        /** GrepMatch line_number. */
-        public line_number: number;
+        /** StartMacroRequest session. */
+        public session?: (vtr.ISessionRef|null);
 
-        /** GrepMatch line. */
-        public line: string;
+        /** StartMacroRequest name. */
+        public name: string;
 
-        /** GrepMatch context_before. */
-        public context_before: string[];
+        /** StartMacroRequest client_id. */
+        public client_id: string;
 
-        /** GrepMatch context_after. */
-        public context_after: string[];
+        /** StartMacroRequest lock_token. */
+        public lock_token: string;
 
         /**
-         * Creates a new GrepMatch instance using the specified properties.
+         * Creates a new StartMacroRequest instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns GrepMatch instance
+         * @returns StartMacroRequest instance
          */
-        public static create(properties?: vtr.IGrepMatch): vtr.GrepMatch;
+        public static create(properties?: vtr.IStartMacroRequest): vtr.StartMacroRequest;
 
         /**
-         * Encodes the specified GrepMatch message. Does not implicitly {@link vtr.GrepMatch.verify|verify} messages.
-         * @param message GrepMatch message or plain object to encode
+         * Encodes the specified StartMacroRequest message. Does not implicitly {@link vtr.StartMacroRequest.verify|verify} messages.
+         * @param message StartMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IGrepMatch, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IStartMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified GrepMatch message, length delimited. Does not implicitly {@link vtr.GrepMatch.verify|verify} messages.
-         * @param message GrepMatch message or plain object to encode
+         * Encodes the specified StartMacroRequest message, length delimited. Does not implicitly {@link vtr.StartMacroRequest.verify|verify} messages.
+         * @param message StartMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IGrepMatch, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IStartMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a GrepMatch message from the specified reader or buffer.
+         * Decodes a StartMacroRequest message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns GrepMatch
+         * @returns StartMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GrepMatch;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.StartMacroRequest;
 
         /**
-         * Decodes a GrepMatch message from the specified reader or buffer, length delimited.
+         * Decodes a StartMacroRequest message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns GrepMatch
+         * @returns StartMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GrepMatch;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.StartMacroRequest;
 
         /**
-         * Verifies a GrepMatch message.
+         * Verifies a StartMacroRequest message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a GrepMatch message from a plain object. Also converts values to their respective internal types.
+         * Creates a StartMacroRequest message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns GrepMatch
+         * @returns StartMacroRequest
          */
-        public static fromObject(object: { [k: string]: any }): vtr.GrepMatch;
+        public static fromObject(object: { [k: string]: any }): vtr.StartMacroRequest;
 
         /**
-         * Creates a plain object from a GrepMatch message. Also converts values to other types if specified.
-         * @param message GrepMatch
+         * Creates a plain object from a StartMacroRequest message. Also converts values to other types if specified.
+         * @param message StartMacroRequest
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.GrepMatch, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.StartMacroRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this GrepMatch to JSON.
+         * Converts this StartMacroRequest to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for GrepMatch
+         * Gets the default type url for StartMacroRequest
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a GrepResponse. */
-    interface IGrepResponse {
-
-        /** GrepResponse matches */
-        matches?: (vtr.IGrepMatch[]|null);
+    /** Properties of a StartMacroResponse. */
+    interface IStartMacroResponse {
     }
 
-    /** Represents a GrepResponse. */
-    class GrepResponse implements IGrepResponse {
+    /** Represents a StartMacroResponse. */
+    class StartMacroResponse implements IStartMacroResponse {
 
         /**
-         * Constructs a new GrepResponse.
+         * Constructs a new StartMacroResponse.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IGrepResponse);
-
-        /** GrepResponse matches. */
-        public matches: vtr.IGrepMatch[];
+        constructor(properties?: vtr.IStartMacroResponse);
 
         /**
-         * Creates a new GrepResponse instance using the specified properties.
+         * Creates a new StartMacroResponse instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns GrepResponse instance
+         * @returns StartMacroResponse instance
          */
-        public static create(properties?: vtr.IGrepResponse): vtr.GrepResponse;
+        public static create(properties?: vtr.IStartMacroResponse): vtr.StartMacroResponse;
 
         /**
-         * Encodes the specified GrepResponse message. Does not implicitly {@link vtr.GrepResponse.verify|verify} messages.
-         * @param message GrepResponse message or plain object to encode
+         * Encodes the specified StartMacroResponse message. Does not implicitly {@link vtr.StartMacroResponse.verify|verify} messages.
+         * @param message StartMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IGrepResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IStartMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified GrepResponse message, length delimited. Does not implicitly {@link vtr.GrepResponse.verify|verify} messages.
-         * @param message GrepResponse message or plain object to encode
+         * Encodes the specified StartMacroResponse message, length delimited. Does not implicitly {@link vtr.StartMacroResponse.verify|verify} messages.
+         * @param message StartMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IGrepResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IStartMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a GrepResponse message from the specified reader or buffer.
+         * Decodes a StartMacroResponse message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns GrepResponse
+         * @returns StartMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.GrepResponse;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.StartMacroResponse;
 
         /**
-         * Decodes a GrepResponse message from the specified reader or buffer, length delimited.
+         * Decodes a StartMacroResponse message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns GrepResponse
+         * @returns StartMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.GrepResponse;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.StartMacroResponse;
 
         /**
-         * Verifies a GrepResponse message.
+         * Verifies a StartMacroResponse message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a GrepResponse message from a plain object. Also converts values to their respective internal types.
+         * Creates a StartMacroResponse message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns GrepResponse
+         * @returns StartMacroResponse
          */
-        public static fromObject(object: { [k: string]: any }): vtr.GrepResponse;
+        public static fromObject(object: { [k: string]: any }): vtr.StartMacroResponse;
 
         /**
-         * Creates a plain object from a GrepResponse message. Also converts values to other types if specified.
-         * @param message GrepResponse
+         * Creates a plain object from a StartMacroResponse message. Also converts values to other types if specified.
+         * @param message StartMacroResponse
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.GrepResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.StartMacroResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this GrepResponse to JSON.
+         * Converts this StartMacroResponse to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for GrepResponse
+         * Gets the default type url for StartMacroResponse
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a SendTextRequest. */
-    interface ISendTextRequest {
+    /** Properties of a StopMacroRequest. */
+    interface IStopMacroRequest {
 
-        /** SendTextRequest session */
+        /** StopMacroRequest session */
         session?: (vtr.ISessionRef|null);
 
-        /** SendTextRequest text */
-        text?: (string|null);
+        /** StopMacroRequest client_id */
+        client_id?: (string|null);
+
+        /** StopMacroRequest lock_token */
+        lock_token?: (string|null);
     }
 
-    /** Represents a SendTextRequest. */
-    class SendTextRequest implements ISendTextRequest {
+    /** Represents a StopMacroRequest. */
+    class StopMacroRequest implements IStopMacroRequest {
 
         /**
-         * Constructs a new SendTextRequest.
+         * Constructs a new StopMacroRequest.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.ISendTextRequest);
+        constructor(properties?: vtr.IStopMacroRequest);
 
-        /** SendTextRequest session. */
+        /** StopMacroRequest session. */
         public session?: (vtr.ISessionRef|null);
 
-        /** SendTextRequest text. */
-        public text: string;
+        /** StopMacroRequest client_id. */
+        public client_id: string;
+
+        /** StopMacroRequest lock_token. */
+        public lock_token: string;
 
         /**
-         * Creates a new SendTextRequest instance using the specified properties.
+         * Creates a new StopMacroRequest instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns SendTextRequest instance
+         * @returns StopMacroRequest instance
          */
-        public static create(properties?: vtr.ISendTextRequest): vtr.SendTextRequest;
+        public static create(properties?: vtr.IStopMacroRequest): vtr.StopMacroRequest;
 
         /**
-         * Encodes the specified SendTextRequest message. Does not implicitly {@link vtr.SendTextRequest.verify|verify} messages.
-         * @param message SendTextRequest message or plain object to encode
+         * Encodes the specified StopMacroRequest message. Does not implicitly {@link vtr.StopMacroRequest.verify|verify} messages.
+         * @param message StopMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.ISendTextRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IStopMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified SendTextRequest message, length delimited. Does not implicitly {@link vtr.SendTextRequest.verify|verify} messages.
-         * @param message SendTextRequest message or plain object to encode
+         * Encodes the specified StopMacroRequest message, length delimited. Does not implicitly {@link vtr.StopMacroRequest.verify|verify} messages.
+         * @param message StopMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.ISendTextRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IStopMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a SendTextRequest message from the specified reader or buffer.
+         * Decodes a StopMacroRequest message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns SendTextRequest
+         * @returns StopMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendTextRequest;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.StopMacroRequest;
 
         /**
-         * Decodes a SendTextRequest message from the specified reader or buffer, length delimited.
+         * Decodes a StopMacroRequest message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns SendTextRequest
+         * @returns StopMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendTextRequest;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.StopMacroRequest;
 
         /**
-         * Verifies a SendTextRequest message.
+         * Verifies a StopMacroRequest message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a SendTextRequest message from a plain object. Also converts values to their respective internal types.
+         * Creates a StopMacroRequest message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns SendTextRequest
+         * @returns StopMacroRequest
          */
-        public static fromObject(object: { [k: string]: any }): vtr.SendTextRequest;
+        public static fromObject(object: { [k: string]: any }): vtr.StopMacroRequest;
 
         /**
-         * Creates a plain object from a SendTextRequest message. Also converts values to other types if specified.
-         * @param message SendTextRequest
+         * Creates a plain object from a StopMacroRequest message. Also converts values to other types if specified.
+         * @param message StopMacroRequest
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.SendTextRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.StopMacroRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this SendTextRequest to JSON.
+         * Converts this StopMacroRequest to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for SendTextRequest
+         * Gets the default type url for StopMacroRequest
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a SendTextResponse. */
-    interface ISendTextResponse {
+    /** Properties of a StopMacroResponse. */
+    interface IStopMacroResponse {
+
+        /** StopMacroResponse macro */
+        macro?: (vtr.IMacroInfo|null);
     }
 
-    /** Represents a SendTextResponse. */
-    class SendTextResponse implements ISendTextResponse {
+    /** Represents a StopMacroResponse. */
+    class StopMacroResponse implements IStopMacroResponse {
 
         /**
-         * Constructs a new SendTextResponse.
+         * Constructs a new StopMacroResponse.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.ISendTextResponse);
+        constructor(properties?: vtr.IStopMacroResponse);
+
+        /** StopMacroResponse macro. */
+        public macro?: (vtr.IMacroInfo|null);
 
         /**
-         * Creates a new SendTextResponse instance using the specified properties.
+         * Creates a new StopMacroResponse instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns SendTextResponse instance
+         * @returns StopMacroResponse instance
          */
-        public static create(properties?: vtr.ISendTextResponse): vtr.SendTextResponse;
+        public static create(properties?: vtr.IStopMacroResponse): vtr.StopMacroResponse;
 
         /**
-         * Encodes the specified SendTextResponse message. Does not implicitly {@link vtr.SendTextResponse.verify|verify} messages.
-         * @param message SendTextResponse message or plain object to encode
+         * Encodes the specified StopMacroResponse message. Does not implicitly {@link vtr.StopMacroResponse.verify|verify} messages.
+         * @param message StopMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.ISendTextResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IStopMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified SendTextResponse message, length delimited. Does not implicitly {@link vtr.SendTextResponse.verify|verify} messages.
-         * @param message SendTextResponse message or plain object to encode
+         * Encodes the specified StopMacroResponse message, length delimited. Does not implicitly {@link vtr.StopMacroResponse.verify|verify} messages.
+         * @param message StopMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.ISendTextResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IStopMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a SendTextResponse message from the specified reader or buffer.
+         * Decodes a StopMacroResponse message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns SendTextResponse
+         * @returns StopMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendTextResponse;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.StopMacroResponse;
 
         /**
-         * Decodes a SendTextResponse message from the specified reader or buffer, length delimited.
+         * Decodes a StopMacroResponse message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns SendTextResponse
+         * @returns StopMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendTextResponse;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.StopMacroResponse;
 
         /**
-         * Verifies a SendTextResponse message.
+         * Verifies a StopMacroResponse message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a SendTextResponse message from a plain object. Also converts values to their respective internal types.
+         * Creates a StopMacroResponse message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns SendTextResponse
+         * @returns StopMacroResponse
          */
-        public static fromObject(object: { [k: string]: any }): vtr.SendTextResponse;
+        public static fromObject(object: { [k: string]: any }): vtr.StopMacroResponse;
 
         /**
-         * Creates a plain object from a SendTextResponse message. Also converts values to other types if specified.
-         * @param message SendTextResponse
+         * Creates a plain object from a StopMacroResponse message. Also converts values to other types if specified.
+         * @param message StopMacroResponse
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.SendTextResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.StopMacroResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this SendTextResponse to JSON.
+         * Converts this StopMacroResponse to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for SendTextResponse
+         * Gets the default type url for StopMacroResponse
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a SendKeyRequest. */
-    interface ISendKeyRequest {
+    /** Properties of a PlayMacroRequest. */
+    interface IPlayMacroRequest {
 
-        /** SendKeyRequest session */
+        /** PlayMacroRequest session */
         session?: (vtr.ISessionRef|null);
 
-        /** SendKeyRequest key */
-        key?: (string|null);
+        /** PlayMacroRequest name */
+        name?: (string|null);
+
+        /** PlayMacroRequest speed */
+        speed?: (number|null);
+
+        /** PlayMacroRequest step_delay */
+        step_delay?: (google.protobuf.IDuration|null);
+
+        /** PlayMacroRequest client_id */
+        client_id?: (string|null);
+
+        /** PlayMacroRequest lock_token */
+        lock_token?: (string|null);
     }
 
-    /** Represents a SendKeyRequest. */
-    class SendKeyRequest implements ISendKeyRequest {
+    /** Represents a PlayMacroRequest. */
+    class PlayMacroRequest implements IPlayMacroRequest {
 
         /**
-         * Constructs a new SendKeyRequest.
+         * Constructs a new PlayMacroRequest.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.ISendKeyRequest);
+        constructor(properties?: vtr.IPlayMacroRequest);
 
-        /** SendKeyRequest session. */
+        /** PlayMacroRequest session. */
         public session?: (vtr.ISessionRef|null);
 
-        /** SendKeyRequest key. */
-        public key: string;
+        /** PlayMacroRequest name. */
+        public name: string;
+
+        /** PlayMacroRequest speed. */
+        public speed: number;
+
+        /** PlayMacroRequest step_delay. */
+        public step_delay?: (google.protobuf.IDuration|null);
+
+        /** PlayMacroRequest client_id. */
+        public client_id: string;
+
+        /** PlayMacroRequest lock_token. */
+        public lock_token: string;
 
         /**
-         * Creates a new SendKeyRequest instance using the specified properties.
+         * Creates a new PlayMacroRequest instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns SendKeyRequest instance
+         * @returns PlayMacroRequest instance
          */
-        public static create(properties?: vtr.ISendKeyRequest): vtr.SendKeyRequest;
+        public static create(properties?: vtr.IPlayMacroRequest): vtr.PlayMacroRequest;
 
         /**
-         * Encodes the specified SendKeyRequest message. Does not implicitly {@link vtr.SendKeyRequest.verify|verify} messages.
-         * @param message SendKeyRequest message or plain object to encode
+         * Encodes the specified PlayMacroRequest message. Does not implicitly {@link vtr.PlayMacroRequest.verify|verify} messages.
+         * @param message PlayMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.ISendKeyRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IPlayMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified SendKeyRequest message, length delimited. Does not implicitly {@link vtr.SendKeyRequest.verify|verify} messages.
-         * @param message SendKeyRequest message or plain object to encode
+         * Encodes the specified PlayMacroRequest message, length delimited. Does not implicitly {@link vtr.PlayMacroRequest.verify|verify} messages.
+         * @param message PlayMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.ISendKeyRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IPlayMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a SendKeyRequest message from the specified reader or buffer.
+         * Decodes a PlayMacroRequest message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns SendKeyRequest
+         * @returns PlayMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendKeyRequest;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.PlayMacroRequest;
 
         /**
-         * Decodes a SendKeyRequest message from the specified reader or buffer, length delimited.
+         * Decodes a PlayMacroRequest message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns SendKeyRequest
+         * @returns PlayMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendKeyRequest;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.PlayMacroRequest;
 
         /**
-         * Verifies a SendKeyRequest message.
+         * Verifies a PlayMacroRequest message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a SendKeyRequest message from a plain object. Also converts values to their respective internal types.
+         * Creates a PlayMacroRequest message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns SendKeyRequest
+         * @returns PlayMacroRequest
          */
-        public static fromObject(object: { [k: string]: any }): vtr.SendKeyRequest;
+        public static fromObject(object: { [k: string]: any }): vtr.PlayMacroRequest;
 
         /**
-         * Creates a plain object from a SendKeyRequest message. Also converts values to other types if specified.
-         * @param message SendKeyRequest
+         * Creates a plain object from a PlayMacroRequest message. Also converts values to other types if specified.
+         * @param message PlayMacroRequest
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.SendKeyRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.PlayMacroRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this SendKeyRequest to JSON.
+         * Converts this PlayMacroRequest to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for SendKeyRequest
+         * Gets the default type url for PlayMacroRequest
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a SendKeyResponse. */
-    interface ISendKeyResponse {
+    /** Properties of a PlayMacroResponse. */
+    interface IPlayMacroResponse {
+
+        /** PlayMacroResponse steps */
+        steps?: (number|null);
     }
 
-    /** Represents a SendKeyResponse. */
-    class SendKeyResponse implements ISendKeyResponse {
+    /** Represents a PlayMacroResponse. */
+    class PlayMacroResponse implements IPlayMacroResponse {
 
         /**
-         * Constructs a new SendKeyResponse.
+         * Constructs a new PlayMacroResponse.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.ISendKeyResponse);
+        constructor(properties?: vtr.IPlayMacroResponse);
+
+        /** PlayMacroResponse steps. */
+        public steps: number;
 
         /**
-         * Creates a new SendKeyResponse instance using the specified properties.
+         * Creates a new PlayMacroResponse instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns SendKeyResponse instance
+         * @returns PlayMacroResponse instance
          */
-        public static create(properties?: vtr.ISendKeyResponse): vtr.SendKeyResponse;
+        public static create(properties?: vtr.IPlayMacroResponse): vtr.PlayMacroResponse;
 
         /**
-         * Encodes the specified SendKeyResponse message. Does not implicitly {@link vtr.SendKeyResponse.verify|verify} messages.
-         * @param message SendKeyResponse message or plain object to encode
+         * Encodes the specified PlayMacroResponse message. Does not implicitly {@link vtr.PlayMacroResponse.verify|verify} messages.
+         * @param message PlayMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.ISendKeyResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IPlayMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified SendKeyResponse message, length delimited. Does not implicitly {@link vtr.SendKeyResponse.verify|verify} messages.
-         * @param message SendKeyResponse message or plain object to encode
+         * Encodes the specified PlayMacroResponse message, length delimited. Does not implicitly {@link vtr.PlayMacroResponse.verify|verify} messages.
+         * @param message PlayMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.ISendKeyResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IPlayMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a SendKeyResponse message from the specified reader or buffer.
+         * Decodes a PlayMacroResponse message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns SendKeyResponse
+         * @returns PlayMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendKeyResponse;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.PlayMacroResponse;
 
         /**
-         * Decodes a SendKeyResponse message from the specified reader or buffer, length delimited.
+         * Decodes a PlayMacroResponse message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns SendKeyResponse
+         * @returns PlayMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendKeyResponse;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.PlayMacroResponse;
 
         /**
-         * Verifies a SendKeyResponse message.
+         * Verifies a PlayMacroResponse message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a SendKeyResponse message from a plain object. Also converts values to their respective internal types.
+         * Creates a PlayMacroResponse message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns SendKeyResponse
+         * @returns PlayMacroResponse
          */
-        public static fromObject(object: { [k: string]: any }): vtr.SendKeyResponse;
+        public static fromObject(object: { [k: string]: any }): vtr.PlayMacroResponse;
 
         /**
-         * Creates a plain object from a SendKeyResponse message. Also converts values to other types if specified.
-         * @param message SendKeyResponse
+         * Creates a plain object from a PlayMacroResponse message. Also converts values to other types if specified.
+         * @param message PlayMacroResponse
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.SendKeyResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.PlayMacroResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this SendKeyResponse to JSON.
+         * Converts this PlayMacroResponse to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for SendKeyResponse
+         * Gets the default type url for PlayMacroResponse
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a SendBytesRequest. */
-    interface ISendBytesRequest {
-
-        /** SendBytesRequest session */
-        session?: (vtr.ISessionRef|null);
+    /** Properties of a ListMacrosRequest. */
+    interface IListMacrosRequest {
 
-        /** SendBytesRequest data */
-        data?: (Uint8Array|null);
+        /** ListMacrosRequest coordinator */
+        coordinator?: (string|null);
     }
 
-    /** Represents a SendBytesRequest. */
-    class SendBytesRequest implements ISendBytesRequest {
+    /** Represents a ListMacrosRequest. */
+    class ListMacrosRequest implements IListMacrosRequest {
 
         /**
-         * Constructs a new SendBytesRequest.
+         * Constructs a new ListMacrosRequest.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.ISendBytesRequest);
-
-        /** SendBytesRequest session. */
-        public session?: (vtr.ISessionRef|null);
+        constructor(properties?: vtr.IListMacrosRequest);
 
-        /** SendBytesRequest data. */
-        public data: Uint8Array;
+        /** ListMacrosRequest coordinator. */
+        public coordinator: string;
 
         /**
-         * Creates a new SendBytesRequest instance using the specified properties.
+         * Creates a new ListMacrosRequest instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns SendBytesRequest instance
+         * @returns ListMacrosRequest instance
          */
-        public static create(properties?: vtr.ISendBytesRequest): vtr.SendBytesRequest;
+        public static create(properties?: vtr.IListMacrosRequest): vtr.ListMacrosRequest;
 
         /**
-         * Encodes the specified SendBytesRequest message. Does not implicitly {@link vtr.SendBytesRequest.verify|verify} messages.
-         * @param message SendBytesRequest message or plain object to encode
+         * Encodes the specified ListMacrosRequest message. Does not implicitly {@link vtr.ListMacrosRequest.verify|verify} messages.
+         * @param message ListMacrosRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.ISendBytesRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IListMacrosRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified SendBytesRequest message, length delimited. Does not implicitly {@link vtr.SendBytesRequest.verify|verify} messages.
-         * @param message SendBytesRequest message or plain object to encode
+         * Encodes the specified ListMacrosRequest message, length delimited. Does not implicitly {@link vtr.ListMacrosRequest.verify|verify} messages.
+         * @param message ListMacrosRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.ISendBytesRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IListMacrosRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a SendBytesRequest message from the specified reader or buffer.
+         * Decodes a ListMacrosRequest message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns SendBytesRequest
+         * @returns ListMacrosRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendBytesRequest;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ListMacrosRequest;
 
         /**
-         * Decodes a SendBytesRequest message from the specified reader or buffer, length delimited.
+         * Decodes a ListMacrosRequest message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns SendBytesRequest
+         * @returns ListMacrosRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendBytesRequest;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ListMacrosRequest;
 
         /**
-         * Verifies a SendBytesRequest message.
+         * Verifies a ListMacrosRequest message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a SendBytesRequest message from a plain object. Also converts values to their respective internal types.
+         * Creates a ListMacrosRequest message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns SendBytesRequest
+         * @returns ListMacrosRequest
          */
-        public static fromObject(object: { [k: string]: any }): vtr.SendBytesRequest;
+        public static fromObject(object: { [k: string]: any }): vtr.ListMacrosRequest;
 
         /**
-         * Creates a plain object from a SendBytesRequest message. Also converts values to other types if specified.
-         * @param message SendBytesRequest
+         * Creates a plain object from a ListMacrosRequest message. Also converts values to other types if specified.
+         * @param message ListMacrosRequest
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.SendBytesRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.ListMacrosRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this SendBytesRequest to JSON.
+         * Converts this ListMacrosRequest to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for SendBytesRequest
+         * Gets the default type url for ListMacrosRequest
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a SendBytesResponse. */
-    interface ISendBytesResponse {
+    /** Properties of a ListMacrosResponse. */
+    interface IListMacrosResponse {
+
+        /** ListMacrosResponse macros */
+        macros?: (vtr.IMacroInfo[]|null);
     }
 
-    /** Represents a SendBytesResponse. */
-    class SendBytesResponse implements ISendBytesResponse {
+    /** Represents a ListMacrosResponse. */
+    class ListMacrosResponse implements IListMacrosResponse {
 
         /**
-         * Constructs a new SendBytesResponse.
+         * Constructs a new ListMacrosResponse.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.ISendBytesResponse);
+        constructor(properties?: vtr.IListMacrosResponse);
+
+        /** ListMacrosResponse macros. */
+        public macros: vtr.IMacroInfo[];
 
         /**
-         * Creates a new SendBytesResponse instance using the specified properties.
+         * Creates a new ListMacrosResponse instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns SendBytesResponse instance
+         * @returns ListMacrosResponse instance
          */
-        public static create(properties?: vtr.ISendBytesResponse): vtr.SendBytesResponse;
+        public static create(properties?: vtr.IListMacrosResponse): vtr.ListMacrosResponse;
 
         /**
-         * Encodes the specified SendBytesResponse message. Does not implicitly {@link vtr.SendBytesResponse.verify|verify} messages.
-         * @param message SendBytesResponse message or plain object to encode
+         * Encodes the specified ListMacrosResponse message. Does not implicitly {@link vtr.ListMacrosResponse.verify|verify} messages.
+         * @param message ListMacrosResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.ISendBytesResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IListMacrosResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified SendBytesResponse message, length delimited. Does not implicitly {@link vtr.SendBytesResponse.verify|verify} messages.
-         * @param message SendBytesResponse message or plain object to encode
+         * Encodes the specified ListMacrosResponse message, length delimited. Does not implicitly {@link vtr.ListMacrosResponse.verify|verify} messages.
+         * @param message ListMacrosResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.ISendBytesResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IListMacrosResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a SendBytesResponse message from the specified reader or buffer.
+         * Decodes a ListMacrosResponse message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns SendBytesResponse
+         * @returns ListMacrosResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.SendBytesResponse;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ListMacrosResponse;
 
         /**
-         * Decodes a SendBytesResponse message from the specified reader or buffer, length delimited.
+         * Decodes a ListMacrosResponse message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns SendBytesResponse
+         * @returns ListMacrosResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.SendBytesResponse;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ListMacrosResponse;
 
         /**
-         * Verifies a SendBytesResponse message.
+         * Verifies a ListMacrosResponse message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a SendBytesResponse message from a plain object. Also converts values to their respective internal types.
+         * Creates a ListMacrosResponse message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns SendBytesResponse
+         * @returns ListMacrosResponse
          */
-        public static fromObject(object: { [k: string]: any }): vtr.SendBytesResponse;
+        public static fromObject(object: { [k: string]: any }): vtr.ListMacrosResponse;
 
         /**
-         * Creates a plain object from a SendBytesResponse message. Also converts values to other types if specified.
-         * @param message SendBytesResponse
+         * Creates a plain object from a ListMacrosResponse message. Also converts values to other types if specified.
+         * @param message ListMacrosResponse
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.SendBytesResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.ListMacrosResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this SendBytesResponse to JSON.
+         * Converts this ListMacrosResponse to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for SendBytesResponse
+         * Gets the default type url for ListMacrosResponse
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a ResizeRequest. */
-    interface IResizeRequest {
-
-        /** ResizeRequest session */
-        session?: (vtr.ISessionRef|null);
+    /** Properties of a DeleteMacroRequest. */
+    interface IDeleteMacroRequest {
 
-        /** ResizeRequest cols */
-        cols?: (number|null);
+        /** DeleteMacroRequest name */
+        name?: (string|null);
 
-        /** ResizeRequest rows */
-        rows?: (number|null);
+        /** DeleteMacroRequest coordinator */
+        coordinator?: (string|null);
     }
 
-    /** Represents a ResizeRequest. */
-    class ResizeRequest implements IResizeRequest {
+    /** Represents a DeleteMacroRequest. */
+    class DeleteMacroRequest implements IDeleteMacroRequest {
 
         /**
-         * Constructs a new ResizeRequest.
+         * Constructs a new DeleteMacroRequest.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IResizeRequest);
-
-        /** ResizeRequest session. */
-        public session?: (vtr.ISessionRef|null);
+        constructor(properties?: vtr.IDeleteMacroRequest);
 
-        /** ResizeRequest cols. */
-        public cols: number;
+        /** DeleteMacroRequest name. */
+        public name: string;
 
-        /** ResizeRequest rows. */
-        public rows: number;
+        /** DeleteMacroRequest coordinator. */
+        public coordinator: string;
 
         /**
-         * Creates a new ResizeRequest instance using the specified properties.
+         * Creates a new DeleteMacroRequest instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns ResizeRequest instance
+         * @returns DeleteMacroRequest instance
          */
-        public static create(properties?: vtr.IResizeRequest): vtr.ResizeRequest;
+        public static create(properties?: vtr.IDeleteMacroRequest): vtr.DeleteMacroRequest;
 
         /**
-         * Encodes the specified ResizeRequest message. Does not implicitly {@link vtr.ResizeRequest.verify|verify} messages.
-         * @param message ResizeRequest message or plain object to encode
+         * Encodes the specified DeleteMacroRequest message. Does not implicitly {@link vtr.DeleteMacroRequest.verify|verify} messages.
+         * @param message DeleteMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IResizeRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IDeleteMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified ResizeRequest message, length delimited. Does not implicitly {@link vtr.ResizeRequest.verify|verify} messages.
-         * @param message ResizeRequest message or plain object to encode
+         * Encodes the specified DeleteMacroRequest message, length delimited. Does not implicitly {@link vtr.DeleteMacroRequest.verify|verify} messages.
+         * @param message DeleteMacroRequest message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IResizeRequest, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IDeleteMacroRequest, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a ResizeRequest message from the specified reader or buffer.
+         * Decodes a DeleteMacroRequest message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns ResizeRequest
+         * @returns DeleteMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ResizeRequest;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.DeleteMacroRequest;
 
         /**
-         * Decodes a ResizeRequest message from the specified reader or buffer, length delimited.
+         * Decodes a DeleteMacroRequest message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns ResizeRequest
+         * @returns DeleteMacroRequest
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ResizeRequest;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.DeleteMacroRequest;
 
         /**
-         * Verifies a ResizeRequest message.
+         * Verifies a DeleteMacroRequest message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a ResizeRequest message from a plain object. Also converts values to their respective internal types.
+         * Creates a DeleteMacroRequest message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns ResizeRequest
+         * @returns DeleteMacroRequest
          */
-        public static fromObject(object: { [k: string]: any }): vtr.ResizeRequest;
+        public static fromObject(object: { [k: string]: any }): vtr.DeleteMacroRequest;
 
         /**
-         * Creates a plain object from a ResizeRequest message. Also converts values to other types if specified.
-         * @param message ResizeRequest
+         * Creates a plain object from a DeleteMacroRequest message. Also converts values to other types if specified.
+         * @param message DeleteMacroRequest
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.ResizeRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.DeleteMacroRequest, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this ResizeRequest to JSON.
+         * Converts this DeleteMacroRequest to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for ResizeRequest
+         * Gets the default type url for DeleteMacroRequest
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
         public static getTypeUrl(typeUrlPrefix?: string): string;
     }
 
-    /** Properties of a ResizeResponse. */
-    interface IResizeResponse {
+    /** Properties of a DeleteMacroResponse. */
+    interface IDeleteMacroResponse {
     }
 
-    /** Represents a ResizeResponse. */
-    class ResizeResponse implements IResizeResponse {
+    /** Represents a DeleteMacroResponse. */
+    class DeleteMacroResponse implements IDeleteMacroResponse {
 
         /**
-         * Constructs a new ResizeResponse.
+         * Constructs a new DeleteMacroResponse.
          * @param [properties] Properties to set
          */
-        constructor(properties?: vtr.IResizeResponse);
+        constructor(properties?: vtr.IDeleteMacroResponse);
 
         /**
-         * Creates a new ResizeResponse instance using the specified properties.
+         * Creates a new DeleteMacroResponse instance using the specified properties.
          * @param [properties] Properties to set
-         * @returns ResizeResponse instance
+         * @returns DeleteMacroResponse instance
          */
-        public static create(properties?: vtr.IResizeResponse): vtr.ResizeResponse;
+        public static create(properties?: vtr.IDeleteMacroResponse): vtr.DeleteMacroResponse;
 
         /**
-         * Encodes the specified ResizeResponse message. Does not implicitly {@link vtr.ResizeResponse.verify|verify} messages.
-         * @param message ResizeResponse message or plain object to encode
+         * Encodes the specified DeleteMacroResponse message. Does not implicitly {@link vtr.DeleteMacroResponse.verify|verify} messages.
+         * @param message DeleteMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encode(message: vtr.IResizeResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encode(message: vtr.IDeleteMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Encodes the specified ResizeResponse message, length delimited. Does not implicitly {@link vtr.ResizeResponse.verify|verify} messages.
-         * @param message ResizeResponse message or plain object to encode
+         * Encodes the specified DeleteMacroResponse message, length delimited. Does not implicitly {@link vtr.DeleteMacroResponse.verify|verify} messages.
+         * @param message DeleteMacroResponse message or plain object to encode
          * @param [writer] Writer to encode to
          * @returns Writer
          */
-        public static encodeDelimited(message: vtr.IResizeResponse, writer?: $protobuf.Writer): $protobuf.Writer;
+        public static encodeDelimited(message: vtr.IDeleteMacroResponse, writer?: $protobuf.Writer): $protobuf.Writer;
 
         /**
-         * Decodes a ResizeResponse message from the specified reader or buffer.
+         * Decodes a DeleteMacroResponse message from the specified reader or buffer.
          * @param reader Reader or buffer to decode from
          * @param [length] Message length if known beforehand
-         * @returns ResizeResponse
+         * @returns DeleteMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.ResizeResponse;
+        public static decode(reader: ($protobuf.Reader|Uint8Array), length?: number): vtr.DeleteMacroResponse;
 
         /**
-         * Decodes a ResizeResponse message from the specified reader or buffer, length delimited.
+         * Decodes a DeleteMacroResponse message from the specified reader or buffer, length delimited.
          * @param reader Reader or buffer to decode from
-         * @returns ResizeResponse
+         * @returns DeleteMacroResponse
          * @throws {Error} If the payload is not a reader or valid buffer
          * @throws {$protobuf.util.ProtocolError} If required fields are missing
          */
-        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.ResizeResponse;
+        public static decodeDelimited(reader: ($protobuf.Reader|Uint8Array)): vtr.DeleteMacroResponse;
 
         /**
-         * Verifies a ResizeResponse message.
+         * Verifies a DeleteMacroResponse message.
          * @param message Plain object to verify
          * @returns `null` if valid, otherwise the reason why it is not
          */
         public static verify(message: { [k: string]: any }): (string|null);
 
         /**
-         * Creates a ResizeResponse message from a plain object. Also converts values to their respective internal types.
+         * Creates a DeleteMacroResponse message from a plain object. Also converts values to their respective internal types.
          * @param object Plain object
-         * @returns ResizeResponse
+         * @returns DeleteMacroResponse
          */
-        public static fromObject(object: { [k: string]: any }): vtr.ResizeResponse;
+        public static fromObject(object: { [k: string]: any }): vtr.DeleteMacroResponse;
 
         /**
-         * Creates a plain object from a ResizeResponse message. Also converts values to other types if specified.
-         * @param message ResizeResponse
+         * Creates a plain object from a DeleteMacroResponse message. Also converts values to other types if specified.
+         * @param message DeleteMacroResponse
          * @param [options] Conversion options
          * @returns Plain object
          */
-        public static toObject(message: vtr.ResizeResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
+        public static toObject(message: vtr.DeleteMacroResponse, options?: $protobuf.IConversionOptions): { [k: string]: any };
 
         /**
-         * Converts this ResizeResponse to JSON.
+         * Converts this DeleteMacroResponse to JSON.
          * @returns JSON object
          */
         public toJSON(): { [k: string]: any };
 
         /**
-         * Gets the default type url for ResizeResponse
+         * Gets the default type url for DeleteMacroResponse
          * @param [typeUrlPrefix] your custom typeUrlPrefix(default "type.googleapis.com")
          * @returns The default type url
          */
@@ -4938,6 +9577,27 @@ export namespace vtr {
 
         /** SubscribeRequest include_raw_output */
         include_raw_output?: (boolean|null);
+
+        /** SubscribeRequest read_only */
+        read_only?: (boolean|null);
+
+        /** SubscribeRequest client_id */
+        client_id?: (string|null);
+
+        /** SubscribeRequest client_kind */
+        client_kind?: (string|null);
+
+        /** SubscribeRequest client_name */
+        client_name?: (string|null);
+
+        /** SubscribeRequest cols */
+        cols?: (number|null);
+
+        /** SubscribeRequest rows */
+        rows?: (number|null);
+
+        /** SubscribeRequest include_initial_screen */
+        include_initial_screen?: (boolean|null);
     }
 
     /** Represents a SubscribeRequest. */
@@ -4958,6 +9618,27 @@ export namespace vtr {
         /** SubscribeRequest include_raw_output. */
         public include_raw_output: boolean;
 
+        /** SubscribeRequest read_only. */
+        public read_only: boolean;
+
+        /** SubscribeRequest client_id. */
+        public client_id: string;
+
+        /** SubscribeRequest client_kind. */
+        public client_kind: string;
+
+        /** SubscribeRequest client_name. */
+        public client_name: string;
+
+        /** SubscribeRequest cols. */
+        public cols: number;
+
+        /** SubscribeRequest rows. */
+        public rows: number;
+
+        /** SubscribeRequest include_initial_screen. */
+        public include_initial_screen: boolean;
+
         /**
          * Creates a new SubscribeRequest instance using the specified properties.
          * @param [properties] Properties to set
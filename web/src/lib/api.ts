@@ -7,6 +7,8 @@ export type SessionCreateRequest = {
   workingDir?: string;
   cols?: number;
   rows?: number;
+  tags?: Record<string, string>;
+  profile?: string;
 };
 
 export type SessionCreateResponse = {
@@ -20,16 +22,45 @@ export type SessionCreateResponse = {
     idle?: boolean;
     exit_code?: number;
     order?: number;
+    tags?: Record<string, string>;
   };
 };
 
 export type SessionActionRequest = {
   id: string;
   coordinator?: string;
-  action: "send_key" | "signal" | "close" | "remove" | "rename";
+  action: "send_key" | "signal" | "close" | "remove" | "rename" | "lock" | "unlock";
   key?: string;
   signal?: string;
   newName?: string;
+  holder?: string;
+  lease?: string;
+};
+
+export type InputLockInfo = {
+  holder: string;
+  expiresAt?: string;
+};
+
+export type SessionPresence = {
+  inputLock?: InputLockInfo;
+  attachedClients: number;
+};
+
+type SessionActionResponse = {
+  ok: boolean;
+  lock_token?: string;
+};
+
+type WebSessionsResponse = {
+  coordinators?: Array<{
+    name: string;
+    sessions?: Array<{
+      id: string;
+      input_lock?: { holder: string; expires_at?: string };
+      attached_clients?: number;
+    }>;
+  }>;
 };
 
 export type WebInfoResponse = {
@@ -42,11 +73,49 @@ export type WebInfoResponse = {
     name?: string;
     path?: string;
   };
+  profiles?: string[];
   errors?: {
     hub?: string;
   };
 };
 
+const clientIdKey = "vtr.clientId";
+let fallbackClientId = "";
+
+// webClientId identifies this tab to the coordinator. It survives reloads but
+// not new tabs.
+export function webClientId() {
+  try {
+    const existing = window.sessionStorage.getItem(clientIdKey);
+    if (existing) {
+      return existing;
+    }
+    const id = `web-${crypto.randomUUID()}`;
+    window.sessionStorage.setItem(clientIdKey, id);
+    return id;
+  } catch {
+    if (!fallbackClientId) {
+      fallbackClientId = `web-${Math.random().toString(36).slice(2)}`;
+    }
+    return fallbackClientId;
+  }
+}
+
+// heldLocks maps session ids to the input locks this tab holds. The token is
+// the lock's credential and never leaves the tab except on its own writes;
+// session listings only name the holder.
+const heldLocks = new Map<string, string>();
+
+export function inputLockToken(sessionId: string) {
+  return heldLocks.get(sessionId);
+}
+
+// ownsInputLock reports whether the listed lock was granted to this tab. A
+// token whose lease lapsed is dropped when its next renewal fails.
+export function ownsInputLock(sessionId: string, lock?: InputLockInfo) {
+  return Boolean(lock && heldLocks.has(sessionId));
+}
+
 function normalizeSession(session: SessionCreateResponse["session"]): SessionInfo {
   return {
     id: session.id,
@@ -74,6 +143,8 @@ export async function createSession(req: SessionCreateRequest) {
       working_dir: req.workingDir,
       cols: req.cols,
       rows: req.rows,
+      tags: req.tags,
+      profile: req.profile,
     }),
   });
   if (!resp.ok) {
@@ -98,12 +169,51 @@ export async function sendSessionAction(req: SessionActionRequest) {
       key: req.key,
       signal: req.signal,
       new_name: req.newName,
+      client_id: webClientId(),
+      lock_token: inputLockToken(req.id),
+      holder: req.holder,
+      lease: req.lease,
     }),
   });
   if (!resp.ok) {
+    if (req.action === "lock" || req.action === "unlock") {
+      heldLocks.delete(req.id);
+    }
     const message = (await resp.text()) || `session action failed: ${resp.status}`;
     throw new Error(message);
   }
+  const data = (await resp.json()) as SessionActionResponse;
+  if (req.action === "lock" && data.lock_token) {
+    heldLocks.set(req.id, data.lock_token);
+  } else if (req.action === "unlock") {
+    heldLocks.delete(req.id);
+  }
+}
+
+// fetchSessionPresence returns input locks and attached client counts keyed by
+// session id.
+export async function fetchSessionPresence() {
+  const resp = await fetch("/api/sessions");
+  if (!resp.ok) {
+    const message = (await resp.text()) || `session list failed: ${resp.status}`;
+    throw new Error(message);
+  }
+  const data = (await resp.json()) as WebSessionsResponse;
+  const presence: Record<string, SessionPresence> = {};
+  for (const coord of data.coordinators ?? []) {
+    for (const session of coord.sessions ?? []) {
+      presence[session.id] = {
+        attachedClients: session.attached_clients ?? 0,
+        inputLock: session.input_lock
+          ? {
+              holder: session.input_lock.holder,
+              expiresAt: session.input_lock.expires_at,
+            }
+          : undefined,
+      };
+    }
+  }
+  return presence;
 }
 
 export async function fetchWebInfo() {
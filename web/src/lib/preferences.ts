@@ -15,6 +15,7 @@ const PreferencesSchema = z
     showClosedSessions: z.boolean().default(false),
     terminalRenderer: z.enum(["dom", "canvas"]).default("dom"),
     autoResize: z.boolean().default(false),
+    readOnly: z.boolean().default(false),
   })
   .passthrough();
 
@@ -8,12 +8,14 @@ import {
   type SessionsSnapshot,
   type SubscribeEvent,
 } from "./proto";
+import { inputLockToken, webClientId } from "./api";
 import type { SessionRef } from "./session";
 
 export type StreamStatus = "idle" | "connecting" | "open" | "reconnecting" | "error" | "closed";
 
 type StreamOptions = {
   includeRawOutput?: boolean;
+  readOnly?: boolean;
 };
 
 type StreamState = {
@@ -32,6 +34,7 @@ export function isTerminalStatusCode(code?: number | null) {
     case 6: // AlreadyExists
     case 7: // PermissionDenied
     case 9: // FailedPrecondition
+    case 10: // Aborted (disconnected by another client)
     case 11: // OutOfRange
     case 12: // Unimplemented
     case 16: // Unauthenticated
@@ -145,6 +148,7 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
       sendProto("vtr.SendTextRequest", {
         session: { id: sessionId, coordinator: sessionCoordinator },
         text: normalizeText(text),
+        lock_token: inputLockToken(sessionId),
       });
     },
     [normalizeText, sendProto, sessionCoordinator, sessionId],
@@ -156,6 +160,7 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
       sendProto("vtr.SendKeyRequest", {
         session: { id: sessionId, coordinator: sessionCoordinator },
         key,
+        lock_token: inputLockToken(sessionId),
       });
     },
     [sendProto, sessionCoordinator, sessionId],
@@ -168,6 +173,7 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
       sendProto("vtr.SendTextRequest", {
         session: { id: targetId, coordinator: target.coordinator?.trim() ?? "" },
         text: normalizeText(text),
+        lock_token: inputLockToken(targetId),
       });
     },
     [normalizeText, sendProto],
@@ -180,6 +186,7 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
       sendProto("vtr.SendKeyRequest", {
         session: { id: targetId, coordinator: target.coordinator?.trim() ?? "" },
         key,
+        lock_token: inputLockToken(targetId),
       });
     },
     [sendProto],
@@ -191,6 +198,7 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
       sendProto("vtr.SendBytesRequest", {
         session: { id: sessionId, coordinator: sessionCoordinator },
         data,
+        lock_token: inputLockToken(sessionId),
       });
     },
     [sendProto, sessionCoordinator, sessionId],
@@ -203,6 +211,7 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
         session: { id: sessionId, coordinator: sessionCoordinator },
         cols,
         rows,
+        lock_token: inputLockToken(sessionId),
       });
     },
     [sendProto, sessionCoordinator, sessionId],
@@ -242,6 +251,8 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
           session: { id: sessionId, coordinator: sessionCoordinator },
           include_screen_updates: true,
           include_raw_output: options.includeRawOutput ?? false,
+          read_only: options.readOnly ?? false,
+          client_id: webClientId(),
         });
         ws.send(hello);
         setState({ status: "open", receiving: false });
@@ -359,7 +370,7 @@ export function useVtrStream(sessionRef: SessionRef | null, options: StreamOptio
         wsRef.current.close();
       }
     };
-  }, [sessionCoordinator, sessionId, options.includeRawOutput, connectSeq]);
+  }, [sessionCoordinator, sessionId, options.includeRawOutput, options.readOnly, connectSeq]);
 
   return {
     state,
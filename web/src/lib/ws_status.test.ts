@@ -6,6 +6,7 @@ describe("isTerminalStatusCode", () => {
     expect(isTerminalStatusCode(3)).toBe(true);
     expect(isTerminalStatusCode(5)).toBe(true);
     expect(isTerminalStatusCode(9)).toBe(true);
+    expect(isTerminalStatusCode(10)).toBe(true);
     expect(isTerminalStatusCode(12)).toBe(true);
   });
 